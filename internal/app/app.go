@@ -9,14 +9,32 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/mechta-market/e-product/internal/config"
 	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/common/keyring"
+	domainImportJobServiceP "github.com/mechta-market/e-product/internal/domain/importjob"
+	domainImportJobRepoDbP "github.com/mechta-market/e-product/internal/domain/importjob/repo/pg"
 	domainKeyServiceP "github.com/mechta-market/e-product/internal/domain/key"
 	domainKeyRepoDbP "github.com/mechta-market/e-product/internal/domain/key/repo/pg"
+	domainOperationServiceP "github.com/mechta-market/e-product/internal/domain/operation"
+	domainOperationRepoDbP "github.com/mechta-market/e-product/internal/domain/operation/repo/pg"
+	domainPoolLevelServiceP "github.com/mechta-market/e-product/internal/domain/poollevel"
+	domainPoolLevelRepoDbP "github.com/mechta-market/e-product/internal/domain/poollevel/repo/pg"
+	domainProductProviderServiceP "github.com/mechta-market/e-product/internal/domain/productprovider"
+	domainProductProviderRepoDbP "github.com/mechta-market/e-product/internal/domain/productprovider/repo/pg"
+	domainWebhookServiceP "github.com/mechta-market/e-product/internal/domain/webhook"
+	domainWebhookRepoDbP "github.com/mechta-market/e-product/internal/domain/webhook/repo/pg"
 	handlerGrpcP "github.com/mechta-market/e-product/internal/handler/grpc"
+	handlerHttpP "github.com/mechta-market/e-product/internal/handler/http"
+	serviceAlertP "github.com/mechta-market/e-product/internal/service/alert"
+	serviceAlertRepoP "github.com/mechta-market/e-product/internal/service/alert/repo"
+	serviceCallbackP "github.com/mechta-market/e-product/internal/service/callback"
+	serviceCallbackRepoP "github.com/mechta-market/e-product/internal/service/callback/repo"
 	serviceMdmP "github.com/mechta-market/e-product/internal/service/mdm"
 	serviceMdmRepoP "github.com/mechta-market/e-product/internal/service/mdm/repo"
 	serviceAsbisP "github.com/mechta-market/e-product/internal/service/provider/asbis"
@@ -25,11 +43,16 @@ import (
 	serviceComportalRepoP "github.com/mechta-market/e-product/internal/service/provider/comportal/repo"
 	serviceMegogoP "github.com/mechta-market/e-product/internal/service/provider/megogo"
 	serviceMegogoRepoP "github.com/mechta-market/e-product/internal/service/provider/megogo/repo"
+	serviceResilientP "github.com/mechta-market/e-product/internal/service/provider/resilient"
+	serviceWebhookP "github.com/mechta-market/e-product/internal/service/webhook"
+	serviceWebhookRepoP "github.com/mechta-market/e-product/internal/service/webhook/repo"
 	usecaseKeyP "github.com/mechta-market/e-product/internal/usecase/key"
+	usecaseWebhookP "github.com/mechta-market/e-product/internal/usecase/webhook"
 	eProductV1 "github.com/mechta-market/e-product/pkg/proto/e_product"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/samber/lo"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -39,12 +62,19 @@ type App struct {
 
 	pgpool *pgxpool.Pool
 
+	keyring *keyring.Keyring
+
+	keyUsecase     *usecaseKeyP.Usecase
+	webhookUsecase *usecaseWebhookP.Usecase
+
 	grpcServer *GrpcServer
 	httpServer *http.Server
 
 	ctx       context.Context
 	ctxCancel context.CancelFunc
 
+	jobsWg sync.WaitGroup
+
 	exitCode int
 }
 
@@ -54,11 +84,20 @@ func (a *App) Init() {
 	a.ctx, a.ctxCancel = context.WithCancel(context.Background())
 
 	var mdmService *serviceMdmP.Service
+	var alertService *serviceAlertP.Service
+	var callbackService *serviceCallbackP.Service
 	var comportalService *serviceComportalP.Service
 	var asbisService *serviceAsbisP.Service
 	var megogoService *serviceMegogoP.Service
 
+	var operationService *domainOperationServiceP.Service
+	var importJobService *domainImportJobServiceP.Service
+	var poolLevelService *domainPoolLevelServiceP.Service
+	var productProviderService *domainProductProviderServiceP.Service
+	var webhookService *domainWebhookServiceP.Service
+
 	var handlerGrpcKey *handlerGrpcP.Key
+	var handlerGrpcWebhook *handlerGrpcP.Webhook
 
 	// logger
 	{
@@ -92,6 +131,16 @@ func (a *App) Init() {
 		errCheck(err, "pgxpool.NewWithConfig")
 	}
 
+	// keyring
+	{
+		if len(config.Conf.MasterKeyFiles) > 0 {
+			a.keyring, err = keyring.New(config.Conf.MasterKeyFiles, config.Conf.MasterKeyActiveID, config.Conf.ValueHashKeyFile)
+			errCheck(err, "keyring.New")
+		} else {
+			slog.Warn("MASTER_KEY_FILES is not set, key values are stored unencrypted")
+		}
+	}
+
 	// providers
 	providers := make(map[string]usecaseKeyP.ProviderServiceI, 3)
 
@@ -118,6 +167,19 @@ func (a *App) Init() {
 			megogoService = serviceMegogoP.New(repo)
 			providers[constant.ProviderMegogo] = megogoService
 		}
+
+		for providerID, service := range providers {
+			providers[providerID] = serviceResilientP.New(providerID, service, serviceResilientP.Config{
+				RetryAttempts:        config.Conf.ProviderRetryAttempts,
+				RetryBaseDelay:       config.Conf.ProviderRetryBaseDelay,
+				RetryMaxDelay:        config.Conf.ProviderRetryMaxDelay,
+				BreakerFailures:      config.Conf.ProviderBreakerFailures,
+				BreakerOpenTimeout:   config.Conf.ProviderBreakerOpenTimeout,
+				OnBreakerStateChange: observeBreakerState,
+				OnRetry:              observeProviderRetry,
+			})
+			setBreakerStateMetric(providerID, constant.BreakerStateClosed)
+		}
 	}
 
 	// mdm
@@ -127,18 +189,89 @@ func (a *App) Init() {
 		mdmService = serviceMdmP.New(repo)
 	}
 
+	// alert
+	{
+		var repo serviceAlertP.RepoI
+		if config.Conf.PoolAlertWebhookUrl != "" {
+			repo = serviceAlertRepoP.New(config.Conf.PoolAlertWebhookUrl)
+		}
+		alertService = serviceAlertP.New(repo)
+	}
+
+	// callback
+	{
+		var repo serviceCallbackP.RepoI
+		if config.Conf.ActivationCallbackUrl != "" {
+			repo, err = serviceCallbackRepoP.New(config.Conf.ActivationCallbackUrl, config.Conf.ActivationCallbackSecretFile)
+			errCheck(err, "serviceCallbackRepoP.New")
+		}
+		callbackService = serviceCallbackP.New(repo)
+	}
+
+	// operation
+	{
+		repo := domainOperationRepoDbP.New(a.pgpool, a.keyring)
+		operationService = domainOperationServiceP.New(repo)
+	}
+
+	// import job
+	{
+		repo := domainImportJobRepoDbP.New(a.pgpool)
+		importJobService = domainImportJobServiceP.New(repo)
+	}
+
+	// pool level
+	{
+		repo := domainPoolLevelRepoDbP.New(a.pgpool)
+		poolLevelService = domainPoolLevelServiceP.New(repo)
+	}
+
+	// product provider
+	{
+		repo := domainProductProviderRepoDbP.New(a.pgpool)
+		productProviderService = domainProductProviderServiceP.New(repo)
+	}
+
+	// webhook
+	{
+		repo := domainWebhookRepoDbP.New(a.pgpool, a.keyring)
+		webhookService = domainWebhookServiceP.New(repo)
+		senderService := serviceWebhookP.New(serviceWebhookRepoP.New(config.Conf.WebhookTimeout))
+		a.webhookUsecase = usecaseWebhookP.New(webhookService, senderService, usecaseWebhookP.Config{
+			MaxAttempts:    config.Conf.WebhookMaxAttempts,
+			RetryBaseDelay: config.Conf.WebhookRetryBaseDelay,
+			RetryMaxDelay:  config.Conf.WebhookRetryMaxDelay,
+			Lease:          2 * config.Conf.WebhookTimeout,
+		})
+		handlerGrpcWebhook = handlerGrpcP.NewWebhook(a.webhookUsecase)
+	}
+
 	// key
 	{
-		repo := domainKeyRepoDbP.New(a.pgpool)
+		if !slices.Contains(constant.KeyReturnPolicies, config.Conf.KeyReturnPolicy) {
+			errCheck(fmt.Errorf("unknown value %q, expected one of %v", config.Conf.KeyReturnPolicy, constant.KeyReturnPolicies), "KEY_RETURN_POLICY")
+		}
+
+		repo := domainKeyRepoDbP.New(a.pgpool, a.keyring)
 		service := domainKeyServiceP.New(repo)
-		usecase := usecaseKeyP.New(service, mdmService, providers)
-		handlerGrpcKey = handlerGrpcP.NewKey(usecase)
+		a.keyUsecase = usecaseKeyP.New(service, operationService, importJobService, poolLevelService, productProviderService, mdmService, alertService, callbackService, webhookService, providers, config.Conf.KeyReturnPolicy)
+		handlerGrpcKey = handlerGrpcP.NewKey(a.keyUsecase)
 	}
 
 	// grpc server
 	{
-		a.grpcServer = NewGrpcServer("main", func(server *grpc.Server) {
+		authenticator, err := newAuthenticator(config.Conf.AuthTokensFile, config.Conf.AuthJwtHs256KeyFile, config.Conf.AuthJwtRs256PublicKeyFile, config.Conf.AuthJwtIssuer)
+		errCheck(err, "newAuthenticator")
+		// без проверки токенов роль не из чего взять: сервис не запускается
+		if authenticator == nil {
+			errCheck(errors.New("AUTH_TOKENS_FILE or AUTH_JWT_* must be set"), "newAuthenticator")
+		}
+
+		permissions := lo.Assign(handlerGrpcP.KeyPermissions, handlerGrpcP.WebhookPermissions)
+
+		a.grpcServer = NewGrpcServer("main", authenticator, permissions, func(server *grpc.Server) {
 			eProductV1.RegisterKeyServer(server, handlerGrpcKey)
+			eProductV1.RegisterWebhookServer(server, handlerGrpcWebhook)
 		})
 	}
 
@@ -156,6 +289,7 @@ func (a *App) Init() {
 			// register grpc handlers
 			handlers := []func(context.Context, *runtime.ServeMux, *grpc.ClientConn) error{
 				eProductV1.RegisterKeyHandler,
+				eProductV1.RegisterWebhookHandler,
 			}
 			for _, h := range handlers {
 				err = h(context.Background(), mux, conn)
@@ -164,6 +298,8 @@ func (a *App) Init() {
 				}
 			}
 
+			handlerHttpKey := handlerHttpP.NewKey(mux, eProductV1.NewKeyClient(conn))
+
 			// http handlers
 			httpHandlers := []struct {
 				method  string
@@ -175,6 +311,8 @@ func (a *App) Init() {
 						slog.Error("test error", "error", errors.New("test error"))
 					},
 				},
+				{"POST", "/key/import", handlerHttpKey.Import},
+				{"GET", "/key/export", handlerHttpKey.Export},
 				// examples:
 				// {"POST", "/route/register", handlerHttpRouteRegister.Register},
 				// {"GET", "/route/{id}/link", handlerHttpRouteRegister.GetLink},
@@ -225,6 +363,19 @@ func (a *App) Start() {
 		}()
 		slog.Info("http-server started " + a.httpServer.Addr)
 	}
+
+	// jobs
+	{
+		a.startJob("reconcile", config.Conf.ReconcileInterval, a.keyUsecase.Reconcile)
+		a.startJob("release_reservations", config.Conf.ReservationSweepInterval, a.keyUsecase.ReleaseExpired)
+		a.startJob("replenish", config.Conf.ReplenishInterval, a.replenish)
+		a.startActivationWorkers(config.Conf.ActivationWorkers, config.Conf.ActivationPollInterval)
+		a.startJob("webhook_deliver", config.Conf.WebhookDeliverInterval, a.webhookUsecase.Deliver)
+
+		if a.keyring != nil {
+			a.startJob("reencrypt", config.Conf.ReencryptInterval, a.keyUsecase.Reencrypt)
+		}
+	}
 }
 
 func (a *App) Listen() {
@@ -258,6 +409,8 @@ func (a *App) Stop() {
 
 func (a *App) WaitJobs() {
 	slog.Info("waiting jobs")
+
+	a.jobsWg.Wait()
 }
 
 func (a *App) Exit() {
@@ -13,12 +13,14 @@ import (
 	otgrpc "github.com/opentracing-contrib/go-grpc"
 	"github.com/opentracing/opentracing-go"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/protoadapt"
 
 	"github.com/mechta-market/e-product/internal/config"
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/common/auth"
 	"github.com/mechta-market/e-product/internal/errs"
 	"github.com/mechta-market/e-product/pkg/proto/common"
 )
@@ -28,23 +30,33 @@ type GrpcServer struct {
 	server *grpc.Server
 }
 
-func NewGrpcServer(name string, register func(*grpc.Server)) *GrpcServer {
+// NewGrpcServer создает grpc-сервер. Каждый вызов проверяется authenticator, роль берется только из токена
+func NewGrpcServer(name string, authenticator *auth.Authenticator, permissions map[string][]string, register func(*grpc.Server)) *GrpcServer {
 	interceptors := make([]grpc.UnaryServerInterceptor, 0, 4)
+	streamInterceptors := make([]grpc.StreamServerInterceptor, 0, 4)
 
 	// ctx without cancel
 	interceptors = append(interceptors, GrpcInterceptorCtxWithoutCancel())
+	streamInterceptors = append(streamInterceptors, GrpcStreamInterceptorCtxWithoutCancel())
 
 	// error
 	interceptors = append(interceptors, GrpcInterceptorError())
+	streamInterceptors = append(streamInterceptors, GrpcStreamInterceptorError())
+
+	// auth, после error: ошибки доступа отдаются в общем формате
+	interceptors = append(interceptors, GrpcInterceptorAuth(authenticator, permissions))
+	streamInterceptors = append(streamInterceptors, GrpcStreamInterceptorAuth(authenticator, permissions))
 
 	// tracing
 	if config.Conf.WithTracing {
 		interceptors = append(interceptors, GrpcInterceptorTracing())
+		streamInterceptors = append(streamInterceptors, GrpcStreamInterceptorTracing())
 	}
 
 	// metrics
 	if config.Conf.WithMetrics {
 		interceptors = append(interceptors, GrpcInterceptorMetrics())
+		streamInterceptors = append(streamInterceptors, GrpcStreamInterceptorMetrics())
 	}
 
 	// server
@@ -52,6 +64,7 @@ func NewGrpcServer(name string, register func(*grpc.Server)) *GrpcServer {
 		grpc.MaxSendMsgSize(math.MaxUint32),
 		grpc.MaxRecvMsgSize(math.MaxUint32),
 		grpc.ChainUnaryInterceptor(interceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
 	)
 
 	// register handlers
@@ -132,49 +145,116 @@ func GrpcInterceptorError() grpc.UnaryServerInterceptor {
 			return h, nil
 		}
 
-		var ei protoadapt.MessageV1
-		errStr := err.Error()
+		return h, grpcErrorStatus(ctx, err, info.FullMethod)
+	}
+}
 
-		var errBase errs.Err
-		if errors.As(err, &errBase) { // constant.Err
-			ei = &common.ErrorRep{
-				Code:    errBase.Error(),
-				Message: errStr,
-			}
-		} else {
-			var errFull errs.ErrFull
-			if errors.As(err, &errFull) { // constant.ErrFull
-				ei = &common.ErrorRep{
-					Code:    errFull.Err.Error(),
-					Message: errFull.Desc,
-					Fields:  errFull.Fields,
-				}
-			}
+func GrpcStreamInterceptorCtxWithoutCancel() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &grpcServerStream{ServerStream: ss, ctx: context.WithoutCancel(ss.Context())})
+	}
+}
+
+func GrpcStreamInterceptorTracing() grpc.StreamServerInterceptor {
+	tracer := opentracing.GlobalTracer()
+
+	return otgrpc.OpenTracingStreamServerInterceptor(tracer)
+}
+
+func GrpcStreamInterceptorMetrics() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		responseStatus := "ok"
+		if err != nil {
+			responseStatus = "error"
 		}
-		if ei == nil {
+
+		metricRequestCounter.WithLabelValues("grpc", info.FullMethod, responseStatus).Inc()
+		metricResponseDuration.WithLabelValues("grpc", info.FullMethod, responseStatus).Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
+
+func GrpcStreamInterceptorError() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err == nil {
+			return nil
+		}
+
+		return grpcErrorStatus(ss.Context(), err, info.FullMethod)
+	}
+}
+
+// grpcServerStream подменяет контекст потока
+type grpcServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *grpcServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func grpcLang(ctx context.Context) string {
+	if values := metadata.ValueFromIncomingContext(ctx, constant.LangMetadataKey); len(values) > 0 {
+		return errs.ParseLang(values[0])
+	}
+
+	return errs.DefaultLang
+}
+
+// grpcErrorStatus переводит ошибку обработчика в grpc-статус с common.ErrorRep в details.
+// Текст ошибки - на языке из метаданных accept-language
+func grpcErrorStatus(ctx context.Context, err error, method string) error {
+	var ei protoadapt.MessageV1
+	errStr := err.Error()
+	lang := grpcLang(ctx)
+
+	var errBase errs.Err
+	if errors.As(err, &errBase) { // constant.Err
+		ei = &common.ErrorRep{
+			Code:    errBase.Error(),
+			Message: errs.Message(errBase, lang),
+		}
+	} else {
+		var errFull errs.ErrFull
+		if errors.As(err, &errFull) { // constant.ErrFull
 			ei = &common.ErrorRep{
-				Code:    errs.ServiceNA.Error(),
-				Message: errStr,
+				Code:    errFull.Err.Error(),
+				Message: errFull.Message(lang),
+				Fields:  errFull.Fields,
 			}
 		}
+	}
+	if ei == nil {
+		ei = &common.ErrorRep{
+			Code:    errs.ServiceNA.Error(),
+			Message: errStr,
+		}
+	}
 
-		slog.Info(
-			"GRPC handler error",
-			slog.String("error", errStr),
-			slog.String("method", info.FullMethod),
-		)
+	slog.Info(
+		"GRPC handler error",
+		slog.String("error", errStr),
+		slog.String("method", method),
+	)
 
-		st := status.New(codes.InvalidArgument, errStr)
-		st, err = st.WithDetails(ei)
-		if err != nil {
-			slog.Error(
-				"error while creating status with details",
-				slog.String("error", errStr),
-				slog.String("method", info.FullMethod),
-			)
-			st = status.New(codes.InvalidArgument, errStr)
-		}
+	code := errs.GrpcCode(err)
 
-		return h, st.Err()
+	st, stErr := status.New(code, errStr).WithDetails(ei)
+	if stErr != nil {
+		slog.Error(
+			"error while creating status with details",
+			slog.String("error", errStr),
+			slog.String("method", method),
+		)
+		st = status.New(code, errStr)
 	}
+
+	return st.Err()
 }
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/common/auth"
+	"github.com/mechta-market/e-product/internal/domain/common/util"
+	"github.com/mechta-market/e-product/internal/errs"
+)
+
+const authMetadataKey = "authorization"
+
+// GrpcInterceptorAuth проверяет токен из authorization (Bearer) и право роли на метод по permissions.
+// Инициатор и роль в ctx берутся только из токена
+func GrpcInterceptorAuth(authenticator *auth.Authenticator, permissions map[string][]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		ctx, err = grpcCtxWithAuth(ctx, authenticator, permissions, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func GrpcStreamInterceptorAuth(authenticator *auth.Authenticator, permissions map[string][]string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := grpcCtxWithAuth(ss.Context(), authenticator, permissions, info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &grpcServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func grpcCtxWithAuth(ctx context.Context, authenticator *auth.Authenticator, permissions map[string][]string, method string) (context.Context, error) {
+	token := ""
+	if values := metadata.ValueFromIncomingContext(ctx, authMetadataKey); len(values) > 0 {
+		token, _ = strings.CutPrefix(values[0], "Bearer ")
+	}
+
+	principal, err := authenticator.Authenticate(token)
+	if err != nil {
+		slog.Info("grpc auth failed", "error", err, "method", method)
+
+		return nil, errs.ErrFull{
+			Err: errs.NotAuthorized,
+		}
+	}
+
+	if principal.Role != constant.RoleAdmin && !slices.Contains(permissions[method], principal.Role) {
+		return nil, errs.ErrFull{
+			Err: errs.PermissionDenied,
+			Msg: errs.MsgMethodDenied,
+			Fields: map[string]string{
+				"role": principal.Role,
+			},
+		}
+	}
+
+	ctx = util.CtxWithActor(ctx, principal.Subject)
+	ctx = util.CtxWithRole(ctx, principal.Role)
+
+	return ctx, nil
+}
+
+// newAuthenticator собирает проверку токенов из конфигурации, nil - ни токены, ни ключи JWT не заданы
+func newAuthenticator(tokensFile, hsKeyFile, rsPublicKeyFile, issuer string) (*auth.Authenticator, error) {
+	verifiers := make([]auth.Verifier, 0, 2)
+
+	if tokensFile != "" {
+		tokens, err := auth.NewStaticTokens(tokensFile)
+		if err != nil {
+			return nil, err
+		}
+		verifiers = append(verifiers, tokens)
+	}
+
+	if hsKeyFile != "" || rsPublicKeyFile != "" {
+		jwtVerifier, err := auth.NewJWT(hsKeyFile, rsPublicKeyFile, issuer)
+		if err != nil {
+			return nil, err
+		}
+		verifiers = append(verifiers, jwtVerifier)
+	}
+
+	if len(verifiers) == 0 {
+		return nil, nil
+	}
+
+	return auth.New(verifiers...), nil
+}
@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"net/http"
 	"runtime/debug"
+	"strings"
 
 	"github.com/goccy/go-json"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -19,10 +20,17 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 
 	"github.com/mechta-market/e-product/internal/config"
+	"github.com/mechta-market/e-product/internal/constant"
 )
 
 func GrpcGatewayCreateHandler(muxHook func(*runtime.ServeMux) error) (http.Handler, error) {
 	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+			if strings.EqualFold(key, constant.LangMetadataKey) {
+				return constant.LangMetadataKey, true
+			}
+			return runtime.DefaultHeaderMatcher(key)
+		}),
 		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
 			MarshalOptions: protojson.MarshalOptions{
 				UseProtoNames:   true,
@@ -34,13 +42,19 @@ func GrpcGatewayCreateHandler(muxHook func(*runtime.ServeMux) error) (http.Handl
 		}),
 		runtime.WithErrorHandler(func(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
 			var repBody []byte
+			httpStatus := http.StatusBadRequest
 
 			if st, ok := status.FromError(err); ok {
-				if st.Code() == codes.NotFound {
+				if st.Code() == codes.NotFound && len(st.Details()) == 0 {
 					w.WriteHeader(http.StatusNotFound)
 					_, _ = w.Write([]byte(`service path not found`))
 					return
-				} else if st.Code() == codes.InvalidArgument && len(st.Details()) > 0 {
+				}
+
+				// http-статус - по grpc-коду, коды ошибок сервиса задаются в errs.GrpcCode
+				httpStatus = runtime.HTTPStatusFromCode(st.Code())
+
+				if len(st.Details()) > 0 {
 					var marshalErr error
 					repBody, marshalErr = marshaler.Marshal(st.Details()[0])
 					if marshalErr != nil {
@@ -68,7 +82,7 @@ func GrpcGatewayCreateHandler(muxHook func(*runtime.ServeMux) error) (http.Handl
 			//slog.Error("GRPC_GW: ErrorHandler", "error", err)
 
 			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
+			w.WriteHeader(httpStatus)
 			_, err = io.Copy(w, bytes.NewReader(repBody))
 			if err != nil {
 				slog.Error("GRPC_GW: ErrorHandler: Failed to write response", "error", err)
@@ -131,6 +145,7 @@ func GrpcGatewayCreateHandler(muxHook func(*runtime.ServeMux) error) (http.Handl
 				"Content-Type",
 				"X-Requested-With",
 				"Authorization",
+				"Accept-Language",
 			},
 			AllowCredentials: true,
 			MaxAge:           604800,
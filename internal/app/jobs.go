@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/mechta-market/e-product/internal/config"
+)
+
+// startJob периодически запускает fn до остановки приложения
+func (a *App) startJob(name string, interval time.Duration, fn func(ctx context.Context) error) {
+	a.jobsWg.Add(1)
+
+	go func() {
+		defer a.jobsWg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-a.ctx.Done():
+				return
+			case <-ticker.C:
+				if err := fn(a.ctx); err != nil {
+					slog.Error("job error", "job", name, "error", err)
+				}
+			}
+		}
+	}()
+
+	slog.Info("job started " + name)
+}
+
+// startActivationWorkers запускает воркеры асинхронной выдачи ключей: очередь разбирается
+// по сигналу о новой выдаче и периодически, чтобы подобрать брошенные выдачи
+func (a *App) startActivationWorkers(count int, interval time.Duration) {
+	for i := 0; i < count; i++ {
+		a.jobsWg.Add(1)
+
+		go func() {
+			defer a.jobsWg.Done()
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-a.ctx.Done():
+					return
+				case <-a.keyUsecase.ActivationWake():
+				case <-ticker.C:
+				}
+
+				if err := a.keyUsecase.ProcessActivations(a.ctx, config.Conf.ActivationProcessingTimeout); err != nil {
+					slog.Error("job error", "job", "activation", "error", err)
+				}
+			}
+		}()
+	}
+
+	slog.Info("activation workers started", "count", count)
+}
+
+// replenish пополняет пулы ключей и обновляет метрики пулов
+func (a *App) replenish(ctx context.Context) error {
+	states, err := a.keyUsecase.Replenish(ctx, config.Conf.ReplenishDailyCap)
+	if err != nil {
+		return fmt.Errorf("keyUsecase.Replenish: %w", err)
+	}
+
+	observePoolStates(states)
+
+	return nil
+}
@@ -8,11 +8,22 @@ import (
 
 	"github.com/mechta-market/e-product/internal/config"
 	"github.com/mechta-market/e-product/internal/constant"
+	poolLevelModel "github.com/mechta-market/e-product/internal/domain/poollevel/model"
 )
 
 var (
 	metricRequestCounter   *prometheus.CounterVec
 	metricResponseDuration *prometheus.HistogramVec
+
+	metricPoolAvailable      *prometheus.GaugeVec
+	metricPoolMinLevel       *prometheus.GaugeVec
+	metricPoolTargetLevel    *prometheus.GaugeVec
+	metricPoolCapReached     *prometheus.GaugeVec
+	metricReplenishPurchased *prometheus.CounterVec
+	metricReplenishFailed    *prometheus.CounterVec
+
+	metricProviderBreakerState *prometheus.GaugeVec
+	metricProviderRetry        *prometheus.CounterVec
 )
 
 func init() {
@@ -39,4 +50,105 @@ func init() {
 		"method",
 		"status",
 	})
+
+	metricPoolAvailable = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: config.Conf.Namespace,
+		Name:      constant.ServiceName + "_pool_available",
+	}, []string{"product_id"})
+
+	metricPoolMinLevel = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: config.Conf.Namespace,
+		Name:      constant.ServiceName + "_pool_min_level",
+	}, []string{"product_id"})
+
+	metricPoolTargetLevel = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: config.Conf.Namespace,
+		Name:      constant.ServiceName + "_pool_target_level",
+	}, []string{"product_id"})
+
+	metricPoolCapReached = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: config.Conf.Namespace,
+		Name:      constant.ServiceName + "_pool_cap_reached",
+	}, []string{"product_id"})
+
+	metricReplenishPurchased = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: config.Conf.Namespace,
+		Name:      constant.ServiceName + "_replenish_purchased_count",
+	}, []string{"product_id"})
+
+	metricReplenishFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: config.Conf.Namespace,
+		Name:      constant.ServiceName + "_replenish_failed_count",
+	}, []string{"product_id"})
+
+	metricProviderBreakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: config.Conf.Namespace,
+		Name:      constant.ServiceName + "_provider_breaker_state",
+	}, []string{"provider_id"})
+
+	metricProviderRetry = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: config.Conf.Namespace,
+		Name:      constant.ServiceName + "_provider_retry_count",
+	}, []string{"provider_id", "method"})
+}
+
+// observePoolStates обновляет метрики пулов по результату пополнения. Пулы, для которых
+// пороги удалены, из метрик пропадают
+func observePoolStates(states []*poolLevelModel.State) {
+	if !config.Conf.WithMetrics {
+		return
+	}
+
+	metricPoolAvailable.Reset()
+	metricPoolMinLevel.Reset()
+	metricPoolTargetLevel.Reset()
+	metricPoolCapReached.Reset()
+
+	for _, state := range states {
+		productID := state.Level.ProductID
+
+		metricPoolAvailable.WithLabelValues(productID).Set(float64(state.Available))
+		metricPoolMinLevel.WithLabelValues(productID).Set(float64(state.Level.MinLevel))
+		metricPoolTargetLevel.WithLabelValues(productID).Set(float64(state.Level.TargetLevel))
+
+		capReached := 0.0
+		if state.CapReached {
+			capReached = 1
+		}
+		metricPoolCapReached.WithLabelValues(productID).Set(capReached)
+
+		metricReplenishPurchased.WithLabelValues(productID).Add(float64(state.Purchased))
+		metricReplenishFailed.WithLabelValues(productID).Add(float64(state.Failed))
+	}
+}
+
+// observeBreakerState вызывается при смене состояния circuit breaker провайдера
+func observeBreakerState(providerID, state string) {
+	slog.Warn("provider breaker state changed", "provider_id", providerID, "state", state)
+
+	setBreakerStateMetric(providerID, state)
+}
+
+// setBreakerStateMetric метрика состояния breaker: 0 - closed, 1 - half_open, 2 - open
+func setBreakerStateMetric(providerID, state string) {
+	if !config.Conf.WithMetrics {
+		return
+	}
+
+	value := 0.0
+	switch state {
+	case constant.BreakerStateHalfOpen:
+		value = 1
+	case constant.BreakerStateOpen:
+		value = 2
+	}
+	metricProviderBreakerState.WithLabelValues(providerID).Set(value)
+}
+
+func observeProviderRetry(providerID, method string) {
+	if !config.Conf.WithMetrics {
+		return
+	}
+
+	metricProviderRetry.WithLabelValues(providerID, method).Inc()
 }
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/caarlos0/env/v9"
 	_ "github.com/joho/godotenv/autoload"
 )
@@ -33,6 +35,58 @@ var Conf = struct {
 	MegogoUrl      string `env:"MEGOGO_URL"`
 	MegogoUsername string `env:"MEGOGO_USERNAME"`
 	MegogoPassword string `env:"MEGOGO_PASSWORD"`
+
+	// повтор чтения каталога провайдеров с jitter backoff и circuit breaker по сбоям (сеть, таймаут, 5xx):
+	// после PROVIDER_BREAKER_FAILURES сбоев подряд провайдер не вызывается PROVIDER_BREAKER_OPEN_TIMEOUT
+	ProviderRetryAttempts      int           `env:"PROVIDER_RETRY_ATTEMPTS" envDefault:"3"`
+	ProviderRetryBaseDelay     time.Duration `env:"PROVIDER_RETRY_BASE_DELAY" envDefault:"200ms"`
+	ProviderRetryMaxDelay      time.Duration `env:"PROVIDER_RETRY_MAX_DELAY" envDefault:"2s"`
+	ProviderBreakerFailures    int           `env:"PROVIDER_BREAKER_FAILURES" envDefault:"5"`
+	ProviderBreakerOpenTimeout time.Duration `env:"PROVIDER_BREAKER_OPEN_TIMEOUT" envDefault:"30s"`
+
+	ReconcileInterval time.Duration `env:"RECONCILE_INTERVAL" envDefault:"1m"`
+
+	// что делать с ключом из пула после отмены заказа: quarantine - оставить в returned,
+	// reissue - снова выдавать из пула. Ключи провайдеров отменяются у провайдера
+	KeyReturnPolicy string `env:"KEY_RETURN_POLICY" envDefault:"quarantine"`
+
+	// период снятия истекших резервов ключей
+	ReservationSweepInterval time.Duration `env:"RESERVATION_SWEEP_INTERVAL" envDefault:"1m"`
+
+	// асинхронная выдача ключей: число воркеров, период опроса очереди, время, после которого выдача
+	// в processing считается брошенной, и callback с результатом (подпись HMAC-SHA256 секретом из файла)
+	ActivationWorkers            int           `env:"ACTIVATION_WORKERS" envDefault:"4"`
+	ActivationPollInterval       time.Duration `env:"ACTIVATION_POLL_INTERVAL" envDefault:"2s"`
+	ActivationProcessingTimeout  time.Duration `env:"ACTIVATION_PROCESSING_TIMEOUT" envDefault:"5m"`
+	ActivationCallbackUrl        string        `env:"ACTIVATION_CALLBACK_URL"`
+	ActivationCallbackSecretFile string        `env:"ACTIVATION_CALLBACK_SECRET_FILE"`
+
+	// webhook подписчиков на события ключей: период отправки очереди, таймаут запроса и повтор неудачных
+	// доставок с экспоненциальной задержкой, после WEBHOOK_MAX_ATTEMPTS попыток доставка переходит в dead
+	WebhookDeliverInterval time.Duration `env:"WEBHOOK_DELIVER_INTERVAL" envDefault:"5s"`
+	WebhookTimeout         time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s"`
+	WebhookMaxAttempts     int64         `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"8"`
+	WebhookRetryBaseDelay  time.Duration `env:"WEBHOOK_RETRY_BASE_DELAY" envDefault:"30s"`
+	WebhookRetryMaxDelay   time.Duration `env:"WEBHOOK_RETRY_MAX_DELAY" envDefault:"1h"`
+
+	// пополнение пулов ключей: период, общий лимит покупок за сутки (0 - без ограничения)
+	// и webhook для оповещений о пулах ниже минимального уровня (если не задан - только лог)
+	ReplenishInterval   time.Duration `env:"REPLENISH_INTERVAL" envDefault:"5m"`
+	ReplenishDailyCap   int64         `env:"REPLENISH_DAILY_CAP" envDefault:"0"`
+	PoolAlertWebhookUrl string        `env:"POOL_ALERT_WEBHOOK_URL"`
+
+	// файлы мастер-ключей для шифрования значений ключей, id ключа - имя файла без расширения
+	MasterKeyFiles    []string      `env:"MASTER_KEY_FILES"`
+	MasterKeyActiveID string        `env:"MASTER_KEY_ACTIVE_ID"`
+	ValueHashKeyFile  string        `env:"VALUE_HASH_KEY_FILE"`
+	ReencryptInterval time.Duration `env:"REENCRYPT_INTERVAL" envDefault:"10m"`
+
+	// аутентификация: файл статических API-токенов и ключи JWT (HS256 - секрет, RS256 - публичный ключ PEM).
+	// Обязательно хотя бы одно: без проверки токенов сервис не запускается
+	AuthTokensFile            string `env:"AUTH_TOKENS_FILE"`
+	AuthJwtHs256KeyFile       string `env:"AUTH_JWT_HS256_KEY_FILE"`
+	AuthJwtRs256PublicKeyFile string `env:"AUTH_JWT_RS256_PUBLIC_KEY_FILE"`
+	AuthJwtIssuer             string `env:"AUTH_JWT_ISSUER"`
 }{}
 
 func init() {
@@ -1,20 +1,176 @@
 package constant
 
+import "time"
+
 const (
 	ServiceName = "e_product"
 
 	MaxPageSize = 1000
+
+	// время, после которого незавершенная блокировка заказа считается зависшей
+	OrderLockTTL = 2 * time.Minute
 )
 
 // Key status
 const (
 	KeyStatusNew       = "new"
+	KeyStatusReserved  = "reserved"
 	KeyStatusActivated = "activated"
 	KeyStatusCancelled = "cancelled"
+	KeyStatusReturned  = "returned"  // возвращен в пул после отмены заказа
+	KeyStatusExpired   = "expired"   // истек срок действия лицензии
+	KeyStatusWithdrawn = "withdrawn" // изъят из оборота
+)
+
+// Key source - откуда получен ключ, от этого зависит отмена
+const (
+	KeySourcePool     = "pool"     // загружен в пул (Load, Import), у провайдера не отменяется
+	KeySourceProvider = "provider" // куплен у провайдера, отменяется через провайдера
+)
+
+// Key return policy - что делать с ключом из пула, возвращенным после отмены заказа
+const (
+	KeyReturnPolicyQuarantine = "quarantine" // остается в returned до решения вручную
+	KeyReturnPolicyReissue    = "reissue"    // снова выдается из пула
 )
 
+var KeyReturnPolicies = []string{KeyReturnPolicyQuarantine, KeyReturnPolicyReissue}
+
 const (
 	ProviderComportal = "42eafc49-dd73-4ae8-9add-c0ffcd0a5a9e"
 	ProviderASBIS     = "00ca36a3-4070-45fe-a319-dd7f5a04ee36"
 	ProviderMegogo    = "8ccd5764-7117-4bf8-9aa8-cad0d8910532"
 )
+
+// Provider operation status
+const (
+	OperationStatusRequested    = "requested"
+	OperationStatusPurchased    = "purchased" // куплен у провайдера, но еще не сохранен в key
+	OperationStatusStored       = "stored"
+	OperationStatusFailed       = "failed"
+	OperationStatusManualReview = "manual_review"
+	OperationStatusSkipped      = "skipped" // провайдер не вызывался: не подключен или открыт breaker
+)
+
+// Key load mode
+const (
+	LoadModeBestEffort   = "best_effort"
+	LoadModeAllOrNothing = "all_or_nothing"
+)
+
+// Key load item result
+const (
+	LoadResultCreated   = "created"
+	LoadResultDuplicate = "duplicate"
+	LoadResultInvalid   = "invalid"
+	LoadResultSkipped   = "skipped"
+	LoadResultFailed    = "failed"
+)
+
+// Import job status
+const (
+	ImportJobStatusRunning   = "running"
+	ImportJobStatusCompleted = "completed"
+	ImportJobStatusFailed    = "failed"
+)
+
+// Import file format
+const (
+	ImportFormatCSV  = "csv"
+	ImportFormatXLSX = "xlsx"
+
+	MaxImportFileSize = 50 << 20
+)
+
+// Key export format
+const (
+	ExportFormatCSV   = "csv"
+	ExportFormatJSONL = "jsonl"
+
+	// размер части выгрузки в потоке Key.Export
+	ExportChunkSize = 64 << 10
+)
+
+// Key reservation status
+const (
+	ReservationStatusActive    = "active"
+	ReservationStatusConfirmed = "confirmed"
+	ReservationStatusReleased  = "released"
+	ReservationStatusExpired   = "expired"
+
+	// время, на которое ключ удерживается до оплаты заказа
+	ReservationTTL = 15 * time.Minute
+)
+
+// Key activation status
+const (
+	ActivationStatusPending    = "pending"
+	ActivationStatusProcessing = "processing"
+	ActivationStatusCompleted  = "completed"
+	ActivationStatusFailed     = "failed"
+)
+
+// Key event reason
+const (
+	EventReasonReservationReleased = "reservation_released"
+	EventReasonReservationExpired  = "reservation_expired"
+)
+
+// Key event actor
+const (
+	// ActorSystem - изменение без внешнего инициатора: фоновые задачи
+	ActorSystem = "system"
+
+	LangMetadataKey = "accept-language"
+)
+
+// роли вызывающей стороны
+const (
+	RoleLoader     = "loader"
+	RoleStorefront = "storefront"
+	RoleSupport    = "support"
+	RoleAdmin      = "admin"
+)
+
+// действия журнала доступа к ключам
+const (
+	AuditActionRevealValue = "reveal_value"
+	AuditActionExport      = "export"
+)
+
+// состояние circuit breaker провайдера
+const (
+	BreakerStateClosed   = "closed"
+	BreakerStateOpen     = "open"
+	BreakerStateHalfOpen = "half_open" // пропускается один пробный вызов
+)
+
+// события webhook
+const (
+	WebhookEventActivated      = "activated"
+	WebhookEventCancelled      = "cancelled"
+	WebhookEventPoolLow        = "pool_low"
+	WebhookEventProviderFailed = "provider_failed"
+)
+
+var WebhookEvents = []string{
+	WebhookEventActivated,
+	WebhookEventCancelled,
+	WebhookEventPoolLow,
+	WebhookEventProviderFailed,
+}
+
+// статус доставки webhook
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusDelivered = "delivered"
+	WebhookDeliveryStatusDead      = "dead" // попытки исчерпаны, повтор только через Replay
+)
+
+// заголовки подписанных HTTP-уведомлений (callback, webhook)
+const (
+	HeaderSignature          = "X-Signature"
+	HeaderSignatureTimestamp = "X-Signature-Timestamp"
+	HeaderWebhookEvent       = "X-Webhook-Event"
+	HeaderWebhookDelivery    = "X-Webhook-Delivery"
+)
@@ -0,0 +1,206 @@
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken токен не подошел ни одному способу проверки
+var ErrInvalidToken = errors.New("invalid token")
+
+// Principal вызывающая сторона: Subject пишется инициатором в журналы, Role проверяется по правам метода
+type Principal struct {
+	Subject string
+	Role    string
+}
+
+// Verifier способ проверки токена. false без ошибки - токен не этого формата, проверяется следующим
+type Verifier interface {
+	Verify(token string) (*Principal, bool, error)
+}
+
+// Authenticator проверяет токен по очереди всеми Verifier
+type Authenticator struct {
+	verifiers []Verifier
+}
+
+func New(verifiers ...Verifier) *Authenticator {
+	return &Authenticator{verifiers: verifiers}
+}
+
+func (a *Authenticator) Authenticate(token string) (*Principal, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, ErrInvalidToken
+	}
+
+	for _, v := range a.verifiers {
+		principal, ok, err := v.Verify(token)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+		}
+		if ok {
+			return principal, nil
+		}
+	}
+
+	return nil, ErrInvalidToken
+}
+
+// StaticTokens статические API-токены сервисов. Хранятся sha256-хэши, а не сами токены
+type StaticTokens struct {
+	tokens map[string]*Principal
+}
+
+// NewStaticTokens читает файл токенов: по строке "<token> <subject> <role>", # - комментарий
+func NewStaticTokens(path string) (*StaticTokens, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &StaticTokens{tokens: make(map[string]*Principal)}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: expected <token> <subject> <role>", n)
+		}
+
+		hash := tokenHash(fields[0])
+		if _, ok := result.tokens[hash]; ok {
+			return nil, fmt.Errorf("line %d: duplicate token", n)
+		}
+
+		result.tokens[hash] = &Principal{
+			Subject: fields[1],
+			Role:    fields[2],
+		}
+	}
+
+	return result, scanner.Err()
+}
+
+func (s *StaticTokens) Verify(token string) (*Principal, bool, error) {
+	principal, ok := s.tokens[tokenHash(token)]
+
+	return principal, ok, nil
+}
+
+// Claims JWT: роль - в claim role, инициатор - sub
+type Claims struct {
+	jwt.RegisteredClaims
+	Role string `json:"role"`
+}
+
+// JWT проверяет токены HS256 и/или RS256. Токены без exp не принимаются
+type JWT struct {
+	hsKey  []byte
+	rsKey  *rsa.PublicKey
+	parser *jwt.Parser
+}
+
+// NewJWT загружает секрет HS256 и публичный ключ RS256 (PEM). Пустой путь - алгоритм не принимается
+func NewJWT(hsKeyFile, rsPublicKeyFile, issuer string) (*JWT, error) {
+	result := &JWT{}
+	methods := make([]string, 0, 2)
+
+	if hsKeyFile != "" {
+		data, err := os.ReadFile(hsKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("read hs256 key: %w", err)
+		}
+
+		result.hsKey = bytes.TrimSpace(data)
+		if len(result.hsKey) == 0 {
+			return nil, errors.New("hs256 key is empty")
+		}
+
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+
+	if rsPublicKeyFile != "" {
+		data, err := os.ReadFile(rsPublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("read rs256 public key: %w", err)
+		}
+
+		result.rsKey, err = jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("jwt.ParseRSAPublicKeyFromPEM: %w", err)
+		}
+
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+
+	if len(methods) == 0 {
+		return nil, errors.New("no jwt keys")
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+	}
+	if issuer != "" {
+		opts = append(opts, jwt.WithIssuer(issuer))
+	}
+
+	result.parser = jwt.NewParser(opts...)
+
+	return result, nil
+}
+
+func (j *JWT) Verify(token string) (*Principal, bool, error) {
+	// статический токен не похож на JWT: header.payload.signature
+	if strings.Count(token, ".") != 2 {
+		return nil, false, nil
+	}
+
+	claims := &Claims{}
+
+	_, err := j.parser.ParseWithClaims(token, claims, j.key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if claims.Subject == "" || claims.Role == "" {
+		return nil, false, errors.New("sub and role claims are required")
+	}
+
+	return &Principal{
+		Subject: claims.Subject,
+		Role:    claims.Role,
+	}, true, nil
+}
+
+func (j *JWT) key(token *jwt.Token) (any, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return j.hsKey, nil
+	case jwt.SigningMethodRS256.Alg():
+		return j.rsKey, nil
+	}
+
+	return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+}
+
+func tokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+
+	f := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(f, data, 0o600))
+
+	return f
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, key any, claims *Claims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	require.NoError(t, err)
+
+	return token
+}
+
+func TestAuthenticator(t *testing.T) {
+	dir := t.TempDir()
+
+	tokensFile := writeFile(t, dir, "tokens", []byte("# storefront\nsecret-1 web storefront\n\nsecret-2 loader-job loader\n"))
+	hsKey := []byte("hs256-secret")
+	hsKeyFile := writeFile(t, dir, "hs.key", append(hsKey, '\n'))
+
+	rsKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pubDer, err := x509.MarshalPKIXPublicKey(&rsKey.PublicKey)
+	require.NoError(t, err)
+	rsKeyFile := writeFile(t, dir, "rs.pub", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDer}))
+
+	tokens, err := NewStaticTokens(tokensFile)
+	require.NoError(t, err)
+	jwtVerifier, err := NewJWT(hsKeyFile, rsKeyFile, "sso")
+	require.NoError(t, err)
+
+	a := New(tokens, jwtVerifier)
+
+	claims := func(role string, exp time.Duration) *Claims {
+		return &Claims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   "operator-1",
+				Issuer:    "sso",
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(exp)),
+			},
+			Role: role,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    *Principal
+		wantErr bool
+	}{
+		{name: "static", token: "secret-1", want: &Principal{Subject: "web", Role: "storefront"}},
+		{name: "hs256", token: signToken(t, jwt.SigningMethodHS256, hsKey, claims("support", time.Hour)), want: &Principal{Subject: "operator-1", Role: "support"}},
+		{name: "rs256", token: signToken(t, jwt.SigningMethodRS256, rsKey, claims("admin", time.Hour)), want: &Principal{Subject: "operator-1", Role: "admin"}},
+		{name: "expired", token: signToken(t, jwt.SigningMethodHS256, hsKey, claims("support", -time.Hour)), wantErr: true},
+		{name: "wrong key", token: signToken(t, jwt.SigningMethodHS256, []byte("other"), claims("support", time.Hour)), wantErr: true},
+		{name: "no role", token: signToken(t, jwt.SigningMethodHS256, hsKey, claims("", time.Hour)), wantErr: true},
+		{name: "unknown static", token: "secret-3", wantErr: true},
+		{name: "empty", token: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := a.Authenticate(tt.token)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidToken)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, principal)
+		})
+	}
+
+	// без ключа RS256 такие токены не принимаются
+	hsOnly, err := NewJWT(hsKeyFile, "", "")
+	require.NoError(t, err)
+	_, err = New(hsOnly).Authenticate(signToken(t, jwt.SigningMethodRS256, rsKey, claims("admin", time.Hour)))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestNewStaticTokens_Invalid(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := NewStaticTokens(writeFile(t, dir, "bad", []byte("secret-1 web\n")))
+	assert.Error(t, err)
+
+	_, err = NewStaticTokens(writeFile(t, dir, "dup", []byte("secret-1 web storefront\nsecret-1 other admin\n")))
+	assert.Error(t, err)
+}
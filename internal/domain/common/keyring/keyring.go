@@ -0,0 +1,227 @@
+package keyring
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const keySize = 32
+
+// Sealed зашифрованное значение: данные шифруются случайным ключом (DEK),
+// а DEK - мастер-ключом KeyID
+type Sealed struct {
+	KeyID string
+	DEK   []byte
+	Data  []byte
+}
+
+// Keyring набор мастер-ключей для envelope-шифрования. Новые значения шифруются активным ключом,
+// остальные нужны для чтения, пока значения не перешифрованы
+type Keyring struct {
+	masters  map[string]cipher.AEAD
+	activeID string
+	hmacKey  []byte
+}
+
+// New загружает мастер-ключи из файлов, id ключа - имя файла без расширения.
+// Если activeID не задан, активным считается последний ключ в списке.
+// hmacKeyFile - ключ для детерминированного хэша значений, при ротации не меняется
+func New(masterKeyFiles []string, activeID, hmacKeyFile string) (*Keyring, error) {
+	if len(masterKeyFiles) == 0 {
+		return nil, errors.New("no master keys")
+	}
+
+	k := &Keyring{
+		masters:  make(map[string]cipher.AEAD, len(masterKeyFiles)),
+		activeID: activeID,
+	}
+
+	for _, f := range masterKeyFiles {
+		key, err := readKeyFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("readKeyFile %s: %w", f, err)
+		}
+
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("newAEAD %s: %w", f, err)
+		}
+
+		id := strings.TrimSuffix(filepath.Base(f), filepath.Ext(f))
+		if _, ok := k.masters[id]; ok {
+			return nil, fmt.Errorf("duplicate master key id %s", id)
+		}
+		k.masters[id] = aead
+
+		if activeID == "" {
+			k.activeID = id
+		}
+	}
+
+	if _, ok := k.masters[k.activeID]; !ok {
+		return nil, fmt.Errorf("active master key %s not found", k.activeID)
+	}
+
+	var err error
+	k.hmacKey, err = readKeyFile(hmacKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("readKeyFile %s: %w", hmacKeyFile, err)
+	}
+
+	return k, nil
+}
+
+func (k *Keyring) ActiveKeyID() string {
+	return k.activeID
+}
+
+func (k *Keyring) Encrypt(value string) (*Sealed, error) {
+	dek := make([]byte, keySize)
+	if _, err := rand.Read(dek); err != nil {
+		return nil, fmt.Errorf("rand.Read: %w", err)
+	}
+
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return nil, fmt.Errorf("newAEAD: %w", err)
+	}
+
+	data, err := seal(aead, []byte(value))
+	if err != nil {
+		return nil, fmt.Errorf("seal: %w", err)
+	}
+
+	wrapped, err := seal(k.masters[k.activeID], dek)
+	if err != nil {
+		return nil, fmt.Errorf("seal dek: %w", err)
+	}
+
+	return &Sealed{
+		KeyID: k.activeID,
+		DEK:   wrapped,
+		Data:  data,
+	}, nil
+}
+
+func (k *Keyring) Decrypt(s *Sealed) (string, error) {
+	dek, err := k.unwrap(s)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return "", fmt.Errorf("newAEAD: %w", err)
+	}
+
+	value, err := open(aead, s.Data)
+	if err != nil {
+		return "", fmt.Errorf("open: %w", err)
+	}
+
+	return string(value), nil
+}
+
+// Rewrap перешифровывает DEK активным мастер-ключом, сами данные не меняются
+func (k *Keyring) Rewrap(s *Sealed) (*Sealed, error) {
+	dek, err := k.unwrap(s)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped, err := seal(k.masters[k.activeID], dek)
+	if err != nil {
+		return nil, fmt.Errorf("seal dek: %w", err)
+	}
+
+	return &Sealed{
+		KeyID: k.activeID,
+		DEK:   wrapped,
+		Data:  s.Data,
+	}, nil
+}
+
+// Hash детерминированный HMAC-SHA256 значения для поиска без расшифровки
+func (k *Keyring) Hash(value string) string {
+	mac := hmac.New(sha256.New, k.hmacKey)
+	mac.Write([]byte(value))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (k *Keyring) unwrap(s *Sealed) ([]byte, error) {
+	master, ok := k.masters[s.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("master key %s not found", s.KeyID)
+	}
+
+	dek, err := open(master, s.DEK)
+	if err != nil {
+		return nil, fmt.Errorf("open dek: %w", err)
+	}
+
+	return dek, nil
+}
+
+// readKeyFile читает 32-байтный ключ: в base64, hex или как есть
+func readKeyFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	text := string(bytes.TrimSpace(data))
+
+	if key, err := base64.StdEncoding.DecodeString(text); err == nil && len(key) == keySize {
+		return key, nil
+	}
+
+	if key, err := hex.DecodeString(text); err == nil && len(key) == keySize {
+		return key, nil
+	}
+
+	if len(data) == keySize {
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("key must be %d bytes", keySize)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, data []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
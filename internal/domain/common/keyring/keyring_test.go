@@ -0,0 +1,86 @@
+package keyring
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKey(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+
+	f := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(f, data, 0o600))
+
+	return f
+}
+
+func TestKeyring(t *testing.T) {
+	dir := t.TempDir()
+
+	k1 := writeKey(t, dir, "k1.key", []byte(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, keySize))+"\n"))
+	k2 := writeKey(t, dir, "k2.key", []byte(hex.EncodeToString(bytes.Repeat([]byte{2}, keySize))))
+	hashKey := writeKey(t, dir, "hash.key", bytes.Repeat([]byte{3}, keySize))
+
+	old, err := New([]string{k1}, "", hashKey)
+	require.NoError(t, err)
+	assert.Equal(t, "k1", old.ActiveKeyID())
+
+	sealed, err := old.Encrypt("XXXX-YYYY-ZZZZ")
+	require.NoError(t, err)
+	assert.Equal(t, "k1", sealed.KeyID)
+	assert.NotContains(t, string(sealed.Data), "XXXX")
+
+	value, err := old.Decrypt(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "XXXX-YYYY-ZZZZ", value)
+
+	// ротация: новый ключ активный, старый остается для чтения
+	kr, err := New([]string{k1, k2}, "", hashKey)
+	require.NoError(t, err)
+	assert.Equal(t, "k2", kr.ActiveKeyID())
+
+	rewrapped, err := kr.Rewrap(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "k2", rewrapped.KeyID)
+	assert.Equal(t, sealed.Data, rewrapped.Data)
+
+	onlyNew, err := New([]string{k2}, "", hashKey)
+	require.NoError(t, err)
+
+	value, err = onlyNew.Decrypt(rewrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "XXXX-YYYY-ZZZZ", value)
+
+	_, err = onlyNew.Decrypt(sealed)
+	assert.ErrorContains(t, err, "master key k1 not found")
+
+	// хэш не зависит от мастер-ключей
+	assert.Equal(t, old.Hash("XXXX-YYYY-ZZZZ"), onlyNew.Hash("XXXX-YYYY-ZZZZ"))
+	assert.NotEqual(t, old.Hash("XXXX-YYYY-ZZZZ"), old.Hash("XXXX-YYYY-ZZZA"))
+}
+
+func TestNew_Invalid(t *testing.T) {
+	dir := t.TempDir()
+
+	k1 := writeKey(t, dir, "k1.key", bytes.Repeat([]byte{1}, keySize))
+	short := writeKey(t, dir, "short.key", []byte("short"))
+
+	_, err := New(nil, "", k1)
+	assert.Error(t, err)
+
+	_, err = New([]string{short}, "", k1)
+	assert.ErrorContains(t, err, "key must be 32 bytes")
+
+	_, err = New([]string{k1}, "k2", k1)
+	assert.ErrorContains(t, err, "active master key k2 not found")
+
+	_, err = New([]string{k1}, "", filepath.Join(dir, "missing.key"))
+	assert.Error(t, err)
+}
@@ -1,7 +1,11 @@
 package pg
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -16,3 +20,36 @@ func NewBase(con *pgxpool.Pool) *Base {
 		QB:  squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
 	}
 }
+
+// WithTx выполняет fn в одной транзакции: commit при успехе, rollback при ошибке
+func (b *Base) WithTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := b.Con.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Con.Begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("tx.Commit: %w", err)
+	}
+
+	return nil
+}
+
+// ColumnMapSplit раскладывает ColumnMap модели на имена колонок и указатели для Scan
+func ColumnMapSplit(colMap map[string]any) ([]string, []any) {
+	names := make([]string, 0, len(colMap))
+	pointers := make([]any, 0, len(colMap))
+	for k, v := range colMap {
+		names = append(names, k)
+		pointers = append(pointers, v)
+	}
+
+	return names, pointers
+}
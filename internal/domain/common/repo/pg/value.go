@@ -0,0 +1,139 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/mechta-market/e-product/internal/domain/common/keyring"
+)
+
+// OpenValue возвращает value строки. Если value_key_id заполнен, значение хранится
+// в value_enc/value_dek и расшифровывается
+func OpenValue(kr *keyring.Keyring, value string, enc, dek []byte, keyID string) (string, error) {
+	if keyID == "" {
+		return value, nil
+	}
+
+	if kr == nil {
+		return "", errors.New("value is encrypted, but keyring is not configured")
+	}
+
+	result, err := kr.Decrypt(&keyring.Sealed{
+		KeyID: keyID,
+		DEK:   dek,
+		Data:  enc,
+	})
+	if err != nil {
+		return "", fmt.Errorf("keyring.Decrypt: %w", err)
+	}
+
+	return result, nil
+}
+
+// ReencryptValues переводит до limit строк таблицы на активный мастер-ключ: открытые значения шифруются
+// (withHash - с заполнением value_hash), у зашифрованных старым ключом перешифровывается DEK.
+// Возвращает число обработанных строк
+func (b *Base) ReencryptValues(ctx context.Context, kr *keyring.Keyring, table string, withHash bool, limit uint64) (int, error) {
+	count := 0
+
+	err := b.WithTx(ctx, func(tx pgx.Tx) error {
+		query, args, err := b.QB.Select("id", "value", "value_enc", "value_dek", "value_key_id").
+			From(table).
+			Where(squirrel.NotEq{"value_key_id": kr.ActiveKeyID()}).
+			Where(squirrel.Or{
+				squirrel.NotEq{"value_key_id": ""},
+				squirrel.NotEq{"value": ""},
+			}).
+			OrderBy("id").
+			Limit(limit).
+			Suffix("FOR UPDATE SKIP LOCKED").
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("fail to build select query: %w", err)
+		}
+
+		type row struct {
+			id    string
+			value string
+			enc   []byte
+			dek   []byte
+			keyID string
+		}
+
+		rows, err := tx.Query(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("fail to select: %w", err)
+		}
+
+		items := make([]*row, 0, limit)
+		for rows.Next() {
+			item := &row{}
+			err = rows.Scan(&item.id, &item.value, &item.enc, &item.dek, &item.keyID)
+			if err != nil {
+				rows.Close()
+				return fmt.Errorf("fail to scan: %w", err)
+			}
+			items = append(items, item)
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			return fmt.Errorf("rows.Err: %w", err)
+		}
+
+		for _, item := range items {
+			var sealed *keyring.Sealed
+			colMap := make(map[string]any, 5)
+
+			if item.keyID == "" {
+				sealed, err = kr.Encrypt(item.value)
+				if err != nil {
+					return fmt.Errorf("keyring.Encrypt: %w", err)
+				}
+
+				colMap["value"] = ""
+				if withHash {
+					colMap["value_hash"] = kr.Hash(item.value)
+				}
+			} else {
+				sealed, err = kr.Rewrap(&keyring.Sealed{
+					KeyID: item.keyID,
+					DEK:   item.dek,
+					Data:  item.enc,
+				})
+				if err != nil {
+					return fmt.Errorf("keyring.Rewrap: %w", err)
+				}
+			}
+
+			colMap["value_enc"] = sealed.Data
+			colMap["value_dek"] = sealed.DEK
+			colMap["value_key_id"] = sealed.KeyID
+
+			query, args, err = b.QB.Update(table).
+				SetMap(colMap).
+				Where(squirrel.Eq{"id": item.id}).
+				ToSql()
+			if err != nil {
+				return fmt.Errorf("fail to build update query: %w", err)
+			}
+
+			_, err = tx.Exec(ctx, query, args...)
+			if err != nil {
+				return fmt.Errorf("fail to update: %w", err)
+			}
+		}
+
+		count = len(items)
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("WithTx: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,36 @@
+package util
+
+import (
+	"context"
+
+	"github.com/mechta-market/e-product/internal/constant"
+)
+
+type actorCtxKey struct{}
+
+// CtxWithActor сохраняет инициатора запроса для журнала изменений
+func CtxWithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorCtxKey{}, actor)
+}
+
+func ActorFromCtx(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorCtxKey{}).(string); ok && actor != "" {
+		return actor
+	}
+
+	return constant.ActorSystem
+}
+
+type roleCtxKey struct{}
+
+// CtxWithRole сохраняет роль вызывающей стороны для проверки доступа
+func CtxWithRole(ctx context.Context, role string) context.Context {
+	return context.WithValue(ctx, roleCtxKey{}, role)
+}
+
+// RoleFromCtx возвращает роль вызывающей стороны, пустая строка - роль не передана
+func RoleFromCtx(ctx context.Context) string {
+	role, _ := ctx.Value(roleCtxKey{}).(string)
+
+	return role
+}
@@ -10,6 +10,8 @@ import (
 
 const (
 	defaultMaxPageSize int64 = 100
+
+	maskVisibleChars = 4
 )
 
 var (
@@ -46,3 +48,25 @@ func NormalizeAndValidatePhone(phone *string) bool {
 	}
 	return phoneRegexp.MatchString(*phone)
 }
+
+// MaskValue скрывает значение ключа, кроме последних символов. Разделители групп (дефис, пробел)
+// сохраняются: ABCD-EFGH-1A2B -> ****-****-1A2B
+func MaskValue(value string) string {
+	runes := []rune(value)
+	if len(runes) <= maskVisibleChars {
+		return strings.Repeat("*", len(runes))
+	}
+
+	visible := maskVisibleChars
+	for i := len(runes) - 1; i >= 0; i-- {
+		switch {
+		case runes[i] == '-' || runes[i] == ' ':
+		case visible > 0:
+			visible--
+		default:
+			runes[i] = '*'
+		}
+	}
+
+	return string(runes)
+}
@@ -0,0 +1,67 @@
+package importjob
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/mechta-market/e-product/internal/domain/importjob/model"
+	"github.com/mechta-market/e-product/internal/errs"
+)
+
+type Service struct {
+	repoDb RepoDbI
+}
+
+func New(repoDb RepoDbI) *Service {
+	return &Service{repoDb: repoDb}
+}
+
+func (s *Service) List(ctx context.Context, pars *model.ListReq) ([]*model.Main, int64, error) {
+	items, tCount, err := s.repoDb.List(ctx, pars)
+	if err != nil {
+		return nil, 0, fmt.Errorf("repoDb.List: %w", err)
+	}
+
+	return items, tCount, nil
+}
+
+func (s *Service) Get(ctx context.Context, id string, errNE bool) (*model.Main, bool, error) {
+	result, found, err := s.repoDb.Get(ctx, id)
+	if err != nil {
+		return nil, false, fmt.Errorf("repoDb.Get: %w", err)
+	}
+	if !found {
+		if errNE {
+			return nil, false, errs.ErrFull{
+				Err: errs.ObjectNotFound,
+				Msg: errs.MsgImportJobNotFound,
+			}
+		}
+		return nil, false, nil
+	}
+
+	return result, true, nil
+}
+
+func (s *Service) Update(ctx context.Context, obj *model.Edit) error {
+	obj.UpdatedAt = lo.ToPtr(time.Now())
+
+	err := s.repoDb.Update(ctx, obj)
+	if err != nil {
+		return fmt.Errorf("repoDb.Update: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) Create(ctx context.Context, obj *model.Edit) (string, error) {
+	id, err := s.repoDb.Create(ctx, obj)
+	if err != nil {
+		return "", fmt.Errorf("repoDb.Create: %w", err)
+	}
+
+	return id, nil
+}
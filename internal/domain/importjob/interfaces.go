@@ -0,0 +1,14 @@
+package importjob
+
+import (
+	"context"
+
+	"github.com/mechta-market/e-product/internal/domain/importjob/model"
+)
+
+type RepoDbI interface {
+	List(ctx context.Context, pars *model.ListReq) (_ []*model.Main, _ int64, finalError error)
+	Get(ctx context.Context, id string) (_ *model.Main, _ bool, finalError error)
+	Update(ctx context.Context, obj *model.Edit) (finalError error)
+	Create(ctx context.Context, obj *model.Edit) (_ string, finalError error)
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+
+	commonModel "github.com/mechta-market/e-product/internal/domain/common/model"
+)
+
+type Main struct {
+	ID             string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	FileName       string
+	Format         string
+	Mode           string
+	Status         string
+	TotalCount     int64
+	CreatedCount   int64
+	DuplicateCount int64
+	InvalidCount   int64
+	SkippedCount   int64
+	FailedCount    int64
+	Error          string
+	Items          []*Item
+}
+
+// Item результат загрузки одной строки файла
+type Item struct {
+	Row               int
+	ProductID         string
+	Result            string
+	ID                string
+	ExistingProductID string
+	Reason            string
+}
+
+type ListReq struct {
+	commonModel.ListParams
+
+	Status *string
+}
+
+type Edit struct {
+	ID             *string
+	UpdatedAt      *time.Time
+	FileName       *string
+	Format         *string
+	Mode           *string
+	Status         *string
+	TotalCount     *int64
+	CreatedCount   *int64
+	DuplicateCount *int64
+	InvalidCount   *int64
+	SkippedCount   *int64
+	FailedCount    *int64
+	Error          *string
+	Items          []*Item
+}
+
+// Mapping описывает, из каких колонок файла брать данные ключа.
+// Колонка задается названием из заголовка, номером (с 1) или буквой, как в Excel
+type Mapping struct {
+	ProductIDColumn  string
+	ValueColumn      string
+	HasHeader        bool
+	DefaultProductID string // используется, если колонка product_id не задана или пуста
+	Sheet            string // xlsx: лист, по умолчанию первый
+	Delimiter        string // csv: разделитель, по умолчанию ","
+}
+
+type ImportReq struct {
+	FileName string
+	Format   string
+	Mode     string
+	Mapping  Mapping
+}
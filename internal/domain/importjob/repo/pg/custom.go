@@ -0,0 +1,21 @@
+package pg
+
+import "github.com/mechta-market/e-product/internal/domain/importjob/model"
+
+var (
+	allowedSortFields = map[string]string{
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	}
+)
+
+func (r *Repo) getConditions(pars *model.ListReq) (map[string]any, map[string][]any) {
+	conditions := make(map[string]any)
+	conditionExps := make(map[string][]any)
+
+	if pars.Status != nil {
+		conditions["status"] = *pars.Status
+	}
+
+	return conditions, conditionExps
+}
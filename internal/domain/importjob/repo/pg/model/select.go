@@ -0,0 +1,117 @@
+package model
+
+import (
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/mechta-market/e-product/internal/domain/importjob/model"
+)
+
+type Select struct {
+	ID             string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	FileName       string
+	Format         string
+	Mode           string
+	Status         string
+	TotalCount     int64
+	CreatedCount   int64
+	DuplicateCount int64
+	InvalidCount   int64
+	SkippedCount   int64
+	FailedCount    int64
+	Error          string
+}
+
+func (m *Select) ListColumnMap() map[string]any {
+	return map[string]any{
+		"id":              &m.ID,
+		"created_at":      &m.CreatedAt,
+		"updated_at":      &m.UpdatedAt,
+		"file_name":       &m.FileName,
+		"format":          &m.Format,
+		"mode":            &m.Mode,
+		"status":          &m.Status,
+		"total_count":     &m.TotalCount,
+		"created_count":   &m.CreatedCount,
+		"duplicate_count": &m.DuplicateCount,
+		"invalid_count":   &m.InvalidCount,
+		"skipped_count":   &m.SkippedCount,
+		"failed_count":    &m.FailedCount,
+		"error":           &m.Error,
+	}
+}
+
+func (m *Select) PKColumnMap() map[string]any {
+	return map[string]any{
+		"id": m.ID,
+	}
+}
+
+func (m *Select) DefaultSortColumns() []string {
+	return []string{
+		"created_at desc",
+	}
+}
+
+// SelectWithItems дополнительно читает построчный отчет, в списке он не нужен
+type SelectWithItems struct {
+	Select
+	Items []*Item
+}
+
+func (m *SelectWithItems) ListColumnMap() map[string]any {
+	result := m.Select.ListColumnMap()
+	result["items"] = &m.Items
+
+	return result
+}
+
+// Item строка отчета в колонке items (jsonb)
+type Item struct {
+	Row               int    `json:"row"`
+	ProductID         string `json:"product_id"`
+	Result            string `json:"result"`
+	ID                string `json:"id,omitempty"`
+	ExistingProductID string `json:"existing_product_id,omitempty"`
+	Reason            string `json:"reason,omitempty"`
+}
+
+func DecodeMain(m *Select, _ int) *model.Main {
+	return &model.Main{
+		ID:             m.ID,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+		FileName:       m.FileName,
+		Format:         m.Format,
+		Mode:           m.Mode,
+		Status:         m.Status,
+		TotalCount:     m.TotalCount,
+		CreatedCount:   m.CreatedCount,
+		DuplicateCount: m.DuplicateCount,
+		InvalidCount:   m.InvalidCount,
+		SkippedCount:   m.SkippedCount,
+		FailedCount:    m.FailedCount,
+		Error:          m.Error,
+	}
+}
+
+func DecodeMainWithItems(m *SelectWithItems) *model.Main {
+	result := DecodeMain(&m.Select, 0)
+	result.Items = lo.Map(m.Items, DecodeItem)
+
+	return result
+}
+
+func DecodeItem(m *Item, _ int) *model.Item {
+	return &model.Item{
+		Row:               m.Row,
+		ProductID:         m.ProductID,
+		Result:            m.Result,
+		ID:                m.ID,
+		ExistingProductID: m.ExistingProductID,
+		Reason:            m.Reason,
+	}
+}
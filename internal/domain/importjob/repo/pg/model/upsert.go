@@ -0,0 +1,146 @@
+package model
+
+import (
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/mechta-market/e-product/internal/domain/importjob/model"
+)
+
+type Upsert struct {
+	ID             string
+	UpdatedAt      *time.Time
+	FileName       *string
+	Format         *string
+	Mode           *string
+	Status         *string
+	TotalCount     *int64
+	CreatedCount   *int64
+	DuplicateCount *int64
+	InvalidCount   *int64
+	SkippedCount   *int64
+	FailedCount    *int64
+	Error          *string
+	Items          []*Item
+}
+
+func (m *Upsert) UpdateColumnMap() map[string]any {
+	res := m.CreateColumnMap()
+
+	pkMap := m.PKColumnMap()
+	for k := range pkMap {
+		delete(res, k)
+	}
+
+	return res
+}
+
+// PKColumnMap возвращает первичный ключ для ON CONFLICT
+func (m *Upsert) PKColumnMap() map[string]any {
+	return map[string]any{
+		"id": m.ID,
+	}
+}
+
+func (m *Upsert) CreateColumnMap() map[string]any {
+	result := make(map[string]any, 14)
+
+	if m.UpdatedAt != nil {
+		result["updated_at"] = *m.UpdatedAt
+	}
+
+	if m.FileName != nil {
+		result["file_name"] = *m.FileName
+	}
+
+	if m.Format != nil {
+		result["format"] = *m.Format
+	}
+
+	if m.Mode != nil {
+		result["mode"] = *m.Mode
+	}
+
+	if m.Status != nil {
+		result["status"] = *m.Status
+	}
+
+	if m.TotalCount != nil {
+		result["total_count"] = *m.TotalCount
+	}
+
+	if m.CreatedCount != nil {
+		result["created_count"] = *m.CreatedCount
+	}
+
+	if m.DuplicateCount != nil {
+		result["duplicate_count"] = *m.DuplicateCount
+	}
+
+	if m.InvalidCount != nil {
+		result["invalid_count"] = *m.InvalidCount
+	}
+
+	if m.SkippedCount != nil {
+		result["skipped_count"] = *m.SkippedCount
+	}
+
+	if m.FailedCount != nil {
+		result["failed_count"] = *m.FailedCount
+	}
+
+	if m.Error != nil {
+		result["error"] = *m.Error
+	}
+
+	if m.Items != nil {
+		result["items"] = m.Items
+	}
+
+	return result
+}
+
+func (m *Upsert) ReturningColumnMap() map[string]any {
+	return map[string]any{
+		"id": &m.ID,
+	}
+}
+
+func EncodeEdit(m *model.Edit) *Upsert {
+	result := &Upsert{}
+
+	if m.ID != nil && *m.ID != "" {
+		result.ID = *m.ID
+	}
+
+	result.UpdatedAt = m.UpdatedAt
+	result.FileName = m.FileName
+	result.Format = m.Format
+	result.Mode = m.Mode
+	result.Status = m.Status
+	result.TotalCount = m.TotalCount
+	result.CreatedCount = m.CreatedCount
+	result.DuplicateCount = m.DuplicateCount
+	result.InvalidCount = m.InvalidCount
+	result.SkippedCount = m.SkippedCount
+	result.FailedCount = m.FailedCount
+	result.Error = m.Error
+
+	if m.Items != nil {
+		result.Items = lo.Map(m.Items, EncodeItem)
+	}
+
+	return result
+}
+
+func EncodeItem(m *model.Item, _ int) *Item {
+	return &Item{
+		Row:               m.Row,
+		ProductID:         m.ProductID,
+		Result:            m.Result,
+		ID:                m.ID,
+		ExistingProductID: m.ExistingProductID,
+		Reason:            m.Reason,
+	}
+}
@@ -0,0 +1,105 @@
+package pg
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	commonModel "github.com/mechta-market/e-product/internal/domain/common/model"
+	"github.com/mechta-market/e-product/internal/domain/importjob/model"
+)
+
+// Тесты работают с реальной БД: TEST_PG_DSN должен указывать на отдельную тестовую базу,
+// схема в ней пересоздается по файлам из migrations
+func newTestRepo(t *testing.T) *Repo {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_PG_DSN")
+	if dsn == "" {
+		t.Skip("TEST_PG_DSN is not set")
+	}
+
+	ctx := context.Background()
+
+	con, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(con.Close)
+
+	migrate(t, con, "*.down.sql", true)
+	migrate(t, con, "*.up.sql", false)
+
+	return New(con)
+}
+
+func migrate(t *testing.T, con *pgxpool.Pool, pattern string, reverse bool) {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join("..", "..", "..", "..", "..", "migrations", pattern))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	sort.Strings(files)
+	if reverse {
+		files = lo.Reverse(files)
+	}
+
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		require.NoError(t, err)
+
+		_, err = con.Exec(context.Background(), string(data))
+		require.NoError(t, err, f)
+	}
+}
+
+func TestRepo_Items(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	id, err := r.Create(ctx, &model.Edit{
+		FileName:   lo.ToPtr("keys.csv"),
+		Format:     lo.ToPtr(constant.ImportFormatCSV),
+		Mode:       lo.ToPtr(constant.LoadModeBestEffort),
+		Status:     lo.ToPtr(constant.ImportJobStatusRunning),
+		TotalCount: lo.ToPtr(int64(2)),
+	})
+	require.NoError(t, err)
+
+	items := []*model.Item{
+		{Row: 2, ProductID: "prod-1", Result: constant.LoadResultCreated, ID: "key-1"},
+		{Row: 3, ProductID: "prod-1", Result: constant.LoadResultDuplicate, ExistingProductID: "prod-2"},
+	}
+
+	err = r.Update(ctx, &model.Edit{
+		ID:             lo.ToPtr(id),
+		Status:         lo.ToPtr(constant.ImportJobStatusCompleted),
+		CreatedCount:   lo.ToPtr(int64(1)),
+		DuplicateCount: lo.ToPtr(int64(1)),
+		Items:          items,
+	})
+	require.NoError(t, err)
+
+	job, found, err := r.Get(ctx, id)
+	require.NoError(t, err)
+	require.True(t, found)
+
+	assert.Equal(t, constant.ImportJobStatusCompleted, job.Status)
+	assert.Equal(t, int64(1), job.CreatedCount)
+	assert.Equal(t, items, job.Items)
+
+	jobs, _, err := r.List(ctx, &model.ListReq{
+		ListParams: commonModel.ListParams{PageSize: 10},
+		Status:     lo.ToPtr(constant.ImportJobStatusCompleted),
+	})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Nil(t, jobs[0].Items)
+}
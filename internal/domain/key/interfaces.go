@@ -2,6 +2,7 @@ package key
 
 import (
 	"context"
+	"time"
 
 	"github.com/mechta-market/e-product/internal/domain/key/model"
 )
@@ -10,7 +11,27 @@ type RepoDbI interface {
 	List(ctx context.Context, pars *model.ListReq) (_ []*model.Main, _ int64, finalError error)
 	Get(ctx context.Context, id string) (_ *model.Main, _ bool, finalError error)
 	GetByOrderID(ctx context.Context, orderID string) (_ *model.Main, _ bool, finalError error)
+	GetByOrderAndProductID(ctx context.Context, orderID, productID string) (_ *model.Main, _ bool, finalError error)
 	GetByValue(ctx context.Context, value string) (_ *model.Main, finalError error)
 	Update(ctx context.Context, obj *model.Edit) (finalError error)
+	UpdateStatus(ctx context.Context, obj *model.Edit, event *model.Event) (_ bool, finalError error)
 	Create(ctx context.Context, obj *model.Edit) (_ string, finalError error)
+	Reencrypt(ctx context.Context, limit uint64) (_ int, finalError error)
+	CreateMany(ctx context.Context, objs []*model.Edit) (_ []string, finalError error)
+	ClaimNew(ctx context.Context, productID, orderID, customerPhone string, event *model.Event) (_ *model.Main, _ bool, finalError error)
+	Export(ctx context.Context, pars *model.ListReq, fn func(item *model.Main) error) (finalError error)
+	InventoryReport(ctx context.Context, pars *model.ListReq) (_ []*model.InventoryItem, finalError error)
+	ListEvents(ctx context.Context, keyID string) (_ []*model.Event, finalError error)
+	CreateAudit(ctx context.Context, obj *model.Audit) (finalError error)
+	CreateReservation(ctx context.Context, obj *model.ReservationEdit, claim bool, event *model.Event) (_ *model.Reservation, finalError error)
+	GetReservation(ctx context.Context, id string) (_ *model.Reservation, _ bool, finalError error)
+	GetActiveReservation(ctx context.Context, orderID, productID string) (_ *model.Reservation, _ bool, finalError error)
+	ListExpiredReservations(ctx context.Context, limit uint64) (_ []*model.Reservation, finalError error)
+	FinishReservation(ctx context.Context, obj *model.ReservationEdit, key *model.Edit, event *model.Event) (_ bool, finalError error)
+	CreateActivation(ctx context.Context, obj *model.ActivationEdit) (_ *model.Activation, _ bool, finalError error)
+	GetActivation(ctx context.Context, id string) (_ *model.Activation, _ bool, finalError error)
+	ClaimActivation(ctx context.Context, staleBefore time.Time) (_ *model.Activation, finalError error)
+	FinishActivation(ctx context.Context, obj *model.ActivationEdit) (_ bool, finalError error)
+	LockOrder(ctx context.Context, orderID, productID string, ttl time.Duration) (_ string, _ bool, finalError error)
+	UnlockOrder(ctx context.Context, orderID, productID, token string) (finalError error)
 }
@@ -2,10 +2,13 @@ package key
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/samber/lo"
 	"time"
 
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/common/util"
 	"github.com/mechta-market/e-product/internal/domain/key/model"
 	"github.com/mechta-market/e-product/internal/errs"
 )
@@ -35,8 +38,8 @@ func (s *Service) Get(ctx context.Context, id string, errNE bool) (*model.Main,
 	if !found {
 		if errNE {
 			return nil, false, errs.ErrFull{
-				Err:  errs.ObjectNotFound,
-				Desc: "Ключ не найден",
+				Err: errs.ObjectNotFound,
+				Msg: errs.MsgKeyNotFound,
 			}
 		}
 		return nil, false, nil
@@ -53,8 +56,11 @@ func (s *Service) GetByOrderID(ctx context.Context, orderID string, errNE bool)
 	if !found {
 		if errNE {
 			return nil, false, errs.ErrFull{
-				Err:  errs.ObjectNotFound,
-				Desc: fmt.Sprintf("Ключ с номером заказа %s не найден", orderID),
+				Err: errs.ObjectNotFound,
+				Msg: errs.MsgKeyByOrderNotFound,
+				Fields: map[string]string{
+					"orderID": orderID,
+				},
 			}
 		}
 		return nil, false, nil
@@ -63,6 +69,15 @@ func (s *Service) GetByOrderID(ctx context.Context, orderID string, errNE bool)
 	return result, true, nil
 }
 
+func (s *Service) GetByOrderAndProductID(ctx context.Context, orderID, productID string) (*model.Main, bool, error) {
+	result, found, err := s.repoDb.GetByOrderAndProductID(ctx, orderID, productID)
+	if err != nil {
+		return nil, false, fmt.Errorf("repoDb.GetByOrderAndProductID: %w", err)
+	}
+
+	return result, found, nil
+}
+
 func (s *Service) GetByValue(ctx context.Context, value string) (*model.Main, error) {
 	result, err := s.repoDb.GetByValue(ctx, value)
 	if err != nil {
@@ -72,7 +87,12 @@ func (s *Service) GetByValue(ctx context.Context, value string) (*model.Main, er
 	return result, nil
 }
 
+// Update меняет поля ключа, кроме статуса: статус меняется только через Transition
 func (s *Service) Update(ctx context.Context, obj *model.Edit) error {
+	if obj.Status != nil {
+		return errors.New("key status must be changed with Transition")
+	}
+
 	obj.UpdatedAt = lo.ToPtr(time.Now())
 
 	err := s.repoDb.Update(ctx, obj)
@@ -83,6 +103,113 @@ func (s *Service) Update(ctx context.Context, obj *model.Edit) error {
 	return nil
 }
 
+// Transition переводит ключ current в статус obj.Status вместе с остальными полями obj и пишет смену
+// в журнал с инициатором из ctx и причиной reason. Недопустимый переход отклоняется,
+// а если статус успел измениться параллельно - возвращается ошибка
+func (s *Service) Transition(ctx context.Context, current *model.Main, obj *model.Edit, reason string) error {
+	to := lo.FromPtr(obj.Status)
+
+	err := model.CheckTransition(current.Status, to)
+	if err != nil {
+		return err
+	}
+
+	obj.ID = lo.ToPtr(current.ID)
+	obj.UpdatedAt = lo.ToPtr(time.Now())
+
+	updated, err := s.repoDb.UpdateStatus(ctx, obj, &model.Event{
+		KeyID:                 current.ID,
+		FromStatus:            current.Status,
+		ToStatus:              to,
+		Actor:                 util.ActorFromCtx(ctx),
+		OrderID:               lo.FromPtrOr(obj.OrderID, current.OrderID),
+		ProviderTransactionID: lo.FromPtrOr(obj.ProviderTransactionID, current.ProviderTransactionID),
+		Reason:                reason,
+	})
+	if err != nil {
+		return fmt.Errorf("repoDb.UpdateStatus: %w", err)
+	}
+	if !updated {
+		return errs.ErrFull{
+			Err: errs.InvalidKeyTransition,
+			Msg: errs.MsgKeyStatusChanged,
+			Fields: map[string]string{
+				"from": current.Status,
+				"to":   to,
+			},
+		}
+	}
+
+	return nil
+}
+
+// Export передает в fn все ключи по фильтрам pars, ошибка fn прерывает выгрузку
+func (s *Service) Export(ctx context.Context, pars *model.ListReq, fn func(item *model.Main) error) error {
+	err := s.repoDb.Export(ctx, pars, fn)
+	if err != nil {
+		return fmt.Errorf("repoDb.Export: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) InventoryReport(ctx context.Context, pars *model.ListReq) ([]*model.InventoryItem, error) {
+	items, err := s.repoDb.InventoryReport(ctx, pars)
+	if err != nil {
+		return nil, fmt.Errorf("repoDb.InventoryReport: %w", err)
+	}
+
+	return items, nil
+}
+
+// History возвращает журнал смены статусов ключа
+func (s *Service) History(ctx context.Context, id string) ([]*model.Event, error) {
+	items, err := s.repoDb.ListEvents(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("repoDb.ListEvents: %w", err)
+	}
+
+	return items, nil
+}
+
+// RevealValue возвращает ключ с открытым значением. Доступ пишется в журнал с инициатором и ролью
+// из ctx до выдачи значения: без записи в журнал значение не выдается
+func (s *Service) RevealValue(ctx context.Context, id, reason string) (*model.Main, error) {
+	result, _, err := s.Get(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repoDb.CreateAudit(ctx, &model.Audit{
+		KeyID:  id,
+		Action: constant.AuditActionRevealValue,
+		Actor:  util.ActorFromCtx(ctx),
+		Role:   util.RoleFromCtx(ctx),
+		Reason: reason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repoDb.CreateAudit: %w", err)
+	}
+
+	return result, nil
+}
+
+// AuditExport пишет в журнал выгрузку открытых значений: инициатор и роль из ctx, фильтры и число ключей
+func (s *Service) AuditExport(ctx context.Context, pars *model.ListReq, rowCount int64) error {
+	err := s.repoDb.CreateAudit(ctx, &model.Audit{
+		Action:   constant.AuditActionExport,
+		Actor:    util.ActorFromCtx(ctx),
+		Role:     util.RoleFromCtx(ctx),
+		Filter:   pars,
+		RowCount: rowCount,
+	})
+	if err != nil {
+		return fmt.Errorf("repoDb.CreateAudit: %w", err)
+	}
+
+	return nil
+}
+
 func (s *Service) Create(ctx context.Context, obj *model.Edit) (string, error) {
 	id, err := s.repoDb.Create(ctx, obj)
 	if err != nil {
@@ -91,3 +218,299 @@ func (s *Service) Create(ctx context.Context, obj *model.Edit) (string, error) {
 
 	return id, nil
 }
+
+func (s *Service) CreateMany(ctx context.Context, objs []*model.Edit) ([]string, error) {
+	ids, err := s.repoDb.CreateMany(ctx, objs)
+	if err != nil {
+		return nil, fmt.Errorf("repoDb.CreateMany: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (s *Service) ClaimNew(ctx context.Context, productID, orderID, customerPhone string) (*model.Main, bool, error) {
+	result, found, err := s.repoDb.ClaimNew(ctx, productID, orderID, customerPhone, &model.Event{
+		Actor: util.ActorFromCtx(ctx),
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("repoDb.ClaimNew: %w", err)
+	}
+
+	return result, found, nil
+}
+
+// LockOrder занимает заказ и возвращает токен блокировки, с которым ее снимает UnlockOrder
+func (s *Service) LockOrder(ctx context.Context, orderID, productID string) (string, bool, error) {
+	token, locked, err := s.repoDb.LockOrder(ctx, orderID, productID, constant.OrderLockTTL)
+	if err != nil {
+		return "", false, fmt.Errorf("repoDb.LockOrder: %w", err)
+	}
+
+	return token, locked, nil
+}
+
+func (s *Service) UnlockOrder(ctx context.Context, orderID, productID, token string) error {
+	err := s.repoDb.UnlockOrder(ctx, orderID, productID, token)
+	if err != nil {
+		return fmt.Errorf("repoDb.UnlockOrder: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) Reencrypt(ctx context.Context, limit uint64) (int, error) {
+	count, err := s.repoDb.Reencrypt(ctx, limit)
+	if err != nil {
+		return 0, fmt.Errorf("repoDb.Reencrypt: %w", err)
+	}
+
+	return count, nil
+}
+
+// Reserve создает резерв заказа на продукт до obj.ExpiresAt. При fromPool резерв удерживает свободный ключ из пула
+func (s *Service) Reserve(ctx context.Context, obj *model.ReservationEdit, fromPool bool) (*model.Reservation, error) {
+	obj.Status = lo.ToPtr(constant.ReservationStatusActive)
+
+	result, err := s.repoDb.CreateReservation(ctx, obj, fromPool, &model.Event{
+		Actor: util.ActorFromCtx(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repoDb.CreateReservation: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *Service) GetReservation(ctx context.Context, id string, errNE bool) (*model.Reservation, bool, error) {
+	result, found, err := s.repoDb.GetReservation(ctx, id)
+	if err != nil {
+		return nil, false, fmt.Errorf("repoDb.GetReservation: %w", err)
+	}
+	if !found {
+		if errNE {
+			return nil, false, errs.ErrFull{
+				Err: errs.ObjectNotFound,
+				Msg: errs.MsgReservationNotFound,
+			}
+		}
+		return nil, false, nil
+	}
+
+	return result, true, nil
+}
+
+func (s *Service) GetActiveReservation(ctx context.Context, orderID, productID string) (*model.Reservation, bool, error) {
+	result, found, err := s.repoDb.GetActiveReservation(ctx, orderID, productID)
+	if err != nil {
+		return nil, false, fmt.Errorf("repoDb.GetActiveReservation: %w", err)
+	}
+
+	return result, found, nil
+}
+
+func (s *Service) ListExpiredReservations(ctx context.Context, limit uint64) ([]*model.Reservation, error) {
+	items, err := s.repoDb.ListExpiredReservations(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("repoDb.ListExpiredReservations: %w", err)
+	}
+
+	return items, nil
+}
+
+// ConfirmReservation завершает резерв выдачей ключа. Ключ из пула активируется вместе с резервом,
+// иначе в резерве сохраняется keyID купленного у провайдера ключа
+func (s *Service) ConfirmReservation(ctx context.Context, reservation *model.Reservation, customerPhone, keyID string) error {
+	var key *model.Edit
+	var event *model.Event
+
+	if reservation.KeyID != "" {
+		keyID = reservation.KeyID
+
+		current, err := s.checkReservedTransition(ctx, keyID, constant.KeyStatusActivated)
+		if err != nil {
+			return err
+		}
+
+		key = &model.Edit{
+			ID:            lo.ToPtr(keyID),
+			UpdatedAt:     lo.ToPtr(time.Now()),
+			Status:        lo.ToPtr(constant.KeyStatusActivated),
+			OrderID:       lo.ToPtr(reservation.OrderID),
+			CustomerPhone: lo.ToPtr(customerPhone),
+		}
+		event = &model.Event{
+			KeyID:      keyID,
+			FromStatus: current.Status,
+			ToStatus:   constant.KeyStatusActivated,
+			Actor:      util.ActorFromCtx(ctx),
+			OrderID:    reservation.OrderID,
+		}
+	}
+
+	return s.finishReservation(ctx, reservation, &model.ReservationEdit{
+		ID:     lo.ToPtr(reservation.ID),
+		Status: lo.ToPtr(constant.ReservationStatusConfirmed),
+		KeyID:  lo.ToPtr(keyID),
+	}, key, event)
+}
+
+// ReleaseReservation завершает резерв статусом released или expired, удерживаемый ключ возвращается в пул
+func (s *Service) ReleaseReservation(ctx context.Context, reservation *model.Reservation, status, reason string) error {
+	var key *model.Edit
+	var event *model.Event
+
+	if reservation.KeyID != "" {
+		current, err := s.checkReservedTransition(ctx, reservation.KeyID, constant.KeyStatusNew)
+		if err != nil {
+			return err
+		}
+
+		key = &model.Edit{
+			ID:        lo.ToPtr(reservation.KeyID),
+			UpdatedAt: lo.ToPtr(time.Now()),
+			Status:    lo.ToPtr(constant.KeyStatusNew),
+		}
+		event = &model.Event{
+			KeyID:      reservation.KeyID,
+			FromStatus: current.Status,
+			ToStatus:   constant.KeyStatusNew,
+			Actor:      util.ActorFromCtx(ctx),
+			OrderID:    reservation.OrderID,
+			Reason:     reason,
+		}
+	}
+
+	return s.finishReservation(ctx, reservation, &model.ReservationEdit{
+		ID:     lo.ToPtr(reservation.ID),
+		Status: lo.ToPtr(status),
+	}, key, event)
+}
+
+// checkReservedTransition возвращает удерживаемый резервом ключ, если его можно перевести
+// из текущего статуса в to. Ключ обновляется, только пока статус не изменился
+func (s *Service) checkReservedTransition(ctx context.Context, keyID, to string) (*model.Main, error) {
+	current, _, err := s.Get(ctx, keyID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = model.CheckTransition(current.Status, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return current, nil
+}
+
+func (s *Service) finishReservation(ctx context.Context, reservation *model.Reservation, obj *model.ReservationEdit, key *model.Edit, event *model.Event) error {
+	obj.UpdatedAt = lo.ToPtr(time.Now())
+
+	updated, err := s.repoDb.FinishReservation(ctx, obj, key, event)
+	if err != nil {
+		return fmt.Errorf("repoDb.FinishReservation: %w", err)
+	}
+	if !updated {
+		return errs.ErrFull{
+			Err: errs.ReservationNotActive,
+			Fields: map[string]string{
+				"reservationID": reservation.ID,
+			},
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) CreateActivation(ctx context.Context, obj *model.ActivationEdit) (*model.Activation, bool, error) {
+	obj.Status = lo.ToPtr(constant.ActivationStatusPending)
+
+	result, created, err := s.repoDb.CreateActivation(ctx, obj)
+	if err != nil {
+		return nil, false, fmt.Errorf("repoDb.CreateActivation: %w", err)
+	}
+
+	return result, created, nil
+}
+
+func (s *Service) GetActivation(ctx context.Context, id string, errNE bool) (*model.Activation, bool, error) {
+	result, found, err := s.repoDb.GetActivation(ctx, id)
+	if err != nil {
+		return nil, false, fmt.Errorf("repoDb.GetActivation: %w", err)
+	}
+	if !found {
+		if errNE {
+			return nil, false, errs.ErrFull{
+				Err: errs.ObjectNotFound,
+				Msg: errs.MsgActivationNotFound,
+			}
+		}
+		return nil, false, nil
+	}
+
+	return result, true, nil
+}
+
+// ClaimActivation забирает следующую выдачу в работу, nil - очередь пуста.
+// Выдача в processing дольше processingTimeout считается брошенной и забирается повторно
+func (s *Service) ClaimActivation(ctx context.Context, processingTimeout time.Duration) (*model.Activation, error) {
+	result, err := s.repoDb.ClaimActivation(ctx, time.Now().Add(-processingTimeout))
+	if err != nil {
+		return nil, fmt.Errorf("repoDb.ClaimActivation: %w", err)
+	}
+
+	return result, nil
+}
+
+// FinishActivation завершает выдачу: completed с keyID или failed с errMsg
+func (s *Service) FinishActivation(ctx context.Context, activation *model.Activation, keyID, errMsg string) error {
+	status := constant.ActivationStatusCompleted
+	if keyID == "" {
+		status = constant.ActivationStatusFailed
+	}
+
+	updated, err := s.repoDb.FinishActivation(ctx, &model.ActivationEdit{
+		ID:        lo.ToPtr(activation.ID),
+		UpdatedAt: lo.ToPtr(time.Now()),
+		Status:    lo.ToPtr(status),
+		KeyID:     lo.ToPtr(keyID),
+		Error:     lo.ToPtr(errMsg),
+	})
+	if err != nil {
+		return fmt.Errorf("repoDb.FinishActivation: %w", err)
+	}
+	if !updated {
+		return fmt.Errorf("activation %s is not processing", activation.ID)
+	}
+
+	activation.Status = status
+	activation.KeyID = keyID
+	activation.Error = errMsg
+
+	return nil
+}
+
+// RetryActivation возвращает выдачу из processing в очередь: следующая попытка не раньше чем через delay
+func (s *Service) RetryActivation(ctx context.Context, activation *model.Activation, errMsg string, delay time.Duration) error {
+	now := time.Now()
+	nextAttemptAt := now.Add(delay)
+
+	updated, err := s.repoDb.FinishActivation(ctx, &model.ActivationEdit{
+		ID:            lo.ToPtr(activation.ID),
+		UpdatedAt:     lo.ToPtr(now),
+		Status:        lo.ToPtr(constant.ActivationStatusPending),
+		Error:         lo.ToPtr(errMsg),
+		NextAttemptAt: lo.ToPtr(nextAttemptAt),
+	})
+	if err != nil {
+		return fmt.Errorf("repoDb.FinishActivation: %w", err)
+	}
+	if !updated {
+		return fmt.Errorf("activation %s is not processing", activation.ID)
+	}
+
+	activation.Status = constant.ActivationStatusPending
+	activation.Error = errMsg
+	activation.NextAttemptAt = nextAttemptAt
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/mechta-market/e-product/internal/errs"
+)
+
+// Cursor позиция keyset-пагинации: следующая страница начинается после строки (CreatedAt, ID)
+// в порядке created_at, id. Пустой курсор - первая страница
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+func (c *Cursor) IsZero() bool {
+	return c.ID == ""
+}
+
+// Encode возвращает непрозрачный для клиента токен курсора
+func (c *Cursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID))
+}
+
+// DecodeCursor разбирает токен, полученный от Encode. Пустой токен - первая страница
+func DecodeCursor(token string) (*Cursor, error) {
+	if token == "" {
+		return &Cursor{}, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errs.InvalidCursor
+	}
+
+	createdAt, id, ok := strings.Cut(string(data), "|")
+	if !ok || id == "" {
+		return nil, errs.InvalidCursor
+	}
+
+	result := &Cursor{ID: id}
+
+	result.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, errs.InvalidCursor
+	}
+
+	return result, nil
+}
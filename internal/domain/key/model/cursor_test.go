@@ -0,0 +1,32 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mechta-market/e-product/internal/errs"
+)
+
+func TestCursor(t *testing.T) {
+	c := &Cursor{
+		CreatedAt: time.Date(2026, 10, 18, 12, 30, 0, 123456000, time.FixedZone("", 5*3600)),
+		ID:        "2f1c6a52-1d7e-4a47-9f0e-6f5c1d3b8a11",
+	}
+
+	result, err := DecodeCursor(c.Encode())
+	require.NoError(t, err)
+	assert.True(t, c.CreatedAt.Equal(result.CreatedAt))
+	assert.Equal(t, c.ID, result.ID)
+
+	result, err = DecodeCursor("")
+	require.NoError(t, err)
+	assert.True(t, result.IsZero())
+
+	for _, token := range []string{"%%%", "bm8tc2VwYXJhdG9y", "YmFkLXRpbWV8aWQ"} {
+		_, err = DecodeCursor(token)
+		assert.ErrorIs(t, err, errs.InvalidCursor, token)
+	}
+}
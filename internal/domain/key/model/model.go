@@ -21,15 +21,55 @@ type Main struct {
 	PromotionKey              string
 	ProviderOrderID           string
 	ProviderTransactionID     string
+	Link                      string // ссылка на скачивание от провайдера
+	Instructions              string // инструкция по активации
+	LicenseTerm               string
+	Source                    string
 }
 
 type ListReq struct {
 	commonModel.ListParams
 
-	ProviderID *string
-	Status     *string
-	OrderID    *string
-	ProductID  *string
+	ProviderID    *string
+	Status        *string
+	OrderID       *string
+	ProductID     *string
+	CustomerPhone *string
+	Value         *string // точное совпадение, для зашифрованных ключей - по value_hash
+
+	// любой из перечисленных, не применяются, если задан Status/ProductID
+	Statuses   []string
+	ProductIDs []string
+
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	UpdatedFrom *time.Time
+	UpdatedTo   *time.Time
+
+	// Cursor - keyset-пагинация вместо Page: сортировка по created_at, id, total count не считается
+	Cursor *Cursor
+}
+
+// ExportReq выгрузка ключей по фильтрам ListReq, пагинация не применяется
+type ExportReq struct {
+	ListReq
+
+	Format      string
+	RevealValue bool // открытые значения вместо маскированных, только для поддержки и администраторов
+}
+
+// InventoryItem число ключей продукта провайдера в статусе с разбивкой по возрасту (от created_at)
+type InventoryItem struct {
+	ProductID       string
+	ProviderID      string
+	Status          string
+	Count           int64
+	AgeDay          int64 // до суток
+	AgeWeek         int64 // от суток до 7 дней
+	AgeMonth        int64 // от 7 до 30 дней
+	AgeQuarter      int64 // от 30 до 90 дней
+	AgeOlder        int64 // старше 90 дней
+	OldestCreatedAt time.Time
 }
 
 type Edit struct {
@@ -45,4 +85,95 @@ type Edit struct {
 	ProviderExternalProductID *string
 	ProviderOrderID           *string
 	ProviderTransactionID     *string
+	Link                      *string
+	Instructions              *string
+	LicenseTerm               *string
+	Source                    *string
+}
+
+type LoadResult struct {
+	Index             int
+	ProductID         string
+	Result            string
+	ID                string
+	ExistingProductID string
+	Reason            string
+}
+
+// Event запись журнала смены статуса ключа
+type Event struct {
+	ID                    string
+	CreatedAt             time.Time
+	KeyID                 string
+	FromStatus            string
+	ToStatus              string
+	Actor                 string
+	OrderID               string
+	ProviderTransactionID string
+	Reason                string
+}
+
+// Audit запись журнала доступа к значению ключа. Для выгрузки KeyID пустой,
+// а в Filter и RowCount пишутся фильтры и число выгруженных ключей
+type Audit struct {
+	ID        string
+	CreatedAt time.Time
+	KeyID     string
+	Action    string
+	Actor     string
+	Role      string
+	Reason    string
+	Filter    *ListReq
+	RowCount  int64
+}
+
+// Reservation удержание ключа на время оплаты заказа. KeyID пустой, если у продукта нет ключей в пуле:
+// тогда ключ покупается у провайдера при подтверждении
+type Reservation struct {
+	ID        string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ProductID string
+	OrderID   string
+	KeyID     string
+	Status    string
+	ExpiresAt time.Time
+}
+
+type ReservationEdit struct {
+	ID        *string
+	UpdatedAt *time.Time
+	ProductID *string
+	OrderID   *string
+	KeyID     *string
+	Status    *string
+	ExpiresAt *time.Time
+}
+
+// Activation асинхронная выдача ключа: создается в pending, воркер выдает ключ
+// и переводит в completed (KeyID) или failed (Error)
+type Activation struct {
+	ID            string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	ProductID     string
+	OrderID       string
+	CustomerPhone string
+	Status        string
+	KeyID         string
+	Error         string
+	Attempts      int64
+	NextAttemptAt time.Time
+}
+
+type ActivationEdit struct {
+	ID            *string
+	UpdatedAt     *time.Time
+	ProductID     *string
+	OrderID       *string
+	CustomerPhone *string
+	Status        *string
+	KeyID         *string
+	Error         *string
+	NextAttemptAt *time.Time
 }
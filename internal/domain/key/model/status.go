@@ -0,0 +1,66 @@
+package model
+
+import (
+	"github.com/samber/lo"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/errs"
+)
+
+// transitions допустимые переходы статусов ключа
+var transitions = map[string][]string{
+	constant.KeyStatusNew: {
+		constant.KeyStatusReserved,
+		constant.KeyStatusActivated,
+		constant.KeyStatusExpired,
+		constant.KeyStatusWithdrawn,
+	},
+	constant.KeyStatusReserved: {
+		constant.KeyStatusNew, // резерв снят или истек
+		constant.KeyStatusActivated,
+		constant.KeyStatusExpired,
+		constant.KeyStatusWithdrawn,
+	},
+	constant.KeyStatusActivated: {
+		constant.KeyStatusCancelled,
+		constant.KeyStatusReturned,
+		constant.KeyStatusExpired,
+	},
+	constant.KeyStatusReturned: {
+		constant.KeyStatusNew, // повторная выдача из пула
+		constant.KeyStatusWithdrawn,
+	},
+	constant.KeyStatusExpired: {
+		constant.KeyStatusWithdrawn,
+	},
+	constant.KeyStatusCancelled: {},
+	constant.KeyStatusWithdrawn: {},
+}
+
+func CanTransition(from, to string) bool {
+	return lo.Contains(transitions[from], to)
+}
+
+// CheckTransition возвращает ошибку, если ключ нельзя перевести из статуса from в to
+func CheckTransition(from, to string) error {
+	if CanTransition(from, to) {
+		return nil
+	}
+
+	if from == to {
+		switch from {
+		case constant.KeyStatusActivated:
+			return errs.AlreadyActivated
+		case constant.KeyStatusCancelled, constant.KeyStatusReturned:
+			return errs.AlreadyCancelled
+		}
+	}
+
+	return errs.ErrFull{
+		Err: errs.InvalidKeyTransition,
+		Fields: map[string]string{
+			"from": from,
+			"to":   to,
+		},
+	}
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/errs"
+)
+
+func TestCheckTransition(t *testing.T) {
+	tests := []struct {
+		name        string
+		from        string
+		to          string
+		expectedErr error
+	}{
+		{
+			name: "new -> reserved",
+			from: constant.KeyStatusNew,
+			to:   constant.KeyStatusReserved,
+		},
+		{
+			name: "reserved -> activated",
+			from: constant.KeyStatusReserved,
+			to:   constant.KeyStatusActivated,
+		},
+		{
+			name: "activated -> returned",
+			from: constant.KeyStatusActivated,
+			to:   constant.KeyStatusReturned,
+		},
+		{
+			name: "returned -> new",
+			from: constant.KeyStatusReturned,
+			to:   constant.KeyStatusNew,
+		},
+		{
+			name:        "activated -> activated",
+			from:        constant.KeyStatusActivated,
+			to:          constant.KeyStatusActivated,
+			expectedErr: errs.AlreadyActivated,
+		},
+		{
+			name:        "cancelled -> cancelled",
+			from:        constant.KeyStatusCancelled,
+			to:          constant.KeyStatusCancelled,
+			expectedErr: errs.AlreadyCancelled,
+		},
+		{
+			name:        "returned -> returned",
+			from:        constant.KeyStatusReturned,
+			to:          constant.KeyStatusReturned,
+			expectedErr: errs.AlreadyCancelled,
+		},
+		{
+			name:        "cancelled -> activated",
+			from:        constant.KeyStatusCancelled,
+			to:          constant.KeyStatusActivated,
+			expectedErr: errs.InvalidKeyTransition,
+		},
+		{
+			name:        "withdrawn -> new",
+			from:        constant.KeyStatusWithdrawn,
+			to:          constant.KeyStatusNew,
+			expectedErr: errs.InvalidKeyTransition,
+		},
+		{
+			name:        "new -> cancelled",
+			from:        constant.KeyStatusNew,
+			to:          constant.KeyStatusCancelled,
+			expectedErr: errs.InvalidKeyTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckTransition(tt.from, tt.to)
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+				require.True(t, CanTransition(tt.from, tt.to))
+				return
+			}
+
+			var errFull errs.ErrFull
+			if errors.As(err, &errFull) {
+				err = errFull.Err
+			}
+
+			require.ErrorIs(t, err, tt.expectedErr)
+			require.False(t, CanTransition(tt.from, tt.to))
+		})
+	}
+}
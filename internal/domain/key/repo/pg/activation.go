@@ -0,0 +1,206 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/opentracing/opentracing-go"
+	"github.com/samber/lo"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	commonRepoPg "github.com/mechta-market/e-product/internal/domain/common/repo/pg"
+	"github.com/mechta-market/e-product/internal/domain/key/model"
+	repoModel "github.com/mechta-market/e-product/internal/domain/key/repo/pg/model"
+)
+
+// CreateActivation создает асинхронную выдачу. Если по заказу и продукту уже есть выдача
+// не в статусе failed, возвращается она и false
+func (r *Repo) CreateActivation(ctx context.Context, obj *model.ActivationEdit) (_ *model.Activation, _ bool, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.CreateActivation")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	m := &repoModel.ActivationSelect{}
+	upsertObj := repoModel.EncodeActivationEdit(obj)
+	colNames, colPointers := commonRepoPg.ColumnMapSplit(m.ListColumnMap())
+
+	query, args, err := r.QB.Insert(r.ActivationStore.TableName).
+		SetMap(upsertObj.CreateColumnMap()).
+		// предикат частичного индекса литералом: с bind-параметром postgres не сопоставит его с индексом
+		Suffix("ON CONFLICT (order_id, product_id) WHERE status <> 'failed' DO NOTHING").
+		Suffix("RETURNING " + strings.Join(colNames, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, false, fmt.Errorf("fail to build query: %w", err)
+	}
+
+	err = r.Con.QueryRow(ctx, query, args...).Scan(colPointers...)
+	if err == nil {
+		return repoModel.DecodeActivation(m, 0), true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, fmt.Errorf("fail to query: %w", err)
+	}
+
+	query, args, err = r.QB.Select(colNames...).
+		From(r.ActivationStore.TableName).
+		Where(squirrel.Eq{
+			"order_id":   lo.FromPtr(upsertObj.OrderID),
+			"product_id": lo.FromPtr(upsertObj.ProductID),
+		}).
+		Where(squirrel.NotEq{"status": constant.ActivationStatusFailed}).
+		ToSql()
+	if err != nil {
+		return nil, false, fmt.Errorf("fail to build select query: %w", err)
+	}
+
+	err = r.Con.QueryRow(ctx, query, args...).Scan(colPointers...)
+	if err != nil {
+		return nil, false, fmt.Errorf("fail to select: %w", err)
+	}
+
+	return repoModel.DecodeActivation(m, 0), false, nil
+}
+
+func (r *Repo) GetActivation(ctx context.Context, id string) (_ *model.Activation, _ bool, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.GetActivation")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	m := &repoModel.ActivationSelect{}
+	m.ID = id
+
+	found, err := r.ActivationStore.Get(ctx, m)
+	if err != nil {
+		return nil, false, fmt.Errorf("ActivationStore.Get: %w", err)
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	return repoModel.DecodeActivation(m, 0), true, nil
+}
+
+// ClaimActivation забирает в processing самую старую ожидающую выдачу, чей next_attempt_at наступил.
+// Выдачи, зависшие в processing дольше staleBefore (воркер упал), забираются повторно. nil - очередь пуста
+func (r *Repo) ClaimActivation(ctx context.Context, staleBefore time.Time) (_ *model.Activation, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.ClaimActivation")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	m := &repoModel.ActivationSelect{}
+	colNames, colPointers := commonRepoPg.ColumnMapSplit(m.ListColumnMap())
+
+	found := false
+
+	err := r.WithTx(ctx, func(tx pgx.Tx) error {
+		query, args, err := r.QB.Select("id").
+			From(r.ActivationStore.TableName).
+			Where(squirrel.Or{
+				squirrel.And{
+					squirrel.Eq{"status": constant.ActivationStatusPending},
+					squirrel.Expr("next_attempt_at <= now()"),
+				},
+				squirrel.And{
+					squirrel.Eq{"status": constant.ActivationStatusProcessing},
+					squirrel.Lt{"updated_at": staleBefore},
+				},
+			}).
+			OrderBy(m.DefaultSortColumns()...).
+			Limit(1).
+			Suffix("FOR UPDATE SKIP LOCKED").
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("fail to build select query: %w", err)
+		}
+
+		var id string
+
+		err = tx.QueryRow(ctx, query, args...).Scan(&id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil
+			}
+			return fmt.Errorf("fail to select: %w", err)
+		}
+
+		query, args, err = r.QB.Update(r.ActivationStore.TableName).
+			Set("status", constant.ActivationStatusProcessing).
+			Set("updated_at", time.Now()).
+			Set("attempts", squirrel.Expr("attempts + 1")).
+			Where(squirrel.Eq{"id": id}).
+			Suffix("RETURNING " + strings.Join(colNames, ", ")).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("fail to build update query: %w", err)
+		}
+
+		err = tx.QueryRow(ctx, query, args...).Scan(colPointers...)
+		if err != nil {
+			return fmt.Errorf("fail to update: %w", err)
+		}
+
+		found = true
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("WithTx: %w", err)
+	}
+	if !found {
+		return nil, nil
+	}
+
+	return repoModel.DecodeActivation(m, 0), nil
+}
+
+// FinishActivation переводит выдачу из processing в obj.Status: завершает или возвращает в очередь.
+// false - выдача уже не в processing
+func (r *Repo) FinishActivation(ctx context.Context, obj *model.ActivationEdit) (_ bool, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.FinishActivation")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	upsertObj := repoModel.EncodeActivationEdit(obj)
+
+	query, args, err := r.QB.Update(r.ActivationStore.TableName).
+		SetMap(upsertObj.UpdateColumnMap()).
+		Where(squirrel.Eq(upsertObj.PKColumnMap())).
+		Where(squirrel.Eq{"status": constant.ActivationStatusProcessing}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("fail to build query: %w", err)
+	}
+
+	tag, err := r.Con.Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("fail to exec: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
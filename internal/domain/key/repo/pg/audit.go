@@ -0,0 +1,38 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/mechta-market/e-product/internal/domain/key/model"
+	repoModel "github.com/mechta-market/e-product/internal/domain/key/repo/pg/model"
+)
+
+const auditTableName = "key_audit"
+
+func (r *Repo) CreateAudit(ctx context.Context, obj *model.Audit) (finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.CreateAudit")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	query, args, err := r.QB.Insert(auditTableName).
+		SetMap(repoModel.EncodeAudit(obj)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("fail to build query: %w", err)
+	}
+
+	_, err = r.Con.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("fail to exec: %w", err)
+	}
+
+	return nil
+}
@@ -1,12 +1,25 @@
 package pg
 
-import "github.com/mechta-market/e-product/internal/domain/key/model"
+import (
+	"fmt"
+
+	"github.com/samber/lo"
+
+	commonRepoPg "github.com/mechta-market/e-product/internal/domain/common/repo/pg"
+	"github.com/mechta-market/e-product/internal/domain/key/model"
+	repoModel "github.com/mechta-market/e-product/internal/domain/key/repo/pg/model"
+)
 
 var (
 	allowedSortFields = map[string]string{
 		"created_at": "created_at",
 		"updated_at": "updated_at",
+		"status":     "status",
+		"product_id": "product_id",
 	}
+
+	// порядок keyset-пагинации, должен совпадать с условием курсора в getConditions
+	cursorSortColumns = []string{"created_at", "id"}
 )
 
 func (r *Repo) getConditions(pars *model.ListReq) (map[string]any, map[string][]any) {
@@ -29,5 +42,80 @@ func (r *Repo) getConditions(pars *model.ListReq) (map[string]any, map[string][]
 		conditions["product_id"] = *pars.ProductID
 	}
 
+	if pars.CustomerPhone != nil {
+		conditions["customer_phone"] = *pars.CustomerPhone
+	}
+
+	if pars.Status == nil && len(pars.Statuses) > 0 {
+		conditions["status"] = pars.Statuses
+	}
+
+	if pars.ProductID == nil && len(pars.ProductIDs) > 0 {
+		conditions["product_id"] = pars.ProductIDs
+	}
+
+	if pars.Value != nil {
+		if r.keyring == nil {
+			conditions["value"] = *pars.Value
+		} else {
+			// еще не перешифрованные ключи ищутся по открытому значению
+			conditionExps["(value_hash = ? OR (value_key_id = '' AND value = ?))"] = []any{r.keyring.Hash(*pars.Value), *pars.Value}
+		}
+	}
+
+	if pars.CreatedFrom != nil {
+		conditionExps["created_at >= ?"] = []any{*pars.CreatedFrom}
+	}
+
+	if pars.CreatedTo != nil {
+		conditionExps["created_at < ?"] = []any{*pars.CreatedTo}
+	}
+
+	if pars.UpdatedFrom != nil {
+		conditionExps["updated_at >= ?"] = []any{*pars.UpdatedFrom}
+	}
+
+	if pars.UpdatedTo != nil {
+		conditionExps["updated_at < ?"] = []any{*pars.UpdatedTo}
+	}
+
+	if pars.Cursor != nil && !pars.Cursor.IsZero() {
+		conditionExps["(created_at, id) > (?, ?)"] = []any{pars.Cursor.CreatedAt, pars.Cursor.ID}
+	}
+
 	return conditions, conditionExps
 }
+
+// encodeEdit шифрует value, если настроен keyring: открытое значение в БД не попадает
+func (r *Repo) encodeEdit(obj *model.Edit) (*repoModel.Upsert, error) {
+	result := repoModel.EncodeEdit(obj)
+
+	if r.keyring == nil || result.Value == nil {
+		return result, nil
+	}
+
+	sealed, err := r.keyring.Encrypt(*result.Value)
+	if err != nil {
+		return nil, fmt.Errorf("keyring.Encrypt: %w", err)
+	}
+
+	result.ValueHash = lo.ToPtr(r.keyring.Hash(*result.Value))
+	result.Value = lo.ToPtr("")
+	result.ValueEnc = sealed.Data
+	result.ValueDEK = sealed.DEK
+	result.ValueKeyID = lo.ToPtr(sealed.KeyID)
+
+	return result, nil
+}
+
+func (r *Repo) decodeMain(m *repoModel.Select) (*model.Main, error) {
+	value, err := commonRepoPg.OpenValue(r.keyring, m.Value, m.ValueEnc, m.ValueDEK, m.ValueKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("OpenValue: %w", err)
+	}
+
+	result := repoModel.DecodeMain(m, 0)
+	result.Value = value
+
+	return result, nil
+}
@@ -0,0 +1,107 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/opentracing/opentracing-go"
+	"github.com/samber/lo"
+
+	commonRepoPg "github.com/mechta-market/e-product/internal/domain/common/repo/pg"
+	"github.com/mechta-market/e-product/internal/domain/key/model"
+	repoModel "github.com/mechta-market/e-product/internal/domain/key/repo/pg/model"
+)
+
+const exportFetchSize = 500
+
+// Export читает ключи по фильтрам List серверным курсором пачками по exportFetchSize и передает их в fn
+// в порядке created_at. Пагинация и сортировка из pars не применяются
+func (r *Repo) Export(ctx context.Context, pars *model.ListReq, fn func(item *model.Main) error) (finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.Export")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	conditions, conditionExps := r.getConditions(pars)
+	colNames, _ := commonRepoPg.ColumnMapSplit((&repoModel.Select{}).ListColumnMap())
+
+	queryBuilder := r.QB.Select(colNames...).
+		From(r.ModelStore.TableName).
+		Where(conditions)
+	for expression, args := range conditionExps {
+		queryBuilder = queryBuilder.Where(expression, args...)
+	}
+
+	query, args, err := queryBuilder.OrderBy("created_at", "id").ToSql()
+	if err != nil {
+		return fmt.Errorf("fail to build query: %w", err)
+	}
+
+	err = r.WithTx(ctx, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, "DECLARE key_export NO SCROLL CURSOR FOR "+query, args...)
+		if err != nil {
+			return fmt.Errorf("fail to declare cursor: %w", err)
+		}
+
+		for {
+			items, err := r.fetchExport(ctx, tx, colNames)
+			if err != nil {
+				return fmt.Errorf("fetchExport: %w", err)
+			}
+
+			// пачка передается в fn после чтения: медленный получатель не держит открытым результат FETCH
+			for _, item := range items {
+				obj, err := r.decodeMain(item)
+				if err != nil {
+					return fmt.Errorf("decodeMain: %w", err)
+				}
+
+				err = fn(obj)
+				if err != nil {
+					return err
+				}
+			}
+
+			if len(items) < exportFetchSize {
+				return nil
+			}
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("WithTx: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) fetchExport(ctx context.Context, tx pgx.Tx, colNames []string) ([]*repoModel.Select, error) {
+	rows, err := tx.Query(ctx, fmt.Sprintf("FETCH FORWARD %d FROM key_export", exportFetchSize))
+	if err != nil {
+		return nil, fmt.Errorf("fail to fetch: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]*repoModel.Select, 0, exportFetchSize)
+	for rows.Next() {
+		item := &repoModel.Select{}
+		colMap := item.ListColumnMap()
+
+		err = rows.Scan(lo.Map(colNames, func(name string, _ int) any {
+			return colMap[name]
+		})...)
+		if err != nil {
+			return nil, fmt.Errorf("fail to scan: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,63 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/samber/lo"
+
+	"github.com/mechta-market/e-product/internal/domain/key/model"
+	repoModel "github.com/mechta-market/e-product/internal/domain/key/repo/pg/model"
+)
+
+// InventoryReport считает ключи по продукту, провайдеру и статусу с разбивкой по возрасту.
+// Фильтры те же, что у List, пагинация и сортировка не применяются
+func (r *Repo) InventoryReport(ctx context.Context, pars *model.ListReq) (_ []*model.InventoryItem, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.InventoryReport")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	conditions, conditionExps := r.getConditions(pars)
+
+	queryBuilder := r.QB.Select(repoModel.InventoryColumns...).
+		From(r.ModelStore.TableName).
+		Where(conditions)
+	for expression, args := range conditionExps {
+		queryBuilder = queryBuilder.Where(expression, args...)
+	}
+
+	query, args, err := queryBuilder.
+		GroupBy(repoModel.InventoryGroupBy...).
+		OrderBy(repoModel.InventoryGroupBy...).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("fail to build query: %w", err)
+	}
+
+	rows, err := r.Con.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("fail to query: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]*repoModel.InventorySelect, 0)
+	for rows.Next() {
+		item := &repoModel.InventorySelect{}
+		err = rows.Scan(item.Pointers()...)
+		if err != nil {
+			return nil, fmt.Errorf("fail to scan: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return lo.Map(items, repoModel.DecodeInventory), nil
+}
@@ -0,0 +1,151 @@
+package model
+
+import (
+	"time"
+
+	"github.com/mechta-market/e-product/internal/domain/key/model"
+)
+
+type ActivationSelect struct {
+	ID            string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	ProductID     string
+	OrderID       string
+	CustomerPhone string
+	Status        string
+	KeyID         string
+	Error         string
+	Attempts      int64
+	NextAttemptAt time.Time
+}
+
+func (m *ActivationSelect) ListColumnMap() map[string]any {
+	return map[string]any{
+		"id":              &m.ID,
+		"created_at":      &m.CreatedAt,
+		"updated_at":      &m.UpdatedAt,
+		"product_id":      &m.ProductID,
+		"order_id":        &m.OrderID,
+		"customer_phone":  &m.CustomerPhone,
+		"status":          &m.Status,
+		"key_id":          &m.KeyID,
+		"error":           &m.Error,
+		"attempts":        &m.Attempts,
+		"next_attempt_at": &m.NextAttemptAt,
+	}
+}
+
+func (m *ActivationSelect) PKColumnMap() map[string]any {
+	return map[string]any{
+		"id": m.ID,
+	}
+}
+
+func (m *ActivationSelect) DefaultSortColumns() []string {
+	return []string{
+		"created_at asc",
+	}
+}
+
+func DecodeActivation(m *ActivationSelect, _ int) *model.Activation {
+	return &model.Activation{
+		ID:            m.ID,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+		ProductID:     m.ProductID,
+		OrderID:       m.OrderID,
+		CustomerPhone: m.CustomerPhone,
+		Status:        m.Status,
+		KeyID:         m.KeyID,
+		Error:         m.Error,
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+	}
+}
+
+type ActivationUpsert struct {
+	ID            string
+	UpdatedAt     *time.Time
+	ProductID     *string
+	OrderID       *string
+	CustomerPhone *string
+	Status        *string
+	KeyID         *string
+	Error         *string
+	NextAttemptAt *time.Time
+}
+
+func (m *ActivationUpsert) UpdateColumnMap() map[string]any {
+	res := m.CreateColumnMap()
+
+	pkMap := m.PKColumnMap()
+	for k := range pkMap {
+		delete(res, k)
+	}
+
+	return res
+}
+
+func (m *ActivationUpsert) PKColumnMap() map[string]any {
+	return map[string]any{
+		"id": m.ID,
+	}
+}
+
+func (m *ActivationUpsert) CreateColumnMap() map[string]any {
+	result := make(map[string]any, 8)
+
+	if m.UpdatedAt != nil {
+		result["updated_at"] = *m.UpdatedAt
+	}
+
+	if m.ProductID != nil {
+		result["product_id"] = *m.ProductID
+	}
+
+	if m.OrderID != nil {
+		result["order_id"] = *m.OrderID
+	}
+
+	if m.CustomerPhone != nil {
+		result["customer_phone"] = *m.CustomerPhone
+	}
+
+	if m.Status != nil {
+		result["status"] = *m.Status
+	}
+
+	if m.KeyID != nil {
+		result["key_id"] = *m.KeyID
+	}
+
+	if m.Error != nil {
+		result["error"] = *m.Error
+	}
+
+	if m.NextAttemptAt != nil {
+		result["next_attempt_at"] = *m.NextAttemptAt
+	}
+
+	return result
+}
+
+func EncodeActivationEdit(m *model.ActivationEdit) *ActivationUpsert {
+	result := &ActivationUpsert{}
+
+	if m.ID != nil && *m.ID != "" {
+		result.ID = *m.ID
+	}
+
+	result.UpdatedAt = m.UpdatedAt
+	result.ProductID = m.ProductID
+	result.OrderID = m.OrderID
+	result.CustomerPhone = m.CustomerPhone
+	result.Status = m.Status
+	result.KeyID = m.KeyID
+	result.Error = m.Error
+	result.NextAttemptAt = m.NextAttemptAt
+
+	return result
+}
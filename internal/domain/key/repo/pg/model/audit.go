@@ -0,0 +1,61 @@
+package model
+
+import (
+	"time"
+
+	"github.com/mechta-market/e-product/internal/domain/key/model"
+)
+
+// AuditFilter фильтры выгрузки в колонке filter (jsonb)
+type AuditFilter struct {
+	ProviderID    *string    `json:"provider_id,omitempty"`
+	Status        *string    `json:"status,omitempty"`
+	OrderID       *string    `json:"order_id,omitempty"`
+	ProductID     *string    `json:"product_id,omitempty"`
+	CustomerPhone *string    `json:"customer_phone,omitempty"`
+	Statuses      []string   `json:"statuses,omitempty"`
+	ProductIDs    []string   `json:"product_ids,omitempty"`
+	CreatedFrom   *time.Time `json:"created_from,omitempty"`
+	CreatedTo     *time.Time `json:"created_to,omitempty"`
+	UpdatedFrom   *time.Time `json:"updated_from,omitempty"`
+	UpdatedTo     *time.Time `json:"updated_to,omitempty"`
+	// значение ключа в журнал не пишется
+	ByValue bool `json:"by_value,omitempty"`
+}
+
+func EncodeAudit(v *model.Audit) map[string]any {
+	result := map[string]any{
+		"action":    v.Action,
+		"actor":     v.Actor,
+		"role":      v.Role,
+		"reason":    v.Reason,
+		"row_count": v.RowCount,
+	}
+
+	if v.KeyID != "" {
+		result["key_id"] = v.KeyID
+	}
+
+	if v.Filter != nil {
+		result["filter"] = EncodeAuditFilter(v.Filter)
+	}
+
+	return result
+}
+
+func EncodeAuditFilter(v *model.ListReq) *AuditFilter {
+	return &AuditFilter{
+		ProviderID:    v.ProviderID,
+		Status:        v.Status,
+		OrderID:       v.OrderID,
+		ProductID:     v.ProductID,
+		CustomerPhone: v.CustomerPhone,
+		Statuses:      v.Statuses,
+		ProductIDs:    v.ProductIDs,
+		CreatedFrom:   v.CreatedFrom,
+		CreatedTo:     v.CreatedTo,
+		UpdatedFrom:   v.UpdatedFrom,
+		UpdatedTo:     v.UpdatedTo,
+		ByValue:       v.Value != nil,
+	}
+}
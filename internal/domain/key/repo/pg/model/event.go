@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+
+	"github.com/mechta-market/e-product/internal/domain/key/model"
+)
+
+type EventSelect struct {
+	ID                    string
+	CreatedAt             time.Time
+	KeyID                 string
+	FromStatus            string
+	ToStatus              string
+	Actor                 string
+	OrderID               string
+	ProviderTransactionID string
+	Reason                string
+}
+
+func (m *EventSelect) ListColumnMap() map[string]any {
+	return map[string]any{
+		"id":                      &m.ID,
+		"created_at":              &m.CreatedAt,
+		"key_id":                  &m.KeyID,
+		"from_status":             &m.FromStatus,
+		"to_status":               &m.ToStatus,
+		"actor":                   &m.Actor,
+		"order_id":                &m.OrderID,
+		"provider_transaction_id": &m.ProviderTransactionID,
+		"reason":                  &m.Reason,
+	}
+}
+
+func (m *EventSelect) DefaultSortColumns() []string {
+	return []string{
+		"created_at asc",
+	}
+}
+
+func DecodeEvent(m *EventSelect, _ int) *model.Event {
+	return &model.Event{
+		ID:                    m.ID,
+		CreatedAt:             m.CreatedAt,
+		KeyID:                 m.KeyID,
+		FromStatus:            m.FromStatus,
+		ToStatus:              m.ToStatus,
+		Actor:                 m.Actor,
+		OrderID:               m.OrderID,
+		ProviderTransactionID: m.ProviderTransactionID,
+		Reason:                m.Reason,
+	}
+}
+
+func EncodeEvent(v *model.Event) map[string]any {
+	return map[string]any{
+		"key_id":                  v.KeyID,
+		"from_status":             v.FromStatus,
+		"to_status":               v.ToStatus,
+		"actor":                   v.Actor,
+		"order_id":                v.OrderID,
+		"provider_transaction_id": v.ProviderTransactionID,
+		"reason":                  v.Reason,
+	}
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"time"
+
+	"github.com/mechta-market/e-product/internal/domain/key/model"
+)
+
+// InventoryColumns колонки агрегата остатков, порядок совпадает с InventorySelect.Pointers
+var InventoryColumns = []string{
+	"product_id",
+	"provider_id",
+	"status",
+	"count(*)",
+	"count(*) FILTER (WHERE created_at > now() - interval '1 day')",
+	"count(*) FILTER (WHERE created_at <= now() - interval '1 day' AND created_at > now() - interval '7 days')",
+	"count(*) FILTER (WHERE created_at <= now() - interval '7 days' AND created_at > now() - interval '30 days')",
+	"count(*) FILTER (WHERE created_at <= now() - interval '30 days' AND created_at > now() - interval '90 days')",
+	"count(*) FILTER (WHERE created_at <= now() - interval '90 days')",
+	"min(created_at)",
+}
+
+// InventoryGroupBy колонки группировки агрегата остатков
+var InventoryGroupBy = []string{
+	"product_id",
+	"provider_id",
+	"status",
+}
+
+type InventorySelect struct {
+	ProductID       string
+	ProviderID      string
+	Status          string
+	Count           int64
+	AgeDay          int64
+	AgeWeek         int64
+	AgeMonth        int64
+	AgeQuarter      int64
+	AgeOlder        int64
+	OldestCreatedAt time.Time
+}
+
+func (m *InventorySelect) Pointers() []any {
+	return []any{
+		&m.ProductID,
+		&m.ProviderID,
+		&m.Status,
+		&m.Count,
+		&m.AgeDay,
+		&m.AgeWeek,
+		&m.AgeMonth,
+		&m.AgeQuarter,
+		&m.AgeOlder,
+		&m.OldestCreatedAt,
+	}
+}
+
+func DecodeInventory(m *InventorySelect, _ int) *model.InventoryItem {
+	return &model.InventoryItem{
+		ProductID:       m.ProductID,
+		ProviderID:      m.ProviderID,
+		Status:          m.Status,
+		Count:           m.Count,
+		AgeDay:          m.AgeDay,
+		AgeWeek:         m.AgeWeek,
+		AgeMonth:        m.AgeMonth,
+		AgeQuarter:      m.AgeQuarter,
+		AgeOlder:        m.AgeOlder,
+		OldestCreatedAt: m.OldestCreatedAt,
+	}
+}
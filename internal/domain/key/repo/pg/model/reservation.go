@@ -0,0 +1,150 @@
+package model
+
+import (
+	"time"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/key/model"
+)
+
+type ReservationSelect struct {
+	ID        string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ProductID string
+	OrderID   string
+	KeyID     string
+	Status    string
+	ExpiresAt time.Time
+}
+
+func (m *ReservationSelect) ListColumnMap() map[string]any {
+	return map[string]any{
+		"id":         &m.ID,
+		"created_at": &m.CreatedAt,
+		"updated_at": &m.UpdatedAt,
+		"product_id": &m.ProductID,
+		"order_id":   &m.OrderID,
+		"key_id":     &m.KeyID,
+		"status":     &m.Status,
+		"expires_at": &m.ExpiresAt,
+	}
+}
+
+func (m *ReservationSelect) PKColumnMap() map[string]any {
+	return map[string]any{
+		"id": m.ID,
+	}
+}
+
+func (m *ReservationSelect) DefaultSortColumns() []string {
+	return []string{
+		"expires_at asc",
+	}
+}
+
+func DecodeReservation(m *ReservationSelect, _ int) *model.Reservation {
+	return &model.Reservation{
+		ID:        m.ID,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+		ProductID: m.ProductID,
+		OrderID:   m.OrderID,
+		KeyID:     m.KeyID,
+		Status:    m.Status,
+		ExpiresAt: m.ExpiresAt,
+	}
+}
+
+type ReservationSelectActive struct {
+	ReservationSelect
+}
+
+func (m *ReservationSelectActive) PKColumnMap() map[string]any {
+	return map[string]any{
+		"order_id":   m.OrderID,
+		"product_id": m.ProductID,
+		"status":     constant.ReservationStatusActive,
+	}
+}
+
+// GetInterceptor пропускает истекшие резервы, которые еще не снял sweeper
+func (m *ReservationSelectActive) GetInterceptor(qb squirrel.SelectBuilder) squirrel.SelectBuilder {
+	return qb.Where("expires_at > now()")
+}
+
+type ReservationUpsert struct {
+	ID        string
+	UpdatedAt *time.Time
+	ProductID *string
+	OrderID   *string
+	KeyID     *string
+	Status    *string
+	ExpiresAt *time.Time
+}
+
+func (m *ReservationUpsert) UpdateColumnMap() map[string]any {
+	res := m.CreateColumnMap()
+
+	pkMap := m.PKColumnMap()
+	for k := range pkMap {
+		delete(res, k)
+	}
+
+	return res
+}
+
+func (m *ReservationUpsert) PKColumnMap() map[string]any {
+	return map[string]any{
+		"id": m.ID,
+	}
+}
+
+func (m *ReservationUpsert) CreateColumnMap() map[string]any {
+	result := make(map[string]any, 6)
+
+	if m.UpdatedAt != nil {
+		result["updated_at"] = *m.UpdatedAt
+	}
+
+	if m.ProductID != nil {
+		result["product_id"] = *m.ProductID
+	}
+
+	if m.OrderID != nil {
+		result["order_id"] = *m.OrderID
+	}
+
+	if m.KeyID != nil {
+		result["key_id"] = *m.KeyID
+	}
+
+	if m.Status != nil {
+		result["status"] = *m.Status
+	}
+
+	if m.ExpiresAt != nil {
+		result["expires_at"] = *m.ExpiresAt
+	}
+
+	return result
+}
+
+func EncodeReservationEdit(m *model.ReservationEdit) *ReservationUpsert {
+	result := &ReservationUpsert{}
+
+	if m.ID != nil && *m.ID != "" {
+		result.ID = *m.ID
+	}
+
+	result.UpdatedAt = m.UpdatedAt
+	result.ProductID = m.ProductID
+	result.OrderID = m.OrderID
+	result.KeyID = m.KeyID
+	result.Status = m.Status
+	result.ExpiresAt = m.ExpiresAt
+
+	return result
+}
@@ -3,6 +3,8 @@ package model
 import (
 	"time"
 
+	"github.com/Masterminds/squirrel"
+
 	"github.com/mechta-market/e-product/internal/domain/key/model"
 )
 
@@ -19,6 +21,13 @@ type Select struct {
 	ProviderOrderID       string
 	ProviderProductID     string
 	ProviderTransactionID string
+	Link                  string
+	Instructions          string
+	LicenseTerm           string
+	Source                string
+	ValueEnc              []byte
+	ValueDEK              []byte
+	ValueKeyID            string
 }
 
 func (m *Select) ListColumnMap() map[string]any {
@@ -36,6 +45,13 @@ func (m *Select) ListColumnMap() map[string]any {
 		"provider_order_id":       &m.ProviderOrderID,
 		"provider_product_id":     &m.ProviderProductID,
 		"provider_transaction_id": &m.ProviderTransactionID,
+		"link":                    &m.Link,
+		"instructions":            &m.Instructions,
+		"license_term":            &m.LicenseTerm,
+		"source":                  &m.Source,
+		"value_enc":               &m.ValueEnc,
+		"value_dek":               &m.ValueDEK,
+		"value_key_id":            &m.ValueKeyID,
 	}
 }
 
@@ -65,17 +81,32 @@ func DecodeMain(m *Select, _ int) *model.Main {
 		ProviderOrderID:       m.ProviderOrderID,
 		ProviderProductID:     m.ProviderProductID,
 		ProviderTransactionID: m.ProviderTransactionID,
+		Link:                  m.Link,
+		Instructions:          m.Instructions,
+		LicenseTerm:           m.LicenseTerm,
+		Source:                m.Source,
 	}
 }
 
 type SelectByValue struct {
 	Select
+	ValueHash string
 }
 
 func (m *SelectByValue) PKColumnMap() map[string]any {
-	return map[string]any{
-		"value": m.Value,
+	return map[string]any{}
+}
+
+// GetInterceptor ищет зашифрованные ключи по value_hash, а еще не перешифрованные - по value
+func (m *SelectByValue) GetInterceptor(qb squirrel.SelectBuilder) squirrel.SelectBuilder {
+	if m.ValueHash == "" {
+		return qb.Where(squirrel.Eq{"value": m.Value})
 	}
+
+	return qb.Where(squirrel.Or{
+		squirrel.Eq{"value_hash": m.ValueHash},
+		squirrel.Eq{"value_key_id": "", "value": m.Value},
+	})
 }
 
 type SelectByOrderID struct {
@@ -87,3 +118,14 @@ func (m *SelectByOrderID) PKColumnMap() map[string]any {
 		"order_id": m.OrderID,
 	}
 }
+
+type SelectByOrderAndProductID struct {
+	Select
+}
+
+func (m *SelectByOrderAndProductID) PKColumnMap() map[string]any {
+	return map[string]any{
+		"order_id":   m.OrderID,
+		"product_id": m.ProductID,
+	}
+}
@@ -18,6 +18,14 @@ type Upsert struct {
 	ProviderOrderID       *string
 	ProviderProductID     *string
 	ProviderTransactionID *string
+	Link                  *string
+	Instructions          *string
+	LicenseTerm           *string
+	Source                *string
+	ValueEnc              []byte
+	ValueDEK              []byte
+	ValueKeyID            *string
+	ValueHash             *string
 }
 
 func (m *Upsert) UpdateColumnMap() map[string]any {
@@ -39,7 +47,7 @@ func (m *Upsert) PKColumnMap() map[string]any {
 }
 
 func (m *Upsert) CreateColumnMap() map[string]any {
-	result := make(map[string]any, 10)
+	result := make(map[string]any, 18)
 
 	if m.UpdatedAt != nil {
 		result["updated_at"] = *m.UpdatedAt
@@ -81,6 +89,32 @@ func (m *Upsert) CreateColumnMap() map[string]any {
 		result["provider_transaction_id"] = *m.ProviderTransactionID
 	}
 
+	if m.Link != nil {
+		result["link"] = *m.Link
+	}
+
+	if m.Instructions != nil {
+		result["instructions"] = *m.Instructions
+	}
+
+	if m.LicenseTerm != nil {
+		result["license_term"] = *m.LicenseTerm
+	}
+
+	if m.Source != nil {
+		result["source"] = *m.Source
+	}
+
+	if m.ValueKeyID != nil {
+		result["value_enc"] = m.ValueEnc
+		result["value_dek"] = m.ValueDEK
+		result["value_key_id"] = *m.ValueKeyID
+	}
+
+	if m.ValueHash != nil {
+		result["value_hash"] = *m.ValueHash
+	}
+
 	return result
 }
 
@@ -107,6 +141,10 @@ func EncodeEdit(m *model.Edit) *Upsert {
 	result.ProviderOrderID = m.ProviderOrderID
 	result.ProviderProductID = m.ProviderProductID
 	result.ProviderTransactionID = m.ProviderTransactionID
+	result.Link = m.Link
+	result.Instructions = m.Instructions
+	result.LicenseTerm = m.LicenseTerm
+	result.Source = m.Source
 
 	return result
 }
@@ -2,13 +2,20 @@ package pg
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/mechta-market/mobone/v2"
 	moboneTools "github.com/mechta-market/mobone/v2/tools"
 	"github.com/opentracing/opentracing-go"
 	"github.com/samber/lo"
 
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/common/keyring"
 	commonRepoPg "github.com/mechta-market/e-product/internal/domain/common/repo/pg"
 	"github.com/mechta-market/e-product/internal/domain/key/model"
 	repoModel "github.com/mechta-market/e-product/internal/domain/key/repo/pg/model"
@@ -16,10 +23,15 @@ import (
 
 type Repo struct {
 	*commonRepoPg.Base
-	ModelStore *mobone.ModelStore
+	ModelStore       *mobone.ModelStore
+	EventStore       *mobone.ModelStore
+	ReservationStore *mobone.ModelStore
+	ActivationStore  *mobone.ModelStore
+	keyring          *keyring.Keyring
 }
 
-func New(con *pgxpool.Pool) *Repo {
+// New создает репозиторий ключей. Если kr == nil, value хранится в открытом виде
+func New(con *pgxpool.Pool, kr *keyring.Keyring) *Repo {
 	base := commonRepoPg.NewBase(con)
 	return &Repo{
 		Base: base,
@@ -28,6 +40,22 @@ func New(con *pgxpool.Pool) *Repo {
 			QB:        base.QB,
 			TableName: "key",
 		},
+		EventStore: &mobone.ModelStore{
+			Con:       base.Con,
+			QB:        base.QB,
+			TableName: "key_event",
+		},
+		ReservationStore: &mobone.ModelStore{
+			Con:       base.Con,
+			QB:        base.QB,
+			TableName: "key_reservation",
+		},
+		ActivationStore: &mobone.ModelStore{
+			Con:       base.Con,
+			QB:        base.QB,
+			TableName: "key_activation",
+		},
+		keyring: kr,
 	}
 }
 
@@ -44,9 +72,7 @@ func (r *Repo) List(ctx context.Context, pars *model.ListReq) (_ []*model.Main,
 	conditions, conditionExps := r.getConditions(pars)
 	sort := moboneTools.ConstructSortColumns(allowedSortFields, pars.Sort)
 
-	items := make([]*repoModel.Select, 0)
-
-	totalCount, err := r.ModelStore.List(ctx, mobone.ListParams{
+	listParams := mobone.ListParams{
 		Conditions:           conditions,
 		ConditionExpressions: conditionExps,
 		Page:                 pars.Page,
@@ -54,7 +80,17 @@ func (r *Repo) List(ctx context.Context, pars *model.ListReq) (_ []*model.Main,
 		WithTotalCount:       pars.WithTotalCount,
 		OnlyCount:            pars.OnlyCount,
 		Sort:                 sort,
-	}, func(add bool) mobone.ListModelI {
+	}
+
+	if pars.Cursor != nil {
+		listParams.Page = 0
+		listParams.WithTotalCount = false
+		listParams.Sort = cursorSortColumns
+	}
+
+	items := make([]*repoModel.Select, 0)
+
+	totalCount, err := r.ModelStore.List(ctx, listParams, func(add bool) mobone.ListModelI {
 		item := &repoModel.Select{}
 
 		if add {
@@ -67,7 +103,16 @@ func (r *Repo) List(ctx context.Context, pars *model.ListReq) (_ []*model.Main,
 		return nil, 0, fmt.Errorf("ModelStore.List: %w", err)
 	}
 
-	return lo.Map(items, repoModel.DecodeMain), totalCount, nil
+	result := make([]*model.Main, 0, len(items))
+	for _, item := range items {
+		obj, err := r.decodeMain(item)
+		if err != nil {
+			return nil, 0, fmt.Errorf("decodeMain: %w", err)
+		}
+		result = append(result, obj)
+	}
+
+	return result, totalCount, nil
 }
 
 func (r *Repo) Get(ctx context.Context, id string) (_ *model.Main, _ bool, finalError error) {
@@ -92,7 +137,12 @@ func (r *Repo) Get(ctx context.Context, id string) (_ *model.Main, _ bool, final
 		return nil, false, nil
 	}
 
-	return repoModel.DecodeMain(m, 0), true, nil
+	result, err := r.decodeMain(m)
+	if err != nil {
+		return nil, false, fmt.Errorf("decodeMain: %w", err)
+	}
+
+	return result, true, nil
 }
 
 func (r *Repo) GetByValue(ctx context.Context, value string) (_ *model.Main, finalError error) {
@@ -107,6 +157,9 @@ func (r *Repo) GetByValue(ctx context.Context, value string) (_ *model.Main, fin
 
 	m := &repoModel.SelectByValue{}
 	m.Value = value
+	if r.keyring != nil {
+		m.ValueHash = r.keyring.Hash(value)
+	}
 
 	found, err := r.ModelStore.Get(ctx, m)
 
@@ -117,7 +170,12 @@ func (r *Repo) GetByValue(ctx context.Context, value string) (_ *model.Main, fin
 		return nil, nil
 	}
 
-	return repoModel.DecodeMain(&m.Select, 0), nil
+	result, err := r.decodeMain(&m.Select)
+	if err != nil {
+		return nil, fmt.Errorf("decodeMain: %w", err)
+	}
+
+	return result, nil
 }
 
 func (r *Repo) GetByOrderID(ctx context.Context, orderID string) (_ *model.Main, _ bool, finalError error) {
@@ -141,7 +199,42 @@ func (r *Repo) GetByOrderID(ctx context.Context, orderID string) (_ *model.Main,
 		return nil, false, nil
 	}
 
-	return repoModel.DecodeMain(&m.Select, 0), true, nil
+	result, err := r.decodeMain(&m.Select)
+	if err != nil {
+		return nil, false, fmt.Errorf("decodeMain: %w", err)
+	}
+
+	return result, true, nil
+}
+
+func (r *Repo) GetByOrderAndProductID(ctx context.Context, orderID, productID string) (_ *model.Main, _ bool, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.GetByOrderAndProductID")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	m := &repoModel.SelectByOrderAndProductID{}
+	m.OrderID = orderID
+	m.ProductID = productID
+
+	found, err := r.ModelStore.Get(ctx, m)
+	if err != nil {
+		return nil, false, fmt.Errorf("ModelStore.Get: %w", err)
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	result, err := r.decodeMain(&m.Select)
+	if err != nil {
+		return nil, false, fmt.Errorf("decodeMain: %w", err)
+	}
+
+	return result, true, nil
 }
 
 func (r *Repo) Update(ctx context.Context, obj *model.Edit) (finalError error) {
@@ -154,7 +247,12 @@ func (r *Repo) Update(ctx context.Context, obj *model.Edit) (finalError error) {
 		}
 	}()
 
-	err := r.ModelStore.Update(ctx, repoModel.EncodeEdit(obj))
+	upsertObj, err := r.encodeEdit(obj)
+	if err != nil {
+		return fmt.Errorf("encodeEdit: %w", err)
+	}
+
+	err = r.ModelStore.Update(ctx, upsertObj)
 	if err != nil {
 		return fmt.Errorf("ModelStore.Update: %w", err)
 	}
@@ -162,6 +260,59 @@ func (r *Repo) Update(ctx context.Context, obj *model.Edit) (finalError error) {
 	return nil
 }
 
+// UpdateStatus обновляет ключ, только если его статус все еще event.FromStatus, и в той же транзакции
+// пишет event в журнал. false - статус уже изменил параллельный запрос
+func (r *Repo) UpdateStatus(ctx context.Context, obj *model.Edit, event *model.Event) (_ bool, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.UpdateStatus")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	upsertObj, err := r.encodeEdit(obj)
+	if err != nil {
+		return false, fmt.Errorf("encodeEdit: %w", err)
+	}
+
+	updated := false
+
+	err = r.WithTx(ctx, func(tx pgx.Tx) error {
+		query, args, err := r.QB.Update(r.ModelStore.TableName).
+			SetMap(upsertObj.UpdateColumnMap()).
+			Where(squirrel.Eq(upsertObj.PKColumnMap())).
+			Where(squirrel.Eq{"status": event.FromStatus}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("fail to build query: %w", err)
+		}
+
+		tag, err := tx.Exec(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("fail to exec: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+
+		err = r.createEvent(ctx, tx, event)
+		if err != nil {
+			return fmt.Errorf("createEvent: %w", err)
+		}
+
+		updated = true
+
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("WithTx: %w", err)
+	}
+
+	return updated, nil
+}
+
 func (r *Repo) Create(ctx context.Context, obj *model.Edit) (_ string, finalError error) {
 	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.Create")
 	defer tracingSpan.Finish()
@@ -172,12 +323,302 @@ func (r *Repo) Create(ctx context.Context, obj *model.Edit) (_ string, finalErro
 		}
 	}()
 
-	upsertObj := repoModel.EncodeEdit(obj)
+	upsertObj, err := r.encodeEdit(obj)
+	if err != nil {
+		return "", fmt.Errorf("encodeEdit: %w", err)
+	}
 
-	err := r.ModelStore.Create(ctx, upsertObj)
+	err = r.ModelStore.Create(ctx, upsertObj)
 	if err != nil {
 		return "", fmt.Errorf("ModelStore.Create: %w", err)
 	}
 
 	return upsertObj.ID, nil
 }
+
+// CreateMany сохраняет все ключи в одной транзакции
+func (r *Repo) CreateMany(ctx context.Context, objs []*model.Edit) (_ []string, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.CreateMany")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	ids := make([]string, 0, len(objs))
+
+	err := r.WithTx(ctx, func(tx pgx.Tx) error {
+		for _, obj := range objs {
+			upsertObj, err := r.encodeEdit(obj)
+			if err != nil {
+				return fmt.Errorf("encodeEdit: %w", err)
+			}
+
+			query, args, err := r.QB.Insert(r.ModelStore.TableName).
+				SetMap(upsertObj.CreateColumnMap()).
+				Suffix("RETURNING id").
+				ToSql()
+			if err != nil {
+				return fmt.Errorf("fail to build query: %w", err)
+			}
+
+			err = tx.QueryRow(ctx, query, args...).Scan(&upsertObj.ID)
+			if err != nil {
+				return fmt.Errorf("fail to query: %w", err)
+			}
+
+			ids = append(ids, upsertObj.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("WithTx: %w", err)
+	}
+
+	return ids, nil
+}
+
+// ClaimNew атомарно выдает свободный ключ из пула: строка блокируется через FOR UPDATE SKIP LOCKED,
+// поэтому параллельные вызовы никогда не получат один и тот же ключ. Выдача пишется в журнал как event,
+// ключ и статусы заполняются здесь
+func (r *Repo) ClaimNew(ctx context.Context, productID, orderID, customerPhone string, event *model.Event) (_ *model.Main, _ bool, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.ClaimNew")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	var result *model.Main
+
+	err := r.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+
+		result, err = r.claimNew(ctx, tx, productID, &model.Edit{
+			Status:        lo.ToPtr(constant.KeyStatusActivated),
+			OrderID:       lo.ToPtr(orderID),
+			CustomerPhone: lo.ToPtr(customerPhone),
+		}, event)
+
+		return err
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("WithTx: %w", err)
+	}
+
+	return result, result != nil, nil
+}
+
+// claimNew переводит первый свободный ключ продукта в obj.Status с полями obj. nil - свободных ключей нет
+func (r *Repo) claimNew(ctx context.Context, tx pgx.Tx, productID string, obj *model.Edit, event *model.Event) (*model.Main, error) {
+	m := &repoModel.Select{}
+	colNames, colPointers := commonRepoPg.ColumnMapSplit(m.ListColumnMap())
+
+	query, args, err := r.QB.Select(colNames...).
+		From(r.ModelStore.TableName).
+		Where(squirrel.Eq{
+			"product_id": productID,
+			"status":     constant.KeyStatusNew,
+		}).
+		OrderBy(m.DefaultSortColumns()...).
+		Limit(1).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("fail to build select query: %w", err)
+	}
+
+	err = tx.QueryRow(ctx, query, args...).Scan(colPointers...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("fail to select: %w", err)
+	}
+
+	obj.ID = lo.ToPtr(m.ID)
+	obj.UpdatedAt = lo.ToPtr(time.Now())
+	upsertObj := repoModel.EncodeEdit(obj)
+
+	query, args, err = r.QB.Update(r.ModelStore.TableName).
+		SetMap(upsertObj.UpdateColumnMap()).
+		Where(squirrel.Eq(upsertObj.PKColumnMap())).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("fail to build update query: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("fail to update: %w", err)
+	}
+
+	event.KeyID = m.ID
+	event.FromStatus = m.Status
+	event.ToStatus = lo.FromPtr(obj.Status)
+	event.OrderID = lo.CoalesceOrEmpty(lo.FromPtr(obj.OrderID), event.OrderID)
+
+	err = r.createEvent(ctx, tx, event)
+	if err != nil {
+		return nil, fmt.Errorf("createEvent: %w", err)
+	}
+
+	m.UpdatedAt = *upsertObj.UpdatedAt
+	m.Status = lo.FromPtr(obj.Status)
+	m.OrderID = lo.FromPtr(obj.OrderID)
+	m.CustomerPhone = lo.FromPtr(obj.CustomerPhone)
+
+	result, err := r.decodeMain(m)
+	if err != nil {
+		return nil, fmt.Errorf("decodeMain: %w", err)
+	}
+
+	return result, nil
+}
+
+// LockOrder занимает пару (order_id, product_id) на время обращения к провайдеру и возвращает токен
+// блокировки для UnlockOrder. Зависшая блокировка старше ttl (по часам БД) перехватывается с новым токеном
+func (r *Repo) LockOrder(ctx context.Context, orderID, productID string, ttl time.Duration) (_ string, _ bool, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.LockOrder")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	query, args, err := r.QB.Insert("key_order").
+		SetMap(map[string]any{
+			"order_id":   orderID,
+			"product_id": productID,
+			"token":      squirrel.Expr("gen_random_uuid()::text"),
+		}).
+		Suffix("ON CONFLICT (order_id, product_id) DO UPDATE SET created_at = now(), token = excluded.token WHERE key_order.created_at < now() - make_interval(secs => ?)", ttl.Seconds()).
+		Suffix("RETURNING token").
+		ToSql()
+	if err != nil {
+		return "", false, fmt.Errorf("fail to build query: %w", err)
+	}
+
+	var token string
+
+	err = r.Con.QueryRow(ctx, query, args...).Scan(&token)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("fail to query: %w", err)
+	}
+
+	return token, true, nil
+}
+
+// UnlockOrder снимает блокировку, только если она занята с token: перехваченную после ttl
+// блокировку другого запроса прежний владелец не снимает
+func (r *Repo) UnlockOrder(ctx context.Context, orderID, productID, token string) (finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.UnlockOrder")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	query, args, err := r.QB.Delete("key_order").
+		Where(squirrel.Eq{
+			"order_id":   orderID,
+			"product_id": productID,
+			"token":      token,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("fail to build query: %w", err)
+	}
+
+	_, err = r.Con.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("fail to exec: %w", err)
+	}
+
+	return nil
+}
+
+// Reencrypt переводит до limit ключей на активный мастер-ключ, возвращает число обработанных
+func (r *Repo) Reencrypt(ctx context.Context, limit uint64) (_ int, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.Reencrypt")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	if r.keyring == nil {
+		return 0, nil
+	}
+
+	count, err := r.ReencryptValues(ctx, r.keyring, r.ModelStore.TableName, true, limit)
+	if err != nil {
+		return 0, fmt.Errorf("ReencryptValues: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListEvents возвращает журнал смены статусов ключа в порядке изменений
+func (r *Repo) ListEvents(ctx context.Context, keyID string) (_ []*model.Event, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.ListEvents")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	items := make([]*repoModel.EventSelect, 0)
+
+	_, err := r.EventStore.List(ctx, mobone.ListParams{
+		Conditions: map[string]any{
+			"key_id": keyID,
+		},
+		// история по времени, id - однозначный порядок событий с одинаковым created_at
+		Sort: []string{"created_at asc", "id asc"},
+	}, func(add bool) mobone.ListModelI {
+		item := &repoModel.EventSelect{}
+
+		if add {
+			items = append(items, item)
+		}
+		return item
+	})
+	if err != nil {
+		return nil, fmt.Errorf("EventStore.List: %w", err)
+	}
+
+	return lo.Map(items, repoModel.DecodeEvent), nil
+}
+
+func (r *Repo) createEvent(ctx context.Context, tx pgx.Tx, event *model.Event) error {
+	query, args, err := r.QB.Insert(r.EventStore.TableName).
+		SetMap(repoModel.EncodeEvent(event)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("fail to build query: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("fail to exec: %w", err)
+	}
+
+	return nil
+}
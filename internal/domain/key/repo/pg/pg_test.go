@@ -0,0 +1,957 @@
+package pg
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/common/keyring"
+	commonModel "github.com/mechta-market/e-product/internal/domain/common/model"
+	"github.com/mechta-market/e-product/internal/domain/key/model"
+)
+
+// Тесты работают с реальной БД: TEST_PG_DSN должен указывать на отдельную тестовую базу,
+// схема в ней пересоздается по файлам из migrations
+func newTestRepo(t *testing.T) *Repo {
+	t.Helper()
+
+	return New(newTestCon(t), newTestKeyring(t, "k1"))
+}
+
+func newTestCon(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_PG_DSN")
+	if dsn == "" {
+		t.Skip("TEST_PG_DSN is not set")
+	}
+
+	ctx := context.Background()
+
+	con, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(con.Close)
+
+	migrate(t, con, "*.down.sql", true)
+	migrate(t, con, "*.up.sql", false)
+
+	return con
+}
+
+// newTestKeyring создает мастер-ключи с заданными id, активный - последний
+func newTestKeyring(t *testing.T, ids ...string) *keyring.Keyring {
+	t.Helper()
+
+	dir := t.TempDir()
+	files := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		f := filepath.Join(dir, id+".key")
+		key := sha256.Sum256([]byte(id))
+		require.NoError(t, os.WriteFile(f, []byte(hex.EncodeToString(key[:])), 0o600))
+		files = append(files, f)
+	}
+
+	hashFile := filepath.Join(dir, "hash.key")
+	require.NoError(t, os.WriteFile(hashFile, []byte(strings.Repeat("h", 32)), 0o600))
+
+	kr, err := keyring.New(files, "", hashFile)
+	require.NoError(t, err)
+
+	return kr
+}
+
+func migrate(t *testing.T, con *pgxpool.Pool, pattern string, reverse bool) {
+	t.Helper()
+
+	files, err := filepath.Glob(migrationFile(pattern))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	sort.Strings(files)
+	if reverse {
+		files = lo.Reverse(files)
+	}
+
+	for _, f := range files {
+		migrateFile(t, con, f)
+	}
+}
+
+func migrateFile(t *testing.T, con *pgxpool.Pool, f string) {
+	t.Helper()
+
+	data, err := os.ReadFile(f)
+	require.NoError(t, err)
+
+	_, err = con.Exec(context.Background(), string(data))
+	require.NoError(t, err, f)
+}
+
+func migrationFile(name string) string {
+	return filepath.Join("..", "..", "..", "..", "..", "migrations", name)
+}
+
+func createKeys(t *testing.T, r *Repo, productID string, count int) {
+	t.Helper()
+
+	for i := 0; i < count; i++ {
+		_, err := r.Create(context.Background(), &model.Edit{
+			ProductID: lo.ToPtr(productID),
+			Value:     lo.ToPtr(productID + "-value-" + strconv.Itoa(i)),
+		})
+		require.NoError(t, err)
+	}
+}
+
+func TestRepo_ClaimNew_Concurrent(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	const (
+		poolSize = 5
+		workers  = 20
+	)
+
+	createKeys(t, r, "prod-1", poolSize)
+	createKeys(t, r, "prod-2", 1)
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		claimed = make(map[string]string) // key id -> order id
+		missed  int
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(orderID string) {
+			defer wg.Done()
+
+			item, found, err := r.ClaimNew(ctx, "prod-1", orderID, "77001112233", &model.Event{Actor: "loader"})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if !found {
+				missed++
+				return
+			}
+
+			_, dup := claimed[item.ID]
+			assert.False(t, dup, "key %s claimed twice", item.ID)
+			claimed[item.ID] = orderID
+
+			assert.Equal(t, constant.KeyStatusActivated, item.Status)
+			assert.Equal(t, orderID, item.OrderID)
+		}("ord-" + strconv.Itoa(i))
+	}
+	wg.Wait()
+
+	require.Len(t, claimed, poolSize)
+	require.Equal(t, workers-poolSize, missed)
+
+	// в БД у каждого выданного ключа свой заказ
+	for id, orderID := range claimed {
+		item, found, err := r.Get(ctx, id)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, constant.KeyStatusActivated, item.Status)
+		require.Equal(t, orderID, item.OrderID)
+
+		events, err := r.ListEvents(ctx, id)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, constant.KeyStatusNew, events[0].FromStatus)
+		require.Equal(t, constant.KeyStatusActivated, events[0].ToStatus)
+		require.Equal(t, orderID, events[0].OrderID)
+		require.Equal(t, "loader", events[0].Actor)
+	}
+
+	// ключи другого продукта не затронуты
+	items, _, err := r.List(ctx, &model.ListReq{
+		ProductID: lo.ToPtr("prod-2"),
+		Status:    lo.ToPtr(constant.KeyStatusNew),
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+}
+
+func TestRepo_ClaimNew_EmptyPool(t *testing.T) {
+	r := newTestRepo(t)
+
+	item, found, err := r.ClaimNew(context.Background(), "prod-absent", "ord-1", "77001112233", &model.Event{})
+	require.NoError(t, err)
+	require.False(t, found)
+	require.Nil(t, item)
+}
+
+func TestRepo_UpdateStatus_Event(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	id, err := r.Create(ctx, &model.Edit{
+		ProductID: lo.ToPtr("prod-1"),
+		Value:     lo.ToPtr("VALUE-1"),
+	})
+	require.NoError(t, err)
+
+	event := &model.Event{
+		KeyID:      id,
+		FromStatus: constant.KeyStatusNew,
+		ToStatus:   constant.KeyStatusActivated,
+		Actor:      "support",
+		OrderID:    "ord-1",
+		Reason:     "manual",
+	}
+
+	updated, err := r.UpdateStatus(ctx, &model.Edit{
+		ID:      lo.ToPtr(id),
+		Status:  lo.ToPtr(constant.KeyStatusActivated),
+		OrderID: lo.ToPtr("ord-1"),
+	}, event)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	// статус уже изменен: ни ключ, ни журнал не меняются
+	updated, err = r.UpdateStatus(ctx, &model.Edit{
+		ID:     lo.ToPtr(id),
+		Status: lo.ToPtr(constant.KeyStatusReserved),
+	}, &model.Event{
+		KeyID:      id,
+		FromStatus: constant.KeyStatusNew,
+		ToStatus:   constant.KeyStatusReserved,
+	})
+	require.NoError(t, err)
+	require.False(t, updated)
+
+	item, found, err := r.Get(ctx, id)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, constant.KeyStatusActivated, item.Status)
+
+	events, err := r.ListEvents(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "support", events[0].Actor)
+	require.Equal(t, "manual", events[0].Reason)
+	require.Equal(t, constant.KeyStatusActivated, events[0].ToStatus)
+}
+
+func TestRepo_LockOrder_Concurrent(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	const workers = 10
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		locked int
+		token  string
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			lockToken, ok, err := r.LockOrder(ctx, "ord-1", "prod-1", time.Minute)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			if ok {
+				mu.Lock()
+				locked++
+				token = lockToken
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 1, locked)
+	require.NotEmpty(t, token)
+
+	// после снятия блокировки заказ можно занять снова
+	require.NoError(t, r.UnlockOrder(ctx, "ord-1", "prod-1", token))
+
+	_, ok, err := r.LockOrder(ctx, "ord-1", "prod-1", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// зависшая блокировка перехватывается
+	_, ok, err = r.LockOrder(ctx, "ord-1", "prod-1", 0)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestRepo_UnlockOrder_Token(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	staleToken, ok, err := r.LockOrder(ctx, "ord-1", "prod-1", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// блокировка перехвачена другим запросом после ttl
+	token, ok, err := r.LockOrder(ctx, "ord-1", "prod-1", 0)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NotEqual(t, staleToken, token)
+
+	// прежний владелец не снимает чужую блокировку
+	require.NoError(t, r.UnlockOrder(ctx, "ord-1", "prod-1", staleToken))
+
+	_, ok, err = r.LockOrder(ctx, "ord-1", "prod-1", time.Minute)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, r.UnlockOrder(ctx, "ord-1", "prod-1", token))
+
+	_, ok, err = r.LockOrder(ctx, "ord-1", "prod-1", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestRepo_OrderProductUnique(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	createKeys(t, r, "prod-1", 2)
+
+	_, found, err := r.ClaimNew(ctx, "prod-1", "ord-1", "77001112233", &model.Event{})
+	require.NoError(t, err)
+	require.True(t, found)
+
+	// второй ключ того же продукта на тот же заказ не выдается
+	_, _, err = r.ClaimNew(ctx, "prod-1", "ord-1", "77001112233", &model.Event{})
+	require.Error(t, err)
+
+	item, found, err := r.GetByOrderAndProductID(ctx, "ord-1", "prod-1")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, constant.KeyStatusActivated, item.Status)
+}
+
+func TestMigration_KeyOrderDuplicates(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	createKeys(t, r, "prod-1", 2)
+
+	_, err := r.Con.Exec(ctx, `DROP INDEX key_order_id_product_id_uidx`)
+	require.NoError(t, err)
+	_, err = r.Con.Exec(ctx, `UPDATE key SET order_id = 'ord-1' WHERE product_id = 'prod-1'`)
+	require.NoError(t, err)
+
+	// дубли не удаляются молча: миграция останавливается и сообщает о них
+	data, err := os.ReadFile(migrationFile("20261018100000_key_order.up.sql"))
+	require.NoError(t, err)
+
+	_, err = r.Con.Exec(ctx, string(data))
+	require.ErrorContains(t, err, "1 (order_id, product_id) pairs have more than one key")
+}
+
+func TestRepo_CreateMany_Atomic(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	ids, err := r.CreateMany(ctx, []*model.Edit{
+		{ProductID: lo.ToPtr("prod-1"), Value: lo.ToPtr("key-1")},
+		{ProductID: lo.ToPtr("prod-1"), Value: lo.ToPtr("key-2")},
+	})
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+
+	// ошибка на втором ключе откатывает и первый
+	_, err = r.CreateMany(ctx, []*model.Edit{
+		{ProductID: lo.ToPtr("prod-2"), Value: lo.ToPtr("key-3")},
+		{ProductID: lo.ToPtr("prod-2"), Value: lo.ToPtr("key-4"), Status: lo.ToPtr("unknown_status")},
+	})
+	require.Error(t, err)
+
+	_, tCount, err := r.List(ctx, &model.ListReq{
+		ListParams: commonModel.ListParams{OnlyCount: true},
+		ProductID:  lo.ToPtr("prod-2"),
+	})
+	require.NoError(t, err)
+	require.Zero(t, tCount)
+}
+
+func TestRepo_EncryptedValue(t *testing.T) {
+	con := newTestCon(t)
+	ctx := context.Background()
+
+	r := New(con, newTestKeyring(t, "k1"))
+
+	id, err := r.Create(ctx, &model.Edit{
+		ProductID: lo.ToPtr("prod-1"),
+		Value:     lo.ToPtr("SECRET-1"),
+	})
+	require.NoError(t, err)
+
+	// ключ, сохраненный до включения шифрования
+	_, err = New(con, nil).Create(ctx, &model.Edit{
+		ProductID: lo.ToPtr("prod-1"),
+		Value:     lo.ToPtr("PLAIN-1"),
+	})
+	require.NoError(t, err)
+
+	var stored string
+	require.NoError(t, con.QueryRow(ctx, "SELECT value FROM key WHERE id = $1", id).Scan(&stored))
+	assert.Empty(t, stored)
+
+	item, found, err := r.Get(ctx, id)
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "SECRET-1", item.Value)
+
+	// поиск дублей работает и по зашифрованным, и по еще открытым значениям
+	for _, value := range []string{"SECRET-1", "PLAIN-1"} {
+		item, err = r.GetByValue(ctx, value)
+		require.NoError(t, err)
+		require.NotNil(t, item, value)
+		assert.Equal(t, value, item.Value)
+	}
+
+	item, err = r.GetByValue(ctx, "SECRET-2")
+	require.NoError(t, err)
+	assert.Nil(t, item)
+}
+
+func TestRepo_Reencrypt(t *testing.T) {
+	con := newTestCon(t)
+	ctx := context.Background()
+
+	old := New(con, newTestKeyring(t, "k1"))
+	_, err := old.Create(ctx, &model.Edit{ProductID: lo.ToPtr("prod-1"), Value: lo.ToPtr("SECRET-1")})
+	require.NoError(t, err)
+
+	_, err = New(con, nil).Create(ctx, &model.Edit{ProductID: lo.ToPtr("prod-1"), Value: lo.ToPtr("PLAIN-1")})
+	require.NoError(t, err)
+
+	// ротация: k2 активный, k1 еще нужен для чтения
+	r := New(con, newTestKeyring(t, "k1", "k2"))
+
+	count, err := r.Reencrypt(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	count, err = r.Reencrypt(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	count, err = r.Reencrypt(ctx, 10)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	var remaining int
+	require.NoError(t, con.QueryRow(ctx, "SELECT count(*) FROM key WHERE value_key_id <> 'k2' OR value <> ''").Scan(&remaining))
+	assert.Zero(t, remaining)
+
+	// после перешифрования k1 больше не нужен
+	r = New(con, newTestKeyring(t, "k2"))
+	for _, value := range []string{"SECRET-1", "PLAIN-1"} {
+		item, err := r.GetByValue(ctx, value)
+		require.NoError(t, err)
+		require.NotNil(t, item, value)
+		assert.Equal(t, value, item.Value)
+	}
+}
+
+func TestRepo_Reservation(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	createKeys(t, r, "prod-1", 1)
+
+	reservation, err := r.CreateReservation(ctx, &model.ReservationEdit{
+		ProductID: lo.ToPtr("prod-1"),
+		OrderID:   lo.ToPtr("ord-1"),
+		Status:    lo.ToPtr(constant.ReservationStatusActive),
+		ExpiresAt: lo.ToPtr(time.Now().Add(-time.Second)),
+	}, true, &model.Event{})
+	require.NoError(t, err)
+	require.NotEmpty(t, reservation.KeyID)
+
+	key, _, err := r.Get(ctx, reservation.KeyID)
+	require.NoError(t, err)
+	require.Equal(t, constant.KeyStatusReserved, key.Status)
+
+	// пул пуст - резерв без ключа
+	other, err := r.CreateReservation(ctx, &model.ReservationEdit{
+		ProductID: lo.ToPtr("prod-1"),
+		OrderID:   lo.ToPtr("ord-2"),
+		Status:    lo.ToPtr(constant.ReservationStatusActive),
+		ExpiresAt: lo.ToPtr(time.Now().Add(time.Minute)),
+	}, true, &model.Event{})
+	require.NoError(t, err)
+	require.Empty(t, other.KeyID)
+
+	_, found, err := r.GetActiveReservation(ctx, "ord-2", "prod-1")
+	require.NoError(t, err)
+	require.True(t, found)
+
+	// истекший резерв не считается действующим, но попадает в sweeper
+	_, found, err = r.GetActiveReservation(ctx, "ord-1", "prod-1")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	expired, err := r.ListExpiredReservations(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	require.Equal(t, reservation.ID, expired[0].ID)
+
+	finish := func() (bool, error) {
+		return r.FinishReservation(ctx, &model.ReservationEdit{
+			ID:     lo.ToPtr(reservation.ID),
+			Status: lo.ToPtr(constant.ReservationStatusExpired),
+		}, &model.Edit{
+			ID:     lo.ToPtr(reservation.KeyID),
+			Status: lo.ToPtr(constant.KeyStatusNew),
+		}, &model.Event{
+			KeyID:      reservation.KeyID,
+			FromStatus: constant.KeyStatusReserved,
+			ToStatus:   constant.KeyStatusNew,
+		})
+	}
+
+	updated, err := finish()
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	key, _, err = r.Get(ctx, reservation.KeyID)
+	require.NoError(t, err)
+	require.Equal(t, constant.KeyStatusNew, key.Status)
+
+	events, err := r.ListEvents(ctx, reservation.KeyID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	// резерв уже завершен
+	updated, err = finish()
+	require.NoError(t, err)
+	require.False(t, updated)
+}
+
+func TestRepo_InventoryReport(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	createKeys(t, r, "prod-1", 3)
+	createKeys(t, r, "prod-2", 1)
+
+	_, err := r.Con.Exec(ctx, `UPDATE key SET created_at = now() - interval '10 days' WHERE product_id = 'prod-2'`)
+	require.NoError(t, err)
+
+	items, err := r.InventoryReport(ctx, &model.ListReq{})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	assert.Equal(t, "prod-1", items[0].ProductID)
+	assert.Equal(t, constant.KeyStatusNew, items[0].Status)
+	assert.Equal(t, int64(3), items[0].Count)
+	assert.Equal(t, int64(3), items[0].AgeDay)
+
+	assert.Equal(t, "prod-2", items[1].ProductID)
+	assert.Equal(t, int64(1), items[1].Count)
+	assert.Equal(t, int64(1), items[1].AgeMonth)
+
+	items, err = r.InventoryReport(ctx, &model.ListReq{ProductID: lo.ToPtr("prod-2")})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "prod-2", items[0].ProductID)
+}
+
+func TestRepo_Export(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	// больше одной пачки курсора
+	createKeys(t, r, "prod-1", exportFetchSize+1)
+	createKeys(t, r, "prod-2", 1)
+
+	ids := make(map[string]struct{})
+	err := r.Export(ctx, &model.ListReq{ProductID: lo.ToPtr("prod-1")}, func(item *model.Main) error {
+		assert.Equal(t, "prod-1", item.ProductID)
+		assert.NotEmpty(t, item.Value)
+		ids[item.ID] = struct{}{}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, ids, exportFetchSize+1)
+
+	count := 0
+	err = r.Export(ctx, &model.ListReq{UpdatedFrom: lo.ToPtr(time.Now().Add(time.Hour))}, func(item *model.Main) error {
+		count++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestRepo_List_Cursor(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	createKeys(t, r, "prod-1", 5)
+	createKeys(t, r, "prod-2", 1)
+
+	ids := make(map[string]struct{})
+	cursor := &model.Cursor{}
+	for {
+		items, _, err := r.List(ctx, &model.ListReq{
+			ListParams: commonModel.ListParams{PageSize: 2},
+			ProductID:  lo.ToPtr("prod-1"),
+			Cursor:     cursor,
+		})
+		require.NoError(t, err)
+
+		for _, item := range items {
+			assert.NotContains(t, ids, item.ID)
+			ids[item.ID] = struct{}{}
+		}
+
+		if len(items) < 2 {
+			break
+		}
+		last := items[len(items)-1]
+		cursor = &model.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	assert.Len(t, ids, 5)
+}
+
+func TestRepo_List_Filters(t *testing.T) {
+	con := newTestCon(t)
+	ctx := context.Background()
+
+	r := New(con, newTestKeyring(t, "k1"))
+
+	createKeys(t, r, "prod-1", 2)
+	createKeys(t, r, "prod-2", 1)
+	createKeys(t, r, "prod-3", 1)
+
+	_, err := New(con, nil).Create(ctx, &model.Edit{
+		ProductID: lo.ToPtr("prod-1"),
+		Value:     lo.ToPtr("PLAIN-1"),
+	})
+	require.NoError(t, err)
+
+	activated, _, err := r.ClaimNew(ctx, "prod-2", "order-1", "77011234567", &model.Event{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		pars  *model.ListReq
+		count int
+	}{
+		{name: "product ids", pars: &model.ListReq{ProductIDs: []string{"prod-1", "prod-2"}}, count: 4},
+		{name: "statuses", pars: &model.ListReq{Statuses: []string{constant.KeyStatusNew, constant.KeyStatusActivated}}, count: 5},
+		{name: "customer phone", pars: &model.ListReq{CustomerPhone: lo.ToPtr("77011234567")}, count: 1},
+		{name: "encrypted value", pars: &model.ListReq{Value: lo.ToPtr("prod-3-value-0")}, count: 1},
+		{name: "plain value", pars: &model.ListReq{Value: lo.ToPtr("PLAIN-1")}, count: 1},
+		{name: "created range", pars: &model.ListReq{CreatedFrom: lo.ToPtr(time.Now().Add(-time.Hour)), CreatedTo: lo.ToPtr(time.Now().Add(time.Hour))}, count: 5},
+		{name: "created future", pars: &model.ListReq{CreatedFrom: lo.ToPtr(time.Now().Add(time.Hour))}, count: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, _, err := r.List(ctx, tt.pars)
+			require.NoError(t, err)
+			assert.Len(t, items, tt.count)
+		})
+	}
+
+	items, _, err := r.List(ctx, &model.ListReq{
+		ListParams:    commonModel.ListParams{Sort: []string{"status"}},
+		CustomerPhone: lo.ToPtr("77011234567"),
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, activated.ID, items[0].ID)
+}
+
+func TestRepo_CreateAudit(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	id, err := r.Create(ctx, &model.Edit{
+		ProductID: lo.ToPtr("prod-1"),
+		Value:     lo.ToPtr("SECRET-1"),
+	})
+	require.NoError(t, err)
+
+	err = r.CreateAudit(ctx, &model.Audit{
+		KeyID:  id,
+		Action: constant.AuditActionRevealValue,
+		Actor:  "operator-1",
+		Role:   constant.RoleSupport,
+		Reason: "ticket-1",
+	})
+	require.NoError(t, err)
+
+	var actor, reason string
+	require.NoError(t, r.Con.QueryRow(ctx, "SELECT actor, reason FROM key_audit WHERE key_id = $1", id).Scan(&actor, &reason))
+	assert.Equal(t, "operator-1", actor)
+	assert.Equal(t, "ticket-1", reason)
+}
+
+func TestRepo_CreateAudit_Export(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	err := r.CreateAudit(ctx, &model.Audit{
+		Action:   constant.AuditActionExport,
+		Actor:    "operator-1",
+		Role:     constant.RoleAdmin,
+		Filter:   &model.ListReq{Status: lo.ToPtr(constant.KeyStatusActivated), Value: lo.ToPtr("SECRET-1")},
+		RowCount: 2,
+	})
+	require.NoError(t, err)
+
+	var status string
+	var byValue bool
+	var rowCount int64
+	require.NoError(t, r.Con.QueryRow(ctx,
+		"SELECT filter->>'status', (filter->>'by_value')::bool, row_count FROM key_audit WHERE key_id IS NULL AND action = $1",
+		constant.AuditActionExport).Scan(&status, &byValue, &rowCount))
+	assert.Equal(t, constant.KeyStatusActivated, status)
+	assert.True(t, byValue)
+	assert.EqualValues(t, 2, rowCount)
+}
+
+func TestRepo_ActivationDetails(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	id, err := r.Create(ctx, &model.Edit{
+		ProductID:       lo.ToPtr("prod-1"),
+		Value:           lo.ToPtr("SECRET-1"),
+		ProviderOrderID: lo.ToPtr("po-1"),
+		Link:            lo.ToPtr("https://dl.example/secret-1"),
+		Instructions:    lo.ToPtr("Скачайте дистрибутив и введите ключ"),
+		LicenseTerm:     lo.ToPtr("1 год"),
+	})
+	require.NoError(t, err)
+
+	item, found, err := r.Get(ctx, id)
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "po-1", item.ProviderOrderID)
+	assert.Equal(t, "https://dl.example/secret-1", item.Link)
+	assert.Equal(t, "Скачайте дистрибутив и введите ключ", item.Instructions)
+	assert.Equal(t, "1 год", item.LicenseTerm)
+}
+
+func TestRepo_Activation(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	create := func() (*model.Activation, bool, error) {
+		return r.CreateActivation(ctx, &model.ActivationEdit{
+			ProductID:     lo.ToPtr("prod-1"),
+			OrderID:       lo.ToPtr("ord-1"),
+			CustomerPhone: lo.ToPtr("77001112233"),
+			Status:        lo.ToPtr(constant.ActivationStatusPending),
+		})
+	}
+
+	activation, created, err := create()
+	require.NoError(t, err)
+	require.True(t, created)
+	require.Equal(t, constant.ActivationStatusPending, activation.Status)
+
+	// повторный запрос по заказу возвращает ту же выдачу
+	again, created, err := create()
+	require.NoError(t, err)
+	require.False(t, created)
+	require.Equal(t, activation.ID, again.ID)
+
+	claimed, err := r.ClaimActivation(ctx, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	require.Equal(t, activation.ID, claimed.ID)
+	require.Equal(t, constant.ActivationStatusProcessing, claimed.Status)
+	require.EqualValues(t, 1, claimed.Attempts)
+
+	// выдача в работе не забирается повторно, пока не зависла
+	claimed, err = r.ClaimActivation(ctx, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	require.Nil(t, claimed)
+
+	claimed, err = r.ClaimActivation(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	require.EqualValues(t, 2, claimed.Attempts)
+
+	// возвращенная в очередь выдача не забирается до next_attempt_at
+	updated, err := r.FinishActivation(ctx, &model.ActivationEdit{
+		ID:            lo.ToPtr(activation.ID),
+		Status:        lo.ToPtr(constant.ActivationStatusPending),
+		Error:         lo.ToPtr("provider unavailable"),
+		NextAttemptAt: lo.ToPtr(time.Now().Add(time.Hour)),
+	})
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	claimed, err = r.ClaimActivation(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Nil(t, claimed)
+
+	_, err = r.Con.Exec(ctx, `UPDATE key_activation SET next_attempt_at = now() WHERE id = $1`, activation.ID)
+	require.NoError(t, err)
+
+	claimed, err = r.ClaimActivation(ctx, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	require.EqualValues(t, 3, claimed.Attempts)
+
+	finish := func() (bool, error) {
+		return r.FinishActivation(ctx, &model.ActivationEdit{
+			ID:     lo.ToPtr(activation.ID),
+			Status: lo.ToPtr(constant.ActivationStatusFailed),
+			Error:  lo.ToPtr("provider unavailable"),
+		})
+	}
+
+	updated, err = finish()
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	updated, err = finish()
+	require.NoError(t, err)
+	require.False(t, updated)
+
+	activation, found, err := r.GetActivation(ctx, activation.ID)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, constant.ActivationStatusFailed, activation.Status)
+	require.Equal(t, "provider unavailable", activation.Error)
+
+	// после неудачи по заказу можно создать новую выдачу
+	_, created, err = create()
+	require.NoError(t, err)
+	require.True(t, created)
+}
+
+func TestRepo_Source(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	poolID, err := r.Create(ctx, &model.Edit{
+		ProductID: lo.ToPtr("prod-1"),
+		Value:     lo.ToPtr("SECRET-1"),
+	})
+	require.NoError(t, err)
+
+	providerID, err := r.Create(ctx, &model.Edit{
+		ProductID: lo.ToPtr("prod-1"),
+		Value:     lo.ToPtr("SECRET-2"),
+		Source:    lo.ToPtr(constant.KeySourceProvider),
+	})
+	require.NoError(t, err)
+
+	item, _, err := r.Get(ctx, poolID)
+	require.NoError(t, err)
+	assert.Equal(t, constant.KeySourcePool, item.Source)
+
+	item, _, err = r.Get(ctx, providerID)
+	require.NoError(t, err)
+	assert.Equal(t, constant.KeySourceProvider, item.Source)
+}
+
+// TestMigration_KeySource проверяет заполнение source для существующих ключей
+func TestMigration_KeySource(t *testing.T) {
+	con := newTestCon(t)
+	ctx := context.Background()
+
+	migrateFile(t, con, migrationFile("20261019010000_key_source.down.sql"))
+
+	insert := func(providerID, transactionID string) string {
+		var id string
+		err := con.QueryRow(ctx, `INSERT INTO key (provider_id, product_id, value, provider_transaction_id)
+			VALUES ($1, 'prod-1', gen_random_uuid()::text, $2) RETURNING id`, providerID, transactionID).Scan(&id)
+		require.NoError(t, err)
+		return id
+	}
+
+	loadedID := insert("", "")
+	// Megogo не возвращает транзакцию
+	megogoID := insert(constant.ProviderMegogo, "")
+	comportalID := insert(constant.ProviderComportal, "tr-1")
+	replenishedID := insert(constant.ProviderComportal, "tr-2")
+
+	_, err := con.Exec(ctx, `INSERT INTO provider_operation (provider_id, product_id, order_id, status, key_id)
+		VALUES ($1, 'prod-1', '', 'stored', $2)`, constant.ProviderComportal, replenishedID)
+	require.NoError(t, err)
+
+	migrateFile(t, con, migrationFile("20261019010000_key_source.up.sql"))
+
+	for id, expected := range map[string]string{
+		loadedID:      constant.KeySourcePool,
+		megogoID:      constant.KeySourceProvider,
+		comportalID:   constant.KeySourceProvider,
+		replenishedID: constant.KeySourcePool,
+	} {
+		var source string
+		require.NoError(t, con.QueryRow(ctx, "SELECT source FROM key WHERE id = $1", id).Scan(&source))
+		assert.Equal(t, expected, source, id)
+	}
+}
+
+func TestMigration_CustomerPhoneNormalize(t *testing.T) {
+	con := newTestCon(t)
+	ctx := context.Background()
+
+	insert := func(phone string) string {
+		var id string
+		err := con.QueryRow(ctx, `INSERT INTO key (product_id, value, customer_phone)
+			VALUES ('prod-1', gen_random_uuid()::text, $1) RETURNING id`, phone).Scan(&id)
+		require.NoError(t, err)
+		return id
+	}
+
+	expected := map[string]string{
+		insert("+77001112233"):  "77001112233",
+		insert(" 87001112233 "): "77001112233",
+		insert("7001112233"):    "77001112233",
+		insert("77001112233"):   "77001112233",
+		insert(""):              "",
+		insert("+49301234567 "): "49301234567",
+	}
+
+	var activationID string
+	require.NoError(t, con.QueryRow(ctx, `INSERT INTO key_activation (product_id, order_id, customer_phone)
+		VALUES ('prod-1', 'ord-1', '+87001112233') RETURNING id`).Scan(&activationID))
+
+	migrateFile(t, con, migrationFile("20261019040000_customer_phone_normalize.up.sql"))
+
+	for id, phone := range expected {
+		var stored string
+		require.NoError(t, con.QueryRow(ctx, "SELECT customer_phone FROM key WHERE id = $1", id).Scan(&stored))
+		assert.Equal(t, phone, stored, id)
+	}
+
+	var stored string
+	require.NoError(t, con.QueryRow(ctx, "SELECT customer_phone FROM key_activation WHERE id = $1", activationID).Scan(&stored))
+	assert.Equal(t, "77001112233", stored)
+}
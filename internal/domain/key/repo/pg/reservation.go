@@ -0,0 +1,238 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/mechta-market/mobone/v2"
+	"github.com/opentracing/opentracing-go"
+	"github.com/samber/lo"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	commonRepoPg "github.com/mechta-market/e-product/internal/domain/common/repo/pg"
+	"github.com/mechta-market/e-product/internal/domain/key/model"
+	repoModel "github.com/mechta-market/e-product/internal/domain/key/repo/pg/model"
+)
+
+// CreateReservation создает резерв. Если claim, в той же транзакции из пула забирается свободный ключ
+// в статус reserved, а если свободных нет - резерв создается без ключа
+func (r *Repo) CreateReservation(ctx context.Context, obj *model.ReservationEdit, claim bool, event *model.Event) (_ *model.Reservation, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.CreateReservation")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	m := &repoModel.ReservationSelect{}
+
+	err := r.WithTx(ctx, func(tx pgx.Tx) error {
+		if claim {
+			event.OrderID = lo.FromPtr(obj.OrderID)
+
+			key, err := r.claimNew(ctx, tx, lo.FromPtr(obj.ProductID), &model.Edit{
+				Status: lo.ToPtr(constant.KeyStatusReserved),
+			}, event)
+			if err != nil {
+				return fmt.Errorf("claimNew: %w", err)
+			}
+			if key != nil {
+				obj.KeyID = lo.ToPtr(key.ID)
+			}
+		}
+
+		upsertObj := repoModel.EncodeReservationEdit(obj)
+		colNames, colPointers := commonRepoPg.ColumnMapSplit(m.ListColumnMap())
+
+		query, args, err := r.QB.Insert(r.ReservationStore.TableName).
+			SetMap(upsertObj.CreateColumnMap()).
+			Suffix("RETURNING " + strings.Join(colNames, ", ")).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("fail to build query: %w", err)
+		}
+
+		err = tx.QueryRow(ctx, query, args...).Scan(colPointers...)
+		if err != nil {
+			return fmt.Errorf("fail to query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("WithTx: %w", err)
+	}
+
+	return repoModel.DecodeReservation(m, 0), nil
+}
+
+func (r *Repo) GetReservation(ctx context.Context, id string) (_ *model.Reservation, _ bool, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.GetReservation")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	m := &repoModel.ReservationSelect{}
+	m.ID = id
+
+	found, err := r.ReservationStore.Get(ctx, m)
+	if err != nil {
+		return nil, false, fmt.Errorf("ReservationStore.Get: %w", err)
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	return repoModel.DecodeReservation(m, 0), true, nil
+}
+
+// GetActiveReservation возвращает действующий резерв заказа на продукт
+func (r *Repo) GetActiveReservation(ctx context.Context, orderID, productID string) (_ *model.Reservation, _ bool, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.GetActiveReservation")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	m := &repoModel.ReservationSelectActive{}
+	m.OrderID = orderID
+	m.ProductID = productID
+
+	found, err := r.ReservationStore.Get(ctx, m)
+	if err != nil {
+		return nil, false, fmt.Errorf("ReservationStore.Get: %w", err)
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	return repoModel.DecodeReservation(&m.ReservationSelect, 0), true, nil
+}
+
+// ListExpiredReservations возвращает до limit действующих резервов с истекшим сроком
+func (r *Repo) ListExpiredReservations(ctx context.Context, limit uint64) (_ []*model.Reservation, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.ListExpiredReservations")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	items := make([]*repoModel.ReservationSelect, 0)
+
+	_, err := r.ReservationStore.List(ctx, mobone.ListParams{
+		Conditions: map[string]any{
+			"status": constant.ReservationStatusActive,
+		},
+		ConditionExpressions: map[string][]any{
+			"expires_at <= ?": {time.Now()},
+		},
+		PageSize: int64(limit),
+	}, func(add bool) mobone.ListModelI {
+		item := &repoModel.ReservationSelect{}
+
+		if add {
+			items = append(items, item)
+		}
+		return item
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ReservationStore.List: %w", err)
+	}
+
+	return lo.Map(items, repoModel.DecodeReservation), nil
+}
+
+// FinishReservation завершает действующий резерв статусом obj.Status. Если key задан, в той же транзакции
+// ключ переводится из event.FromStatus с записью в журнал. false - резерв уже завершен
+func (r *Repo) FinishReservation(ctx context.Context, obj *model.ReservationEdit, key *model.Edit, event *model.Event) (_ bool, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "key.repo.PG.FinishReservation")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	var keyUpsert *repoModel.Upsert
+	if key != nil {
+		var err error
+		keyUpsert, err = r.encodeEdit(key)
+		if err != nil {
+			return false, fmt.Errorf("encodeEdit: %w", err)
+		}
+	}
+
+	updated := false
+
+	err := r.WithTx(ctx, func(tx pgx.Tx) error {
+		upsertObj := repoModel.EncodeReservationEdit(obj)
+
+		query, args, err := r.QB.Update(r.ReservationStore.TableName).
+			SetMap(upsertObj.UpdateColumnMap()).
+			Where(squirrel.Eq(upsertObj.PKColumnMap())).
+			Where(squirrel.Eq{"status": constant.ReservationStatusActive}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("fail to build reservation query: %w", err)
+		}
+
+		tag, err := tx.Exec(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("fail to update reservation: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+
+		if keyUpsert != nil {
+			query, args, err = r.QB.Update(r.ModelStore.TableName).
+				SetMap(keyUpsert.UpdateColumnMap()).
+				Where(squirrel.Eq(keyUpsert.PKColumnMap())).
+				Where(squirrel.Eq{"status": event.FromStatus}).
+				ToSql()
+			if err != nil {
+				return fmt.Errorf("fail to build key query: %w", err)
+			}
+
+			tag, err = tx.Exec(ctx, query, args...)
+			if err != nil {
+				return fmt.Errorf("fail to update key: %w", err)
+			}
+			if tag.RowsAffected() == 0 {
+				return errors.New("reserved key status changed")
+			}
+
+			err = r.createEvent(ctx, tx, event)
+			if err != nil {
+				return fmt.Errorf("createEvent: %w", err)
+			}
+		}
+
+		updated = true
+
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("WithTx: %w", err)
+	}
+
+	return updated, nil
+}
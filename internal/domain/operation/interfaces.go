@@ -0,0 +1,14 @@
+package operation
+
+import (
+	"context"
+
+	"github.com/mechta-market/e-product/internal/domain/operation/model"
+)
+
+type RepoDbI interface {
+	List(ctx context.Context, pars *model.ListReq) (_ []*model.Main, _ int64, finalError error)
+	Update(ctx context.Context, obj *model.Edit) (finalError error)
+	Create(ctx context.Context, obj *model.Edit) (_ string, finalError error)
+	Reencrypt(ctx context.Context, limit uint64) (_ int, finalError error)
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"time"
+
+	commonModel "github.com/mechta-market/e-product/internal/domain/common/model"
+)
+
+type Main struct {
+	ID                    string
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	ProviderID            string
+	ProductID             string
+	ProviderProductID     string
+	OrderID               string
+	Status                string
+	KeyID                 string
+	Value                 string
+	ProviderOrderID       string
+	ProviderTransactionID string
+	Error                 string
+}
+
+type ListReq struct {
+	commonModel.ListParams
+
+	Status        *string
+	ExcludeStatus *string
+	ProductID     *string
+	OrderID       *string
+	UpdatedBefore *time.Time
+	CreatedAfter  *time.Time
+}
+
+type Edit struct {
+	ID                    *string
+	UpdatedAt             *time.Time
+	ProviderID            *string
+	ProductID             *string
+	ProviderProductID     *string
+	OrderID               *string
+	Status                *string
+	KeyID                 *string
+	Value                 *string
+	ProviderOrderID       *string
+	ProviderTransactionID *string
+	Error                 *string
+}
@@ -0,0 +1,57 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/mechta-market/e-product/internal/domain/operation/model"
+)
+
+type Service struct {
+	repoDb RepoDbI
+}
+
+func New(repoDb RepoDbI) *Service {
+	return &Service{repoDb: repoDb}
+}
+
+func (s *Service) List(ctx context.Context, pars *model.ListReq) ([]*model.Main, int64, error) {
+	items, tCount, err := s.repoDb.List(ctx, pars)
+	if err != nil {
+		return nil, 0, fmt.Errorf("repoDb.List: %w", err)
+	}
+
+	return items, tCount, nil
+}
+
+func (s *Service) Update(ctx context.Context, obj *model.Edit) error {
+	obj.UpdatedAt = lo.ToPtr(time.Now())
+
+	err := s.repoDb.Update(ctx, obj)
+	if err != nil {
+		return fmt.Errorf("repoDb.Update: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) Create(ctx context.Context, obj *model.Edit) (string, error) {
+	id, err := s.repoDb.Create(ctx, obj)
+	if err != nil {
+		return "", fmt.Errorf("repoDb.Create: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *Service) Reencrypt(ctx context.Context, limit uint64) (int, error) {
+	count, err := s.repoDb.Reencrypt(ctx, limit)
+	if err != nil {
+		return 0, fmt.Errorf("repoDb.Reencrypt: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,82 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/samber/lo"
+
+	commonRepoPg "github.com/mechta-market/e-product/internal/domain/common/repo/pg"
+	"github.com/mechta-market/e-product/internal/domain/operation/model"
+	repoModel "github.com/mechta-market/e-product/internal/domain/operation/repo/pg/model"
+)
+
+var (
+	allowedSortFields = map[string]string{
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	}
+)
+
+func (r *Repo) getConditions(pars *model.ListReq) (map[string]any, map[string][]any) {
+	conditions := make(map[string]any)
+	conditionExps := make(map[string][]any)
+
+	if pars.Status != nil {
+		conditions["status"] = *pars.Status
+	}
+
+	if pars.ExcludeStatus != nil {
+		conditionExps["status != ?"] = []any{*pars.ExcludeStatus}
+	}
+
+	if pars.ProductID != nil {
+		conditions["product_id"] = *pars.ProductID
+	}
+
+	if pars.OrderID != nil {
+		conditions["order_id"] = *pars.OrderID
+	}
+
+	if pars.UpdatedBefore != nil {
+		conditionExps["updated_at < ?"] = []any{*pars.UpdatedBefore}
+	}
+
+	if pars.CreatedAfter != nil {
+		conditionExps["created_at >= ?"] = []any{*pars.CreatedAfter}
+	}
+
+	return conditions, conditionExps
+}
+
+// encodeEdit шифрует value, если настроен keyring
+func (r *Repo) encodeEdit(obj *model.Edit) (*repoModel.Upsert, error) {
+	result := repoModel.EncodeEdit(obj)
+
+	if r.keyring == nil || result.Value == nil || *result.Value == "" {
+		return result, nil
+	}
+
+	sealed, err := r.keyring.Encrypt(*result.Value)
+	if err != nil {
+		return nil, fmt.Errorf("keyring.Encrypt: %w", err)
+	}
+
+	result.Value = lo.ToPtr("")
+	result.ValueEnc = sealed.Data
+	result.ValueDEK = sealed.DEK
+	result.ValueKeyID = lo.ToPtr(sealed.KeyID)
+
+	return result, nil
+}
+
+func (r *Repo) decodeMain(m *repoModel.Select) (*model.Main, error) {
+	value, err := commonRepoPg.OpenValue(r.keyring, m.Value, m.ValueEnc, m.ValueDEK, m.ValueKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("OpenValue: %w", err)
+	}
+
+	result := repoModel.DecodeMain(m, 0)
+	result.Value = value
+
+	return result, nil
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"time"
+
+	"github.com/mechta-market/e-product/internal/domain/operation/model"
+)
+
+type Select struct {
+	ID                    string
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	ProviderID            string
+	ProductID             string
+	ProviderProductID     string
+	OrderID               string
+	Status                string
+	KeyID                 string
+	Value                 string
+	ProviderOrderID       string
+	ProviderTransactionID string
+	Error                 string
+	ValueEnc              []byte
+	ValueDEK              []byte
+	ValueKeyID            string
+}
+
+func (m *Select) ListColumnMap() map[string]any {
+	return map[string]any{
+		"id":                      &m.ID,
+		"created_at":              &m.CreatedAt,
+		"updated_at":              &m.UpdatedAt,
+		"provider_id":             &m.ProviderID,
+		"product_id":              &m.ProductID,
+		"provider_product_id":     &m.ProviderProductID,
+		"order_id":                &m.OrderID,
+		"status":                  &m.Status,
+		"key_id":                  &m.KeyID,
+		"value":                   &m.Value,
+		"provider_order_id":       &m.ProviderOrderID,
+		"provider_transaction_id": &m.ProviderTransactionID,
+		"error":                   &m.Error,
+		"value_enc":               &m.ValueEnc,
+		"value_dek":               &m.ValueDEK,
+		"value_key_id":            &m.ValueKeyID,
+	}
+}
+
+func (m *Select) PKColumnMap() map[string]any {
+	return map[string]any{
+		"id": m.ID,
+	}
+}
+
+func (m *Select) DefaultSortColumns() []string {
+	return []string{
+		"created_at asc",
+	}
+}
+
+func DecodeMain(m *Select, _ int) *model.Main {
+	return &model.Main{
+		ID:                    m.ID,
+		CreatedAt:             m.CreatedAt,
+		UpdatedAt:             m.UpdatedAt,
+		ProviderID:            m.ProviderID,
+		ProductID:             m.ProductID,
+		ProviderProductID:     m.ProviderProductID,
+		OrderID:               m.OrderID,
+		Status:                m.Status,
+		KeyID:                 m.KeyID,
+		Value:                 m.Value,
+		ProviderOrderID:       m.ProviderOrderID,
+		ProviderTransactionID: m.ProviderTransactionID,
+		Error:                 m.Error,
+	}
+}
@@ -0,0 +1,127 @@
+package model
+
+import (
+	"time"
+
+	"github.com/mechta-market/e-product/internal/domain/operation/model"
+)
+
+type Upsert struct {
+	ID                    string
+	UpdatedAt             *time.Time
+	ProviderID            *string
+	ProductID             *string
+	ProviderProductID     *string
+	OrderID               *string
+	Status                *string
+	KeyID                 *string
+	Value                 *string
+	ProviderOrderID       *string
+	ProviderTransactionID *string
+	Error                 *string
+	ValueEnc              []byte
+	ValueDEK              []byte
+	ValueKeyID            *string
+}
+
+func (m *Upsert) UpdateColumnMap() map[string]any {
+	res := m.CreateColumnMap()
+
+	pkMap := m.PKColumnMap()
+	for k := range pkMap {
+		delete(res, k)
+	}
+
+	return res
+}
+
+// PKColumnMap возвращает первичный ключ для ON CONFLICT
+func (m *Upsert) PKColumnMap() map[string]any {
+	return map[string]any{
+		"id": m.ID,
+	}
+}
+
+func (m *Upsert) CreateColumnMap() map[string]any {
+	result := make(map[string]any, 15)
+
+	if m.UpdatedAt != nil {
+		result["updated_at"] = *m.UpdatedAt
+	}
+
+	if m.ProviderID != nil {
+		result["provider_id"] = *m.ProviderID
+	}
+
+	if m.ProductID != nil {
+		result["product_id"] = *m.ProductID
+	}
+
+	if m.ProviderProductID != nil {
+		result["provider_product_id"] = *m.ProviderProductID
+	}
+
+	if m.OrderID != nil {
+		result["order_id"] = *m.OrderID
+	}
+
+	if m.Status != nil {
+		result["status"] = *m.Status
+	}
+
+	if m.KeyID != nil {
+		result["key_id"] = *m.KeyID
+	}
+
+	if m.Value != nil {
+		result["value"] = *m.Value
+	}
+
+	if m.ProviderOrderID != nil {
+		result["provider_order_id"] = *m.ProviderOrderID
+	}
+
+	if m.ProviderTransactionID != nil {
+		result["provider_transaction_id"] = *m.ProviderTransactionID
+	}
+
+	if m.Error != nil {
+		result["error"] = *m.Error
+	}
+
+	if m.ValueKeyID != nil {
+		result["value_enc"] = m.ValueEnc
+		result["value_dek"] = m.ValueDEK
+		result["value_key_id"] = *m.ValueKeyID
+	}
+
+	return result
+}
+
+func (m *Upsert) ReturningColumnMap() map[string]any {
+	return map[string]any{
+		"id": &m.ID,
+	}
+}
+
+func EncodeEdit(m *model.Edit) *Upsert {
+	result := &Upsert{}
+
+	if m.ID != nil && *m.ID != "" {
+		result.ID = *m.ID
+	}
+
+	result.UpdatedAt = m.UpdatedAt
+	result.ProviderID = m.ProviderID
+	result.ProductID = m.ProductID
+	result.ProviderProductID = m.ProviderProductID
+	result.OrderID = m.OrderID
+	result.Status = m.Status
+	result.KeyID = m.KeyID
+	result.Value = m.Value
+	result.ProviderOrderID = m.ProviderOrderID
+	result.ProviderTransactionID = m.ProviderTransactionID
+	result.Error = m.Error
+
+	return result
+}
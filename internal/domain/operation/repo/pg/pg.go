@@ -0,0 +1,153 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mechta-market/mobone/v2"
+	moboneTools "github.com/mechta-market/mobone/v2/tools"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/mechta-market/e-product/internal/domain/common/keyring"
+	commonRepoPg "github.com/mechta-market/e-product/internal/domain/common/repo/pg"
+	"github.com/mechta-market/e-product/internal/domain/operation/model"
+	repoModel "github.com/mechta-market/e-product/internal/domain/operation/repo/pg/model"
+)
+
+type Repo struct {
+	*commonRepoPg.Base
+	ModelStore *mobone.ModelStore
+	keyring    *keyring.Keyring
+}
+
+// New создает репозиторий журнала. Если kr == nil, value хранится в открытом виде
+func New(con *pgxpool.Pool, kr *keyring.Keyring) *Repo {
+	base := commonRepoPg.NewBase(con)
+	return &Repo{
+		Base: base,
+		ModelStore: &mobone.ModelStore{
+			Con:       base.Con,
+			QB:        base.QB,
+			TableName: "provider_operation",
+		},
+		keyring: kr,
+	}
+}
+
+func (r *Repo) List(ctx context.Context, pars *model.ListReq) (_ []*model.Main, _ int64, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "operation.repo.PG.List")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	conditions, conditionExps := r.getConditions(pars)
+	sort := moboneTools.ConstructSortColumns(allowedSortFields, pars.Sort)
+
+	items := make([]*repoModel.Select, 0)
+
+	totalCount, err := r.ModelStore.List(ctx, mobone.ListParams{
+		Conditions:           conditions,
+		ConditionExpressions: conditionExps,
+		Page:                 pars.Page,
+		PageSize:             pars.PageSize,
+		WithTotalCount:       pars.WithTotalCount,
+		OnlyCount:            pars.OnlyCount,
+		Sort:                 sort,
+	}, func(add bool) mobone.ListModelI {
+		item := &repoModel.Select{}
+
+		if add {
+			items = append(items, item)
+		}
+		return item
+	})
+
+	if err != nil {
+		return nil, 0, fmt.Errorf("ModelStore.List: %w", err)
+	}
+
+	result := make([]*model.Main, 0, len(items))
+	for _, item := range items {
+		obj, err := r.decodeMain(item)
+		if err != nil {
+			return nil, 0, fmt.Errorf("decodeMain: %w", err)
+		}
+		result = append(result, obj)
+	}
+
+	return result, totalCount, nil
+}
+
+func (r *Repo) Update(ctx context.Context, obj *model.Edit) (finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "operation.repo.PG.Update")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	upsertObj, err := r.encodeEdit(obj)
+	if err != nil {
+		return fmt.Errorf("encodeEdit: %w", err)
+	}
+
+	err = r.ModelStore.Update(ctx, upsertObj)
+	if err != nil {
+		return fmt.Errorf("ModelStore.Update: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) Create(ctx context.Context, obj *model.Edit) (_ string, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "operation.repo.PG.Create")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	upsertObj, err := r.encodeEdit(obj)
+	if err != nil {
+		return "", fmt.Errorf("encodeEdit: %w", err)
+	}
+
+	err = r.ModelStore.Create(ctx, upsertObj)
+	if err != nil {
+		return "", fmt.Errorf("ModelStore.Create: %w", err)
+	}
+
+	return upsertObj.ID, nil
+}
+
+// Reencrypt переводит до limit операций на активный мастер-ключ, возвращает число обработанных
+func (r *Repo) Reencrypt(ctx context.Context, limit uint64) (_ int, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "operation.repo.PG.Reencrypt")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	if r.keyring == nil {
+		return 0, nil
+	}
+
+	count, err := r.ReencryptValues(ctx, r.keyring, r.ModelStore.TableName, false, limit)
+	if err != nil {
+		return 0, fmt.Errorf("ReencryptValues: %w", err)
+	}
+
+	return count, nil
+}
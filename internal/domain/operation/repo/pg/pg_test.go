@@ -0,0 +1,85 @@
+package pg
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	commonModel "github.com/mechta-market/e-product/internal/domain/common/model"
+	"github.com/mechta-market/e-product/internal/domain/operation/model"
+)
+
+// Тесты работают с реальной БД: TEST_PG_DSN должен указывать на отдельную тестовую базу,
+// схема в ней пересоздается по файлам из migrations
+func newTestRepo(t *testing.T) *Repo {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_PG_DSN")
+	if dsn == "" {
+		t.Skip("TEST_PG_DSN is not set")
+	}
+
+	ctx := context.Background()
+
+	con, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(con.Close)
+
+	migrate(t, con, "*.down.sql", true)
+	migrate(t, con, "*.up.sql", false)
+
+	return New(con, nil)
+}
+
+func migrate(t *testing.T, con *pgxpool.Pool, pattern string, reverse bool) {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join("..", "..", "..", "..", "..", "migrations", pattern))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	sort.Strings(files)
+	if reverse {
+		files = lo.Reverse(files)
+	}
+
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		require.NoError(t, err)
+
+		_, err = con.Exec(context.Background(), string(data))
+		require.NoError(t, err, f)
+	}
+}
+
+// TestRepo_Skipped провайдер, пропущенный при маршрутизации, журналируется операцией skipped
+func TestRepo_Skipped(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	id, err := r.Create(ctx, &model.Edit{
+		ProviderID: lo.ToPtr(constant.ProviderComportal),
+		ProductID:  lo.ToPtr("prod-1"),
+		OrderID:    lo.ToPtr("ord-1"),
+		Status:     lo.ToPtr(constant.OperationStatusSkipped),
+		Error:      lo.ToPtr("provider is unavailable"),
+	})
+	require.NoError(t, err)
+
+	items, _, err := r.List(ctx, &model.ListReq{
+		ListParams: commonModel.ListParams{PageSize: 10},
+		Status:     lo.ToPtr(constant.OperationStatusSkipped),
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, id, items[0].ID)
+	assert.Equal(t, "provider is unavailable", items[0].Error)
+}
@@ -0,0 +1,14 @@
+package poollevel
+
+import (
+	"context"
+
+	"github.com/mechta-market/e-product/internal/domain/poollevel/model"
+)
+
+type RepoDbI interface {
+	List(ctx context.Context, pars *model.ListReq) (_ []*model.Main, _ int64, finalError error)
+	Get(ctx context.Context, productID string) (_ *model.Main, _ bool, finalError error)
+	Set(ctx context.Context, obj *model.Edit) (finalError error)
+	Delete(ctx context.Context, productID string) (finalError error)
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	commonModel "github.com/mechta-market/e-product/internal/domain/common/model"
+)
+
+// Main пороги пула ключей продукта. Когда свободных ключей меньше MinLevel,
+// пул докупается у провайдера до TargetLevel, но не больше DailyCap ключей в сутки
+type Main struct {
+	ProductID   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	MinLevel    int64
+	TargetLevel int64
+	DailyCap    int64 // 0 - без ограничения по продукту
+}
+
+type ListReq struct {
+	commonModel.ListParams
+}
+
+type Edit struct {
+	ProductID   string
+	UpdatedAt   *time.Time
+	MinLevel    *int64
+	TargetLevel *int64
+	DailyCap    *int64
+}
+
+// State состояние пула продукта после пополнения
+type State struct {
+	Level      *Main
+	Available  int64 // свободных ключей в пуле
+	Purchased  int64 // докуплено за этот запуск
+	Failed     int64 // неудачных покупок за этот запуск
+	CapReached bool  // пополнение остановлено лимитом закупок
+	Error      string
+}
+
+// Low пул ниже минимального уровня
+func (s *State) Low() bool {
+	return s.Available < s.Level.MinLevel
+}
@@ -0,0 +1,68 @@
+package poollevel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/mechta-market/e-product/internal/domain/poollevel/model"
+	"github.com/mechta-market/e-product/internal/errs"
+)
+
+type Service struct {
+	repoDb RepoDbI
+}
+
+func New(repoDb RepoDbI) *Service {
+	return &Service{repoDb: repoDb}
+}
+
+func (s *Service) List(ctx context.Context, pars *model.ListReq) ([]*model.Main, int64, error) {
+	items, tCount, err := s.repoDb.List(ctx, pars)
+	if err != nil {
+		return nil, 0, fmt.Errorf("repoDb.List: %w", err)
+	}
+
+	return items, tCount, nil
+}
+
+func (s *Service) Get(ctx context.Context, productID string, errNE bool) (*model.Main, bool, error) {
+	result, found, err := s.repoDb.Get(ctx, productID)
+	if err != nil {
+		return nil, false, fmt.Errorf("repoDb.Get: %w", err)
+	}
+	if !found {
+		if errNE {
+			return nil, false, errs.ErrFull{
+				Err: errs.ObjectNotFound,
+				Msg: errs.MsgPoolLevelNotFound,
+			}
+		}
+		return nil, false, nil
+	}
+
+	return result, true, nil
+}
+
+// Set создает или обновляет пороги пула продукта
+func (s *Service) Set(ctx context.Context, obj *model.Edit) error {
+	obj.UpdatedAt = lo.ToPtr(time.Now())
+
+	err := s.repoDb.Set(ctx, obj)
+	if err != nil {
+		return fmt.Errorf("repoDb.Set: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) Delete(ctx context.Context, productID string) error {
+	err := s.repoDb.Delete(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("repoDb.Delete: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package pg
+
+import "github.com/mechta-market/e-product/internal/domain/poollevel/model"
+
+var (
+	allowedSortFields = map[string]string{
+		"product_id": "product_id",
+		"updated_at": "updated_at",
+	}
+)
+
+func (r *Repo) getConditions(_ *model.ListReq) (map[string]any, map[string][]any) {
+	conditions := make(map[string]any)
+	conditionExps := make(map[string][]any)
+
+	return conditions, conditionExps
+}
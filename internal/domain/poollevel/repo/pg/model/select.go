@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	"github.com/mechta-market/e-product/internal/domain/poollevel/model"
+)
+
+type Select struct {
+	ProductID   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	MinLevel    int64
+	TargetLevel int64
+	DailyCap    int64
+}
+
+func (m *Select) ListColumnMap() map[string]any {
+	return map[string]any{
+		"product_id":   &m.ProductID,
+		"created_at":   &m.CreatedAt,
+		"updated_at":   &m.UpdatedAt,
+		"min_level":    &m.MinLevel,
+		"target_level": &m.TargetLevel,
+		"daily_cap":    &m.DailyCap,
+	}
+}
+
+func (m *Select) PKColumnMap() map[string]any {
+	return map[string]any{
+		"product_id": m.ProductID,
+	}
+}
+
+func (m *Select) DefaultSortColumns() []string {
+	return []string{
+		"product_id asc",
+	}
+}
+
+func DecodeMain(m *Select, _ int) *model.Main {
+	return &model.Main{
+		ProductID:   m.ProductID,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		MinLevel:    m.MinLevel,
+		TargetLevel: m.TargetLevel,
+		DailyCap:    m.DailyCap,
+	}
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"time"
+
+	"github.com/mechta-market/e-product/internal/domain/poollevel/model"
+)
+
+type Upsert struct {
+	ProductID   string
+	UpdatedAt   *time.Time
+	MinLevel    *int64
+	TargetLevel *int64
+	DailyCap    *int64
+}
+
+func (m *Upsert) UpdateColumnMap() map[string]any {
+	res := m.CreateColumnMap()
+
+	pkMap := m.PKColumnMap()
+	for k := range pkMap {
+		delete(res, k)
+	}
+
+	return res
+}
+
+// PKColumnMap возвращает первичный ключ для ON CONFLICT
+func (m *Upsert) PKColumnMap() map[string]any {
+	return map[string]any{
+		"product_id": m.ProductID,
+	}
+}
+
+func (m *Upsert) CreateColumnMap() map[string]any {
+	result := make(map[string]any, 5)
+
+	result["product_id"] = m.ProductID
+
+	if m.UpdatedAt != nil {
+		result["updated_at"] = *m.UpdatedAt
+	}
+
+	if m.MinLevel != nil {
+		result["min_level"] = *m.MinLevel
+	}
+
+	if m.TargetLevel != nil {
+		result["target_level"] = *m.TargetLevel
+	}
+
+	if m.DailyCap != nil {
+		result["daily_cap"] = *m.DailyCap
+	}
+
+	return result
+}
+
+func (m *Upsert) ReturningColumnMap() map[string]any {
+	return map[string]any{}
+}
+
+func EncodeEdit(m *model.Edit) *Upsert {
+	return &Upsert{
+		ProductID:   m.ProductID,
+		UpdatedAt:   m.UpdatedAt,
+		MinLevel:    m.MinLevel,
+		TargetLevel: m.TargetLevel,
+		DailyCap:    m.DailyCap,
+	}
+}
@@ -0,0 +1,132 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mechta-market/mobone/v2"
+	moboneTools "github.com/mechta-market/mobone/v2/tools"
+	"github.com/opentracing/opentracing-go"
+	"github.com/samber/lo"
+
+	commonRepoPg "github.com/mechta-market/e-product/internal/domain/common/repo/pg"
+	"github.com/mechta-market/e-product/internal/domain/poollevel/model"
+	repoModel "github.com/mechta-market/e-product/internal/domain/poollevel/repo/pg/model"
+)
+
+type Repo struct {
+	*commonRepoPg.Base
+	ModelStore *mobone.ModelStore
+}
+
+func New(con *pgxpool.Pool) *Repo {
+	base := commonRepoPg.NewBase(con)
+	return &Repo{
+		Base: base,
+		ModelStore: &mobone.ModelStore{
+			Con:       base.Con,
+			QB:        base.QB,
+			TableName: "pool_level",
+		},
+	}
+}
+
+func (r *Repo) List(ctx context.Context, pars *model.ListReq) (_ []*model.Main, _ int64, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "poollevel.repo.PG.List")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	conditions, conditionExps := r.getConditions(pars)
+	sort := moboneTools.ConstructSortColumns(allowedSortFields, pars.Sort)
+
+	items := make([]*repoModel.Select, 0)
+
+	totalCount, err := r.ModelStore.List(ctx, mobone.ListParams{
+		Conditions:           conditions,
+		ConditionExpressions: conditionExps,
+		Page:                 pars.Page,
+		PageSize:             pars.PageSize,
+		WithTotalCount:       pars.WithTotalCount,
+		OnlyCount:            pars.OnlyCount,
+		Sort:                 sort,
+	}, func(add bool) mobone.ListModelI {
+		item := &repoModel.Select{}
+
+		if add {
+			items = append(items, item)
+		}
+		return item
+	})
+
+	if err != nil {
+		return nil, 0, fmt.Errorf("ModelStore.List: %w", err)
+	}
+
+	return lo.Map(items, repoModel.DecodeMain), totalCount, nil
+}
+
+func (r *Repo) Get(ctx context.Context, productID string) (_ *model.Main, _ bool, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "poollevel.repo.PG.Get")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	m := &repoModel.Select{}
+	m.ProductID = productID
+
+	found, err := r.ModelStore.Get(ctx, m)
+	if err != nil {
+		return nil, false, fmt.Errorf("ModelStore.Get: %w", err)
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	return repoModel.DecodeMain(m, 0), true, nil
+}
+
+func (r *Repo) Set(ctx context.Context, obj *model.Edit) (finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "poollevel.repo.PG.Set")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	err := r.ModelStore.UpdateOrCreate(ctx, repoModel.EncodeEdit(obj))
+	if err != nil {
+		return fmt.Errorf("ModelStore.UpdateOrCreate: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) Delete(ctx context.Context, productID string) (finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "poollevel.repo.PG.Delete")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	err := r.ModelStore.Delete(ctx, &repoModel.Upsert{ProductID: productID})
+	if err != nil {
+		return fmt.Errorf("ModelStore.Delete: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package pg
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mechta-market/e-product/internal/domain/poollevel/model"
+)
+
+// Тесты работают с реальной БД: TEST_PG_DSN должен указывать на отдельную тестовую базу,
+// схема в ней пересоздается по файлам из migrations
+func newTestRepo(t *testing.T) *Repo {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_PG_DSN")
+	if dsn == "" {
+		t.Skip("TEST_PG_DSN is not set")
+	}
+
+	ctx := context.Background()
+
+	con, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(con.Close)
+
+	migrate(t, con, "*.down.sql", true)
+	migrate(t, con, "*.up.sql", false)
+
+	return New(con)
+}
+
+func migrate(t *testing.T, con *pgxpool.Pool, pattern string, reverse bool) {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join("..", "..", "..", "..", "..", "migrations", pattern))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	sort.Strings(files)
+	if reverse {
+		files = lo.Reverse(files)
+	}
+
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		require.NoError(t, err)
+
+		_, err = con.Exec(context.Background(), string(data))
+		require.NoError(t, err, f)
+	}
+}
+
+func TestRepo_Set(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	err := r.Set(ctx, &model.Edit{
+		ProductID:   "prod-1",
+		MinLevel:    lo.ToPtr(int64(2)),
+		TargetLevel: lo.ToPtr(int64(5)),
+	})
+	require.NoError(t, err)
+
+	// повторный Set обновляет пороги
+	err = r.Set(ctx, &model.Edit{
+		ProductID:   "prod-1",
+		MinLevel:    lo.ToPtr(int64(3)),
+		TargetLevel: lo.ToPtr(int64(10)),
+		DailyCap:    lo.ToPtr(int64(20)),
+	})
+	require.NoError(t, err)
+
+	level, found, err := r.Get(ctx, "prod-1")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, int64(3), level.MinLevel)
+	assert.Equal(t, int64(10), level.TargetLevel)
+	assert.Equal(t, int64(20), level.DailyCap)
+
+	levels, _, err := r.List(ctx, &model.ListReq{})
+	require.NoError(t, err)
+	assert.Len(t, levels, 1)
+
+	require.NoError(t, r.Delete(ctx, "prod-1"))
+
+	_, found, err = r.Get(ctx, "prod-1")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
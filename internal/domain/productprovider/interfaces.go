@@ -0,0 +1,12 @@
+package productprovider
+
+import (
+	"context"
+
+	"github.com/mechta-market/e-product/internal/domain/productprovider/model"
+)
+
+type RepoDbI interface {
+	List(ctx context.Context, pars *model.ListReq) (_ []*model.Main, _ int64, finalError error)
+	Replace(ctx context.Context, productID string, items []*model.Main) (finalError error)
+}
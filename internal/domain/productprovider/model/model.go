@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	commonModel "github.com/mechta-market/e-product/internal/domain/common/model"
+)
+
+// Main привязка продукта к провайдеру. Продукт может продаваться несколькими провайдерами:
+// при выдаче ключа они пробуются по возрастанию Priority
+type Main struct {
+	ProductID                 string
+	ProviderID                string
+	CreatedAt                 time.Time
+	UpdatedAt                 time.Time
+	Priority                  int64
+	ProviderProductID         string
+	ProviderExternalProductID string
+	PromotionKey              string
+}
+
+type ListReq struct {
+	commonModel.ListParams
+
+	ProductID  *string
+	ProviderID *string
+}
+
+type Edit struct {
+	ProviderID                string
+	ProviderProductID         string
+	ProviderExternalProductID string
+	PromotionKey              string
+}
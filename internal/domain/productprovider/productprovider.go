@@ -0,0 +1,68 @@
+package productprovider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+
+	commonModel "github.com/mechta-market/e-product/internal/domain/common/model"
+	"github.com/mechta-market/e-product/internal/domain/productprovider/model"
+)
+
+type Service struct {
+	repoDb RepoDbI
+}
+
+func New(repoDb RepoDbI) *Service {
+	return &Service{repoDb: repoDb}
+}
+
+func (s *Service) List(ctx context.Context, pars *model.ListReq) ([]*model.Main, int64, error) {
+	items, tCount, err := s.repoDb.List(ctx, pars)
+	if err != nil {
+		return nil, 0, fmt.Errorf("repoDb.List: %w", err)
+	}
+
+	return items, tCount, nil
+}
+
+// ListByProduct провайдеры продукта в порядке приоритета
+func (s *Service) ListByProduct(ctx context.Context, productID string) ([]*model.Main, error) {
+	items, _, err := s.repoDb.List(ctx, &model.ListReq{
+		ListParams: commonModel.ListParams{Sort: []string{"priority"}},
+		ProductID:  lo.ToPtr(productID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repoDb.List: %w", err)
+	}
+
+	return items, nil
+}
+
+// Set заменяет провайдеров продукта, приоритет - порядок в items. Пустой items удаляет привязки
+func (s *Service) Set(ctx context.Context, productID string, items []*model.Edit) ([]*model.Main, error) {
+	now := time.Now()
+
+	result := make([]*model.Main, 0, len(items))
+	for i, item := range items {
+		result = append(result, &model.Main{
+			ProductID:                 productID,
+			ProviderID:                item.ProviderID,
+			CreatedAt:                 now,
+			UpdatedAt:                 now,
+			Priority:                  int64(i),
+			ProviderProductID:         item.ProviderProductID,
+			ProviderExternalProductID: item.ProviderExternalProductID,
+			PromotionKey:              item.PromotionKey,
+		})
+	}
+
+	err := s.repoDb.Replace(ctx, productID, result)
+	if err != nil {
+		return nil, fmt.Errorf("repoDb.Replace: %w", err)
+	}
+
+	return result, nil
+}
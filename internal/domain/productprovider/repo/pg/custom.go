@@ -0,0 +1,25 @@
+package pg
+
+import "github.com/mechta-market/e-product/internal/domain/productprovider/model"
+
+var (
+	allowedSortFields = map[string]string{
+		"product_id": "product_id",
+		"priority":   "priority",
+	}
+)
+
+func (r *Repo) getConditions(pars *model.ListReq) (map[string]any, map[string][]any) {
+	conditions := make(map[string]any)
+	conditionExps := make(map[string][]any)
+
+	if pars.ProductID != nil {
+		conditions["product_id"] = *pars.ProductID
+	}
+
+	if pars.ProviderID != nil {
+		conditions["provider_id"] = *pars.ProviderID
+	}
+
+	return conditions, conditionExps
+}
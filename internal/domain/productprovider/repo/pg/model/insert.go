@@ -0,0 +1,18 @@
+package model
+
+import (
+	"github.com/mechta-market/e-product/internal/domain/productprovider/model"
+)
+
+func EncodeMain(m *model.Main) map[string]any {
+	return map[string]any{
+		"product_id":                   m.ProductID,
+		"provider_id":                  m.ProviderID,
+		"created_at":                   m.CreatedAt,
+		"updated_at":                   m.UpdatedAt,
+		"priority":                     m.Priority,
+		"provider_product_id":          m.ProviderProductID,
+		"provider_external_product_id": m.ProviderExternalProductID,
+		"promotion_key":                m.PromotionKey,
+	}
+}
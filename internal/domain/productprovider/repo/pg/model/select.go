@@ -0,0 +1,58 @@
+package model
+
+import (
+	"time"
+
+	"github.com/mechta-market/e-product/internal/domain/productprovider/model"
+)
+
+type Select struct {
+	ProductID                 string
+	ProviderID                string
+	CreatedAt                 time.Time
+	UpdatedAt                 time.Time
+	Priority                  int64
+	ProviderProductID         string
+	ProviderExternalProductID string
+	PromotionKey              string
+}
+
+func (m *Select) ListColumnMap() map[string]any {
+	return map[string]any{
+		"product_id":                   &m.ProductID,
+		"provider_id":                  &m.ProviderID,
+		"created_at":                   &m.CreatedAt,
+		"updated_at":                   &m.UpdatedAt,
+		"priority":                     &m.Priority,
+		"provider_product_id":          &m.ProviderProductID,
+		"provider_external_product_id": &m.ProviderExternalProductID,
+		"promotion_key":                &m.PromotionKey,
+	}
+}
+
+func (m *Select) PKColumnMap() map[string]any {
+	return map[string]any{
+		"product_id":  m.ProductID,
+		"provider_id": m.ProviderID,
+	}
+}
+
+func (m *Select) DefaultSortColumns() []string {
+	return []string{
+		"product_id asc",
+		"priority asc",
+	}
+}
+
+func DecodeMain(m *Select, _ int) *model.Main {
+	return &model.Main{
+		ProductID:                 m.ProductID,
+		ProviderID:                m.ProviderID,
+		CreatedAt:                 m.CreatedAt,
+		UpdatedAt:                 m.UpdatedAt,
+		Priority:                  m.Priority,
+		ProviderProductID:         m.ProviderProductID,
+		ProviderExternalProductID: m.ProviderExternalProductID,
+		PromotionKey:              m.PromotionKey,
+	}
+}
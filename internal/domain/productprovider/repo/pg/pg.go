@@ -0,0 +1,121 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mechta-market/mobone/v2"
+	moboneTools "github.com/mechta-market/mobone/v2/tools"
+	"github.com/opentracing/opentracing-go"
+	"github.com/samber/lo"
+
+	commonRepoPg "github.com/mechta-market/e-product/internal/domain/common/repo/pg"
+	"github.com/mechta-market/e-product/internal/domain/productprovider/model"
+	repoModel "github.com/mechta-market/e-product/internal/domain/productprovider/repo/pg/model"
+)
+
+type Repo struct {
+	*commonRepoPg.Base
+	ModelStore *mobone.ModelStore
+}
+
+func New(con *pgxpool.Pool) *Repo {
+	base := commonRepoPg.NewBase(con)
+	return &Repo{
+		Base: base,
+		ModelStore: &mobone.ModelStore{
+			Con:       base.Con,
+			QB:        base.QB,
+			TableName: "product_provider",
+		},
+	}
+}
+
+func (r *Repo) List(ctx context.Context, pars *model.ListReq) (_ []*model.Main, _ int64, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "productprovider.repo.PG.List")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	conditions, conditionExps := r.getConditions(pars)
+	sort := moboneTools.ConstructSortColumns(allowedSortFields, pars.Sort)
+
+	items := make([]*repoModel.Select, 0)
+
+	totalCount, err := r.ModelStore.List(ctx, mobone.ListParams{
+		Conditions:           conditions,
+		ConditionExpressions: conditionExps,
+		Page:                 pars.Page,
+		PageSize:             pars.PageSize,
+		WithTotalCount:       pars.WithTotalCount,
+		OnlyCount:            pars.OnlyCount,
+		Sort:                 sort,
+	}, func(add bool) mobone.ListModelI {
+		item := &repoModel.Select{}
+
+		if add {
+			items = append(items, item)
+		}
+		return item
+	})
+
+	if err != nil {
+		return nil, 0, fmt.Errorf("ModelStore.List: %w", err)
+	}
+
+	return lo.Map(items, repoModel.DecodeMain), totalCount, nil
+}
+
+// Replace заменяет все привязки продукта в одной транзакции
+func (r *Repo) Replace(ctx context.Context, productID string, items []*model.Main) (finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "productprovider.repo.PG.Replace")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	err := r.WithTx(ctx, func(tx pgx.Tx) error {
+		query, args, err := r.QB.Delete(r.ModelStore.TableName).
+			Where(squirrel.Eq{"product_id": productID}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("fail to build query: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("fail to exec: %w", err)
+		}
+
+		for _, item := range items {
+			query, args, err = r.QB.Insert(r.ModelStore.TableName).
+				SetMap(repoModel.EncodeMain(item)).
+				ToSql()
+			if err != nil {
+				return fmt.Errorf("fail to build query: %w", err)
+			}
+
+			_, err = tx.Exec(ctx, query, args...)
+			if err != nil {
+				return fmt.Errorf("fail to exec: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("WithTx: %w", err)
+	}
+
+	return nil
+}
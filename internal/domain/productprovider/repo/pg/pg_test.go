@@ -0,0 +1,98 @@
+package pg
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mechta-market/e-product/internal/domain/productprovider/model"
+)
+
+// Тесты работают с реальной БД: TEST_PG_DSN должен указывать на отдельную тестовую базу,
+// схема в ней пересоздается по файлам из migrations
+func newTestRepo(t *testing.T) *Repo {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_PG_DSN")
+	if dsn == "" {
+		t.Skip("TEST_PG_DSN is not set")
+	}
+
+	ctx := context.Background()
+
+	con, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(con.Close)
+
+	migrate(t, con, "*.down.sql", true)
+	migrate(t, con, "*.up.sql", false)
+
+	return New(con)
+}
+
+func migrate(t *testing.T, con *pgxpool.Pool, pattern string, reverse bool) {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join("..", "..", "..", "..", "..", "migrations", pattern))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	sort.Strings(files)
+	if reverse {
+		files = lo.Reverse(files)
+	}
+
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		require.NoError(t, err)
+
+		_, err = con.Exec(context.Background(), string(data))
+		require.NoError(t, err, f)
+	}
+}
+
+func TestRepo_Replace(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	err := r.Replace(ctx, "prod-1", []*model.Main{
+		{ProductID: "prod-1", ProviderID: "comportal", Priority: 0, ProviderProductID: "KL-1"},
+		{ProductID: "prod-1", ProviderID: "asbis", Priority: 1, ProviderProductID: "A-1"},
+	})
+	require.NoError(t, err)
+
+	// повторный Replace заменяет список и порядок
+	err = r.Replace(ctx, "prod-1", []*model.Main{
+		{ProductID: "prod-1", ProviderID: "asbis", Priority: 0, ProviderProductID: "A-2"},
+		{ProductID: "prod-1", ProviderID: "comportal", Priority: 1, ProviderProductID: "KL-1"},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, r.Replace(ctx, "prod-2", []*model.Main{
+		{ProductID: "prod-2", ProviderID: "megogo", ProviderProductID: "M-1"},
+	}))
+
+	items, _, err := r.List(ctx, &model.ListReq{ProductID: lo.ToPtr("prod-1")})
+	require.NoError(t, err)
+	if assert.Len(t, items, 2) {
+		assert.Equal(t, "asbis", items[0].ProviderID)
+		assert.Equal(t, "A-2", items[0].ProviderProductID)
+		assert.Equal(t, "comportal", items[1].ProviderID)
+	}
+
+	// пустой список удаляет привязки только этого продукта
+	require.NoError(t, r.Replace(ctx, "prod-1", nil))
+
+	items, _, err = r.List(ctx, &model.ListReq{})
+	require.NoError(t, err)
+	if assert.Len(t, items, 1) {
+		assert.Equal(t, "prod-2", items[0].ProductID)
+	}
+}
@@ -0,0 +1,21 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/mechta-market/e-product/internal/domain/webhook/model"
+)
+
+type RepoDbI interface {
+	ListSubscriptions(ctx context.Context, pars *model.SubscriptionListReq) (_ []*model.Subscription, _ int64, finalError error)
+	GetSubscription(ctx context.Context, id string) (_ *model.Subscription, _ bool, finalError error)
+	CreateSubscription(ctx context.Context, obj *model.SubscriptionEdit) (_ string, finalError error)
+	UpdateSubscription(ctx context.Context, obj *model.SubscriptionEdit) (_ bool, finalError error)
+	DeleteSubscription(ctx context.Context, id string) (_ bool, finalError error)
+	CreateDeliveries(ctx context.Context, event, payload string) (_ int64, finalError error)
+	ListDeliveries(ctx context.Context, pars *model.DeliveryListReq) (_ []*model.Delivery, _ int64, finalError error)
+	GetDelivery(ctx context.Context, id string) (_ *model.Delivery, _ bool, finalError error)
+	ClaimDeliveries(ctx context.Context, limit uint64, lockUntil time.Time) (_ []*model.Delivery, finalError error)
+	UpdateDelivery(ctx context.Context, obj *model.DeliveryEdit) (finalError error)
+}
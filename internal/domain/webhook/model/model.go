@@ -0,0 +1,106 @@
+package model
+
+import (
+	"time"
+
+	commonModel "github.com/mechta-market/e-product/internal/domain/common/model"
+)
+
+// Subscription подписчик webhook: на URL отправляются события из Events, тело подписывается Secret
+type Subscription struct {
+	ID        string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Name      string
+	URL       string
+	Events    []string
+	Active    bool
+	Secret    string
+}
+
+type SubscriptionListReq struct {
+	commonModel.ListParams
+
+	Active *bool
+}
+
+type SubscriptionEdit struct {
+	ID        *string
+	UpdatedAt *time.Time
+	Name      *string
+	URL       *string
+	Events    *[]string
+	Active    *bool
+	Secret    *string
+}
+
+// Delivery доставка события подписчику. Неудачная попытка повторяется в NextAttemptAt,
+// после исчерпания попыток доставка переходит в dead
+type Delivery struct {
+	ID             string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	SubscriptionID string
+	Event          string
+	Payload        string
+	Status         string
+	Attempts       int64
+	NextAttemptAt  time.Time
+	LastError      string
+	ResponseStatus int64
+	DeliveredAt    *time.Time
+}
+
+type DeliveryListReq struct {
+	commonModel.ListParams
+
+	SubscriptionID *string
+	Event          *string
+	Status         *string
+}
+
+type DeliveryEdit struct {
+	ID             *string
+	UpdatedAt      *time.Time
+	Status         *string
+	Attempts       *int64
+	NextAttemptAt  *time.Time
+	LastError      *string
+	ResponseStatus *int64
+	DeliveredAt    *time.Time
+}
+
+// Envelope тело webhook: тип события, время и данные события
+type Envelope struct {
+	Event      string    `json:"event"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+// KeyEvent данные событий activated и cancelled
+type KeyEvent struct {
+	KeyID      string `json:"key_id"`
+	ProductID  string `json:"product_id"`
+	OrderID    string `json:"order_id"`
+	ProviderID string `json:"provider_id"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// PoolLowEvent данные события pool_low
+type PoolLowEvent struct {
+	ProductID   string `json:"product_id"`
+	MinLevel    int64  `json:"min_level"`
+	TargetLevel int64  `json:"target_level"`
+	Available   int64  `json:"available"`
+	CapReached  bool   `json:"cap_reached"`
+	Error       string `json:"error,omitempty"`
+}
+
+// ProviderFailedEvent данные события provider_failed
+type ProviderFailedEvent struct {
+	ProviderID  string `json:"provider_id"`
+	ProductID   string `json:"product_id"`
+	OrderID     string `json:"order_id"`
+	OperationID string `json:"operation_id"`
+	Error       string `json:"error"`
+}
@@ -0,0 +1,80 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/samber/lo"
+
+	commonRepoPg "github.com/mechta-market/e-product/internal/domain/common/repo/pg"
+	"github.com/mechta-market/e-product/internal/domain/webhook/model"
+	repoModel "github.com/mechta-market/e-product/internal/domain/webhook/repo/pg/model"
+)
+
+var (
+	allowedSubscriptionSortFields = map[string]string{
+		"created_at": "created_at",
+		"name":       "name",
+	}
+
+	allowedDeliverySortFields = map[string]string{
+		"created_at":      "created_at",
+		"next_attempt_at": "next_attempt_at",
+	}
+)
+
+func (r *Repo) getDeliveryConditions(pars *model.DeliveryListReq) map[string]any {
+	conditions := make(map[string]any)
+
+	if pars.SubscriptionID != nil {
+		conditions["subscription_id"] = *pars.SubscriptionID
+	}
+
+	if pars.Event != nil {
+		conditions["event"] = *pars.Event
+	}
+
+	if pars.Status != nil {
+		conditions["status"] = *pars.Status
+	}
+
+	return conditions
+}
+
+// encodeSubscriptionEdit шифрует секрет, если настроен keyring
+func (r *Repo) encodeSubscriptionEdit(obj *model.SubscriptionEdit) (*repoModel.SubscriptionUpsert, error) {
+	result := repoModel.EncodeSubscriptionEdit(obj)
+
+	if result.Secret == nil {
+		return result, nil
+	}
+
+	// открытый секрет заменяет ранее зашифрованный
+	if r.keyring == nil || *result.Secret == "" {
+		result.SecretKeyID = lo.ToPtr("")
+		return result, nil
+	}
+
+	sealed, err := r.keyring.Encrypt(*result.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("keyring.Encrypt: %w", err)
+	}
+
+	result.Secret = lo.ToPtr("")
+	result.SecretEnc = sealed.Data
+	result.SecretDEK = sealed.DEK
+	result.SecretKeyID = lo.ToPtr(sealed.KeyID)
+
+	return result, nil
+}
+
+func (r *Repo) decodeSubscription(m *repoModel.SubscriptionSelect) (*model.Subscription, error) {
+	secret, err := commonRepoPg.OpenValue(r.keyring, m.Secret, m.SecretEnc, m.SecretDEK, m.SecretKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("OpenValue: %w", err)
+	}
+
+	result := repoModel.DecodeSubscription(m, 0)
+	result.Secret = secret
+
+	return result, nil
+}
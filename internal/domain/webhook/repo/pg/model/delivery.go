@@ -0,0 +1,148 @@
+package model
+
+import (
+	"time"
+
+	"github.com/mechta-market/e-product/internal/domain/webhook/model"
+)
+
+type DeliverySelect struct {
+	ID             string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	SubscriptionID string
+	Event          string
+	Payload        string
+	Status         string
+	Attempts       int64
+	NextAttemptAt  time.Time
+	LastError      string
+	ResponseStatus int64
+	DeliveredAt    *time.Time
+}
+
+func (m *DeliverySelect) ListColumnMap() map[string]any {
+	return map[string]any{
+		"id":              &m.ID,
+		"created_at":      &m.CreatedAt,
+		"updated_at":      &m.UpdatedAt,
+		"subscription_id": &m.SubscriptionID,
+		"event":           &m.Event,
+		"payload":         &m.Payload,
+		"status":          &m.Status,
+		"attempts":        &m.Attempts,
+		"next_attempt_at": &m.NextAttemptAt,
+		"last_error":      &m.LastError,
+		"response_status": &m.ResponseStatus,
+		"delivered_at":    &m.DeliveredAt,
+	}
+}
+
+func (m *DeliverySelect) PKColumnMap() map[string]any {
+	return map[string]any{
+		"id": m.ID,
+	}
+}
+
+func (m *DeliverySelect) DefaultSortColumns() []string {
+	return []string{
+		"created_at desc",
+	}
+}
+
+func DecodeDelivery(m *DeliverySelect, _ int) *model.Delivery {
+	return &model.Delivery{
+		ID:             m.ID,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+		SubscriptionID: m.SubscriptionID,
+		Event:          m.Event,
+		Payload:        m.Payload,
+		Status:         m.Status,
+		Attempts:       m.Attempts,
+		NextAttemptAt:  m.NextAttemptAt,
+		LastError:      m.LastError,
+		ResponseStatus: m.ResponseStatus,
+		DeliveredAt:    m.DeliveredAt,
+	}
+}
+
+type DeliveryUpsert struct {
+	ID             string
+	UpdatedAt      *time.Time
+	Status         *string
+	Attempts       *int64
+	NextAttemptAt  *time.Time
+	LastError      *string
+	ResponseStatus *int64
+	DeliveredAt    *time.Time
+}
+
+func (m *DeliveryUpsert) UpdateColumnMap() map[string]any {
+	res := m.CreateColumnMap()
+
+	pkMap := m.PKColumnMap()
+	for k := range pkMap {
+		delete(res, k)
+	}
+
+	return res
+}
+
+func (m *DeliveryUpsert) PKColumnMap() map[string]any {
+	return map[string]any{
+		"id": m.ID,
+	}
+}
+
+func (m *DeliveryUpsert) CreateColumnMap() map[string]any {
+	result := make(map[string]any, 7)
+
+	if m.UpdatedAt != nil {
+		result["updated_at"] = *m.UpdatedAt
+	}
+
+	if m.Status != nil {
+		result["status"] = *m.Status
+	}
+
+	if m.Attempts != nil {
+		result["attempts"] = *m.Attempts
+	}
+
+	if m.NextAttemptAt != nil {
+		result["next_attempt_at"] = *m.NextAttemptAt
+	}
+
+	if m.LastError != nil {
+		result["last_error"] = *m.LastError
+	}
+
+	if m.ResponseStatus != nil {
+		result["response_status"] = *m.ResponseStatus
+	}
+
+	if m.DeliveredAt != nil {
+		result["delivered_at"] = *m.DeliveredAt
+	}
+
+	return result
+}
+
+func EncodeDeliveryEdit(m *model.DeliveryEdit) *DeliveryUpsert {
+	result := &DeliveryUpsert{}
+
+	if m.ID != nil && *m.ID != "" {
+		result.ID = *m.ID
+	}
+
+	result.UpdatedAt = m.UpdatedAt
+	result.Status = m.Status
+	result.Attempts = m.Attempts
+	result.NextAttemptAt = m.NextAttemptAt
+	result.LastError = m.LastError
+	result.ResponseStatus = m.ResponseStatus
+	result.DeliveredAt = m.DeliveredAt
+
+	return result
+}
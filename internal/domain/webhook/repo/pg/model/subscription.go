@@ -0,0 +1,151 @@
+package model
+
+import (
+	"time"
+
+	"github.com/mechta-market/e-product/internal/domain/webhook/model"
+)
+
+type SubscriptionSelect struct {
+	ID          string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Name        string
+	URL         string
+	Events      []string
+	Active      bool
+	Secret      string
+	SecretEnc   []byte
+	SecretDEK   []byte
+	SecretKeyID string
+}
+
+func (m *SubscriptionSelect) ListColumnMap() map[string]any {
+	return map[string]any{
+		"id":            &m.ID,
+		"created_at":    &m.CreatedAt,
+		"updated_at":    &m.UpdatedAt,
+		"name":          &m.Name,
+		"url":           &m.URL,
+		"events":        &m.Events,
+		"active":        &m.Active,
+		"secret":        &m.Secret,
+		"secret_enc":    &m.SecretEnc,
+		"secret_dek":    &m.SecretDEK,
+		"secret_key_id": &m.SecretKeyID,
+	}
+}
+
+func (m *SubscriptionSelect) PKColumnMap() map[string]any {
+	return map[string]any{
+		"id": m.ID,
+	}
+}
+
+func (m *SubscriptionSelect) DefaultSortColumns() []string {
+	return []string{
+		"created_at asc",
+	}
+}
+
+// DecodeSubscription без Secret: секрет расшифровывает репозиторий
+func DecodeSubscription(m *SubscriptionSelect, _ int) *model.Subscription {
+	return &model.Subscription{
+		ID:        m.ID,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+		Name:      m.Name,
+		URL:       m.URL,
+		Events:    m.Events,
+		Active:    m.Active,
+	}
+}
+
+type SubscriptionUpsert struct {
+	ID          string
+	UpdatedAt   *time.Time
+	Name        *string
+	URL         *string
+	Events      *[]string
+	Active      *bool
+	Secret      *string
+	SecretEnc   []byte
+	SecretDEK   []byte
+	SecretKeyID *string
+}
+
+func (m *SubscriptionUpsert) UpdateColumnMap() map[string]any {
+	res := m.CreateColumnMap()
+
+	pkMap := m.PKColumnMap()
+	for k := range pkMap {
+		delete(res, k)
+	}
+
+	return res
+}
+
+func (m *SubscriptionUpsert) PKColumnMap() map[string]any {
+	return map[string]any{
+		"id": m.ID,
+	}
+}
+
+func (m *SubscriptionUpsert) ReturningColumnMap() map[string]any {
+	return map[string]any{
+		"id": &m.ID,
+	}
+}
+
+func (m *SubscriptionUpsert) CreateColumnMap() map[string]any {
+	result := make(map[string]any, 9)
+
+	if m.UpdatedAt != nil {
+		result["updated_at"] = *m.UpdatedAt
+	}
+
+	if m.Name != nil {
+		result["name"] = *m.Name
+	}
+
+	if m.URL != nil {
+		result["url"] = *m.URL
+	}
+
+	if m.Events != nil {
+		result["events"] = *m.Events
+	}
+
+	if m.Active != nil {
+		result["active"] = *m.Active
+	}
+
+	if m.Secret != nil {
+		result["secret"] = *m.Secret
+	}
+
+	if m.SecretKeyID != nil {
+		result["secret_enc"] = m.SecretEnc
+		result["secret_dek"] = m.SecretDEK
+		result["secret_key_id"] = *m.SecretKeyID
+	}
+
+	return result
+}
+
+func EncodeSubscriptionEdit(m *model.SubscriptionEdit) *SubscriptionUpsert {
+	result := &SubscriptionUpsert{}
+
+	if m.ID != nil && *m.ID != "" {
+		result.ID = *m.ID
+	}
+
+	result.UpdatedAt = m.UpdatedAt
+	result.Name = m.Name
+	result.URL = m.URL
+	result.Events = m.Events
+	result.Active = m.Active
+	result.Secret = m.Secret
+
+	return result
+}
@@ -0,0 +1,386 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mechta-market/mobone/v2"
+	moboneTools "github.com/mechta-market/mobone/v2/tools"
+	"github.com/opentracing/opentracing-go"
+	"github.com/samber/lo"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/common/keyring"
+	commonRepoPg "github.com/mechta-market/e-product/internal/domain/common/repo/pg"
+	"github.com/mechta-market/e-product/internal/domain/webhook/model"
+	repoModel "github.com/mechta-market/e-product/internal/domain/webhook/repo/pg/model"
+)
+
+type Repo struct {
+	*commonRepoPg.Base
+	SubscriptionStore *mobone.ModelStore
+	DeliveryStore     *mobone.ModelStore
+	keyring           *keyring.Keyring
+}
+
+// New создает репозиторий webhook. Если kr == nil, секреты подписчиков хранятся в открытом виде
+func New(con *pgxpool.Pool, kr *keyring.Keyring) *Repo {
+	base := commonRepoPg.NewBase(con)
+	return &Repo{
+		Base: base,
+		SubscriptionStore: &mobone.ModelStore{
+			Con:       base.Con,
+			QB:        base.QB,
+			TableName: "webhook_subscription",
+		},
+		DeliveryStore: &mobone.ModelStore{
+			Con:       base.Con,
+			QB:        base.QB,
+			TableName: "webhook_delivery",
+		},
+		keyring: kr,
+	}
+}
+
+func (r *Repo) ListSubscriptions(ctx context.Context, pars *model.SubscriptionListReq) (_ []*model.Subscription, _ int64, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "webhook.repo.PG.ListSubscriptions")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	conditions := make(map[string]any)
+	if pars.Active != nil {
+		conditions["active"] = *pars.Active
+	}
+
+	items := make([]*repoModel.SubscriptionSelect, 0)
+
+	totalCount, err := r.SubscriptionStore.List(ctx, mobone.ListParams{
+		Conditions:     conditions,
+		Page:           pars.Page,
+		PageSize:       pars.PageSize,
+		WithTotalCount: pars.WithTotalCount,
+		OnlyCount:      pars.OnlyCount,
+		Sort:           moboneTools.ConstructSortColumns(allowedSubscriptionSortFields, pars.Sort),
+	}, func(add bool) mobone.ListModelI {
+		item := &repoModel.SubscriptionSelect{}
+
+		if add {
+			items = append(items, item)
+		}
+		return item
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("SubscriptionStore.List: %w", err)
+	}
+
+	result := make([]*model.Subscription, 0, len(items))
+	for _, item := range items {
+		obj, err := r.decodeSubscription(item)
+		if err != nil {
+			return nil, 0, fmt.Errorf("decodeSubscription: %w", err)
+		}
+		result = append(result, obj)
+	}
+
+	return result, totalCount, nil
+}
+
+func (r *Repo) GetSubscription(ctx context.Context, id string) (_ *model.Subscription, _ bool, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "webhook.repo.PG.GetSubscription")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	m := &repoModel.SubscriptionSelect{}
+	m.ID = id
+
+	found, err := r.SubscriptionStore.Get(ctx, m)
+	if err != nil {
+		return nil, false, fmt.Errorf("SubscriptionStore.Get: %w", err)
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	result, err := r.decodeSubscription(m)
+	if err != nil {
+		return nil, false, fmt.Errorf("decodeSubscription: %w", err)
+	}
+
+	return result, true, nil
+}
+
+func (r *Repo) CreateSubscription(ctx context.Context, obj *model.SubscriptionEdit) (_ string, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "webhook.repo.PG.CreateSubscription")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	upsertObj, err := r.encodeSubscriptionEdit(obj)
+	if err != nil {
+		return "", fmt.Errorf("encodeSubscriptionEdit: %w", err)
+	}
+
+	err = r.SubscriptionStore.Create(ctx, upsertObj)
+	if err != nil {
+		return "", fmt.Errorf("SubscriptionStore.Create: %w", err)
+	}
+
+	return upsertObj.ID, nil
+}
+
+// UpdateSubscription обновляет заданные поля подписки, false - подписка не найдена
+func (r *Repo) UpdateSubscription(ctx context.Context, obj *model.SubscriptionEdit) (_ bool, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "webhook.repo.PG.UpdateSubscription")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	upsertObj, err := r.encodeSubscriptionEdit(obj)
+	if err != nil {
+		return false, fmt.Errorf("encodeSubscriptionEdit: %w", err)
+	}
+
+	query, args, err := r.QB.Update(r.SubscriptionStore.TableName).
+		SetMap(upsertObj.UpdateColumnMap()).
+		Where(squirrel.Eq(upsertObj.PKColumnMap())).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("fail to build query: %w", err)
+	}
+
+	tag, err := r.Con.Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("fail to exec: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// DeleteSubscription удаляет подписку, доставки удаляются каскадно. false - подписка не найдена
+func (r *Repo) DeleteSubscription(ctx context.Context, id string) (_ bool, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "webhook.repo.PG.DeleteSubscription")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	query, args, err := r.QB.Delete(r.SubscriptionStore.TableName).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("fail to build query: %w", err)
+	}
+
+	tag, err := r.Con.Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("fail to exec: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// CreateDeliveries создает доставку события каждому активному подписчику на event. Возвращает число доставок
+func (r *Repo) CreateDeliveries(ctx context.Context, event, payload string) (_ int64, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "webhook.repo.PG.CreateDeliveries")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	// вложенный запрос с "?": плейсхолдеры нумерует внешний
+	subscribers := squirrel.Select("id").
+		Column(squirrel.Expr("?::text", event)).
+		Column(squirrel.Expr("?::text", payload)).
+		From(r.SubscriptionStore.TableName).
+		Where(squirrel.Eq{"active": true}).
+		Where("?::text = ANY(events)", event)
+
+	query, args, err := r.QB.Insert(r.DeliveryStore.TableName).
+		Columns("subscription_id", "event", "payload").
+		Select(subscribers).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("fail to build query: %w", err)
+	}
+
+	tag, err := r.Con.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("fail to exec: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *Repo) ListDeliveries(ctx context.Context, pars *model.DeliveryListReq) (_ []*model.Delivery, _ int64, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "webhook.repo.PG.ListDeliveries")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	items := make([]*repoModel.DeliverySelect, 0)
+
+	totalCount, err := r.DeliveryStore.List(ctx, mobone.ListParams{
+		Conditions:     r.getDeliveryConditions(pars),
+		Page:           pars.Page,
+		PageSize:       pars.PageSize,
+		WithTotalCount: pars.WithTotalCount,
+		OnlyCount:      pars.OnlyCount,
+		Sort:           moboneTools.ConstructSortColumns(allowedDeliverySortFields, pars.Sort),
+	}, func(add bool) mobone.ListModelI {
+		item := &repoModel.DeliverySelect{}
+
+		if add {
+			items = append(items, item)
+		}
+		return item
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("DeliveryStore.List: %w", err)
+	}
+
+	return lo.Map(items, repoModel.DecodeDelivery), totalCount, nil
+}
+
+func (r *Repo) GetDelivery(ctx context.Context, id string) (_ *model.Delivery, _ bool, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "webhook.repo.PG.GetDelivery")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	m := &repoModel.DeliverySelect{}
+	m.ID = id
+
+	found, err := r.DeliveryStore.Get(ctx, m)
+	if err != nil {
+		return nil, false, fmt.Errorf("DeliveryStore.Get: %w", err)
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	return repoModel.DecodeDelivery(m, 0), true, nil
+}
+
+// ClaimDeliveries забирает до limit ожидающих доставок, время попытки которых наступило: счетчик попыток
+// увеличивается, следующая попытка переносится на lockUntil. Строки блокируются через FOR UPDATE SKIP LOCKED,
+// поэтому несколько экземпляров сервиса не заберут одну доставку
+func (r *Repo) ClaimDeliveries(ctx context.Context, limit uint64, lockUntil time.Time) (_ []*model.Delivery, finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "webhook.repo.PG.ClaimDeliveries")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	colNames, _ := commonRepoPg.ColumnMapSplit((&repoModel.DeliverySelect{}).ListColumnMap())
+	result := make([]*model.Delivery, 0)
+
+	err := r.WithTx(ctx, func(tx pgx.Tx) error {
+		// вложенный запрос с "?": плейсхолдеры нумерует внешний
+		due := squirrel.Select("id").
+			From(r.DeliveryStore.TableName).
+			Where(squirrel.Eq{"status": constant.WebhookDeliveryStatusPending}).
+			Where(squirrel.LtOrEq{"next_attempt_at": time.Now()}).
+			OrderBy("next_attempt_at").
+			Limit(limit).
+			Suffix("FOR UPDATE SKIP LOCKED")
+
+		dueSql, dueArgs, err := due.ToSql()
+		if err != nil {
+			return fmt.Errorf("fail to build select query: %w", err)
+		}
+
+		query, args, err := r.QB.Update(r.DeliveryStore.TableName).
+			Set("attempts", squirrel.Expr("attempts + 1")).
+			Set("next_attempt_at", lockUntil).
+			Set("updated_at", time.Now()).
+			Where(squirrel.Expr("id IN ("+dueSql+")", dueArgs...)).
+			Suffix("RETURNING " + strings.Join(colNames, ", ")).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("fail to build update query: %w", err)
+		}
+
+		rows, err := tx.Query(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("fail to query: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			m := &repoModel.DeliverySelect{}
+			colMap := m.ListColumnMap()
+
+			err = rows.Scan(lo.Map(colNames, func(name string, _ int) any { return colMap[name] })...)
+			if err != nil {
+				return fmt.Errorf("fail to scan: %w", err)
+			}
+
+			result = append(result, repoModel.DecodeDelivery(m, 0))
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("WithTx: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *Repo) UpdateDelivery(ctx context.Context, obj *model.DeliveryEdit) (finalError error) {
+	tracingSpan, ctx := opentracing.StartSpanFromContext(ctx, "webhook.repo.PG.UpdateDelivery")
+	defer tracingSpan.Finish()
+	defer func() {
+		if finalError != nil {
+			tracingSpan.SetTag("error", true)
+			tracingSpan.LogKV("error", finalError.Error())
+		}
+	}()
+
+	err := r.DeliveryStore.Update(ctx, repoModel.EncodeDeliveryEdit(obj))
+	if err != nil {
+		return fmt.Errorf("DeliveryStore.Update: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package pg
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/webhook/model"
+)
+
+// Тесты работают с реальной БД: TEST_PG_DSN должен указывать на отдельную тестовую базу,
+// схема в ней пересоздается по файлам из migrations
+func newTestRepo(t *testing.T) *Repo {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_PG_DSN")
+	if dsn == "" {
+		t.Skip("TEST_PG_DSN is not set")
+	}
+
+	ctx := context.Background()
+
+	con, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(con.Close)
+
+	migrate(t, con, "*.down.sql", true)
+	migrate(t, con, "*.up.sql", false)
+
+	return New(con, nil)
+}
+
+func migrate(t *testing.T, con *pgxpool.Pool, pattern string, reverse bool) {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join("..", "..", "..", "..", "..", "migrations", pattern))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	sort.Strings(files)
+	if reverse {
+		files = lo.Reverse(files)
+	}
+
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		require.NoError(t, err)
+
+		_, err = con.Exec(context.Background(), string(data))
+		require.NoError(t, err, f)
+	}
+}
+
+func TestRepo_Deliveries(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	crmID, err := r.CreateSubscription(ctx, &model.SubscriptionEdit{
+		Name:   lo.ToPtr("crm"),
+		URL:    lo.ToPtr("https://crm.example.com/hook"),
+		Events: lo.ToPtr([]string{constant.WebhookEventActivated, constant.WebhookEventCancelled}),
+		Secret: lo.ToPtr("s1"),
+	})
+	require.NoError(t, err)
+
+	_, err = r.CreateSubscription(ctx, &model.SubscriptionEdit{
+		URL:    lo.ToPtr("https://oms.example.com/hook"),
+		Events: lo.ToPtr([]string{constant.WebhookEventPoolLow}),
+	})
+	require.NoError(t, err)
+
+	_, err = r.CreateSubscription(ctx, &model.SubscriptionEdit{
+		URL:    lo.ToPtr("https://off.example.com/hook"),
+		Events: lo.ToPtr([]string{constant.WebhookEventActivated}),
+		Active: lo.ToPtr(false),
+	})
+	require.NoError(t, err)
+
+	subscription, found, err := r.GetSubscription(ctx, crmID)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "s1", subscription.Secret)
+
+	// событие получает только активный подписчик на него
+	count, err := r.CreateDeliveries(ctx, constant.WebhookEventActivated, `{"event":"activated"}`)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, count)
+
+	claimed, err := r.ClaimDeliveries(ctx, 10, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, crmID, claimed[0].SubscriptionID)
+	require.Equal(t, `{"event":"activated"}`, claimed[0].Payload)
+	require.EqualValues(t, 1, claimed[0].Attempts)
+
+	// до lockUntil доставка повторно не забирается
+	again, err := r.ClaimDeliveries(ctx, 10, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Empty(t, again)
+
+	err = r.UpdateDelivery(ctx, &model.DeliveryEdit{
+		ID:     lo.ToPtr(claimed[0].ID),
+		Status: lo.ToPtr(constant.WebhookDeliveryStatusDead),
+	})
+	require.NoError(t, err)
+
+	items, _, err := r.ListDeliveries(ctx, &model.DeliveryListReq{Status: lo.ToPtr(constant.WebhookDeliveryStatusDead)})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	deleted, err := r.DeleteSubscription(ctx, crmID)
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	_, found, err = r.GetDelivery(ctx, claimed[0].ID)
+	require.NoError(t, err)
+	require.False(t, found)
+}
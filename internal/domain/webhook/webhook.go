@@ -0,0 +1,200 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/samber/lo"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/webhook/model"
+	"github.com/mechta-market/e-product/internal/errs"
+)
+
+type Service struct {
+	repoDb RepoDbI
+}
+
+func New(repoDb RepoDbI) *Service {
+	return &Service{repoDb: repoDb}
+}
+
+func (s *Service) ListSubscriptions(ctx context.Context, pars *model.SubscriptionListReq) ([]*model.Subscription, int64, error) {
+	items, tCount, err := s.repoDb.ListSubscriptions(ctx, pars)
+	if err != nil {
+		return nil, 0, fmt.Errorf("repoDb.ListSubscriptions: %w", err)
+	}
+
+	return items, tCount, nil
+}
+
+func (s *Service) GetSubscription(ctx context.Context, id string, errNE bool) (*model.Subscription, bool, error) {
+	result, found, err := s.repoDb.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, false, fmt.Errorf("repoDb.GetSubscription: %w", err)
+	}
+	if !found {
+		if errNE {
+			return nil, false, errs.ErrFull{
+				Err: errs.ObjectNotFound,
+				Msg: errs.MsgWebhookNotFound,
+			}
+		}
+		return nil, false, nil
+	}
+
+	return result, true, nil
+}
+
+func (s *Service) CreateSubscription(ctx context.Context, obj *model.SubscriptionEdit) (string, error) {
+	id, err := s.repoDb.CreateSubscription(ctx, obj)
+	if err != nil {
+		return "", fmt.Errorf("repoDb.CreateSubscription: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *Service) UpdateSubscription(ctx context.Context, obj *model.SubscriptionEdit) error {
+	obj.UpdatedAt = lo.ToPtr(time.Now())
+
+	updated, err := s.repoDb.UpdateSubscription(ctx, obj)
+	if err != nil {
+		return fmt.Errorf("repoDb.UpdateSubscription: %w", err)
+	}
+	if !updated {
+		return errs.ErrFull{
+			Err: errs.ObjectNotFound,
+			Msg: errs.MsgWebhookNotFound,
+		}
+	}
+
+	return nil
+}
+
+// DeleteSubscription удаляет подписку вместе с ее доставками
+func (s *Service) DeleteSubscription(ctx context.Context, id string) error {
+	deleted, err := s.repoDb.DeleteSubscription(ctx, id)
+	if err != nil {
+		return fmt.Errorf("repoDb.DeleteSubscription: %w", err)
+	}
+	if !deleted {
+		return errs.ErrFull{
+			Err: errs.ObjectNotFound,
+			Msg: errs.MsgWebhookNotFound,
+		}
+	}
+
+	return nil
+}
+
+// Publish ставит событие в очередь доставки всем активным подписчикам на event.
+// Возвращает число созданных доставок
+func (s *Service) Publish(ctx context.Context, event string, data any) (int64, error) {
+	payload, err := json.Marshal(&model.Envelope{
+		Event:      event,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	count, err := s.repoDb.CreateDeliveries(ctx, event, string(payload))
+	if err != nil {
+		return 0, fmt.Errorf("repoDb.CreateDeliveries: %w", err)
+	}
+
+	return count, nil
+}
+
+func (s *Service) ListDeliveries(ctx context.Context, pars *model.DeliveryListReq) ([]*model.Delivery, int64, error) {
+	items, tCount, err := s.repoDb.ListDeliveries(ctx, pars)
+	if err != nil {
+		return nil, 0, fmt.Errorf("repoDb.ListDeliveries: %w", err)
+	}
+
+	return items, tCount, nil
+}
+
+func (s *Service) GetDelivery(ctx context.Context, id string, errNE bool) (*model.Delivery, bool, error) {
+	result, found, err := s.repoDb.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, false, fmt.Errorf("repoDb.GetDelivery: %w", err)
+	}
+	if !found {
+		if errNE {
+			return nil, false, errs.ErrFull{
+				Err: errs.ObjectNotFound,
+				Msg: errs.MsgDeliveryNotFound,
+			}
+		}
+		return nil, false, nil
+	}
+
+	return result, true, nil
+}
+
+// ClaimDeliveries забирает до limit доставок, время попытки которых наступило. Следующая попытка
+// откладывается на lease: если отправка не завершится (сервис упал), доставка будет забрана повторно
+func (s *Service) ClaimDeliveries(ctx context.Context, limit uint64, lease time.Duration) ([]*model.Delivery, error) {
+	items, err := s.repoDb.ClaimDeliveries(ctx, limit, time.Now().Add(lease))
+	if err != nil {
+		return nil, fmt.Errorf("repoDb.ClaimDeliveries: %w", err)
+	}
+
+	return items, nil
+}
+
+// Delivered отмечает успешную доставку
+func (s *Service) Delivered(ctx context.Context, id string, responseStatus int64) error {
+	now := time.Now()
+
+	return s.updateDelivery(ctx, &model.DeliveryEdit{
+		ID:             lo.ToPtr(id),
+		Status:         lo.ToPtr(constant.WebhookDeliveryStatusDelivered),
+		LastError:      lo.ToPtr(""),
+		ResponseStatus: lo.ToPtr(responseStatus),
+		DeliveredAt:    lo.ToPtr(now),
+	})
+}
+
+// Failed отмечает неудачную попытку: доставка повторяется в nextAttemptAt, если nextAttemptAt == nil - переходит в dead
+func (s *Service) Failed(ctx context.Context, id string, responseStatus int64, errMsg string, nextAttemptAt *time.Time) error {
+	obj := &model.DeliveryEdit{
+		ID:             lo.ToPtr(id),
+		Status:         lo.ToPtr(constant.WebhookDeliveryStatusPending),
+		LastError:      lo.ToPtr(errMsg),
+		ResponseStatus: lo.ToPtr(responseStatus),
+		NextAttemptAt:  nextAttemptAt,
+	}
+	if nextAttemptAt == nil {
+		obj.Status = lo.ToPtr(constant.WebhookDeliveryStatusDead)
+	}
+
+	return s.updateDelivery(ctx, obj)
+}
+
+// Replay возвращает доставку в очередь с обнуленным счетчиком попыток
+func (s *Service) Replay(ctx context.Context, id string) error {
+	return s.updateDelivery(ctx, &model.DeliveryEdit{
+		ID:            lo.ToPtr(id),
+		Status:        lo.ToPtr(constant.WebhookDeliveryStatusPending),
+		Attempts:      lo.ToPtr(int64(0)),
+		NextAttemptAt: lo.ToPtr(time.Now()),
+		LastError:     lo.ToPtr(""),
+	})
+}
+
+func (s *Service) updateDelivery(ctx context.Context, obj *model.DeliveryEdit) error {
+	obj.UpdatedAt = lo.ToPtr(time.Now())
+
+	err := s.repoDb.UpdateDelivery(ctx, obj)
+	if err != nil {
+		return fmt.Errorf("repoDb.UpdateDelivery: %w", err)
+	}
+
+	return nil
+}
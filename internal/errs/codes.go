@@ -0,0 +1,44 @@
+package errs
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+)
+
+// grpcCodes grpc-коды ошибок, по ним grpc-gateway выбирает http-статус.
+// Ошибки не из таблицы - ошибки валидации, InvalidArgument
+var grpcCodes = map[Err]codes.Code{
+	NoRows:               codes.NotFound,
+	ObjectNotFound:       codes.NotFound,
+	ServiceNA:            codes.Unavailable,
+	NotAuthorized:        codes.Unauthenticated,
+	PermissionDenied:     codes.PermissionDenied,
+	MethodNotSupported:   codes.Unimplemented,
+	AlreadyExists:        codes.AlreadyExists,
+	AlreadyActivated:     codes.FailedPrecondition,
+	AlreadyCancelled:     codes.FailedPrecondition,
+	ActivationInProgress: codes.Aborted,
+	InvalidKeyTransition: codes.FailedPrecondition,
+	ReservationExpired:   codes.FailedPrecondition,
+	ReservationNotActive: codes.FailedPrecondition,
+	PoolNotSupported:     codes.FailedPrecondition,
+	ProviderRejected:     codes.FailedPrecondition,
+}
+
+// GrpcCode возвращает grpc-код ошибки errs.Err или errs.ErrFull. Для остальных ошибок - Internal
+func GrpcCode(err error) codes.Code {
+	var errBase Err
+	if !errors.As(err, &errBase) {
+		var errFull ErrFull
+		if !errors.As(err, &errFull) || !errors.As(errFull.Err, &errBase) {
+			return codes.Internal
+		}
+	}
+
+	if code, ok := grpcCodes[errBase]; ok {
+		return code
+	}
+
+	return codes.InvalidArgument
+}
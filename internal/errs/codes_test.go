@@ -0,0 +1,31 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestGrpcCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{name: "not found", err: ObjectNotFound, want: codes.NotFound},
+		{name: "wrapped", err: fmt.Errorf("service.Get: %w", ServiceNA), want: codes.Unavailable},
+		{name: "full", err: ErrFull{Err: AlreadyActivated, Desc: "desc"}, want: codes.FailedPrecondition},
+		{name: "wrapped full", err: fmt.Errorf("usecase: %w", ErrFull{Err: MethodNotSupported}), want: codes.Unimplemented},
+		{name: "validation", err: IDRequired, want: codes.InvalidArgument},
+		{name: "unknown", err: errors.New("connection reset"), want: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GrpcCode(tt.err))
+		})
+	}
+}
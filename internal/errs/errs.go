@@ -26,19 +26,42 @@ const (
 	InvalidPhone          = Err("invalid_phone")
 	AlreadyCancelled      = Err("already_cancelled")
 	AlreadyActivated      = Err("already_activated")
+	ActivationInProgress  = Err("activation_in_progress")
+	InvalidFile           = Err("invalid_file")
+	InvalidImportFormat   = Err("invalid_import_format")
+	ImportColumnRequired  = Err("import_column_required")
+	ImportColumnNotFound  = Err("import_column_not_found")
+	FileTooLarge          = Err("file_too_large")
+	InvalidKeyTransition  = Err("invalid_key_transition")
+	ReservationExpired    = Err("reservation_expired")
+	ReservationNotActive  = Err("reservation_not_active")
+	InvalidPoolLevel      = Err("invalid_pool_level")
+	PoolNotSupported      = Err("pool_not_supported")
+	ProviderRejected      = Err("provider_rejected")
+	InvalidExportFormat   = Err("invalid_export_format")
+	InvalidCursor         = Err("invalid_cursor")
+	PermissionDenied      = Err("permission_denied")
+	ReasonRequired        = Err("reason_required")
+
+	ProviderProductIDRequired = Err("provider_product_id_required")
+	InvalidWebhookURL         = Err("invalid_webhook_url")
+	InvalidWebhookEvent       = Err("invalid_webhook_event")
+	WebhookEventsRequired     = Err("webhook_events_required")
 )
 
 const (
 	MethodNotSupported = Err("method_not_supported")
 )
 
-// ErrFull
+// ErrFull ошибка с описанием для клиента. Текст берется из каталога messages на языке запроса
+// по Msg, если не задан - по коду Err. Desc - текст без перевода, например, от внешних сервисов
 type ErrFull struct {
 	Err    error
+	Msg    Msg
 	Desc   string
 	Fields map[string]string
 }
 
 func (e ErrFull) Error() string {
-	return e.Err.Error() + ", desc: " + e.Desc
+	return e.Err.Error() + ", desc: " + e.Message(DefaultLang)
 }
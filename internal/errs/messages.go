@@ -0,0 +1,440 @@
+package errs
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// языки сообщений об ошибках
+const (
+	LangRu = "ru"
+	LangKk = "kk"
+	LangEn = "en"
+
+	DefaultLang = LangRu
+)
+
+// Msg ключ сообщения каталога, если у кода ошибки несколько текстов. Без Msg сообщение ищется по коду
+type Msg string
+
+const (
+	MsgHeaderRequired        = Msg("header_required")
+	MsgImportNoKeys          = Msg("import_no_keys")
+	MsgFileEmpty             = Msg("file_empty")
+	MsgValueColumnRequired   = Msg("value_column_required")
+	MsgProductColumnRequired = Msg("product_column_required")
+	MsgKeysEmpty             = Msg("keys_empty")
+	MsgRevealValueDenied     = Msg("reveal_value_denied")
+	MsgMethodDenied          = Msg("method_denied")
+	MsgNoPoolKeys            = Msg("no_pool_keys")
+	MsgProviderNotConnected  = Msg("provider_not_connected")
+	MsgKeyStatusChanged      = Msg("key_status_changed")
+	MsgCancelNotSupported    = Msg("cancel_not_supported")
+	MsgCatalogNotSupported   = Msg("catalog_not_supported")
+	MsgKeyNotFound           = Msg("key_not_found")
+	MsgKeyByOrderNotFound    = Msg("key_by_order_not_found")
+	MsgReservationNotFound   = Msg("reservation_not_found")
+	MsgPoolLevelNotFound     = Msg("pool_level_not_found")
+	MsgImportJobNotFound     = Msg("import_job_not_found")
+	MsgProviderUnavailable   = Msg("provider_unavailable")
+	MsgActivationNotFound    = Msg("activation_not_found")
+	MsgWebhookNotFound       = Msg("webhook_not_found")
+	MsgDeliveryNotFound      = Msg("webhook_delivery_not_found")
+)
+
+// messages каталог сообщений: ключ - код Err или Msg, далее язык. {name} заменяется на ErrFull.Fields[name]
+var messages = map[string]map[string]string{
+	string(NoRows): {
+		LangRu: "Запись не найдена",
+		LangKk: "Жазба табылмады",
+		LangEn: "Record not found",
+	},
+	string(ServiceNA): {
+		LangRu: "Сервис временно недоступен, повторите запрос позже",
+		LangKk: "Сервис уақытша қолжетімсіз, сұрауды кейінірек қайталаңыз",
+		LangEn: "Service is temporarily unavailable, please retry later",
+	},
+	string(NotAuthorized): {
+		LangRu: "Требуется действующий токен доступа",
+		LangKk: "Жарамды қолжетімділік токені қажет",
+		LangEn: "A valid access token is required",
+	},
+	string(ObjectNotFound): {
+		LangRu: "Объект не найден",
+		LangKk: "Нысан табылмады",
+		LangEn: "Object not found",
+	},
+	string(IncorrectPageSize): {
+		LangRu: "Некорректный размер страницы",
+		LangKk: "Бет өлшемі қате",
+		LangEn: "Invalid page size",
+	},
+	string(AlreadyExists): {
+		LangRu: "Объект уже существует",
+		LangKk: "Нысан бұрыннан бар",
+		LangEn: "Object already exists",
+	},
+	string(EmptyData): {
+		LangRu: "Нет данных",
+		LangKk: "Деректер жоқ",
+		LangEn: "No data",
+	},
+	string(ProviderIDRequired): {
+		LangRu: "Не указан провайдер",
+		LangKk: "Провайдер көрсетілмеген",
+		LangEn: "Provider is required",
+	},
+	string(ProductIDRequired): {
+		LangRu: "Не указан продукт",
+		LangKk: "Өнім көрсетілмеген",
+		LangEn: "Product is required",
+	},
+	string(ProviderProductIDRequired): {
+		LangRu: "Не указан продукт провайдера {providerID}",
+		LangKk: "{providerID} провайдерінің өнімі көрсетілмеген",
+		LangEn: "Product of provider {providerID} is required",
+	},
+	string(IDRequired): {
+		LangRu: "Не указан идентификатор",
+		LangKk: "Идентификатор көрсетілмеген",
+		LangEn: "ID is required",
+	},
+	string(OrderIDRequired): {
+		LangRu: "Не указан номер заказа",
+		LangKk: "Тапсырыс нөмірі көрсетілмеген",
+		LangEn: "Order number is required",
+	},
+	string(CustomerPhoneRequired): {
+		LangRu: "Не указан номер телефона клиента",
+		LangKk: "Клиенттің телефон нөмірі көрсетілмеген",
+		LangEn: "Customer phone number is required",
+	},
+	string(InvalidProviderID): {
+		LangRu: "Неизвестный провайдер",
+		LangKk: "Белгісіз провайдер",
+		LangEn: "Unknown provider",
+	},
+	string(ValueRequired): {
+		LangRu: "Не указано значение ключа",
+		LangKk: "Кілт мәні көрсетілмеген",
+		LangEn: "Key value is required",
+	},
+	string(InvalidPhone): {
+		LangRu: "Номер телефона клиента не прошел валидацию",
+		LangKk: "Клиенттің телефон нөмірі тексеруден өтпеді",
+		LangEn: "Customer phone number is invalid",
+	},
+	string(AlreadyCancelled): {
+		LangRu: "Заказ уже отменен",
+		LangKk: "Тапсырыс бұрыннан жойылған",
+		LangEn: "The order is already cancelled",
+	},
+	string(AlreadyActivated): {
+		LangRu: "Ключ по заказу уже выдан",
+		LangKk: "Тапсырыс бойынша кілт бұрыннан берілген",
+		LangEn: "A key has already been issued for this order",
+	},
+	string(ActivationInProgress): {
+		LangRu: "Заказ уже обрабатывается, повторите запрос позже",
+		LangKk: "Тапсырыс өңделуде, сұрауды кейінірек қайталаңыз",
+		LangEn: "The order is being processed, please retry later",
+	},
+	string(InvalidFile): {
+		LangRu: "Не удалось прочитать файл",
+		LangKk: "Файлды оқу мүмкін болмады",
+		LangEn: "Failed to read the file",
+	},
+	string(InvalidImportFormat): {
+		LangRu: "Поддерживаются только файлы csv и xlsx",
+		LangKk: "Тек csv және xlsx файлдарына қолдау көрсетіледі",
+		LangEn: "Only csv and xlsx files are supported",
+	},
+	string(ImportColumnRequired): {
+		LangRu: "Не указана колонка файла",
+		LangKk: "Файл бағаны көрсетілмеген",
+		LangEn: "File column is required",
+	},
+	string(ImportColumnNotFound): {
+		LangRu: "Колонка {column} не найдена в файле",
+		LangKk: "{column} бағаны файлда табылмады",
+		LangEn: "Column {column} not found in the file",
+	},
+	string(FileTooLarge): {
+		LangRu: "Файл слишком большой",
+		LangKk: "Файл тым үлкен",
+		LangEn: "The file is too large",
+	},
+	string(InvalidKeyTransition): {
+		LangRu: "Недопустимая смена статуса ключа",
+		LangKk: "Кілт мәртебесін бұлай өзгертуге болмайды",
+		LangEn: "Invalid key status change",
+	},
+	string(ReservationExpired): {
+		LangRu: "Срок резерва истек",
+		LangKk: "Резерв мерзімі өтті",
+		LangEn: "The reservation has expired",
+	},
+	string(ReservationNotActive): {
+		LangRu: "Резерв уже завершен",
+		LangKk: "Резерв аяқталған",
+		LangEn: "The reservation is no longer active",
+	},
+	string(InvalidWebhookURL): {
+		LangRu: "Адрес webhook должен быть абсолютным http(s) URL",
+		LangKk: "Webhook мекенжайы абсолютті http(s) URL болуы керек",
+		LangEn: "Webhook URL must be an absolute http(s) URL",
+	},
+	string(InvalidWebhookEvent): {
+		LangRu: "Неизвестное событие webhook {event}",
+		LangKk: "Белгісіз webhook оқиғасы {event}",
+		LangEn: "Unknown webhook event {event}",
+	},
+	string(WebhookEventsRequired): {
+		LangRu: "Не указаны события webhook",
+		LangKk: "Webhook оқиғалары көрсетілмеген",
+		LangEn: "Webhook events are required",
+	},
+	string(InvalidPoolLevel): {
+		LangRu: "Целевой уровень пула должен быть больше нуля и не меньше минимального, лимит закупок - не отрицательным",
+		LangKk: "Пулдың мақсатты деңгейі нөлден үлкен және ең төменгі деңгейден кем болмауы, сатып алу лимиті теріс болмауы керек",
+		LangEn: "The pool target level must be positive and not below the minimum level, the daily cap must not be negative",
+	},
+	string(PoolNotSupported): {
+		LangRu: "Провайдер продукта не работает с пулом ключей",
+		LangKk: "Өнім провайдері кілттер пулымен жұмыс істемейді",
+		LangEn: "The product's provider does not support a key pool",
+	},
+	string(ProviderRejected): {
+		LangRu: "Провайдер отклонил запрос",
+		LangKk: "Провайдер сұрауды қабылдамады",
+		LangEn: "The provider rejected the request",
+	},
+	string(InvalidExportFormat): {
+		LangRu: "Поддерживаются форматы csv и jsonl",
+		LangKk: "csv және jsonl форматтарына қолдау көрсетіледі",
+		LangEn: "Supported formats are csv and jsonl",
+	},
+	string(InvalidCursor): {
+		LangRu: "Некорректный курсор, начните с первой страницы",
+		LangKk: "Курсор қате, бірінші беттен бастаңыз",
+		LangEn: "Invalid cursor, start from the first page",
+	},
+	string(PermissionDenied): {
+		LangRu: "Недостаточно прав для операции",
+		LangKk: "Операцияға құқық жеткіліксіз",
+		LangEn: "Insufficient permissions for the operation",
+	},
+	string(ReasonRequired): {
+		LangRu: "Не указана причина",
+		LangKk: "Себебі көрсетілмеген",
+		LangEn: "Reason is required",
+	},
+	string(MethodNotSupported): {
+		LangRu: "Провайдер не поддерживает данную услугу",
+		LangKk: "Провайдер бұл қызметті қолдамайды",
+		LangEn: "The provider does not support this service",
+	},
+
+	string(MsgHeaderRequired): {
+		LangRu: "Первым сообщением должен быть header",
+		LangKk: "Бірінші хабарлама header болуы керек",
+		LangEn: "The first message must be a header",
+	},
+	string(MsgImportNoKeys): {
+		LangRu: "В файле нет ключей",
+		LangKk: "Файлда кілттер жоқ",
+		LangEn: "The file contains no keys",
+	},
+	string(MsgFileEmpty): {
+		LangRu: "Файл пустой",
+		LangKk: "Файл бос",
+		LangEn: "The file is empty",
+	},
+	string(MsgValueColumnRequired): {
+		LangRu: "Не указана колонка с ключом",
+		LangKk: "Кілт бағаны көрсетілмеген",
+		LangEn: "Key column is required",
+	},
+	string(MsgProductColumnRequired): {
+		LangRu: "Не указана колонка с product_id или product_id по умолчанию",
+		LangKk: "product_id бағаны немесе әдепкі product_id көрсетілмеген",
+		LangEn: "A product_id column or a default product_id is required",
+	},
+	string(MsgKeysEmpty): {
+		LangRu: "Список ключей пуст",
+		LangKk: "Кілттер тізімі бос",
+		LangEn: "Keys list cannot be empty",
+	},
+	string(MsgRevealValueDenied): {
+		LangRu: "Просмотр значения ключа доступен только поддержке и администраторам",
+		LangKk: "Кілт мәнін тек қолдау қызметі мен әкімшілер көре алады",
+		LangEn: "Only support and administrators can view key values",
+	},
+	string(MsgMethodDenied): {
+		LangRu: "Метод недоступен для роли {role}",
+		LangKk: "Әдіс {role} рөлі үшін қолжетімсіз",
+		LangEn: "The method is not available for role {role}",
+	},
+	string(MsgNoPoolKeys): {
+		LangRu: "Услуга провайдера в данный момент недоступна. Не найдено доступных ключей для данного продукта",
+		LangKk: "Провайдер қызметі қазір қолжетімсіз. Бұл өнім үшін қолжетімді кілттер табылмады",
+		LangEn: "The provider service is currently unavailable. No keys are available for this product",
+	},
+	string(MsgProviderNotConnected): {
+		LangRu: "Услуги провайдера не подключены",
+		LangKk: "Провайдер қызметтері қосылмаған",
+		LangEn: "Provider services are not connected",
+	},
+	string(MsgKeyStatusChanged): {
+		LangRu: "Статус ключа изменился, повторите запрос",
+		LangKk: "Кілт мәртебесі өзгерді, сұрауды қайталаңыз",
+		LangEn: "The key status has changed, please retry",
+	},
+	string(MsgCancelNotSupported): {
+		LangRu: "Данный провайдер не поддерживает услугу аннулирования заказа",
+		LangKk: "Бұл провайдер тапсырысты жою қызметін қолдамайды",
+		LangEn: "This provider does not support order cancellation",
+	},
+	string(MsgCatalogNotSupported): {
+		LangRu: "Провайдер не поддерживает данную услугу",
+		LangKk: "Провайдер бұл қызметті қолдамайды",
+		LangEn: "This provider does not support this service",
+	},
+	string(MsgKeyNotFound): {
+		LangRu: "Ключ не найден",
+		LangKk: "Кілт табылмады",
+		LangEn: "Key not found",
+	},
+	string(MsgKeyByOrderNotFound): {
+		LangRu: "Ключ с номером заказа {orderID} не найден",
+		LangKk: "{orderID} тапсырыс нөмірі бар кілт табылмады",
+		LangEn: "Key for order {orderID} not found",
+	},
+	string(MsgReservationNotFound): {
+		LangRu: "Резерв не найден",
+		LangKk: "Резерв табылмады",
+		LangEn: "Reservation not found",
+	},
+	string(MsgPoolLevelNotFound): {
+		LangRu: "Пороги пула не заданы",
+		LangKk: "Пул шектері берілмеген",
+		LangEn: "Pool levels are not set",
+	},
+	string(MsgImportJobNotFound): {
+		LangRu: "Загрузка не найдена",
+		LangKk: "Жүктеу табылмады",
+		LangEn: "Import job not found",
+	},
+	string(MsgActivationNotFound): {
+		LangRu: "Активация не найдена",
+		LangKk: "Белсендіру табылмады",
+		LangEn: "Activation not found",
+	},
+	string(MsgWebhookNotFound): {
+		LangRu: "Подписка webhook не найдена",
+		LangKk: "Webhook жазылымы табылмады",
+		LangEn: "Webhook subscription not found",
+	},
+	string(MsgDeliveryNotFound): {
+		LangRu: "Доставка webhook не найдена",
+		LangKk: "Webhook жеткізілімі табылмады",
+		LangEn: "Webhook delivery not found",
+	},
+	string(MsgProviderUnavailable): {
+		LangRu: "Провайдер {provider} временно недоступен, повторите запрос позже",
+		LangKk: "{provider} провайдері уақытша қолжетімсіз, сұрауды кейінірек қайталаңыз",
+		LangEn: "Provider {provider} is temporarily unavailable, please retry later",
+	},
+}
+
+// Message возвращает текст ошибки на языке lang (если перевода нет - на русском):
+// для ErrFull - по Msg, затем Desc, затем по коду, для Err - по коду, для остальных - err.Error()
+func Message(err error, lang string) string {
+	var errFull ErrFull
+	if errors.As(err, &errFull) {
+		return errFull.Message(lang)
+	}
+
+	var errBase Err
+	if errors.As(err, &errBase) {
+		if text, ok := lookupMessage(string(errBase), lang, nil); ok {
+			return text
+		}
+	}
+
+	return err.Error()
+}
+
+func (e ErrFull) Message(lang string) string {
+	if e.Msg != "" {
+		if text, ok := lookupMessage(string(e.Msg), lang, e.Fields); ok {
+			return text
+		}
+	}
+
+	if e.Desc != "" || e.Err == nil {
+		return e.Desc
+	}
+
+	if text, ok := lookupMessage(e.Err.Error(), lang, e.Fields); ok {
+		return text
+	}
+
+	return ""
+}
+
+func lookupMessage(key, lang string, fields map[string]string) (string, bool) {
+	texts, ok := messages[key]
+	if !ok {
+		return "", false
+	}
+
+	text, ok := texts[lang]
+	if !ok {
+		text = texts[DefaultLang]
+	}
+
+	for name, value := range fields {
+		text = strings.ReplaceAll(text, "{"+name+"}", value)
+	}
+
+	return text, true
+}
+
+// ParseLang выбирает поддерживаемый язык из значения accept-language (ru-RU, kk;q=0.9, en;q=0.8).
+// Если ни один не поддерживается - DefaultLang
+func ParseLang(acceptLanguage string) string {
+	type tag struct {
+		lang string
+		q    float64
+	}
+
+	tags := make([]tag, 0, 3)
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		lang, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, _, _ = strings.Cut(strings.ToLower(strings.TrimSpace(lang)), "-")
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+
+		if lang == LangRu || lang == LangKk || lang == LangEn {
+			tags = append(tags, tag{lang: lang, q: q})
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	if len(tags) == 0 || tags[0].q <= 0 {
+		return DefaultLang
+	}
+
+	return tags[0].lang
+}
@@ -0,0 +1,61 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessages_Complete(t *testing.T) {
+	for key, texts := range messages {
+		for _, lang := range []string{LangRu, LangKk, LangEn} {
+			assert.NotEmpty(t, texts[lang], "%s: %s", key, lang)
+		}
+	}
+}
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		lang string
+		want string
+	}{
+		{name: "code", err: fmt.Errorf("service.Get: %w", IDRequired), lang: LangEn, want: "ID is required"},
+		{name: "default lang", err: IDRequired, lang: "de", want: "Не указан идентификатор"},
+		{name: "msg", err: ErrFull{Err: ObjectNotFound, Msg: MsgKeyNotFound}, lang: LangKk, want: "Кілт табылмады"},
+		{name: "fields", err: ErrFull{Err: ObjectNotFound, Msg: MsgKeyByOrderNotFound, Fields: map[string]string{"orderID": "A-1"}}, lang: LangEn, want: "Key for order A-1 not found"},
+		{name: "full by code", err: ErrFull{Err: InvalidPhone}, lang: LangEn, want: "Customer phone number is invalid"},
+		{name: "desc", err: ErrFull{Err: ObjectNotFound, Desc: "mdm: product not found"}, lang: LangEn, want: "mdm: product not found"},
+		{name: "unknown code", err: Err("remote_code"), lang: LangEn, want: "remote_code"},
+		{name: "plain", err: errors.New("connection reset"), lang: LangEn, want: "connection reset"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Message(tt.err, tt.lang))
+		})
+	}
+}
+
+func TestParseLang(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "", want: LangRu},
+		{header: "kk", want: LangKk},
+		{header: "en-US,en;q=0.9", want: LangEn},
+		{header: "de-DE, kk;q=0.5, en;q=0.8", want: LangEn},
+		{header: "fr", want: LangRu},
+		{header: "en;q=0", want: LangRu},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseLang(tt.header))
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package dto
+
+import (
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/importjob/model"
+	e_product_v1 "github.com/mechta-market/e-product/pkg/proto/e_product"
+)
+
+func DecodeImportKeysHeader(v *e_product_v1.ImportKeysHeader) *model.ImportReq {
+	result := &model.ImportReq{
+		FileName: v.FileName,
+		Format:   mapProtoEnumToImportFormat(v.Format),
+		Mode:     DecodeLoadMode(v.Mode),
+	}
+
+	if v.Mapping != nil {
+		result.Mapping = model.Mapping{
+			ProductIDColumn:  v.Mapping.ProductIdColumn,
+			ValueColumn:      v.Mapping.ValueColumn,
+			HasHeader:        v.Mapping.HasHeader,
+			DefaultProductID: v.Mapping.DefaultProductId,
+			Sheet:            v.Mapping.Sheet,
+			Delimiter:        v.Mapping.Delimiter,
+		}
+	}
+
+	return result
+}
+
+func DecodeImportJobListReq(v *e_product_v1.ImportJobListReq) *model.ListReq {
+	result := &model.ListReq{
+		ListParams: DecodeListParams(v.ListParams),
+	}
+
+	if v.Status != nil {
+		result.Status = mapProtoEnumToImportJobStatus(*v.Status)
+	}
+
+	return result
+}
+
+func EncodeImportJob(v *model.Main, _ int) *e_product_v1.ImportJob {
+	if v == nil {
+		return nil
+	}
+
+	return &e_product_v1.ImportJob{
+		Id:             v.ID,
+		CreatedAt:      timestamppb.New(v.CreatedAt),
+		UpdatedAt:      timestamppb.New(v.UpdatedAt),
+		FileName:       v.FileName,
+		Format:         mapImportFormatToProtoEnum(v.Format),
+		Mode:           mapLoadModeToProtoEnum(v.Mode),
+		Status:         mapImportJobStatusToProtoEnum(v.Status),
+		TotalCount:     v.TotalCount,
+		CreatedCount:   v.CreatedCount,
+		DuplicateCount: v.DuplicateCount,
+		InvalidCount:   v.InvalidCount,
+		SkippedCount:   v.SkippedCount,
+		FailedCount:    v.FailedCount,
+		Error:          v.Error,
+		Items:          lo.Map(v.Items, EncodeImportJobItem),
+	}
+}
+
+func EncodeImportJobItem(v *model.Item, _ int) *e_product_v1.ImportJobItem {
+	return &e_product_v1.ImportJobItem{
+		Row:               int64(v.Row),
+		ProductId:         v.ProductID,
+		Result:            mapLoadResultToProtoEnum(v.Result),
+		Id:                v.ID,
+		ExistingProductId: v.ExistingProductID,
+		Reason:            v.Reason,
+	}
+}
+
+//
+
+func mapProtoEnumToImportFormat(format e_product_v1.ImportFormat) string {
+	switch format {
+	case e_product_v1.ImportFormat_csv:
+		return constant.ImportFormatCSV
+	case e_product_v1.ImportFormat_xlsx:
+		return constant.ImportFormatXLSX
+	default:
+		return ""
+	}
+}
+
+func mapImportFormatToProtoEnum(format string) e_product_v1.ImportFormat {
+	switch format {
+	case constant.ImportFormatCSV:
+		return e_product_v1.ImportFormat_csv
+	case constant.ImportFormatXLSX:
+		return e_product_v1.ImportFormat_xlsx
+	default:
+		return e_product_v1.ImportFormat_import_format_auto
+	}
+}
+
+func mapLoadModeToProtoEnum(mode string) e_product_v1.LoadMode {
+	if mode == constant.LoadModeAllOrNothing {
+		return e_product_v1.LoadMode_all_or_nothing
+	}
+
+	return e_product_v1.LoadMode_best_effort
+}
+
+func mapImportJobStatusToProtoEnum(status string) e_product_v1.ImportJobStatus {
+	switch status {
+	case constant.ImportJobStatusRunning:
+		return e_product_v1.ImportJobStatus_import_running
+	case constant.ImportJobStatusCompleted:
+		return e_product_v1.ImportJobStatus_import_completed
+	case constant.ImportJobStatusFailed:
+		return e_product_v1.ImportJobStatus_import_failed
+	default:
+		return e_product_v1.ImportJobStatus_import_running
+	}
+}
+
+func mapProtoEnumToImportJobStatus(status e_product_v1.ImportJobStatus) *string {
+	var s string
+
+	switch status {
+	case e_product_v1.ImportJobStatus_import_running:
+		s = constant.ImportJobStatusRunning
+	case e_product_v1.ImportJobStatus_import_completed:
+		s = constant.ImportJobStatusCompleted
+	case e_product_v1.ImportJobStatus_import_failed:
+		s = constant.ImportJobStatusFailed
+	default:
+		return nil
+	}
+
+	return &s
+}
@@ -5,6 +5,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/common/util"
 	"github.com/mechta-market/e-product/internal/domain/key/model"
 	providerModel "github.com/mechta-market/e-product/internal/service/provider/model"
 	e_product_v1 "github.com/mechta-market/e-product/pkg/proto/e_product"
@@ -12,9 +13,60 @@ import (
 
 func DecodeKeyListReq(v *e_product_v1.KeyListReq) *model.ListReq {
 	result := &model.ListReq{
-		ListParams: DecodeListParams(v.ListParams),
+		ListParams:    DecodeListParams(v.ListParams),
+		ProviderID:    v.ProviderId,
+		OrderID:       v.OrderId,
+		ProductID:     v.ProductId,
+		CustomerPhone: v.CustomerPhone,
+		Value:         v.Value,
+		ProductIDs:    v.ProductIds,
+	}
+
+	if v.Status != nil {
+		result.Status = mapProtoEnumToStatus(*v.Status)
+	}
+
+	for _, status := range v.Statuses {
+		if s := mapProtoEnumToStatus(status); s != nil {
+			result.Statuses = append(result.Statuses, *s)
+		}
+	}
+
+	if v.CreatedFrom != nil {
+		result.CreatedFrom = lo.ToPtr(v.CreatedFrom.AsTime())
+	}
+
+	if v.CreatedTo != nil {
+		result.CreatedTo = lo.ToPtr(v.CreatedTo.AsTime())
+	}
+
+	if v.UpdatedFrom != nil {
+		result.UpdatedFrom = lo.ToPtr(v.UpdatedFrom.AsTime())
+	}
+
+	if v.UpdatedTo != nil {
+		result.UpdatedTo = lo.ToPtr(v.UpdatedTo.AsTime())
+	}
+
+	return result
+}
+
+func DecodeKeyExportReq(v *e_product_v1.KeyExportReq) *model.ExportReq {
+	result := &model.ExportReq{
+		Format:      mapProtoEnumToExportFormat(v.Format),
+		RevealValue: v.RevealValue,
+	}
+
+	if v.Filter != nil {
+		result.ListReq = *DecodeKeyListReq(v.Filter)
+	}
+
+	return result
+}
+
+func DecodeKeyInventoryReq(v *e_product_v1.KeyInventoryReq) *model.ListReq {
+	result := &model.ListReq{
 		ProviderID: v.ProviderId,
-		OrderID:    v.OrderId,
 		ProductID:  v.ProductId,
 	}
 
@@ -34,6 +86,48 @@ func DecodeLoadKeyReq(v *e_product_v1.LoadKeyReq) []*model.Edit {
 	})
 }
 
+func DecodeLoadMode(v e_product_v1.LoadMode) string {
+	if v == e_product_v1.LoadMode_all_or_nothing {
+		return constant.LoadModeAllOrNothing
+	}
+
+	return constant.LoadModeBestEffort
+}
+
+func EncodeLoadKeyRep(v []*model.LoadResult) *e_product_v1.LoadKeyRep {
+	result := &e_product_v1.LoadKeyRep{
+		Items: lo.Map(v, EncodeLoadResult),
+	}
+
+	for _, item := range v {
+		switch item.Result {
+		case constant.LoadResultCreated:
+			result.CreatedCount++
+		case constant.LoadResultDuplicate:
+			result.DuplicateCount++
+		case constant.LoadResultInvalid:
+			result.InvalidCount++
+		case constant.LoadResultSkipped:
+			result.SkippedCount++
+		case constant.LoadResultFailed:
+			result.FailedCount++
+		}
+	}
+
+	return result
+}
+
+func EncodeLoadResult(v *model.LoadResult, _ int) *e_product_v1.LoadKeyItemRep {
+	return &e_product_v1.LoadKeyItemRep{
+		Index:             int64(v.Index),
+		ProductId:         v.ProductID,
+		Result:            mapLoadResultToProtoEnum(v.Result),
+		Id:                v.ID,
+		ExistingProductId: v.ExistingProductID,
+		Reason:            v.Reason,
+	}
+}
+
 func EncodeKeyMain(v *model.Main, _ int) *e_product_v1.KeyResponseItem {
 	if v == nil {
 		return nil
@@ -50,6 +144,47 @@ func EncodeKeyMain(v *model.Main, _ int) *e_product_v1.KeyResponseItem {
 		OrderId:           v.OrderID,
 		ProviderProductId: v.ProviderProductID,
 		ProviderOrderId:   v.ProviderOrderID,
+		MaskedValue:       util.MaskValue(v.Value),
+		Link:              v.Link,
+		Instructions:      v.Instructions,
+		LicenseTerm:       v.LicenseTerm,
+		Source:            mapSourceToProtoEnum(v.Source),
+	}
+}
+
+func EncodeKeyEvent(v *model.Event, _ int) *e_product_v1.KeyEvent {
+	if v == nil {
+		return nil
+	}
+
+	return &e_product_v1.KeyEvent{
+		Id:                    v.ID,
+		CreatedAt:             timestamppb.New(v.CreatedAt),
+		FromStatus:            mapStatusToProtoEnum(v.FromStatus),
+		ToStatus:              mapStatusToProtoEnum(v.ToStatus),
+		Actor:                 v.Actor,
+		OrderId:               v.OrderID,
+		ProviderTransactionId: v.ProviderTransactionID,
+		Reason:                v.Reason,
+	}
+}
+
+func EncodeKeyInventoryItem(v *model.InventoryItem, _ int) *e_product_v1.KeyInventoryItem {
+	if v == nil {
+		return nil
+	}
+
+	return &e_product_v1.KeyInventoryItem{
+		ProductId:       v.ProductID,
+		ProviderId:      v.ProviderID,
+		Status:          mapStatusToProtoEnum(v.Status),
+		Count:           v.Count,
+		AgeDay:          v.AgeDay,
+		AgeWeek:         v.AgeWeek,
+		AgeMonth:        v.AgeMonth,
+		AgeQuarter:      v.AgeQuarter,
+		AgeOlder:        v.AgeOlder,
+		OldestCreatedAt: timestamppb.New(v.OldestCreatedAt),
 	}
 }
 
@@ -66,13 +201,61 @@ func EncodeCatalogRep(v *providerModel.CatalogResponse, _ int) *e_product_v1.Cat
 	}
 }
 
-func EncodeActivateRep(v *string) *e_product_v1.KeyActivateRep {
+func EncodeActivateRep(v *model.Main) *e_product_v1.KeyActivateRep {
+	if v == nil {
+		return nil
+	}
+
+	return &e_product_v1.KeyActivateRep{
+		Value:           v.Value,
+		Link:            v.Link,
+		Instructions:    v.Instructions,
+		LicenseTerm:     v.LicenseTerm,
+		ProviderOrderId: v.ProviderOrderID,
+		Status:          e_product_v1.ActivationStatus_activation_completed,
+	}
+}
+
+func EncodeActivateAsyncRep(v *model.Activation) *e_product_v1.KeyActivateRep {
 	if v == nil {
 		return nil
 	}
 
 	return &e_product_v1.KeyActivateRep{
-		Value: *v,
+		ActivationId: v.ID,
+		Status:       mapActivationStatusToProtoEnum(v.Status),
+	}
+}
+
+func EncodeActivation(v *model.Activation, key *model.Main) *e_product_v1.KeyActivation {
+	if v == nil {
+		return nil
+	}
+
+	return &e_product_v1.KeyActivation{
+		Id:        v.ID,
+		CreatedAt: timestamppb.New(v.CreatedAt),
+		UpdatedAt: timestamppb.New(v.UpdatedAt),
+		Status:    mapActivationStatusToProtoEnum(v.Status),
+		ProductId: v.ProductID,
+		OrderId:   v.OrderID,
+		Error:     v.Error,
+		Key:       EncodeActivateRep(key),
+	}
+}
+
+func EncodeReservation(v *model.Reservation) *e_product_v1.KeyReservation {
+	if v == nil {
+		return nil
+	}
+
+	return &e_product_v1.KeyReservation{
+		Id:        v.ID,
+		ProductId: v.ProductID,
+		OrderId:   v.OrderID,
+		Status:    mapReservationStatusToProtoEnum(v.Status),
+		ExpiresAt: timestamppb.New(v.ExpiresAt),
+		FromPool:  v.KeyID != "",
 	}
 }
 
@@ -96,11 +279,27 @@ func mapStatusToProtoEnum(status string) e_product_v1.KeyStatus {
 		return e_product_v1.KeyStatus_activated
 	case constant.KeyStatusCancelled:
 		return e_product_v1.KeyStatus_cancelled
+	case constant.KeyStatusReserved:
+		return e_product_v1.KeyStatus_reserved
+	case constant.KeyStatusReturned:
+		return e_product_v1.KeyStatus_returned
+	case constant.KeyStatusExpired:
+		return e_product_v1.KeyStatus_expired
+	case constant.KeyStatusWithdrawn:
+		return e_product_v1.KeyStatus_withdrawn
 	default:
 		return e_product_v1.KeyStatus_new
 	}
 }
 
+func mapSourceToProtoEnum(source string) e_product_v1.KeySource {
+	if source == constant.KeySourceProvider {
+		return e_product_v1.KeySource_source_provider
+	}
+
+	return e_product_v1.KeySource_source_pool
+}
+
 func mapProtoEnumToStatus(status e_product_v1.KeyStatus) *string {
 	var s string
 
@@ -111,9 +310,71 @@ func mapProtoEnumToStatus(status e_product_v1.KeyStatus) *string {
 		s = constant.KeyStatusActivated
 	case e_product_v1.KeyStatus_cancelled:
 		s = constant.KeyStatusCancelled
+	case e_product_v1.KeyStatus_reserved:
+		s = constant.KeyStatusReserved
+	case e_product_v1.KeyStatus_returned:
+		s = constant.KeyStatusReturned
+	case e_product_v1.KeyStatus_expired:
+		s = constant.KeyStatusExpired
+	case e_product_v1.KeyStatus_withdrawn:
+		s = constant.KeyStatusWithdrawn
 	default:
 		return nil
 	}
 
 	return &s
 }
+
+func mapProtoEnumToExportFormat(format e_product_v1.ExportFormat) string {
+	switch format {
+	case e_product_v1.ExportFormat_export_csv:
+		return constant.ExportFormatCSV
+	case e_product_v1.ExportFormat_export_jsonl:
+		return constant.ExportFormatJSONL
+	default:
+		return ""
+	}
+}
+
+func mapReservationStatusToProtoEnum(status string) e_product_v1.ReservationStatus {
+	switch status {
+	case constant.ReservationStatusConfirmed:
+		return e_product_v1.ReservationStatus_reservation_confirmed
+	case constant.ReservationStatusReleased:
+		return e_product_v1.ReservationStatus_reservation_released
+	case constant.ReservationStatusExpired:
+		return e_product_v1.ReservationStatus_reservation_expired
+	default:
+		return e_product_v1.ReservationStatus_reservation_active
+	}
+}
+
+func mapActivationStatusToProtoEnum(status string) e_product_v1.ActivationStatus {
+	switch status {
+	case constant.ActivationStatusProcessing:
+		return e_product_v1.ActivationStatus_activation_processing
+	case constant.ActivationStatusCompleted:
+		return e_product_v1.ActivationStatus_activation_completed
+	case constant.ActivationStatusFailed:
+		return e_product_v1.ActivationStatus_activation_failed
+	default:
+		return e_product_v1.ActivationStatus_activation_pending
+	}
+}
+
+func mapLoadResultToProtoEnum(result string) e_product_v1.LoadItemResult {
+	switch result {
+	case constant.LoadResultCreated:
+		return e_product_v1.LoadItemResult_created
+	case constant.LoadResultDuplicate:
+		return e_product_v1.LoadItemResult_duplicate
+	case constant.LoadResultInvalid:
+		return e_product_v1.LoadItemResult_invalid
+	case constant.LoadResultSkipped:
+		return e_product_v1.LoadItemResult_skipped
+	case constant.LoadResultFailed:
+		return e_product_v1.LoadItemResult_failed
+	default:
+		return e_product_v1.LoadItemResult_failed
+	}
+}
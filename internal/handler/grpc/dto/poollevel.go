@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mechta-market/e-product/internal/domain/poollevel/model"
+	e_product_v1 "github.com/mechta-market/e-product/pkg/proto/e_product"
+)
+
+func DecodePoolLevelListReq(v *e_product_v1.PoolLevelListReq) *model.ListReq {
+	return &model.ListReq{
+		ListParams: DecodeListParams(v.ListParams),
+	}
+}
+
+func DecodePoolLevelSetReq(v *e_product_v1.PoolLevelSetReq) *model.Edit {
+	return &model.Edit{
+		ProductID:   v.ProductId,
+		MinLevel:    &v.MinLevel,
+		TargetLevel: &v.TargetLevel,
+		DailyCap:    &v.DailyCap,
+	}
+}
+
+func EncodePoolLevel(v *model.Main) *e_product_v1.PoolLevel {
+	if v == nil {
+		return nil
+	}
+
+	return &e_product_v1.PoolLevel{
+		ProductId:   v.ProductID,
+		CreatedAt:   timestamppb.New(v.CreatedAt),
+		UpdatedAt:   timestamppb.New(v.UpdatedAt),
+		MinLevel:    v.MinLevel,
+		TargetLevel: v.TargetLevel,
+		DailyCap:    v.DailyCap,
+	}
+}
+
+func EncodePoolState(v *model.State, _ int) *e_product_v1.PoolLevel {
+	result := EncodePoolLevel(v.Level)
+	result.Available = v.Available
+
+	return result
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mechta-market/e-product/internal/domain/productprovider/model"
+	e_product_v1 "github.com/mechta-market/e-product/pkg/proto/e_product"
+)
+
+func DecodeProductProvider(v *e_product_v1.ProductProvider, _ int) *model.Edit {
+	return &model.Edit{
+		ProviderID:                v.GetProviderId(),
+		ProviderProductID:         v.GetProviderProductId(),
+		ProviderExternalProductID: v.GetProviderExternalProductId(),
+		PromotionKey:              v.GetPromotionKey(),
+	}
+}
+
+func EncodeProductProvider(v *model.Main, _ int) *e_product_v1.ProductProvider {
+	if v == nil {
+		return nil
+	}
+
+	return &e_product_v1.ProductProvider{
+		ProviderId:                v.ProviderID,
+		Priority:                  v.Priority,
+		ProviderProductId:         v.ProviderProductID,
+		ProviderExternalProductId: v.ProviderExternalProductID,
+		PromotionKey:              v.PromotionKey,
+		UpdatedAt:                 timestamppb.New(v.UpdatedAt),
+	}
+}
+
+func EncodeProductProviderListRep(productID string, items []*model.Main) *e_product_v1.ProductProviderListRep {
+	return &e_product_v1.ProductProviderListRep{
+		ProductId: productID,
+		Providers: lo.Map(items, EncodeProductProvider),
+	}
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	providerModel "github.com/mechta-market/e-product/internal/service/provider/model"
+	e_product_v1 "github.com/mechta-market/e-product/pkg/proto/e_product"
+)
+
+func EncodeProviderBreaker(v *providerModel.BreakerState, _ int) *e_product_v1.ProviderBreaker {
+	if v == nil {
+		return nil
+	}
+
+	result := &e_product_v1.ProviderBreaker{
+		ProviderId: v.ProviderID,
+		State:      mapBreakerStateToProtoEnum(v.State),
+		Failures:   int64(v.Failures),
+	}
+
+	// у закрытого breaker времени открытия нет
+	if !v.OpenedAt.IsZero() {
+		result.OpenedAt = timestamppb.New(v.OpenedAt)
+		result.RetryAt = timestamppb.New(v.RetryAt)
+	}
+
+	return result
+}
+
+func mapBreakerStateToProtoEnum(state string) e_product_v1.ProviderBreakerState {
+	switch state {
+	case constant.BreakerStateOpen:
+		return e_product_v1.ProviderBreakerState_breaker_open
+	case constant.BreakerStateHalfOpen:
+		return e_product_v1.ProviderBreakerState_breaker_half_open
+	default:
+		return e_product_v1.ProviderBreakerState_breaker_closed
+	}
+}
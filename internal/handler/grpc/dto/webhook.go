@@ -0,0 +1,125 @@
+package dto
+
+import (
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/webhook/model"
+	e_product_v1 "github.com/mechta-market/e-product/pkg/proto/e_product"
+)
+
+func DecodeWebhookSubscriptionListReq(v *e_product_v1.WebhookSubscriptionListReq) *model.SubscriptionListReq {
+	return &model.SubscriptionListReq{
+		ListParams: DecodeListParams(v.ListParams),
+		Active:     v.Active,
+	}
+}
+
+func DecodeWebhookSubscriptionCreateReq(v *e_product_v1.WebhookSubscriptionCreateReq) *model.SubscriptionEdit {
+	return &model.SubscriptionEdit{
+		Name:   &v.Name,
+		URL:    &v.Url,
+		Events: &v.Events,
+		Active: v.Active,
+		Secret: &v.Secret,
+	}
+}
+
+func DecodeWebhookSubscriptionUpdateReq(v *e_product_v1.WebhookSubscriptionUpdateReq) *model.SubscriptionEdit {
+	result := &model.SubscriptionEdit{
+		ID:     &v.Id,
+		Name:   v.Name,
+		URL:    v.Url,
+		Active: v.Active,
+		Secret: &v.Secret,
+	}
+
+	if len(v.Events) > 0 {
+		result.Events = &v.Events
+	}
+
+	return result
+}
+
+// EncodeWebhookSubscription секрет не возвращается, кроме ответа на создание
+func EncodeWebhookSubscription(v *model.Subscription, _ int) *e_product_v1.WebhookSubscription {
+	if v == nil {
+		return nil
+	}
+
+	return &e_product_v1.WebhookSubscription{
+		Id:        v.ID,
+		CreatedAt: timestamppb.New(v.CreatedAt),
+		UpdatedAt: timestamppb.New(v.UpdatedAt),
+		Name:      v.Name,
+		Url:       v.URL,
+		Events:    v.Events,
+		Active:    v.Active,
+	}
+}
+
+func DecodeWebhookDeliveryListReq(v *e_product_v1.WebhookDeliveryListReq) *model.DeliveryListReq {
+	result := &model.DeliveryListReq{
+		ListParams:     DecodeListParams(v.ListParams),
+		SubscriptionID: v.SubscriptionId,
+		Event:          v.Event,
+	}
+
+	if v.Status != nil {
+		result.Status = lo.ToPtr(mapProtoEnumToDeliveryStatus(*v.Status))
+	}
+
+	return result
+}
+
+func EncodeWebhookDelivery(v *model.Delivery, _ int) *e_product_v1.WebhookDelivery {
+	if v == nil {
+		return nil
+	}
+
+	result := &e_product_v1.WebhookDelivery{
+		Id:             v.ID,
+		CreatedAt:      timestamppb.New(v.CreatedAt),
+		UpdatedAt:      timestamppb.New(v.UpdatedAt),
+		SubscriptionId: v.SubscriptionID,
+		Event:          v.Event,
+		Payload:        v.Payload,
+		Status:         mapDeliveryStatusToProtoEnum(v.Status),
+		Attempts:       v.Attempts,
+		LastError:      v.LastError,
+		ResponseStatus: v.ResponseStatus,
+	}
+
+	if v.Status == constant.WebhookDeliveryStatusPending {
+		result.NextAttemptAt = timestamppb.New(v.NextAttemptAt)
+	}
+
+	if v.DeliveredAt != nil {
+		result.DeliveredAt = timestamppb.New(*v.DeliveredAt)
+	}
+
+	return result
+}
+
+func mapDeliveryStatusToProtoEnum(status string) e_product_v1.WebhookDeliveryStatus {
+	switch status {
+	case constant.WebhookDeliveryStatusDelivered:
+		return e_product_v1.WebhookDeliveryStatus_delivery_delivered
+	case constant.WebhookDeliveryStatusDead:
+		return e_product_v1.WebhookDeliveryStatus_delivery_dead
+	default:
+		return e_product_v1.WebhookDeliveryStatus_delivery_pending
+	}
+}
+
+func mapProtoEnumToDeliveryStatus(status e_product_v1.WebhookDeliveryStatus) string {
+	switch status {
+	case e_product_v1.WebhookDeliveryStatus_delivery_delivered:
+		return constant.WebhookDeliveryStatusDelivered
+	case e_product_v1.WebhookDeliveryStatus_delivery_dead:
+		return constant.WebhookDeliveryStatusDead
+	default:
+		return constant.WebhookDeliveryStatusPending
+	}
+}
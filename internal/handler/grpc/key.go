@@ -1,10 +1,14 @@
 package grpc
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"github.com/samber/lo"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"io"
 
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/errs"
 	"github.com/mechta-market/e-product/internal/handler/grpc/dto"
 	keyUsecase "github.com/mechta-market/e-product/internal/usecase/key"
 	"github.com/mechta-market/e-product/pkg/proto/common"
@@ -22,15 +26,92 @@ func NewKey(keyUsecase *keyUsecase.Usecase) *Key {
 	}
 }
 
-func (h *Key) Load(ctx context.Context, req *e_product_v1.LoadKeyReq) (*emptypb.Empty, error) {
+func (h *Key) Load(ctx context.Context, req *e_product_v1.LoadKeyReq) (*e_product_v1.LoadKeyRep, error) {
 	loadReq := dto.DecodeLoadKeyReq(req)
 
-	err := h.keyUsecase.Load(ctx, loadReq)
+	result, err := h.keyUsecase.Load(ctx, loadReq, dto.DecodeLoadMode(req.Mode))
 	if err != nil {
 		return nil, err
 	}
 
-	return &emptypb.Empty{}, nil
+	return dto.EncodeLoadKeyRep(result), nil
+}
+
+// ImportKeys ждет header первым сообщением, затем передает части файла в Import через pipe:
+// файл разбирается по мере получения и целиком в памяти не собирается
+func (h *Key) ImportKeys(stream e_product_v1.Key_ImportKeysServer) error {
+	req, err := stream.Recv()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	header := req.GetHeader()
+	if header == nil {
+		return errs.ErrFull{
+			Err: errs.EmptyData,
+			Msg: errs.MsgHeaderRequired,
+		}
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(receiveImportChunks(stream, pw))
+	}()
+	// Import может вернуться, не дочитав файл: закрытие снимает получение частей с записи в pipe
+	defer pr.Close()
+
+	result, err := h.keyUsecase.Import(stream.Context(), dto.DecodeImportKeysHeader(header), pr)
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(dto.EncodeImportJob(result, 0))
+}
+
+// receiveImportChunks пишет части файла из потока в w до конца потока
+func receiveImportChunks(stream e_product_v1.Key_ImportKeysServer, w io.Writer) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, err = w.Write(req.GetChunk()); err != nil {
+			return err
+		}
+	}
+}
+
+func (h *Key) GetImportJob(ctx context.Context, req *e_product_v1.ImportJobGetReq) (*e_product_v1.ImportJob, error) {
+	result, err := h.keyUsecase.GetImportJob(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.EncodeImportJob(result, 0), nil
+}
+
+func (h *Key) ListImportJobs(ctx context.Context, req *e_product_v1.ImportJobListReq) (*e_product_v1.ImportJobListRep, error) {
+	if req.ListParams == nil {
+		req.ListParams = &common.ListParamsSt{}
+	}
+
+	items, tCount, err := h.keyUsecase.ListImportJobs(ctx, dto.DecodeImportJobListReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &e_product_v1.ImportJobListRep{
+		PaginationInfo: &common.PaginationInfoSt{
+			Page:       req.ListParams.Page,
+			PageSize:   req.ListParams.PageSize,
+			TotalCount: tCount,
+		},
+		Jobs: lo.Map(items, dto.EncodeImportJob),
+	}, nil
 }
 
 func (h *Key) List(ctx context.Context, req *e_product_v1.KeyListReq) (*e_product_v1.KeyListRep, error) {
@@ -38,6 +119,21 @@ func (h *Key) List(ctx context.Context, req *e_product_v1.KeyListReq) (*e_produc
 		req.ListParams = &common.ListParamsSt{}
 	}
 
+	if req.Cursor != nil {
+		items, nextCursor, err := h.keyUsecase.ListAfter(ctx, dto.DecodeKeyListReq(req), *req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		return &e_product_v1.KeyListRep{
+			PaginationInfo: &common.PaginationInfoSt{
+				PageSize: req.ListParams.PageSize,
+			},
+			Keys:       lo.Map(items, dto.EncodeKeyMain),
+			NextCursor: nextCursor,
+		}, nil
+	}
+
 	items, tCount, err := h.keyUsecase.List(ctx, dto.DecodeKeyListReq(req))
 	if err != nil {
 		return nil, err
@@ -62,7 +158,94 @@ func (h *Key) Get(ctx context.Context, req *e_product_v1.KeyGetReq) (*e_product_
 	return dto.EncodeKeyMain(result, 0), nil
 }
 
+func (h *Key) History(ctx context.Context, req *e_product_v1.KeyHistoryReq) (*e_product_v1.KeyHistoryRep, error) {
+	items, err := h.keyUsecase.History(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &e_product_v1.KeyHistoryRep{
+		Events: lo.Map(items, dto.EncodeKeyEvent),
+	}, nil
+}
+
+func (h *Key) RevealValue(ctx context.Context, req *e_product_v1.KeyRevealValueReq) (*e_product_v1.KeyRevealValueRep, error) {
+	value, err := h.keyUsecase.RevealValue(ctx, req.Id, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &e_product_v1.KeyRevealValueRep{
+		Value: value,
+	}, nil
+}
+
+func (h *Key) Export(req *e_product_v1.KeyExportReq, stream e_product_v1.Key_ExportServer) error {
+	w := bufio.NewWriterSize(&exportStreamWriter{stream: stream}, constant.ExportChunkSize)
+
+	err := h.keyUsecase.Export(stream.Context(), dto.DecodeKeyExportReq(req), w)
+	if err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+// exportStreamWriter отправляет записанные данные частью потока Key.Export
+type exportStreamWriter struct {
+	stream e_product_v1.Key_ExportServer
+}
+
+func (w *exportStreamWriter) Write(p []byte) (int, error) {
+	err := w.stream.Send(&e_product_v1.KeyExportChunk{Data: p})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (h *Key) InventoryReport(ctx context.Context, req *e_product_v1.KeyInventoryReq) (*e_product_v1.KeyInventoryRep, error) {
+	items, err := h.keyUsecase.InventoryReport(ctx, dto.DecodeKeyInventoryReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &e_product_v1.KeyInventoryRep{
+		Items: lo.Map(items, dto.EncodeKeyInventoryItem),
+	}, nil
+}
+
+func (h *Key) ListByCustomer(ctx context.Context, req *e_product_v1.KeyListByCustomerReq) (*e_product_v1.KeyListByCustomerRep, error) {
+	if req.ListParams == nil {
+		req.ListParams = &common.ListParamsSt{}
+	}
+
+	items, tCount, err := h.keyUsecase.ListByCustomer(ctx, req.CustomerPhone, dto.DecodeListParams(req.ListParams))
+	if err != nil {
+		return nil, err
+	}
+
+	return &e_product_v1.KeyListByCustomerRep{
+		PaginationInfo: &common.PaginationInfoSt{
+			Page:       req.ListParams.Page,
+			PageSize:   req.ListParams.PageSize,
+			TotalCount: tCount,
+		},
+		Keys: lo.Map(items, dto.EncodeKeyMain),
+	}, nil
+}
+
 func (h *Key) Activate(ctx context.Context, req *e_product_v1.KeyActivateReq) (*e_product_v1.KeyActivateRep, error) {
+	if req.Async {
+		activation, err := h.keyUsecase.ActivateAsync(ctx, req.ProductId, req.OrderId, req.CustomerPhone)
+		if err != nil {
+			return nil, err
+		}
+
+		return dto.EncodeActivateAsyncRep(activation), nil
+	}
+
 	result, err := h.keyUsecase.Activate(ctx, req.ProductId, req.OrderId, req.CustomerPhone)
 	if err != nil {
 		return nil, err
@@ -71,8 +254,82 @@ func (h *Key) Activate(ctx context.Context, req *e_product_v1.KeyActivateReq) (*
 	return dto.EncodeActivateRep(result), nil
 }
 
+func (h *Key) GetActivation(ctx context.Context, req *e_product_v1.KeyActivationGetReq) (*e_product_v1.KeyActivation, error) {
+	activation, key, err := h.keyUsecase.GetActivation(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.EncodeActivation(activation, key), nil
+}
+
+func (h *Key) Reserve(ctx context.Context, req *e_product_v1.KeyReserveReq) (*e_product_v1.KeyReservation, error) {
+	result, err := h.keyUsecase.Reserve(ctx, req.ProductId, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.EncodeReservation(result), nil
+}
+
+func (h *Key) Confirm(ctx context.Context, req *e_product_v1.KeyConfirmReq) (*e_product_v1.KeyActivateRep, error) {
+	result, err := h.keyUsecase.Confirm(ctx, req.ReservationId, req.CustomerPhone)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.EncodeActivateRep(result), nil
+}
+
+func (h *Key) Release(ctx context.Context, req *e_product_v1.KeyReleaseReq) (*e_product_v1.KeyReleaseRep, error) {
+	err := h.keyUsecase.Release(ctx, req.ReservationId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &e_product_v1.KeyReleaseRep{}, nil
+}
+
+func (h *Key) ListPoolLevels(ctx context.Context, req *e_product_v1.PoolLevelListReq) (*e_product_v1.PoolLevelListRep, error) {
+	if req.ListParams == nil {
+		req.ListParams = &common.ListParamsSt{}
+	}
+
+	items, tCount, err := h.keyUsecase.ListPoolLevels(ctx, dto.DecodePoolLevelListReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &e_product_v1.PoolLevelListRep{
+		PaginationInfo: &common.PaginationInfoSt{
+			Page:       req.ListParams.Page,
+			PageSize:   req.ListParams.PageSize,
+			TotalCount: tCount,
+		},
+		Levels: lo.Map(items, dto.EncodePoolState),
+	}, nil
+}
+
+func (h *Key) SetPoolLevel(ctx context.Context, req *e_product_v1.PoolLevelSetReq) (*e_product_v1.PoolLevel, error) {
+	result, err := h.keyUsecase.SetPoolLevel(ctx, dto.DecodePoolLevelSetReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.EncodePoolLevel(result), nil
+}
+
+func (h *Key) DeletePoolLevel(ctx context.Context, req *e_product_v1.PoolLevelDeleteReq) (*e_product_v1.PoolLevelDeleteRep, error) {
+	err := h.keyUsecase.DeletePoolLevel(ctx, req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &e_product_v1.PoolLevelDeleteRep{}, nil
+}
+
 func (h *Key) Cancel(ctx context.Context, req *e_product_v1.KeyCancelReq) (*e_product_v1.KeyCancelRep, error) {
-	result, err := h.keyUsecase.Cancel(ctx, req.OrderId)
+	result, err := h.keyUsecase.Cancel(ctx, req.OrderId, req.ProductId, req.Reason)
 	if err != nil {
 		return nil, err
 	}
@@ -80,6 +337,24 @@ func (h *Key) Cancel(ctx context.Context, req *e_product_v1.KeyCancelReq) (*e_pr
 	return dto.EncodeCancelRep(result), nil
 }
 
+func (h *Key) ListProductProviders(ctx context.Context, req *e_product_v1.ProductProviderListReq) (*e_product_v1.ProductProviderListRep, error) {
+	result, err := h.keyUsecase.ListProductProviders(ctx, req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.EncodeProductProviderListRep(req.ProductId, result), nil
+}
+
+func (h *Key) SetProductProviders(ctx context.Context, req *e_product_v1.ProductProviderSetReq) (*e_product_v1.ProductProviderListRep, error) {
+	result, err := h.keyUsecase.SetProductProviders(ctx, req.ProductId, lo.Map(req.Providers, dto.DecodeProductProvider))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.EncodeProductProviderListRep(req.ProductId, result), nil
+}
+
 func (h *Key) Catalog(ctx context.Context, req *e_product_v1.GetCatalogReq) (*e_product_v1.GetCatalogRep, error) {
 	result, err := h.keyUsecase.GetCatalog(ctx, req.ProviderId)
 	if err != nil {
@@ -90,3 +365,20 @@ func (h *Key) Catalog(ctx context.Context, req *e_product_v1.GetCatalogReq) (*e_
 		Items: lo.Map(result, dto.EncodeCatalogRep),
 	}, nil
 }
+
+func (h *Key) ListProviderBreakers(ctx context.Context, _ *e_product_v1.ProviderBreakerListReq) (*e_product_v1.ProviderBreakerListRep, error) {
+	result := h.keyUsecase.ListProviderBreakers(ctx)
+
+	return &e_product_v1.ProviderBreakerListRep{
+		Breakers: lo.Map(result, dto.EncodeProviderBreaker),
+	}, nil
+}
+
+func (h *Key) ResetProviderBreaker(ctx context.Context, req *e_product_v1.ProviderBreakerResetReq) (*e_product_v1.ProviderBreaker, error) {
+	result, err := h.keyUsecase.ResetProviderBreaker(ctx, req.ProviderId)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.EncodeProviderBreaker(result, 0), nil
+}
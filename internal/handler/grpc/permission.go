@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"github.com/mechta-market/e-product/internal/constant"
+	e_product_v1 "github.com/mechta-market/e-product/pkg/proto/e_product"
+)
+
+// KeyPermissions роли, которым доступны методы Key. Роль admin доступна на все методы,
+// методы без записи - только admin
+var KeyPermissions = map[string][]string{
+	e_product_v1.Key_Load_FullMethodName:           {constant.RoleLoader},
+	e_product_v1.Key_ImportKeys_FullMethodName:     {constant.RoleLoader},
+	e_product_v1.Key_GetImportJob_FullMethodName:   {constant.RoleLoader, constant.RoleSupport},
+	e_product_v1.Key_ListImportJobs_FullMethodName: {constant.RoleLoader, constant.RoleSupport},
+
+	e_product_v1.Key_List_FullMethodName:            {constant.RoleSupport},
+	e_product_v1.Key_Get_FullMethodName:             {constant.RoleSupport},
+	e_product_v1.Key_History_FullMethodName:         {constant.RoleSupport},
+	e_product_v1.Key_RevealValue_FullMethodName:     {constant.RoleSupport},
+	e_product_v1.Key_Export_FullMethodName:          {constant.RoleSupport},
+	e_product_v1.Key_InventoryReport_FullMethodName: {constant.RoleSupport},
+	e_product_v1.Key_ListByCustomer_FullMethodName:  {constant.RoleSupport},
+	e_product_v1.Key_ListPoolLevels_FullMethodName:  {constant.RoleSupport},
+
+	e_product_v1.Key_Activate_FullMethodName:      {constant.RoleStorefront},
+	e_product_v1.Key_GetActivation_FullMethodName: {constant.RoleStorefront, constant.RoleSupport},
+	e_product_v1.Key_Reserve_FullMethodName:       {constant.RoleStorefront},
+	e_product_v1.Key_Confirm_FullMethodName:       {constant.RoleStorefront},
+	e_product_v1.Key_Release_FullMethodName:       {constant.RoleStorefront},
+	e_product_v1.Key_Cancel_FullMethodName:        {constant.RoleStorefront, constant.RoleSupport},
+
+	e_product_v1.Key_Catalog_FullMethodName: {constant.RoleLoader, constant.RoleStorefront, constant.RoleSupport},
+
+	e_product_v1.Key_SetPoolLevel_FullMethodName:    {},
+	e_product_v1.Key_DeletePoolLevel_FullMethodName: {},
+
+	e_product_v1.Key_ListProviderBreakers_FullMethodName: {constant.RoleSupport},
+	e_product_v1.Key_ResetProviderBreaker_FullMethodName: {},
+
+	e_product_v1.Key_ListProductProviders_FullMethodName: {constant.RoleSupport},
+	e_product_v1.Key_SetProductProviders_FullMethodName:  {},
+}
+
+// WebhookPermissions роли, которым доступны методы Webhook. Подписки меняет только admin
+var WebhookPermissions = map[string][]string{
+	e_product_v1.Webhook_ListSubscriptions_FullMethodName:  {},
+	e_product_v1.Webhook_CreateSubscription_FullMethodName: {},
+	e_product_v1.Webhook_UpdateSubscription_FullMethodName: {},
+	e_product_v1.Webhook_DeleteSubscription_FullMethodName: {},
+
+	e_product_v1.Webhook_ListDeliveries_FullMethodName: {constant.RoleSupport},
+	e_product_v1.Webhook_Replay_FullMethodName:         {},
+}
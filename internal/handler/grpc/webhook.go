@@ -0,0 +1,104 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+
+	"github.com/mechta-market/e-product/internal/handler/grpc/dto"
+	webhookUsecase "github.com/mechta-market/e-product/internal/usecase/webhook"
+	"github.com/mechta-market/e-product/pkg/proto/common"
+	e_product_v1 "github.com/mechta-market/e-product/pkg/proto/e_product"
+)
+
+type Webhook struct {
+	e_product_v1.UnsafeWebhookServer
+	webhookUsecase *webhookUsecase.Usecase
+}
+
+func NewWebhook(webhookUsecase *webhookUsecase.Usecase) *Webhook {
+	return &Webhook{
+		webhookUsecase: webhookUsecase,
+	}
+}
+
+func (h *Webhook) ListSubscriptions(ctx context.Context, req *e_product_v1.WebhookSubscriptionListReq) (*e_product_v1.WebhookSubscriptionListRep, error) {
+	if req.ListParams == nil {
+		req.ListParams = &common.ListParamsSt{}
+	}
+
+	items, tCount, err := h.webhookUsecase.ListSubscriptions(ctx, dto.DecodeWebhookSubscriptionListReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &e_product_v1.WebhookSubscriptionListRep{
+		PaginationInfo: &common.PaginationInfoSt{
+			Page:       req.ListParams.Page,
+			PageSize:   req.ListParams.PageSize,
+			TotalCount: tCount,
+		},
+		Subscriptions: lo.Map(items, dto.EncodeWebhookSubscription),
+	}, nil
+}
+
+func (h *Webhook) CreateSubscription(ctx context.Context, req *e_product_v1.WebhookSubscriptionCreateReq) (*e_product_v1.WebhookSubscription, error) {
+	obj := dto.DecodeWebhookSubscriptionCreateReq(req)
+
+	result, err := h.webhookUsecase.CreateSubscription(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	rep := dto.EncodeWebhookSubscription(result, 0)
+	rep.Secret = lo.FromPtr(obj.Secret)
+
+	return rep, nil
+}
+
+func (h *Webhook) UpdateSubscription(ctx context.Context, req *e_product_v1.WebhookSubscriptionUpdateReq) (*e_product_v1.WebhookSubscription, error) {
+	result, err := h.webhookUsecase.UpdateSubscription(ctx, dto.DecodeWebhookSubscriptionUpdateReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.EncodeWebhookSubscription(result, 0), nil
+}
+
+func (h *Webhook) DeleteSubscription(ctx context.Context, req *e_product_v1.WebhookSubscriptionDeleteReq) (*e_product_v1.WebhookSubscriptionDeleteRep, error) {
+	err := h.webhookUsecase.DeleteSubscription(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &e_product_v1.WebhookSubscriptionDeleteRep{}, nil
+}
+
+func (h *Webhook) ListDeliveries(ctx context.Context, req *e_product_v1.WebhookDeliveryListReq) (*e_product_v1.WebhookDeliveryListRep, error) {
+	if req.ListParams == nil {
+		req.ListParams = &common.ListParamsSt{}
+	}
+
+	items, tCount, err := h.webhookUsecase.ListDeliveries(ctx, dto.DecodeWebhookDeliveryListReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &e_product_v1.WebhookDeliveryListRep{
+		PaginationInfo: &common.PaginationInfoSt{
+			Page:       req.ListParams.Page,
+			PageSize:   req.ListParams.PageSize,
+			TotalCount: tCount,
+		},
+		Deliveries: lo.Map(items, dto.EncodeWebhookDelivery),
+	}, nil
+}
+
+func (h *Webhook) Replay(ctx context.Context, req *e_product_v1.WebhookReplayReq) (*e_product_v1.WebhookDelivery, error) {
+	result, err := h.webhookUsecase.Replay(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.EncodeWebhookDelivery(result, 0), nil
+}
@@ -0,0 +1,222 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/grpc/status"
+
+	"github.com/mechta-market/e-product/internal/errs"
+	"github.com/mechta-market/e-product/pkg/proto/common"
+	e_product_v1 "github.com/mechta-market/e-product/pkg/proto/e_product"
+)
+
+const importChunkSize = 64 * 1024
+
+type Key struct {
+	mux    *runtime.ServeMux
+	client e_product_v1.KeyClient
+}
+
+func NewKey(mux *runtime.ServeMux, client e_product_v1.KeyClient) *Key {
+	return &Key{
+		mux:    mux,
+		client: client,
+	}
+}
+
+// Import принимает файл в multipart/form-data (поле file) и по частям передает его в Key.ImportKeys.
+// Поля ImportKeysHeader задаются query-параметрами: ?mapping.value_column=B&mapping.has_header=true&mode=all_or_nothing
+func (h *Key) Import(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	_, outboundMarshaler := runtime.MarshalerForRequest(h.mux, r)
+	lang := errs.ParseLang(r.Header.Get("Accept-Language"))
+
+	ctx, err := runtime.AnnotateContext(r.Context(), h.mux, r, e_product_v1.Key_ImportKeys_FullMethodName,
+		runtime.WithHTTPPathPattern("/key/import"))
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, err)
+		return
+	}
+
+	header := &e_product_v1.ImportKeysHeader{}
+	err = runtime.PopulateQueryParameters(header, r.URL.Query(), utilities.NewDoubleArray(nil))
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, errorStatus(lang, errs.InvalidFile, err.Error()))
+		return
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, errorStatus(lang, errs.InvalidFile, err.Error()))
+		return
+	}
+
+	stream, err := h.client.ImportKeys(ctx)
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, err)
+		return
+	}
+
+	file, err := nextFilePart(reader)
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, errorStatus(lang, errs.InvalidFile, err.Error()))
+		return
+	}
+
+	// без поля file отправляется только header, пустой файл отклонит ImportKeys
+	var fileReader io.Reader = http.NoBody
+	if file != nil {
+		if header.FileName == "" {
+			header.FileName = file.FileName()
+		}
+		fileReader = file
+	}
+
+	err = h.sendFile(stream, header, fileReader, lang)
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, err)
+		return
+	}
+
+	rep, err := stream.CloseAndRecv()
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, err)
+		return
+	}
+
+	runtime.ForwardResponseMessage(ctx, h.mux, outboundMarshaler, w, r, rep)
+}
+
+// Export отдает выгрузку Key.Export файлом. Поля KeyExportReq задаются query-параметрами:
+// ?format=export_jsonl&reveal_value=true&filter.status=activated&filter.updated_from=2026-01-01T00:00:00Z
+func (h *Key) Export(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	_, outboundMarshaler := runtime.MarshalerForRequest(h.mux, r)
+	lang := errs.ParseLang(r.Header.Get("Accept-Language"))
+
+	ctx, err := runtime.AnnotateContext(r.Context(), h.mux, r, e_product_v1.Key_Export_FullMethodName,
+		runtime.WithHTTPPathPattern("/key/export"))
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, err)
+		return
+	}
+
+	req := &e_product_v1.KeyExportReq{}
+	err = runtime.PopulateQueryParameters(req, r.URL.Query(), utilities.NewDoubleArray(nil))
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, errorStatus(lang, errs.InvalidExportFormat, err.Error()))
+		return
+	}
+
+	stream, err := h.client.Export(ctx, req)
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, err)
+		return
+	}
+
+	// ошибки валидации приходят до первой части, пока ответ еще можно отдать в обычном формате
+	chunk, err := stream.Recv()
+	if err != nil && !errors.Is(err, io.EOF) {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, err)
+		return
+	}
+
+	contentType, fileName := "text/csv; charset=utf-8", "keys.csv"
+	if req.Format == e_product_v1.ExportFormat_export_jsonl {
+		contentType, fileName = "application/x-ndjson", "keys.jsonl"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+fileName+`"`)
+	w.WriteHeader(http.StatusOK)
+
+	for chunk != nil {
+		if _, err = w.Write(chunk.Data); err != nil {
+			slog.Warn("export: write response", "error", err)
+			return
+		}
+
+		chunk, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			// заголовки уже отправлены, клиент получит обрезанный файл
+			slog.Error("export: stream.Recv", "error", err)
+			return
+		}
+	}
+}
+
+func nextFilePart(reader *multipart.Reader) (*multipart.Part, error) {
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if part.FormName() == "file" {
+			return part, nil
+		}
+	}
+}
+
+// sendFile отправляет header и содержимое файла. io.EOF от Send означает, что сервер
+// уже завершил поток, причину вернет CloseAndRecv
+func (h *Key) sendFile(stream e_product_v1.Key_ImportKeysClient, header *e_product_v1.ImportKeysHeader, file io.Reader, lang string) error {
+	err := stream.Send(&e_product_v1.ImportKeysReq{
+		Payload: &e_product_v1.ImportKeysReq_Header{Header: header},
+	})
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return err
+	}
+
+	buf := make([]byte, importChunkSize)
+	for {
+		n, err := file.Read(buf)
+		if n > 0 {
+			sendErr := stream.Send(&e_product_v1.ImportKeysReq{
+				Payload: &e_product_v1.ImportKeysReq_Chunk{Chunk: buf[:n]},
+			})
+			if sendErr != nil {
+				if errors.Is(sendErr, io.EOF) {
+					return nil
+				}
+				return sendErr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return errorStatus(lang, errs.InvalidFile, err.Error())
+		}
+	}
+}
+
+// errorStatus ошибка в формате GrpcInterceptorError, чтобы ответ не отличался от остальных методов.
+// detail - текст исходной ошибки, передается в fields.error
+func errorStatus(lang string, code errs.Err, detail string) error {
+	st, err := status.New(errs.GrpcCode(code), detail).WithDetails(&common.ErrorRep{
+		Code:    code.Error(),
+		Message: errs.Message(code, lang),
+		Fields: map[string]string{
+			"error": detail,
+		},
+	})
+	if err != nil {
+		return status.Error(errs.GrpcCode(code), detail)
+	}
+
+	return st.Err()
+}
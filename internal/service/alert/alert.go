@@ -0,0 +1,41 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mechta-market/e-product/internal/service/alert/model"
+)
+
+type Service struct {
+	repo RepoI
+}
+
+// New создает сервис оповещений. Если repo == nil, оповещения только пишутся в лог
+func New(repo RepoI) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+func (s *Service) PoolAlert(ctx context.Context, obj *model.PoolAlert) error {
+	slog.Warn("pool is below min level",
+		"product_id", obj.ProductID,
+		"available", obj.Available,
+		"min_level", obj.MinLevel,
+		"cap_reached", obj.CapReached,
+		"error", obj.Error,
+	)
+
+	if s.repo == nil {
+		return nil
+	}
+
+	err := s.repo.SendPoolAlert(ctx, obj)
+	if err != nil {
+		return fmt.Errorf("repo.SendPoolAlert: %w", err)
+	}
+
+	return nil
+}
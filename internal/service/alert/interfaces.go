@@ -0,0 +1,11 @@
+package alert
+
+import (
+	"context"
+
+	"github.com/mechta-market/e-product/internal/service/alert/model"
+)
+
+type RepoI interface {
+	SendPoolAlert(ctx context.Context, obj *model.PoolAlert) error
+}
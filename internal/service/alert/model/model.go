@@ -0,0 +1,13 @@
+package model
+
+// PoolAlert пул продукта не удалось пополнить до минимального уровня
+type PoolAlert struct {
+	ProductID   string `json:"product_id"`
+	MinLevel    int64  `json:"min_level"`
+	TargetLevel int64  `json:"target_level"`
+	Available   int64  `json:"available"`
+	Purchased   int64  `json:"purchased"`
+	Failed      int64  `json:"failed"`
+	CapReached  bool   `json:"cap_reached"`
+	Error       string `json:"error,omitempty"`
+}
@@ -0,0 +1,62 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/mechta-market/e-product/internal/service/alert/model"
+)
+
+// Repo отправляет оповещения POST-запросом с JSON на webhook
+type Repo struct {
+	uri string
+
+	client *http.Client
+}
+
+func New(uri string) *Repo {
+	return &Repo{
+		uri: uri,
+
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+func (r *Repo) SendPoolAlert(ctx context.Context, obj *model.PoolAlert) error {
+	jsonData, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("fail to marshal obj: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.uri, bytes.NewReader(jsonData))
+	if err != nil {
+		return fmt.Errorf("http.NewRequest: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("httpClient.Do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	repBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("bad response status: %s, uri: %s, respBody: %q", resp.Status, r.uri, string(repBody))
+	}
+
+	return nil
+}
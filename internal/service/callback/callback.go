@@ -0,0 +1,41 @@
+package callback
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mechta-market/e-product/internal/service/callback/model"
+)
+
+type Service struct {
+	repo RepoI
+}
+
+// New создает сервис callback-уведомлений. Если repo == nil, результат только пишется в лог
+func New(repo RepoI) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+func (s *Service) ActivationCallback(ctx context.Context, obj *model.ActivationCallback) error {
+	slog.Info("activation finished",
+		"activation_id", obj.ActivationID,
+		"status", obj.Status,
+		"order_id", obj.OrderID,
+		"product_id", obj.ProductID,
+		"error", obj.Error,
+	)
+
+	if s.repo == nil {
+		return nil
+	}
+
+	err := s.repo.SendActivation(ctx, obj)
+	if err != nil {
+		return fmt.Errorf("repo.SendActivation: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,11 @@
+package callback
+
+import (
+	"context"
+
+	"github.com/mechta-market/e-product/internal/service/callback/model"
+)
+
+type RepoI interface {
+	SendActivation(ctx context.Context, obj *model.ActivationCallback) error
+}
@@ -0,0 +1,16 @@
+package model
+
+// ActivationCallback результат асинхронной выдачи ключа. Value и детали активации заполнены для completed,
+// Error - для failed
+type ActivationCallback struct {
+	ActivationID    string `json:"activation_id"`
+	Status          string `json:"status"`
+	OrderID         string `json:"order_id"`
+	ProductID       string `json:"product_id"`
+	Value           string `json:"value,omitempty"`
+	Link            string `json:"link,omitempty"`
+	Instructions    string `json:"instructions,omitempty"`
+	LicenseTerm     string `json:"license_term,omitempty"`
+	ProviderOrderID string `json:"provider_order_id,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
@@ -0,0 +1,87 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/common/util"
+	"github.com/mechta-market/e-product/internal/service/callback/model"
+)
+
+// Repo отправляет callback POST-запросом с JSON. Тело подписывается HMAC-SHA256 по строке
+// "<timestamp>.<body>": подпись в X-Signature ("sha256=<hex>"), unix-время в X-Signature-Timestamp
+type Repo struct {
+	uri    string
+	secret []byte
+
+	client *http.Client
+	now    func() time.Time
+}
+
+// New создает отправку callback, секрет подписи читается из secretFile
+func New(uri, secretFile string) (*Repo, error) {
+	data, err := os.ReadFile(secretFile)
+	if err != nil {
+		return nil, fmt.Errorf("read secret file: %w", err)
+	}
+
+	secret := bytes.TrimSpace(data)
+	if len(secret) == 0 {
+		return nil, errors.New("secret file is empty")
+	}
+
+	return &Repo{
+		uri:    uri,
+		secret: secret,
+
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		now: time.Now,
+	}, nil
+}
+
+func (r *Repo) SendActivation(ctx context.Context, obj *model.ActivationCallback) error {
+	jsonData, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("fail to marshal obj: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.uri, bytes.NewReader(jsonData))
+	if err != nil {
+		return fmt.Errorf("http.NewRequest: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(r.now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constant.HeaderSignatureTimestamp, timestamp)
+	req.Header.Set(constant.HeaderSignature, "sha256="+util.Sign(r.secret, timestamp, jsonData))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("httpClient.Do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	repBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("bad response status: %s, uri: %s, respBody: %q", resp.Status, r.uri, string(repBody))
+	}
+
+	return nil
+}
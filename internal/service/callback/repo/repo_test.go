@@ -0,0 +1,63 @@
+package repo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/common/util"
+	"github.com/mechta-market/e-product/internal/service/callback/model"
+)
+
+func TestRepo_SendActivation(t *testing.T) {
+	var body []byte
+	var header http.Header
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header
+	}))
+	defer server.Close()
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("secret\n"), 0o600))
+
+	r, err := New(server.URL, secretFile)
+	require.NoError(t, err)
+	r.now = func() time.Time { return time.Unix(1700000000, 0) }
+
+	err = r.SendActivation(context.Background(), &model.ActivationCallback{
+		ActivationID: "act-1",
+		Status:       "completed",
+		Value:        "secret-key",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "1700000000", header.Get(constant.HeaderSignatureTimestamp))
+	require.Equal(t, "sha256="+util.Sign([]byte("secret"), "1700000000", body), header.Get(constant.HeaderSignature))
+	require.JSONEq(t, `{"activation_id":"act-1","status":"completed","order_id":"","product_id":"","value":"secret-key"}`, string(body))
+}
+
+func TestRepo_SendActivation_BadStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("secret"), 0o600))
+
+	r, err := New(server.URL, secretFile)
+	require.NoError(t, err)
+
+	err = r.SendActivation(context.Background(), &model.ActivationCallback{ActivationID: "act-1"})
+	require.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/xml"
 	"github.com/samber/lo"
 	"regexp"
+	"strings"
 
 	"github.com/mechta-market/e-product/internal/service/provider/asbis/constant"
 	providerModel "github.com/mechta-market/e-product/internal/service/provider/model"
@@ -129,6 +130,10 @@ func DecodeActivateResponse(resp OrderRep) *providerModel.OrderResponse {
 				receipt += line.Text + "\n"
 			}
 			result.Link = getLink(receipt)
+
+			if instructions := strings.TrimSpace(receipt); instructions != "" {
+				result.Instructions = &instructions
+			}
 		}
 
 	}
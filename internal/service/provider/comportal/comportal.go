@@ -30,8 +30,8 @@ func (s *Service) CreateOrder(ctx context.Context, req *providerModel.OrderReque
 
 func (s *Service) CancelOrder(ctx context.Context, req *providerModel.CancelRequest) (*providerModel.CancelResponse, error) {
 	return nil, errs.ErrFull{
-		Err:  errs.MethodNotSupported,
-		Desc: "Данный провайдер не поддерживает услугу аннулирования заказа",
+		Err: errs.MethodNotSupported,
+		Msg: errs.MsgCancelNotSupported,
 	}
 }
 
@@ -68,12 +68,16 @@ func EncodeOrderRequest(req *providerModel.OrderRequest, catalogProduct *Catalog
 	}
 }
 
-func DecodeOrderResponse(rep OrderRep) *providerModel.OrderResponse {
+func DecodeOrderResponse(rep OrderRep, catalogProduct *CatalogProduct) *providerModel.OrderResponse {
 	result := &providerModel.OrderResponse{
 		OrderID:       &rep.Data.OrderID,
 		TransactionID: rep.Data.TransactionID,
 	}
 
+	if catalogProduct != nil && catalogProduct.LicenseType != "" {
+		result.LicenseTerm = &catalogProduct.LicenseType
+	}
+
 	if len(rep.Data.Keys) > 0 {
 		keyData := rep.Data.Keys[0]
 
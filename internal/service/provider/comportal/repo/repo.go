@@ -62,7 +62,7 @@ func (r *Repo) getProduct(ctx context.Context, sku string) (*repoModel.CatalogPr
 		}
 	}
 
-	return nil, fmt.Errorf("product with SKU '%s' not found in catalog", sku)
+	return nil, fmt.Errorf("%w: product with SKU '%s' not found in catalog", errs.ProviderRejected, sku)
 }
 
 func (r *Repo) CreateOrder(ctx context.Context, obj *providerModel.OrderRequest) (*providerModel.OrderResponse, error) {
@@ -101,7 +101,7 @@ func (r *Repo) CreateOrder(ctx context.Context, obj *providerModel.OrderRequest)
 		return nil, fmt.Errorf("send request: %w", err)
 	}
 
-	decodedResponse := repoModel.DecodeOrderResponse(*apiResp)
+	decodedResponse := repoModel.DecodeOrderResponse(*apiResp, catalogProduct)
 
 	return decodedResponse, nil
 }
@@ -175,20 +175,20 @@ func (r *Repo) sendRequest(ctx context.Context, method, path string, timeout tim
 
 	resp, err := r.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("httpClient.Do: %w", err)
+		return nil, fmt.Errorf("httpClient.Do: %w: %w", providerModel.ErrUnavailable, err)
 	}
 	defer resp.Body.Close()
 
 	repBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("read body: %w", err)
+		return nil, fmt.Errorf("read body: %w: %w", providerModel.ErrUnavailable, err)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		if resp.StatusCode == http.StatusNotFound {
 			return repBody, fmt.Errorf("bad response status: %w %s, uri: %s, respBody: %q", errs.ObjectNotFound, resp.Status, r.uri+path, string(repBody))
 		}
-		return repBody, fmt.Errorf("bad response status: %s, uri: %s, respBody: %q", resp.Status, r.uri+path, string(repBody))
+		return repBody, fmt.Errorf("bad response status: %w %s, uri: %s, respBody: %q", providerModel.StatusErr(resp.StatusCode), resp.Status, r.uri+path, string(repBody))
 	}
 
 	if repObj != nil {
@@ -38,8 +38,8 @@ func (s *Service) CancelOrder(ctx context.Context, req *providerModel.CancelRequ
 
 func (s *Service) ListCatalog(ctx context.Context, providerID string) ([]*providerModel.CatalogResponse, error) {
 	return nil, errs.ErrFull{
-		Err:  errs.MethodNotSupported,
-		Desc: "Провайдер не поддерживает данную услугу",
+		Err: errs.MethodNotSupported,
+		Msg: errs.MsgCatalogNotSupported,
 	}
 }
 
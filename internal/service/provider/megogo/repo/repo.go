@@ -14,6 +14,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/mechta-market/e-product/internal/errs"
 	repoModel "github.com/mechta-market/e-product/internal/service/provider/megogo/repo/model"
 	providerModel "github.com/mechta-market/e-product/internal/service/provider/model"
 )
@@ -67,7 +68,7 @@ func (r *Repo) CreateOrder(ctx context.Context, obj *providerModel.OrderRequest)
 	result := &providerModel.OrderResponse{}
 
 	if !apiResp.Successful {
-		return nil, fmt.Errorf("send request: %s", repBody)
+		return nil, fmt.Errorf("send request: %w: %s", errs.ProviderRejected, repBody)
 	}
 
 	return result, nil
@@ -97,7 +98,7 @@ func (r *Repo) CancelOrder(ctx context.Context, obj *providerModel.CancelRequest
 	}
 
 	if !apiResp.Successful {
-		return nil, fmt.Errorf("send request: %s", repBody)
+		return nil, fmt.Errorf("send request: %w: %s", errs.ProviderRejected, repBody)
 	}
 
 	// decode
@@ -158,17 +159,17 @@ func (r *Repo) sendRequest(ctx context.Context, method, path string, timeout tim
 
 	resp, err := r.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("httpClient.Do: %w", err)
+		return nil, fmt.Errorf("httpClient.Do: %w: %w", providerModel.ErrUnavailable, err)
 	}
 	defer resp.Body.Close()
 
 	repBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("read body: %w", err)
+		return nil, fmt.Errorf("read body: %w: %w", providerModel.ErrUnavailable, err)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return repBody, fmt.Errorf("bad response status: %s, uri: %s, respBody: %q", resp.Status, uri, string(repBody))
+		return repBody, fmt.Errorf("bad response status: %w %s, uri: %s, respBody: %q", providerModel.StatusErr(resp.StatusCode), resp.Status, uri, string(repBody))
 	}
 
 	if repObj != nil {
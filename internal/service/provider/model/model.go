@@ -1,6 +1,18 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mechta-market/e-product/internal/errs"
+)
+
+// ErrUnavailable сбой провайдера: сеть, таймаут, ответ 5xx. Только такие ошибки учитывает circuit breaker,
+// отказ по существу провайдеры возвращают как errs.ProviderRejected
+var ErrUnavailable = errors.New("provider unavailable")
 
 type OrderRequest struct {
 	ProviderID        string
@@ -16,9 +28,11 @@ type OrderRequest struct {
 type OrderResponse struct {
 	Value         string
 	Success       bool
-	TransactionID string // номер транзакции
-	Link          *string
+	TransactionID string  // номер транзакции
+	Link          *string // ссылка на скачивание
 	OrderID       *string // номер заказа провайдера
+	Instructions  *string // инструкция по активации
+	LicenseTerm   *string // срок (тип) лицензии по каталогу провайдера
 }
 
 type CancelRequest struct {
@@ -41,6 +55,24 @@ type CatalogResponse struct {
 	ProviderExternalProductID *string
 }
 
+// BreakerState состояние circuit breaker провайдера
+type BreakerState struct {
+	ProviderID string
+	State      string
+	Failures   int // неудачных вызовов подряд
+	OpenedAt   time.Time
+	RetryAt    time.Time // после этого времени открытый breaker пропускает пробный вызов
+}
+
+// StatusErr ошибка ответа провайдера не из 2xx: 5xx - сбой провайдера, остальные - отказ по существу
+func StatusErr(statusCode int) error {
+	if statusCode >= http.StatusInternalServerError {
+		return ErrUnavailable
+	}
+
+	return errs.ProviderRejected
+}
+
 func GenerateUUID() string {
 	return uuid.New().String()
 }
@@ -0,0 +1,135 @@
+package resilient
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	providerModel "github.com/mechta-market/e-product/internal/service/provider/model"
+)
+
+// Breaker circuit breaker провайдера: после failureThreshold неудачных вызовов подряд открывается
+// и отклоняет вызовы, через openTimeout пропускает один пробный вызов. Успешная проба закрывает breaker
+type Breaker struct {
+	failureThreshold int
+	openTimeout      time.Duration
+	onStateChange    func(state string)
+	now              func() time.Time
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func NewBreaker(failureThreshold int, openTimeout time.Duration, onStateChange func(state string)) *Breaker {
+	return &Breaker{
+		failureThreshold: max(failureThreshold, 1),
+		openTimeout:      openTimeout,
+		onStateChange:    onStateChange,
+		now:              time.Now,
+		state:            constant.BreakerStateClosed,
+	}
+}
+
+// Allow сообщает, можно ли вызвать провайдера. В half_open разрешается только один вызов,
+// до его результата остальные отклоняются
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.currentState() {
+	case constant.BreakerStateOpen:
+		return false
+	case constant.BreakerStateHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.setState(constant.BreakerStateHalfOpen)
+		b.probing = true
+	}
+
+	return true
+}
+
+// Available как Allow, но не занимает пробный вызов
+func (b *Breaker) Available() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.currentState() {
+	case constant.BreakerStateOpen:
+		return false
+	case constant.BreakerStateHalfOpen:
+		return !b.probing
+	}
+
+	return true
+}
+
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.probing = false
+	b.setState(constant.BreakerStateClosed)
+}
+
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+
+	if b.probing || b.failures >= b.failureThreshold {
+		b.probing = false
+		b.openedAt = b.now()
+		b.setState(constant.BreakerStateOpen)
+	}
+}
+
+// Reset закрывает breaker вручную
+func (b *Breaker) Reset() {
+	b.Success()
+}
+
+func (b *Breaker) State(providerID string) *providerModel.BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	result := &providerModel.BreakerState{
+		ProviderID: providerID,
+		State:      b.currentState(),
+		Failures:   b.failures,
+	}
+
+	if result.State != constant.BreakerStateClosed {
+		result.OpenedAt = b.openedAt
+		result.RetryAt = b.openedAt.Add(b.openTimeout)
+	}
+
+	return result
+}
+
+// currentState учитывает истечение openTimeout: открытый breaker переходит в half_open при обращении
+func (b *Breaker) currentState() string {
+	if b.state == constant.BreakerStateOpen && !b.now().Before(b.openedAt.Add(b.openTimeout)) {
+		return constant.BreakerStateHalfOpen
+	}
+
+	return b.state
+}
+
+func (b *Breaker) setState(state string) {
+	if b.state == state {
+		return
+	}
+
+	b.state = state
+
+	if b.onStateChange != nil {
+		b.onStateChange(state)
+	}
+}
@@ -0,0 +1,14 @@
+package resilient
+
+import (
+	"context"
+
+	providerModel "github.com/mechta-market/e-product/internal/service/provider/model"
+)
+
+type ServiceI interface {
+	CreateOrder(ctx context.Context, obj *providerModel.OrderRequest) (*providerModel.OrderResponse, error)
+	CancelOrder(ctx context.Context, req *providerModel.CancelRequest) (*providerModel.CancelResponse, error)
+	ListCatalog(ctx context.Context, providerID string) ([]*providerModel.CatalogResponse, error)
+	SupportsPool() bool
+}
@@ -0,0 +1,199 @@
+package resilient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"time"
+
+	"github.com/mechta-market/e-product/internal/errs"
+	providerModel "github.com/mechta-market/e-product/internal/service/provider/model"
+)
+
+type Config struct {
+	RetryAttempts        int // всего попыток, включая первую
+	RetryBaseDelay       time.Duration
+	RetryMaxDelay        time.Duration
+	BreakerFailures      int
+	BreakerOpenTimeout   time.Duration
+	OnBreakerStateChange func(providerID, state string)
+	OnRetry              func(providerID, method string)
+}
+
+// Service оборачивает провайдера: повторяет чтение каталога с backoff и отклоняет вызовы
+// при открытом circuit breaker. Покупка CreateOrder не повторяется, чтобы не купить ключ дважды.
+// Отмена CancelOrder тоже не повторяется: провайдеры не гарантируют ее идемпотентность
+// (asbis выдает новый CancelID на каждый запрос аннулирования)
+type Service struct {
+	providerID string
+	service    ServiceI
+	breaker    *Breaker
+	conf       Config
+	sleep      func(ctx context.Context, d time.Duration) error
+}
+
+func New(providerID string, service ServiceI, conf Config) *Service {
+	var onStateChange func(state string)
+	if conf.OnBreakerStateChange != nil {
+		onStateChange = func(state string) {
+			conf.OnBreakerStateChange(providerID, state)
+		}
+	}
+
+	return &Service{
+		providerID: providerID,
+		service:    service,
+		breaker:    NewBreaker(conf.BreakerFailures, conf.BreakerOpenTimeout, onStateChange),
+		conf:       conf,
+		sleep:      sleep,
+	}
+}
+
+func (s *Service) CreateOrder(ctx context.Context, obj *providerModel.OrderRequest) (*providerModel.OrderResponse, error) {
+	var result *providerModel.OrderResponse
+
+	err := s.call(ctx, "CreateOrder", false, func() error {
+		var err error
+		result, err = s.service.CreateOrder(ctx, obj)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *Service) CancelOrder(ctx context.Context, req *providerModel.CancelRequest) (*providerModel.CancelResponse, error) {
+	var result *providerModel.CancelResponse
+
+	err := s.call(ctx, "CancelOrder", false, func() error {
+		var err error
+		result, err = s.service.CancelOrder(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *Service) ListCatalog(ctx context.Context, providerID string) ([]*providerModel.CatalogResponse, error) {
+	var result []*providerModel.CatalogResponse
+
+	err := s.call(ctx, "ListCatalog", true, func() error {
+		var err error
+		result, err = s.service.ListCatalog(ctx, providerID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *Service) SupportsPool() bool {
+	return s.service.SupportsPool()
+}
+
+// Available false, пока breaker открыт: вызов будет отклонен без обращения к провайдеру
+func (s *Service) Available() bool {
+	return s.breaker.Available()
+}
+
+func (s *Service) BreakerState() *providerModel.BreakerState {
+	return s.breaker.State(s.providerID)
+}
+
+func (s *Service) ResetBreaker() {
+	s.breaker.Reset()
+}
+
+func (s *Service) call(ctx context.Context, method string, retry bool, fn func() error) error {
+	attempts := 1
+	if retry {
+		attempts = max(s.conf.RetryAttempts, 1)
+	}
+
+	var err error
+
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if s.conf.OnRetry != nil {
+				s.conf.OnRetry(s.providerID, method)
+			}
+
+			if sleepErr := s.sleep(ctx, s.backoff(attempt)); sleepErr != nil {
+				return err
+			}
+		}
+
+		if !s.breaker.Allow() {
+			return s.unavailableErr()
+		}
+
+		err = fn()
+		if err == nil || !isProviderFailure(ctx, err) {
+			s.breaker.Success()
+			return err
+		}
+
+		s.breaker.Failure()
+	}
+
+	return err
+}
+
+// backoff - экспоненциальная задержка с полным jitter: случайная от 0 до base*2^(attempt-1), не больше max
+func (s *Service) backoff(attempt int) time.Duration {
+	delay := s.conf.RetryBaseDelay << (attempt - 1)
+	if delay <= 0 || (s.conf.RetryMaxDelay > 0 && delay > s.conf.RetryMaxDelay) {
+		delay = s.conf.RetryMaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	return rand.N(delay + 1)
+}
+
+func (s *Service) unavailableErr() error {
+	return errs.ErrFull{
+		Err: errs.ServiceNA,
+		Msg: errs.MsgProviderUnavailable,
+		Fields: map[string]string{
+			"provider": s.providerID,
+		},
+	}
+}
+
+// isProviderFailure - сбой провайдера (сеть, таймаут, 5xx), а не ответ по существу:
+// отказы провайдера, ошибки errs и отмена запроса вызывающей стороной не считаются
+func isProviderFailure(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if errors.Is(err, providerModel.ErrUnavailable) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("ctx.Done: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,248 @@
+package resilient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/errs"
+	providerModel "github.com/mechta-market/e-product/internal/service/provider/model"
+)
+
+type fakeProvider struct {
+	errs  []error // ошибки по очереди вызовов, после окончания - успех
+	calls int
+}
+
+func (f *fakeProvider) next() error {
+	f.calls++
+	if f.calls <= len(f.errs) {
+		return f.errs[f.calls-1]
+	}
+	return nil
+}
+
+func (f *fakeProvider) CreateOrder(context.Context, *providerModel.OrderRequest) (*providerModel.OrderResponse, error) {
+	if err := f.next(); err != nil {
+		return nil, err
+	}
+	return &providerModel.OrderResponse{Value: "secret"}, nil
+}
+
+func (f *fakeProvider) CancelOrder(context.Context, *providerModel.CancelRequest) (*providerModel.CancelResponse, error) {
+	if err := f.next(); err != nil {
+		return nil, err
+	}
+	return &providerModel.CancelResponse{Success: true}, nil
+}
+
+func (f *fakeProvider) ListCatalog(context.Context, string) ([]*providerModel.CatalogResponse, error) {
+	if err := f.next(); err != nil {
+		return nil, err
+	}
+	return []*providerModel.CatalogResponse{{}}, nil
+}
+
+func (f *fakeProvider) SupportsPool() bool {
+	return true
+}
+
+func newTestService(provider *fakeProvider, retries *int, states *[]string) *Service {
+	s := New("provider-1", provider, Config{
+		RetryAttempts:      3,
+		RetryBaseDelay:     time.Millisecond,
+		BreakerFailures:    2,
+		BreakerOpenTimeout: time.Minute,
+		OnRetry: func(string, string) {
+			*retries++
+		},
+		OnBreakerStateChange: func(_ string, state string) {
+			*states = append(*states, state)
+		},
+	})
+	s.sleep = func(context.Context, time.Duration) error { return nil }
+
+	return s
+}
+
+func TestService_Retry(t *testing.T) {
+	errDown := fmt.Errorf("httpClient.Do: %w: connection refused", providerModel.ErrUnavailable)
+
+	tests := []struct {
+		name          string
+		errs          []error
+		call          func(s *Service) error
+		expectedErr   error
+		expectedCalls int
+	}{
+		{
+			name: "catalog retried until success",
+			errs: []error{errDown},
+			call: func(s *Service) error {
+				_, err := s.ListCatalog(context.Background(), "provider-1")
+				return err
+			},
+			expectedCalls: 2,
+		},
+		{
+			name: "catalog retried on timeout",
+			errs: []error{fmt.Errorf("httpClient.Do: %w", context.DeadlineExceeded)},
+			call: func(s *Service) error {
+				_, err := s.ListCatalog(context.Background(), "provider-1")
+				return err
+			},
+			expectedCalls: 2,
+		},
+		{
+			name: "cancel not retried",
+			errs: []error{errDown},
+			call: func(s *Service) error {
+				_, err := s.CancelOrder(context.Background(), &providerModel.CancelRequest{})
+				return err
+			},
+			expectedErr:   errDown,
+			expectedCalls: 1,
+		},
+		{
+			name: "catalog rejection not retried",
+			errs: []error{fmt.Errorf("bad response status: %w 400 Bad Request", providerModel.StatusErr(400))},
+			call: func(s *Service) error {
+				_, err := s.ListCatalog(context.Background(), "provider-1")
+				return err
+			},
+			expectedErr:   errs.ProviderRejected,
+			expectedCalls: 1,
+		},
+		{
+			name: "order not retried",
+			errs: []error{errDown},
+			call: func(s *Service) error {
+				_, err := s.CreateOrder(context.Background(), &providerModel.OrderRequest{})
+				return err
+			},
+			expectedErr:   errDown,
+			expectedCalls: 1,
+		},
+		{
+			name: "domain error not retried",
+			errs: []error{errs.MethodNotSupported},
+			call: func(s *Service) error {
+				_, err := s.ListCatalog(context.Background(), "provider-1")
+				return err
+			},
+			expectedErr:   errs.MethodNotSupported,
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{errs: tt.errs}
+			retries := 0
+			var states []string
+
+			err := tt.call(newTestService(provider, &retries, &states))
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedCalls, provider.calls)
+			assert.Equal(t, tt.expectedCalls-1, retries)
+		})
+	}
+}
+
+func TestService_Breaker(t *testing.T) {
+	errDown := fmt.Errorf("bad response status: %w 503 Service Unavailable", providerModel.StatusErr(503))
+	provider := &fakeProvider{errs: []error{errDown, errDown, errDown}}
+	retries := 0
+	var states []string
+
+	s := newTestService(provider, &retries, &states)
+	now := time.Now()
+	s.breaker.now = func() time.Time { return now }
+
+	// две неудачные покупки подряд открывают breaker
+	for i := 0; i < 2; i++ {
+		_, err := s.CreateOrder(context.Background(), &providerModel.OrderRequest{})
+		assert.ErrorIs(t, err, errDown)
+	}
+	assert.False(t, s.Available())
+	assert.Equal(t, constant.BreakerStateOpen, s.BreakerState().State)
+	assert.Equal(t, now.Add(time.Minute), s.BreakerState().RetryAt)
+
+	// открытый breaker отклоняет вызов без обращения к провайдеру
+	_, err := s.CreateOrder(context.Background(), &providerModel.OrderRequest{})
+	var errFull errs.ErrFull
+	assert.True(t, errors.As(err, &errFull))
+	assert.Equal(t, errs.ServiceNA, errFull.Err)
+	assert.Equal(t, 2, provider.calls)
+
+	// неудачная проба снова открывает breaker
+	now = now.Add(time.Minute)
+	assert.True(t, s.Available())
+	_, err = s.CreateOrder(context.Background(), &providerModel.OrderRequest{})
+	assert.ErrorIs(t, err, errDown)
+	assert.False(t, s.Available())
+
+	// успешная проба закрывает breaker
+	now = now.Add(time.Minute)
+	_, err = s.CreateOrder(context.Background(), &providerModel.OrderRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, constant.BreakerStateClosed, s.BreakerState().State)
+	assert.Equal(t, 0, s.BreakerState().Failures)
+
+	assert.Equal(t, []string{
+		constant.BreakerStateOpen,
+		constant.BreakerStateHalfOpen,
+		constant.BreakerStateOpen,
+		constant.BreakerStateHalfOpen,
+		constant.BreakerStateClosed,
+	}, states)
+}
+
+func TestService_ResetBreaker(t *testing.T) {
+	provider := &fakeProvider{errs: []error{providerModel.ErrUnavailable, providerModel.ErrUnavailable}}
+	retries := 0
+	var states []string
+
+	s := newTestService(provider, &retries, &states)
+
+	_, _ = s.CreateOrder(context.Background(), &providerModel.OrderRequest{})
+	_, _ = s.CreateOrder(context.Background(), &providerModel.OrderRequest{})
+	assert.False(t, s.Available())
+
+	s.ResetBreaker()
+	assert.True(t, s.Available())
+	assert.Equal(t, constant.BreakerStateClosed, s.BreakerState().State)
+}
+
+func TestService_Breaker_Rejection(t *testing.T) {
+	// отказы по существу (нет товара, ошибка бизнес-логики, 4xx) не открывают breaker
+	provider := &fakeProvider{errs: []error{
+		fmt.Errorf("send request: %w: {\"successful\":false}", errs.ProviderRejected),
+		fmt.Errorf("repo.getProduct: %w: product with SKU 'sku-1' not found in catalog", errs.ProviderRejected),
+		fmt.Errorf("bad response status: %w 422 Unprocessable Entity", providerModel.StatusErr(422)),
+		errors.New("json.Unmarshal: unexpected end of JSON input"),
+	}}
+	retries := 0
+	var states []string
+
+	s := newTestService(provider, &retries, &states)
+
+	for i := 0; i < len(provider.errs); i++ {
+		_, err := s.CreateOrder(context.Background(), &providerModel.OrderRequest{})
+		assert.Error(t, err)
+	}
+
+	assert.True(t, s.Available())
+	assert.Equal(t, 0, s.BreakerState().Failures)
+	assert.Empty(t, states)
+}
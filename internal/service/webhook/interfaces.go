@@ -0,0 +1,11 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/mechta-market/e-product/internal/service/webhook/model"
+)
+
+type RepoI interface {
+	Send(ctx context.Context, req *model.Request) (*model.Response, error)
+}
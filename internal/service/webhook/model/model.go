@@ -0,0 +1,14 @@
+package model
+
+// Request отправка доставки webhook. Payload отправляется как есть, подпись считается по нему
+type Request struct {
+	URL        string
+	Secret     string
+	DeliveryID string
+	Event      string
+	Payload    []byte
+}
+
+type Response struct {
+	StatusCode int64
+}
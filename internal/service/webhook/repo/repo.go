@@ -0,0 +1,70 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/common/util"
+	"github.com/mechta-market/e-product/internal/service/webhook/model"
+)
+
+// responseBodyLimit сколько байт ответа подписчика попадает в ошибку
+const responseBodyLimit = 512
+
+// Repo отправляет webhook POST-запросом с JSON. Тело подписывается HMAC-SHA256 секретом подписчика
+// по строке "<timestamp>.<body>": подпись в X-Signature ("sha256=<hex>"), unix-время в X-Signature-Timestamp
+type Repo struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+func New(timeout time.Duration) *Repo {
+	return &Repo{
+		client: &http.Client{
+			Timeout: timeout,
+		},
+		now: time.Now,
+	}
+}
+
+func (r *Repo) Send(ctx context.Context, obj *model.Request) (*model.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, obj.URL, bytes.NewReader(obj.Payload))
+	if err != nil {
+		return nil, fmt.Errorf("http.NewRequest: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(r.now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constant.HeaderWebhookEvent, obj.Event)
+	req.Header.Set(constant.HeaderWebhookDelivery, obj.DeliveryID)
+	req.Header.Set(constant.HeaderSignatureTimestamp, timestamp)
+	req.Header.Set(constant.HeaderSignature, "sha256="+util.Sign([]byte(obj.Secret), timestamp, obj.Payload))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("httpClient.Do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	rep := &model.Response{
+		StatusCode: int64(resp.StatusCode),
+	}
+
+	repBody, err := io.ReadAll(io.LimitReader(resp.Body, responseBodyLimit))
+	if err != nil {
+		return rep, fmt.Errorf("read body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return rep, fmt.Errorf("bad response status: %s, respBody: %q", resp.Status, string(repBody))
+	}
+
+	return rep, nil
+}
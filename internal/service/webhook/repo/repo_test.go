@@ -0,0 +1,59 @@
+package repo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/common/util"
+	"github.com/mechta-market/e-product/internal/service/webhook/model"
+)
+
+func TestRepo_Send(t *testing.T) {
+	var body []byte
+	var header http.Header
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	r := New(time.Second)
+	r.now = func() time.Time { return time.Unix(1700000000, 0) }
+
+	rep, err := r.Send(context.Background(), &model.Request{
+		URL:        server.URL,
+		Secret:     "secret",
+		DeliveryID: "del-1",
+		Event:      constant.WebhookEventActivated,
+		Payload:    []byte(`{"event":"activated"}`),
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, http.StatusAccepted, rep.StatusCode)
+
+	require.Equal(t, `{"event":"activated"}`, string(body))
+	require.Equal(t, constant.WebhookEventActivated, header.Get(constant.HeaderWebhookEvent))
+	require.Equal(t, "del-1", header.Get(constant.HeaderWebhookDelivery))
+	require.Equal(t, "1700000000", header.Get(constant.HeaderSignatureTimestamp))
+	require.Equal(t, "sha256="+util.Sign([]byte("secret"), "1700000000", body), header.Get(constant.HeaderSignature))
+}
+
+func TestRepo_Send_BadStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("maintenance"))
+	}))
+	defer server.Close()
+
+	rep, err := New(time.Second).Send(context.Background(), &model.Request{URL: server.URL})
+	require.ErrorContains(t, err, "maintenance")
+	require.EqualValues(t, http.StatusServiceUnavailable, rep.StatusCode)
+}
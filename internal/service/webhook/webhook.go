@@ -0,0 +1,28 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mechta-market/e-product/internal/service/webhook/model"
+)
+
+type Service struct {
+	repo RepoI
+}
+
+func New(repo RepoI) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// Send отправляет доставку подписчику. Response возвращается и при ошибке, если подписчик ответил
+func (s *Service) Send(ctx context.Context, req *model.Request) (*model.Response, error) {
+	rep, err := s.repo.Send(ctx, req)
+	if err != nil {
+		return rep, fmt.Errorf("repo.Send: %w", err)
+	}
+
+	return rep, nil
+}
@@ -0,0 +1,195 @@
+package key
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/key/model"
+	"github.com/mechta-market/e-product/internal/errs"
+	callbackModel "github.com/mechta-market/e-product/internal/service/callback/model"
+	providerModel "github.com/mechta-market/e-product/internal/service/provider/model"
+)
+
+const (
+	// после стольких попыток временная ошибка считается окончательной
+	activationMaxAttempts = 5
+	// задержка перед повторной попыткой удваивается с каждой попыткой, но не больше activationRetryMaxDelay
+	activationRetryBaseDelay = 30 * time.Second
+	activationRetryMaxDelay  = 10 * time.Minute
+)
+
+// ActivateAsync ставит выдачу ключа в очередь и сразу возвращает ее в статусе pending:
+// ключ выдают воркеры ProcessActivations. Повторный вызов по тому же заказу возвращает ту же выдачу
+func (u *Usecase) ActivateAsync(ctx context.Context, productID, orderID, customerPhone string) (*model.Activation, error) {
+	if err := u.validateActivate(ctx, &orderID, &productID, &customerPhone); err != nil {
+		return nil, err
+	}
+
+	activation, created, err := u.service.CreateActivation(ctx, &model.ActivationEdit{
+		ProductID:     &productID,
+		OrderID:       &orderID,
+		CustomerPhone: &customerPhone,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service.CreateActivation: %w", err)
+	}
+
+	if created {
+		u.wakeActivations()
+	}
+
+	return activation, nil
+}
+
+// GetActivation возвращает выдачу и, если она завершена успешно, выданный ключ
+func (u *Usecase) GetActivation(ctx context.Context, id string) (*model.Activation, *model.Main, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, nil, errs.IDRequired
+	}
+
+	activation, _, err := u.service.GetActivation(ctx, id, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("service.GetActivation: %w", err)
+	}
+
+	if activation.Status != constant.ActivationStatusCompleted {
+		return activation, nil, nil
+	}
+
+	key, _, err := u.service.Get(ctx, activation.KeyID, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("service.Get: %w", err)
+	}
+
+	return activation, key, nil
+}
+
+// ActivationWake сигналит воркерам о новой выдаче в очереди, чтобы не ждать следующего опроса
+func (u *Usecase) ActivationWake() <-chan struct{} {
+	return u.activationWake
+}
+
+// ProcessActivations выдает ключи по очереди асинхронных выдач, пока она не опустеет.
+// Выдача, зависшая в processing дольше processingTimeout, забирается повторно
+func (u *Usecase) ProcessActivations(ctx context.Context, processingTimeout time.Duration) error {
+	for ctx.Err() == nil {
+		activation, err := u.service.ClaimActivation(ctx, processingTimeout)
+		if err != nil {
+			return fmt.Errorf("service.ClaimActivation: %w", err)
+		}
+		if activation == nil {
+			return nil
+		}
+
+		u.processActivation(ctx, activation)
+	}
+
+	return nil
+}
+
+func (u *Usecase) processActivation(ctx context.Context, activation *model.Activation) {
+	key, err := u.Activate(ctx, activation.ProductID, activation.OrderID, activation.CustomerPhone)
+
+	// при остановке сервиса выдача остается в processing и будет забрана повторно
+	if ctx.Err() != nil {
+		return
+	}
+
+	if err != nil && activationRetryable(err) && activation.Attempts < activationMaxAttempts {
+		slog.Warn("async activation retry", "error", err, "activation_id", activation.ID, "order_id", activation.OrderID, "attempts", activation.Attempts)
+
+		err = u.service.RetryActivation(ctx, activation, activationError(err), activationRetryDelay(activation.Attempts))
+		if err != nil {
+			slog.Error("service.RetryActivation", "error", err, "activation_id", activation.ID)
+		}
+		return
+	}
+
+	keyID, errMsg := "", ""
+	if err != nil {
+		slog.Error("async activation failed", "error", err, "activation_id", activation.ID, "order_id", activation.OrderID)
+		errMsg = activationError(err)
+	} else {
+		keyID = key.ID
+	}
+
+	err = u.service.FinishActivation(ctx, activation, keyID, errMsg)
+	if err != nil {
+		slog.Error("service.FinishActivation", "error", err, "activation_id", activation.ID)
+		return
+	}
+
+	err = u.callbackService.ActivationCallback(ctx, encodeActivationCallback(activation, key))
+	if err != nil {
+		slog.Error("callbackService.ActivationCallback", "error", err, "activation_id", activation.ID)
+	}
+}
+
+func (u *Usecase) wakeActivations() {
+	select {
+	case u.activationWake <- struct{}{}:
+	default:
+	}
+}
+
+// activationRetryable временная ошибка: провайдер недоступен (сбой, открытый breaker) или по заказу
+// идет другая выдача. Повтор позже может завершиться успешно
+func activationRetryable(err error) bool {
+	if errors.Is(err, providerModel.ErrUnavailable) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var errFull errs.ErrFull
+	if errors.As(err, &errFull) {
+		err = errFull.Err
+	}
+
+	return errors.Is(err, errs.ServiceNA) || errors.Is(err, errs.ActivationInProgress)
+}
+
+// activationRetryDelay задержка перед следующей попыткой после attempts попыток
+func activationRetryDelay(attempts int64) time.Duration {
+	delay := activationRetryBaseDelay << max(attempts-1, 0)
+	if delay <= 0 || delay > activationRetryMaxDelay {
+		return activationRetryMaxDelay
+	}
+
+	return delay
+}
+
+// activationError текст ошибки для клиента: внутренние ошибки не раскрываются
+func activationError(err error) string {
+	var errBase errs.Err
+	var errFull errs.ErrFull
+	if errors.As(err, &errFull) || errors.As(err, &errBase) {
+		return errs.Message(err, errs.DefaultLang)
+	}
+
+	return errs.Message(errs.ServiceNA, errs.DefaultLang)
+}
+
+func encodeActivationCallback(activation *model.Activation, key *model.Main) *callbackModel.ActivationCallback {
+	result := &callbackModel.ActivationCallback{
+		ActivationID: activation.ID,
+		Status:       activation.Status,
+		OrderID:      activation.OrderID,
+		ProductID:    activation.ProductID,
+		Error:        activation.Error,
+	}
+
+	if key != nil {
+		result.Value = key.Value
+		result.Link = key.Link
+		result.Instructions = key.Instructions
+		result.LicenseTerm = key.LicenseTerm
+		result.ProviderOrderID = key.ProviderOrderID
+	}
+
+	return result
+}
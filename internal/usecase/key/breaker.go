@@ -0,0 +1,56 @@
+package key
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/mechta-market/e-product/internal/errs"
+	providerModel "github.com/mechta-market/e-product/internal/service/provider/model"
+)
+
+// ListProviderBreakers состояние circuit breaker подключенных провайдеров
+func (u *Usecase) ListProviderBreakers(_ context.Context) []*providerModel.BreakerState {
+	result := make([]*providerModel.BreakerState, 0, len(u.providers))
+
+	for _, provider := range u.providers {
+		if breaker, ok := provider.(ProviderBreakerI); ok {
+			result = append(result, breaker.BreakerState())
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ProviderID < result[j].ProviderID
+	})
+
+	return result
+}
+
+// ResetProviderBreaker закрывает breaker провайдера, не дожидаясь пробного вызова
+func (u *Usecase) ResetProviderBreaker(_ context.Context, providerID string) (*providerModel.BreakerState, error) {
+	providerID = strings.TrimSpace(providerID)
+	if providerID == "" {
+		return nil, errs.ProviderIDRequired
+	}
+
+	provider, err := u.getProvider(providerID)
+	if err != nil {
+		return nil, err
+	}
+
+	breaker, ok := provider.(ProviderBreakerI)
+	if !ok {
+		return nil, errs.MethodNotSupported
+	}
+
+	breaker.ResetBreaker()
+
+	return breaker.BreakerState(), nil
+}
+
+// providerAvailable false, если breaker провайдера открыт
+func providerAvailable(provider ProviderServiceI) bool {
+	breaker, ok := provider.(ProviderBreakerI)
+
+	return !ok || breaker.Available()
+}
@@ -0,0 +1,174 @@
+package key
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/common/util"
+	"github.com/mechta-market/e-product/internal/domain/key/model"
+	"github.com/mechta-market/e-product/internal/errs"
+)
+
+var exportColumns = []string{
+	"id",
+	"created_at",
+	"updated_at",
+	"provider_id",
+	"product_id",
+	"status",
+	"order_id",
+	"customer_phone",
+	"provider_product_id",
+	"provider_order_id",
+	"provider_transaction_id",
+	"value",
+}
+
+// exportItem строка выгрузки, поля в порядке exportColumns
+type exportItem struct {
+	ID                    string `json:"id"`
+	CreatedAt             string `json:"created_at"`
+	UpdatedAt             string `json:"updated_at"`
+	ProviderID            string `json:"provider_id"`
+	ProductID             string `json:"product_id"`
+	Status                string `json:"status"`
+	OrderID               string `json:"order_id"`
+	CustomerPhone         string `json:"customer_phone"`
+	ProviderProductID     string `json:"provider_product_id"`
+	ProviderOrderID       string `json:"provider_order_id"`
+	ProviderTransactionID string `json:"provider_transaction_id"`
+	Value                 string `json:"value"`
+}
+
+func (e *exportItem) record() []string {
+	return []string{
+		e.ID,
+		e.CreatedAt,
+		e.UpdatedAt,
+		e.ProviderID,
+		e.ProductID,
+		e.Status,
+		e.OrderID,
+		e.CustomerPhone,
+		e.ProviderProductID,
+		e.ProviderOrderID,
+		e.ProviderTransactionID,
+		e.Value,
+	}
+}
+
+// Export пишет в w все ключи по фильтрам pars в формате CSV (с заголовком) или JSONL.
+// Ключи читаются из БД курсором, поэтому объем выгрузки не ограничен MaxPageSize.
+// Значения маскируются; открытые значения выгружаются только поддержке и администраторам
+// и пишутся в журнал доступа с фильтрами и числом выгруженных ключей
+func (u *Usecase) Export(ctx context.Context, pars *model.ExportReq, w io.Writer) error {
+	if err := u.validateListReq(&pars.ListReq); err != nil {
+		return err
+	}
+
+	if role := util.RoleFromCtx(ctx); pars.RevealValue && !slices.Contains(revealValueRoles, role) {
+		return errs.ErrFull{
+			Err: errs.PermissionDenied,
+			Msg: errs.MsgRevealValueDenied,
+			Fields: map[string]string{
+				"role": role,
+			},
+		}
+	}
+
+	var write func(item *exportItem) error
+	var flush func() error
+
+	switch pars.Format {
+	case constant.ExportFormatCSV:
+		csvWriter := csv.NewWriter(w)
+
+		err := csvWriter.Write(exportColumns)
+		if err != nil {
+			return fmt.Errorf("csvWriter.Write: %w", err)
+		}
+
+		write = func(item *exportItem) error {
+			return csvWriter.Write(item.record())
+		}
+		flush = func() error {
+			csvWriter.Flush()
+			return csvWriter.Error()
+		}
+	case constant.ExportFormatJSONL:
+		encoder := json.NewEncoder(w)
+
+		write = func(item *exportItem) error {
+			return encoder.Encode(item)
+		}
+		flush = func() error {
+			return nil
+		}
+	default:
+		return errs.ErrFull{
+			Err: errs.InvalidExportFormat,
+		}
+	}
+
+	var rowCount int64
+
+	err := u.service.Export(ctx, &pars.ListReq, func(item *model.Main) error {
+		err := write(encodeExportItem(item, !pars.RevealValue))
+		if err != nil {
+			return err
+		}
+		rowCount++
+		return nil
+	})
+
+	// в журнал попадает выгрузка и при обрыве: часть значений уже отдана
+	if pars.RevealValue {
+		auditErr := u.service.AuditExport(ctx, &pars.ListReq, rowCount)
+		if auditErr != nil {
+			return fmt.Errorf("service.AuditExport: %w", auditErr)
+		}
+
+		slog.Info("key values exported", "row_count", rowCount, "actor", util.ActorFromCtx(ctx))
+	}
+
+	if err != nil {
+		return fmt.Errorf("service.Export: %w", err)
+	}
+
+	err = flush()
+	if err != nil {
+		return fmt.Errorf("flush: %w", err)
+	}
+
+	return nil
+}
+
+func encodeExportItem(v *model.Main, maskValue bool) *exportItem {
+	value := v.Value
+	if maskValue {
+		value = util.MaskValue(value)
+	}
+
+	return &exportItem{
+		ID:                    v.ID,
+		CreatedAt:             v.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:             v.UpdatedAt.Format(time.RFC3339),
+		ProviderID:            v.ProviderID,
+		ProductID:             v.ProductID,
+		Status:                v.Status,
+		OrderID:               v.OrderID,
+		CustomerPhone:         v.CustomerPhone,
+		ProviderProductID:     v.ProviderProductID,
+		ProviderOrderID:       v.ProviderOrderID,
+		ProviderTransactionID: v.ProviderTransactionID,
+		Value:                 value,
+	}
+}
@@ -0,0 +1,405 @@
+package key
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/samber/lo"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/common/util"
+	importJobModel "github.com/mechta-market/e-product/internal/domain/importjob/model"
+	"github.com/mechta-market/e-product/internal/domain/key/model"
+	"github.com/mechta-market/e-product/internal/errs"
+)
+
+// Import загружает ключи из CSV/XLSX файла с той же проверкой, что и Load.
+// Задание import_job создается до чтения файла, CSV разбирается построчно по мере получения.
+// Ошибка чтения файла и построчный отчет фиксируются в задании
+func (u *Usecase) Import(ctx context.Context, req *importJobModel.ImportReq, file io.Reader) (*importJobModel.Main, error) {
+	err := u.validateImport(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := u.importJobService.Create(ctx, &importJobModel.Edit{
+		FileName: lo.ToPtr(req.FileName),
+		Format:   lo.ToPtr(req.Format),
+		Mode:     lo.ToPtr(req.Mode),
+		Status:   lo.ToPtr(constant.ImportJobStatusRunning),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("importJobService.Create: %w", err)
+	}
+
+	objs, rowNumbers, err := readImport(req, file)
+	if err != nil {
+		u.updateImportJob(ctx, &importJobModel.Edit{
+			ID:     lo.ToPtr(id),
+			Status: lo.ToPtr(constant.ImportJobStatusFailed),
+			Error:  lo.ToPtr(err.Error()),
+		})
+		return nil, err
+	}
+
+	results, err := u.Load(ctx, objs, req.Mode)
+	if err != nil {
+		u.updateImportJob(ctx, &importJobModel.Edit{
+			ID:     lo.ToPtr(id),
+			Status: lo.ToPtr(constant.ImportJobStatusFailed),
+			Error:  lo.ToPtr(err.Error()),
+		})
+		return nil, fmt.Errorf("Load: %w", err)
+	}
+
+	job := encodeImportReport(results, rowNumbers)
+	job.ID = lo.ToPtr(id)
+	job.Status = lo.ToPtr(constant.ImportJobStatusCompleted)
+	job.TotalCount = lo.ToPtr(int64(len(objs)))
+
+	err = u.importJobService.Update(ctx, job)
+	if err != nil {
+		return nil, fmt.Errorf("importJobService.Update: %w", err)
+	}
+
+	result, _, err := u.importJobService.Get(ctx, id, true)
+	if err != nil {
+		return nil, fmt.Errorf("importJobService.Get: %w", err)
+	}
+
+	return result, nil
+}
+
+// readImport читает файл и собирает ключи. Ошибки чтения и разбора возвращаются как errs
+func readImport(req *importJobModel.ImportReq, file io.Reader) ([]*model.Edit, []int, error) {
+	reader := &importReader{r: file}
+
+	objs, rowNumbers, err := readImportFile(req.Format, req.Mapping, reader)
+	if err != nil {
+		var errFull errs.ErrFull
+		switch {
+		case errors.Is(err, errs.FileTooLarge):
+			return nil, nil, errs.FileTooLarge
+		case errors.As(err, &errFull):
+			return nil, nil, errFull
+		}
+
+		if reader.n > 0 {
+			return nil, nil, errs.ErrFull{
+				Err: errs.InvalidFile,
+				Fields: map[string]string{
+					"error": err.Error(),
+				},
+			}
+		}
+	}
+
+	if reader.n == 0 {
+		return nil, nil, errs.ErrFull{
+			Err: errs.EmptyData,
+			Msg: errs.MsgFileEmpty,
+		}
+	}
+
+	if len(objs) == 0 {
+		return nil, nil, errs.ErrFull{
+			Err: errs.EmptyData,
+			Msg: errs.MsgImportNoKeys,
+		}
+	}
+
+	return objs, rowNumbers, nil
+}
+
+func (u *Usecase) GetImportJob(ctx context.Context, id string) (*importJobModel.Main, error) {
+	result, _, err := u.importJobService.Get(ctx, id, true)
+	if err != nil {
+		return nil, fmt.Errorf("importJobService.Get: %w", err)
+	}
+
+	return result, nil
+}
+
+func (u *Usecase) ListImportJobs(ctx context.Context, pars *importJobModel.ListReq) ([]*importJobModel.Main, int64, error) {
+	if err := util.RequirePageSize(pars.ListParams, constant.MaxPageSize); err != nil {
+		return nil, 0, errs.IncorrectPageSize
+	}
+
+	items, tCount, err := u.importJobService.List(ctx, pars)
+	if err != nil {
+		return nil, 0, fmt.Errorf("importJobService.List: %w", err)
+	}
+
+	return items, tCount, nil
+}
+
+func (u *Usecase) updateImportJob(ctx context.Context, obj *importJobModel.Edit) {
+	err := u.importJobService.Update(ctx, obj)
+	if err != nil {
+		slog.Error("importJobService.Update", "error", err, "import_job_id", lo.FromPtr(obj.ID))
+	}
+}
+
+func (u *Usecase) validateImport(_ context.Context, req *importJobModel.ImportReq) error {
+	req.FileName = strings.TrimSpace(req.FileName)
+	req.Format = strings.ToLower(strings.TrimSpace(req.Format))
+
+	// формат не указан явно - определяем по расширению файла
+	if req.Format == "" {
+		req.Format = strings.ToLower(strings.TrimPrefix(filepath.Ext(req.FileName), "."))
+	}
+
+	if req.Format != constant.ImportFormatCSV && req.Format != constant.ImportFormatXLSX {
+		return errs.ErrFull{
+			Err: errs.InvalidImportFormat,
+			Fields: map[string]string{
+				"format": req.Format,
+			},
+		}
+	}
+
+	if req.Mode != constant.LoadModeAllOrNothing {
+		req.Mode = constant.LoadModeBestEffort
+	}
+
+	req.Mapping.ProductIDColumn = strings.TrimSpace(req.Mapping.ProductIDColumn)
+	req.Mapping.ValueColumn = strings.TrimSpace(req.Mapping.ValueColumn)
+	req.Mapping.DefaultProductID = strings.TrimSpace(req.Mapping.DefaultProductID)
+
+	if req.Mapping.ValueColumn == "" {
+		return errs.ErrFull{
+			Err: errs.ImportColumnRequired,
+			Msg: errs.MsgValueColumnRequired,
+		}
+	}
+
+	if req.Mapping.ProductIDColumn == "" && req.Mapping.DefaultProductID == "" {
+		return errs.ErrFull{
+			Err: errs.ImportColumnRequired,
+			Msg: errs.MsgProductColumnRequired,
+		}
+	}
+
+	return nil
+}
+
+// importReader считает прочитанные байты файла и обрывает чтение сверх constant.MaxImportFileSize
+type importReader struct {
+	r io.Reader
+	n int
+}
+
+func (r *importReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += n
+	if r.n > constant.MaxImportFileSize {
+		return n, errs.FileTooLarge
+	}
+
+	return n, err
+}
+
+// readImportFile собирает ключи из файла по сопоставлению колонок. CSV читается построчно,
+// XLSX (zip) - целиком. Для каждого ключа возвращается номер строки в файле (с 1) для отчета
+func readImportFile(format string, mapping importJobModel.Mapping, file io.Reader) ([]*model.Edit, []int, error) {
+	rows := &importRows{mapping: mapping}
+
+	if format == constant.ImportFormatXLSX {
+		f, err := excelize.OpenReader(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("excelize.OpenReader: %w", err)
+		}
+		defer f.Close()
+
+		sheet := mapping.Sheet
+		if sheet == "" {
+			sheet = f.GetSheetName(0)
+		}
+
+		sheetRows, err := f.Rows(sheet)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Rows: %w", err)
+		}
+		defer sheetRows.Close()
+
+		for sheetRows.Next() {
+			row, err := sheetRows.Columns()
+			if err != nil {
+				return nil, nil, fmt.Errorf("Columns: %w", err)
+			}
+
+			if err = rows.add(row); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		return rows.objs, rows.rowNumbers, nil
+	}
+
+	buf := bufio.NewReader(file)
+	if bom, _ := buf.Peek(3); bytes.Equal(bom, []byte("\xef\xbb\xbf")) {
+		_, _ = buf.Discard(3)
+	}
+
+	reader := csv.NewReader(buf)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.ReuseRecord = true
+
+	if mapping.Delimiter != "" {
+		delimiter, _ := utf8.DecodeRuneInString(mapping.Delimiter)
+		reader.Comma = delimiter
+	}
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("csv.Read: %w", err)
+		}
+
+		if err = rows.add(row); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return rows.objs, rows.rowNumbers, nil
+}
+
+// importRows собирает ключи из строк файла по мере чтения. Колонки определяются по первой строке:
+// по заголовку, если он есть, иначе по номеру или букве. Пустые строки пропускаются
+type importRows struct {
+	mapping    importJobModel.Mapping
+	row        int
+	valueIdx   int
+	productIdx int
+	objs       []*model.Edit
+	rowNumbers []int
+}
+
+func (r *importRows) add(row []string) error {
+	r.row++
+
+	if r.row == 1 {
+		var header []string
+		if r.mapping.HasHeader {
+			header = row
+		}
+
+		err := r.resolveColumns(header)
+		if err != nil || r.mapping.HasHeader {
+			return err
+		}
+	}
+
+	if lo.EveryBy(row, func(cell string) bool { return strings.TrimSpace(cell) == "" }) {
+		return nil
+	}
+
+	productID := importCell(row, r.productIdx)
+	if strings.TrimSpace(productID) == "" {
+		productID = r.mapping.DefaultProductID
+	}
+
+	r.objs = append(r.objs, &model.Edit{
+		ProductID: lo.ToPtr(productID),
+		Value:     lo.ToPtr(importCell(row, r.valueIdx)),
+	})
+	r.rowNumbers = append(r.rowNumbers, r.row)
+
+	return nil
+}
+
+func (r *importRows) resolveColumns(header []string) error {
+	var err error
+
+	r.valueIdx, err = importColumnIndex(r.mapping.ValueColumn, header)
+	if err != nil {
+		return err
+	}
+
+	r.productIdx = -1
+	if r.mapping.ProductIDColumn != "" {
+		r.productIdx, err = importColumnIndex(r.mapping.ProductIDColumn, header)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importColumnIndex ищет колонку по названию в заголовке, затем по номеру (с 1)
+// и по букве, как в Excel
+func importColumnIndex(column string, header []string) (int, error) {
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), column) {
+			return i, nil
+		}
+	}
+
+	if n, err := strconv.Atoi(column); err == nil && n > 0 {
+		return n - 1, nil
+	}
+
+	if column == strings.ToUpper(column) {
+		if n, err := excelize.ColumnNameToNumber(column); err == nil {
+			return n - 1, nil
+		}
+	}
+
+	return 0, errs.ErrFull{
+		Err: errs.ImportColumnNotFound,
+		Fields: map[string]string{
+			"column": column,
+		},
+	}
+}
+
+func importCell(row []string, idx int) string {
+	if idx < 0 || idx >= len(row) {
+		return ""
+	}
+
+	return row[idx]
+}
+
+func encodeImportReport(results []*model.LoadResult, rowNumbers []int) *importJobModel.Edit {
+	counts := make(map[string]int64, 5)
+	items := make([]*importJobModel.Item, 0, len(results))
+
+	for _, result := range results {
+		counts[result.Result]++
+
+		items = append(items, &importJobModel.Item{
+			Row:               rowNumbers[result.Index],
+			ProductID:         result.ProductID,
+			Result:            result.Result,
+			ID:                result.ID,
+			ExistingProductID: result.ExistingProductID,
+			Reason:            result.Reason,
+		})
+	}
+
+	return &importJobModel.Edit{
+		CreatedCount:   lo.ToPtr(counts[constant.LoadResultCreated]),
+		DuplicateCount: lo.ToPtr(counts[constant.LoadResultDuplicate]),
+		InvalidCount:   lo.ToPtr(counts[constant.LoadResultInvalid]),
+		SkippedCount:   lo.ToPtr(counts[constant.LoadResultSkipped]),
+		FailedCount:    lo.ToPtr(counts[constant.LoadResultFailed]),
+		Items:          items,
+	}
+}
@@ -2,8 +2,15 @@ package key
 
 import (
 	"context"
+	"time"
 
+	importJobModel "github.com/mechta-market/e-product/internal/domain/importjob/model"
 	"github.com/mechta-market/e-product/internal/domain/key/model"
+	operationModel "github.com/mechta-market/e-product/internal/domain/operation/model"
+	poolLevelModel "github.com/mechta-market/e-product/internal/domain/poollevel/model"
+	productProviderModel "github.com/mechta-market/e-product/internal/domain/productprovider/model"
+	alertModel "github.com/mechta-market/e-product/internal/service/alert/model"
+	callbackModel "github.com/mechta-market/e-product/internal/service/callback/model"
 	mdmModel "github.com/mechta-market/e-product/internal/service/mdm/model"
 	providerModel "github.com/mechta-market/e-product/internal/service/provider/model"
 )
@@ -11,19 +18,86 @@ import (
 type KeyServiceI interface {
 	List(ctx context.Context, pars *model.ListReq) ([]*model.Main, int64, error)
 	Get(ctx context.Context, ID string, errNE bool) (*model.Main, bool, error)
-	GetByOrderID(ctx context.Context, orderID string, errNE bool) (*model.Main, bool, error)
+	GetByOrderAndProductID(ctx context.Context, orderID, productID string) (*model.Main, bool, error)
 	GetByValue(ctx context.Context, value string) (_ *model.Main, finalError error)
 	Update(ctx context.Context, edit *model.Edit) error
+	Transition(ctx context.Context, current *model.Main, obj *model.Edit, reason string) error
+	History(ctx context.Context, id string) ([]*model.Event, error)
+	RevealValue(ctx context.Context, id, reason string) (*model.Main, error)
+	InventoryReport(ctx context.Context, pars *model.ListReq) ([]*model.InventoryItem, error)
+	Export(ctx context.Context, pars *model.ListReq, fn func(item *model.Main) error) error
+	AuditExport(ctx context.Context, pars *model.ListReq, rowCount int64) error
 	Create(ctx context.Context, obj *model.Edit) (string, error)
+	CreateMany(ctx context.Context, objs []*model.Edit) ([]string, error)
+	ClaimNew(ctx context.Context, productID, orderID, customerPhone string) (*model.Main, bool, error)
+	LockOrder(ctx context.Context, orderID, productID string) (string, bool, error)
+	UnlockOrder(ctx context.Context, orderID, productID, token string) error
+	Reencrypt(ctx context.Context, limit uint64) (int, error)
+	Reserve(ctx context.Context, obj *model.ReservationEdit, fromPool bool) (*model.Reservation, error)
+	GetReservation(ctx context.Context, id string, errNE bool) (*model.Reservation, bool, error)
+	GetActiveReservation(ctx context.Context, orderID, productID string) (*model.Reservation, bool, error)
+	ListExpiredReservations(ctx context.Context, limit uint64) ([]*model.Reservation, error)
+	ConfirmReservation(ctx context.Context, reservation *model.Reservation, customerPhone, keyID string) error
+	ReleaseReservation(ctx context.Context, reservation *model.Reservation, status, reason string) error
+	CreateActivation(ctx context.Context, obj *model.ActivationEdit) (*model.Activation, bool, error)
+	GetActivation(ctx context.Context, id string, errNE bool) (*model.Activation, bool, error)
+	ClaimActivation(ctx context.Context, processingTimeout time.Duration) (*model.Activation, error)
+	FinishActivation(ctx context.Context, activation *model.Activation, keyID, errMsg string) error
+	RetryActivation(ctx context.Context, activation *model.Activation, errMsg string, delay time.Duration) error
+}
+
+type OperationServiceI interface {
+	List(ctx context.Context, pars *operationModel.ListReq) ([]*operationModel.Main, int64, error)
+	Update(ctx context.Context, obj *operationModel.Edit) error
+	Create(ctx context.Context, obj *operationModel.Edit) (string, error)
+	Reencrypt(ctx context.Context, limit uint64) (int, error)
+}
+
+type ImportJobServiceI interface {
+	List(ctx context.Context, pars *importJobModel.ListReq) ([]*importJobModel.Main, int64, error)
+	Get(ctx context.Context, id string, errNE bool) (*importJobModel.Main, bool, error)
+	Update(ctx context.Context, obj *importJobModel.Edit) error
+	Create(ctx context.Context, obj *importJobModel.Edit) (string, error)
+}
+
+type PoolLevelServiceI interface {
+	List(ctx context.Context, pars *poolLevelModel.ListReq) ([]*poolLevelModel.Main, int64, error)
+	Get(ctx context.Context, productID string, errNE bool) (*poolLevelModel.Main, bool, error)
+	Set(ctx context.Context, obj *poolLevelModel.Edit) error
+	Delete(ctx context.Context, productID string) error
+}
+
+type ProductProviderServiceI interface {
+	ListByProduct(ctx context.Context, productID string) ([]*productProviderModel.Main, error)
+	Set(ctx context.Context, productID string, items []*productProviderModel.Edit) ([]*productProviderModel.Main, error)
 }
 
 type MdmServiceI interface {
 	FindProduct(ctx context.Context, productID *string) (*mdmModel.Product, bool, error)
 }
 
+type AlertServiceI interface {
+	PoolAlert(ctx context.Context, obj *alertModel.PoolAlert) error
+}
+
+type CallbackServiceI interface {
+	ActivationCallback(ctx context.Context, obj *callbackModel.ActivationCallback) error
+}
+
+type WebhookServiceI interface {
+	Publish(ctx context.Context, event string, data any) (int64, error)
+}
+
 type ProviderServiceI interface {
 	CreateOrder(ctx context.Context, obj *providerModel.OrderRequest) (*providerModel.OrderResponse, error)
 	CancelOrder(ctx context.Context, req *providerModel.CancelRequest) (*providerModel.CancelResponse, error)
 	ListCatalog(ctx context.Context, providerID string) ([]*providerModel.CatalogResponse, error)
 	SupportsPool() bool
 }
+
+// ProviderBreakerI реализуют провайдеры с circuit breaker (resilient.Service)
+type ProviderBreakerI interface {
+	Available() bool
+	BreakerState() *providerModel.BreakerState
+	ResetBreaker()
+}
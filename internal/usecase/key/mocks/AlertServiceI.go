@@ -0,0 +1,48 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/mechta-market/e-product/internal/service/alert/model"
+)
+
+// AlertServiceI is an autogenerated mock type for the AlertServiceI type
+type AlertServiceI struct {
+	mock.Mock
+}
+
+// PoolAlert provides a mock function with given fields: ctx, obj
+func (_m *AlertServiceI) PoolAlert(ctx context.Context, obj *model.PoolAlert) error {
+	ret := _m.Called(ctx, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PoolAlert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.PoolAlert) error); ok {
+		r0 = rf(ctx, obj)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAlertServiceI creates a new instance of AlertServiceI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAlertServiceI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AlertServiceI {
+	mock := &AlertServiceI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
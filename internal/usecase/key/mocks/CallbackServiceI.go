@@ -0,0 +1,48 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/mechta-market/e-product/internal/service/callback/model"
+)
+
+// CallbackServiceI is an autogenerated mock type for the CallbackServiceI type
+type CallbackServiceI struct {
+	mock.Mock
+}
+
+// ActivationCallback provides a mock function with given fields: ctx, obj
+func (_m *CallbackServiceI) ActivationCallback(ctx context.Context, obj *model.ActivationCallback) error {
+	ret := _m.Called(ctx, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActivationCallback")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ActivationCallback) error); ok {
+		r0 = rf(ctx, obj)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCallbackServiceI creates a new instance of CallbackServiceI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCallbackServiceI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CallbackServiceI {
+	mock := &CallbackServiceI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
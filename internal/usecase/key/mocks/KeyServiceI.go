@@ -8,6 +8,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	model "github.com/mechta-market/e-product/internal/domain/key/model"
+
+	time "time"
 )
 
 // KeyServiceI is an autogenerated mock type for the KeyServiceI type
@@ -15,6 +17,109 @@ type KeyServiceI struct {
 	mock.Mock
 }
 
+// AuditExport provides a mock function with given fields: ctx, pars, rowCount
+func (_m *KeyServiceI) AuditExport(ctx context.Context, pars *model.ListReq, rowCount int64) error {
+	ret := _m.Called(ctx, pars, rowCount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuditExport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListReq, int64) error); ok {
+		r0 = rf(ctx, pars, rowCount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClaimActivation provides a mock function with given fields: ctx, processingTimeout
+func (_m *KeyServiceI) ClaimActivation(ctx context.Context, processingTimeout time.Duration) (*model.Activation, error) {
+	ret := _m.Called(ctx, processingTimeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimActivation")
+	}
+
+	var r0 *model.Activation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) (*model.Activation, error)); ok {
+		return rf(ctx, processingTimeout)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) *model.Activation); ok {
+		r0 = rf(ctx, processingTimeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Activation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, processingTimeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClaimNew provides a mock function with given fields: ctx, productID, orderID, customerPhone
+func (_m *KeyServiceI) ClaimNew(ctx context.Context, productID string, orderID string, customerPhone string) (*model.Main, bool, error) {
+	ret := _m.Called(ctx, productID, orderID, customerPhone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimNew")
+	}
+
+	var r0 *model.Main
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*model.Main, bool, error)); ok {
+		return rf(ctx, productID, orderID, customerPhone)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *model.Main); ok {
+		r0 = rf(ctx, productID, orderID, customerPhone)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Main)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) bool); ok {
+		r1 = rf(ctx, productID, orderID, customerPhone)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string) error); ok {
+		r2 = rf(ctx, productID, orderID, customerPhone)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ConfirmReservation provides a mock function with given fields: ctx, reservation, customerPhone, keyID
+func (_m *KeyServiceI) ConfirmReservation(ctx context.Context, reservation *model.Reservation, customerPhone string, keyID string) error {
+	ret := _m.Called(ctx, reservation, customerPhone, keyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Reservation, string, string) error); ok {
+		r0 = rf(ctx, reservation, customerPhone, keyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: ctx, obj
 func (_m *KeyServiceI) Create(ctx context.Context, obj *model.Edit) (string, error) {
 	ret := _m.Called(ctx, obj)
@@ -43,6 +148,109 @@ func (_m *KeyServiceI) Create(ctx context.Context, obj *model.Edit) (string, err
 	return r0, r1
 }
 
+// CreateActivation provides a mock function with given fields: ctx, obj
+func (_m *KeyServiceI) CreateActivation(ctx context.Context, obj *model.ActivationEdit) (*model.Activation, bool, error) {
+	ret := _m.Called(ctx, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateActivation")
+	}
+
+	var r0 *model.Activation
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ActivationEdit) (*model.Activation, bool, error)); ok {
+		return rf(ctx, obj)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ActivationEdit) *model.Activation); ok {
+		r0 = rf(ctx, obj)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Activation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ActivationEdit) bool); ok {
+		r1 = rf(ctx, obj)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *model.ActivationEdit) error); ok {
+		r2 = rf(ctx, obj)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateMany provides a mock function with given fields: ctx, objs
+func (_m *KeyServiceI) CreateMany(ctx context.Context, objs []*model.Edit) ([]string, error) {
+	ret := _m.Called(ctx, objs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMany")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*model.Edit) ([]string, error)); ok {
+		return rf(ctx, objs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*model.Edit) []string); ok {
+		r0 = rf(ctx, objs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*model.Edit) error); ok {
+		r1 = rf(ctx, objs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Export provides a mock function with given fields: ctx, pars, fn
+func (_m *KeyServiceI) Export(ctx context.Context, pars *model.ListReq, fn func(*model.Main) error) error {
+	ret := _m.Called(ctx, pars, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListReq, func(*model.Main) error) error); ok {
+		r0 = rf(ctx, pars, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FinishActivation provides a mock function with given fields: ctx, activation, keyID, errMsg
+func (_m *KeyServiceI) FinishActivation(ctx context.Context, activation *model.Activation, keyID string, errMsg string) error {
+	ret := _m.Called(ctx, activation, keyID, errMsg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishActivation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Activation, string, string) error); ok {
+		r0 = rf(ctx, activation, keyID, errMsg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, ID, errNE
 func (_m *KeyServiceI) Get(ctx context.Context, ID string, errNE bool) (*model.Main, bool, error) {
 	ret := _m.Called(ctx, ID, errNE)
@@ -80,36 +288,110 @@ func (_m *KeyServiceI) Get(ctx context.Context, ID string, errNE bool) (*model.M
 	return r0, r1, r2
 }
 
-// GetByOrderID provides a mock function with given fields: ctx, orderID, errNE
-func (_m *KeyServiceI) GetByOrderID(ctx context.Context, orderID string, errNE bool) (*model.Main, bool, error) {
-	ret := _m.Called(ctx, orderID, errNE)
+// GetActivation provides a mock function with given fields: ctx, id, errNE
+func (_m *KeyServiceI) GetActivation(ctx context.Context, id string, errNE bool) (*model.Activation, bool, error) {
+	ret := _m.Called(ctx, id, errNE)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByOrderID")
+		panic("no return value specified for GetActivation")
 	}
 
-	var r0 *model.Main
+	var r0 *model.Activation
 	var r1 bool
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*model.Main, bool, error)); ok {
-		return rf(ctx, orderID, errNE)
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*model.Activation, bool, error)); ok {
+		return rf(ctx, id, errNE)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *model.Main); ok {
-		r0 = rf(ctx, orderID, errNE)
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *model.Activation); ok {
+		r0 = rf(ctx, id, errNE)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Main)
+			r0 = ret.Get(0).(*model.Activation)
 		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string, bool) bool); ok {
-		r1 = rf(ctx, orderID, errNE)
+		r1 = rf(ctx, id, errNE)
 	} else {
 		r1 = ret.Get(1).(bool)
 	}
 
 	if rf, ok := ret.Get(2).(func(context.Context, string, bool) error); ok {
-		r2 = rf(ctx, orderID, errNE)
+		r2 = rf(ctx, id, errNE)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetActiveReservation provides a mock function with given fields: ctx, orderID, productID
+func (_m *KeyServiceI) GetActiveReservation(ctx context.Context, orderID string, productID string) (*model.Reservation, bool, error) {
+	ret := _m.Called(ctx, orderID, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveReservation")
+	}
+
+	var r0 *model.Reservation
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Reservation, bool, error)); ok {
+		return rf(ctx, orderID, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Reservation); ok {
+		r0 = rf(ctx, orderID, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) bool); ok {
+		r1 = rf(ctx, orderID, productID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, orderID, productID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetByOrderAndProductID provides a mock function with given fields: ctx, orderID, productID
+func (_m *KeyServiceI) GetByOrderAndProductID(ctx context.Context, orderID string, productID string) (*model.Main, bool, error) {
+	ret := _m.Called(ctx, orderID, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByOrderAndProductID")
+	}
+
+	var r0 *model.Main
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Main, bool, error)); ok {
+		return rf(ctx, orderID, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Main); ok {
+		r0 = rf(ctx, orderID, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Main)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) bool); ok {
+		r1 = rf(ctx, orderID, productID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, orderID, productID)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -147,6 +429,103 @@ func (_m *KeyServiceI) GetByValue(ctx context.Context, value string) (*model.Mai
 	return r0, r1
 }
 
+// GetReservation provides a mock function with given fields: ctx, id, errNE
+func (_m *KeyServiceI) GetReservation(ctx context.Context, id string, errNE bool) (*model.Reservation, bool, error) {
+	ret := _m.Called(ctx, id, errNE)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReservation")
+	}
+
+	var r0 *model.Reservation
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*model.Reservation, bool, error)); ok {
+		return rf(ctx, id, errNE)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *model.Reservation); ok {
+		r0 = rf(ctx, id, errNE)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) bool); ok {
+		r1 = rf(ctx, id, errNE)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, bool) error); ok {
+		r2 = rf(ctx, id, errNE)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// History provides a mock function with given fields: ctx, id
+func (_m *KeyServiceI) History(ctx context.Context, id string) ([]*model.Event, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 []*model.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*model.Event, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*model.Event); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InventoryReport provides a mock function with given fields: ctx, pars
+func (_m *KeyServiceI) InventoryReport(ctx context.Context, pars *model.ListReq) ([]*model.InventoryItem, error) {
+	ret := _m.Called(ctx, pars)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InventoryReport")
+	}
+
+	var r0 []*model.InventoryItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListReq) ([]*model.InventoryItem, error)); ok {
+		return rf(ctx, pars)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListReq) []*model.InventoryItem); ok {
+		r0 = rf(ctx, pars)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.InventoryItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListReq) error); ok {
+		r1 = rf(ctx, pars)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, pars
 func (_m *KeyServiceI) List(ctx context.Context, pars *model.ListReq) ([]*model.Main, int64, error) {
 	ret := _m.Called(ctx, pars)
@@ -184,6 +563,231 @@ func (_m *KeyServiceI) List(ctx context.Context, pars *model.ListReq) ([]*model.
 	return r0, r1, r2
 }
 
+// ListExpiredReservations provides a mock function with given fields: ctx, limit
+func (_m *KeyServiceI) ListExpiredReservations(ctx context.Context, limit uint64) ([]*model.Reservation, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExpiredReservations")
+	}
+
+	var r0 []*model.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]*model.Reservation, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []*model.Reservation); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LockOrder provides a mock function with given fields: ctx, orderID, productID
+func (_m *KeyServiceI) LockOrder(ctx context.Context, orderID string, productID string) (string, bool, error) {
+	ret := _m.Called(ctx, orderID, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockOrder")
+	}
+
+	var r0 string
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, bool, error)); ok {
+		return rf(ctx, orderID, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, orderID, productID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) bool); ok {
+		r1 = rf(ctx, orderID, productID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, orderID, productID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Reencrypt provides a mock function with given fields: ctx, limit
+func (_m *KeyServiceI) Reencrypt(ctx context.Context, limit uint64) (int, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reencrypt")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (int, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) int); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseReservation provides a mock function with given fields: ctx, reservation, status, reason
+func (_m *KeyServiceI) ReleaseReservation(ctx context.Context, reservation *model.Reservation, status string, reason string) error {
+	ret := _m.Called(ctx, reservation, status, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Reservation, string, string) error); ok {
+		r0 = rf(ctx, reservation, status, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reserve provides a mock function with given fields: ctx, obj, fromPool
+func (_m *KeyServiceI) Reserve(ctx context.Context, obj *model.ReservationEdit, fromPool bool) (*model.Reservation, error) {
+	ret := _m.Called(ctx, obj, fromPool)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 *model.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ReservationEdit, bool) (*model.Reservation, error)); ok {
+		return rf(ctx, obj, fromPool)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ReservationEdit, bool) *model.Reservation); ok {
+		r0 = rf(ctx, obj, fromPool)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ReservationEdit, bool) error); ok {
+		r1 = rf(ctx, obj, fromPool)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetryActivation provides a mock function with given fields: ctx, activation, errMsg, delay
+func (_m *KeyServiceI) RetryActivation(ctx context.Context, activation *model.Activation, errMsg string, delay time.Duration) error {
+	ret := _m.Called(ctx, activation, errMsg, delay)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryActivation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Activation, string, time.Duration) error); ok {
+		r0 = rf(ctx, activation, errMsg, delay)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevealValue provides a mock function with given fields: ctx, id, reason
+func (_m *KeyServiceI) RevealValue(ctx context.Context, id string, reason string) (*model.Main, error) {
+	ret := _m.Called(ctx, id, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevealValue")
+	}
+
+	var r0 *model.Main
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Main, error)); ok {
+		return rf(ctx, id, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Main); ok {
+		r0 = rf(ctx, id, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Main)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Transition provides a mock function with given fields: ctx, current, obj, reason
+func (_m *KeyServiceI) Transition(ctx context.Context, current *model.Main, obj *model.Edit, reason string) error {
+	ret := _m.Called(ctx, current, obj, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Main, *model.Edit, string) error); ok {
+		r0 = rf(ctx, current, obj, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnlockOrder provides a mock function with given fields: ctx, orderID, productID, token
+func (_m *KeyServiceI) UnlockOrder(ctx context.Context, orderID string, productID string, token string) error {
+	ret := _m.Called(ctx, orderID, productID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlockOrder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, orderID, productID, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, edit
 func (_m *KeyServiceI) Update(ctx context.Context, edit *model.Edit) error {
 	ret := _m.Called(ctx, edit)
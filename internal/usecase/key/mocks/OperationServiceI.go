@@ -0,0 +1,141 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/mechta-market/e-product/internal/domain/operation/model"
+)
+
+// OperationServiceI is an autogenerated mock type for the OperationServiceI type
+type OperationServiceI struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, obj
+func (_m *OperationServiceI) Create(ctx context.Context, obj *model.Edit) (string, error) {
+	ret := _m.Called(ctx, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Edit) (string, error)); ok {
+		return rf(ctx, obj)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Edit) string); ok {
+		r0 = rf(ctx, obj)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Edit) error); ok {
+		r1 = rf(ctx, obj)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, pars
+func (_m *OperationServiceI) List(ctx context.Context, pars *model.ListReq) ([]*model.Main, int64, error) {
+	ret := _m.Called(ctx, pars)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*model.Main
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListReq) ([]*model.Main, int64, error)); ok {
+		return rf(ctx, pars)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListReq) []*model.Main); ok {
+		r0 = rf(ctx, pars)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Main)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListReq) int64); ok {
+		r1 = rf(ctx, pars)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *model.ListReq) error); ok {
+		r2 = rf(ctx, pars)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Reencrypt provides a mock function with given fields: ctx, limit
+func (_m *OperationServiceI) Reencrypt(ctx context.Context, limit uint64) (int, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reencrypt")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (int, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) int); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, obj
+func (_m *OperationServiceI) Update(ctx context.Context, obj *model.Edit) error {
+	ret := _m.Called(ctx, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Edit) error); ok {
+		r0 = rf(ctx, obj)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewOperationServiceI creates a new instance of OperationServiceI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOperationServiceI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OperationServiceI {
+	mock := &OperationServiceI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
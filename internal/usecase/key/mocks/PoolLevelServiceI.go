@@ -0,0 +1,140 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/mechta-market/e-product/internal/domain/poollevel/model"
+)
+
+// PoolLevelServiceI is an autogenerated mock type for the PoolLevelServiceI type
+type PoolLevelServiceI struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, productID
+func (_m *PoolLevelServiceI) Delete(ctx context.Context, productID string) error {
+	ret := _m.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, productID, errNE
+func (_m *PoolLevelServiceI) Get(ctx context.Context, productID string, errNE bool) (*model.Main, bool, error) {
+	ret := _m.Called(ctx, productID, errNE)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.Main
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*model.Main, bool, error)); ok {
+		return rf(ctx, productID, errNE)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *model.Main); ok {
+		r0 = rf(ctx, productID, errNE)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Main)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) bool); ok {
+		r1 = rf(ctx, productID, errNE)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, bool) error); ok {
+		r2 = rf(ctx, productID, errNE)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// List provides a mock function with given fields: ctx, pars
+func (_m *PoolLevelServiceI) List(ctx context.Context, pars *model.ListReq) ([]*model.Main, int64, error) {
+	ret := _m.Called(ctx, pars)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*model.Main
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListReq) ([]*model.Main, int64, error)); ok {
+		return rf(ctx, pars)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListReq) []*model.Main); ok {
+		r0 = rf(ctx, pars)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Main)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListReq) int64); ok {
+		r1 = rf(ctx, pars)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *model.ListReq) error); ok {
+		r2 = rf(ctx, pars)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Set provides a mock function with given fields: ctx, obj
+func (_m *PoolLevelServiceI) Set(ctx context.Context, obj *model.Edit) error {
+	ret := _m.Called(ctx, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Set")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Edit) error); ok {
+		r0 = rf(ctx, obj)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPoolLevelServiceI creates a new instance of PoolLevelServiceI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPoolLevelServiceI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PoolLevelServiceI {
+	mock := &PoolLevelServiceI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
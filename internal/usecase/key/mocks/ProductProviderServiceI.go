@@ -0,0 +1,90 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/mechta-market/e-product/internal/domain/productprovider/model"
+)
+
+// ProductProviderServiceI is an autogenerated mock type for the ProductProviderServiceI type
+type ProductProviderServiceI struct {
+	mock.Mock
+}
+
+// ListByProduct provides a mock function with given fields: ctx, productID
+func (_m *ProductProviderServiceI) ListByProduct(ctx context.Context, productID string) ([]*model.Main, error) {
+	ret := _m.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByProduct")
+	}
+
+	var r0 []*model.Main
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*model.Main, error)); ok {
+		return rf(ctx, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*model.Main); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Main)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Set provides a mock function with given fields: ctx, productID, items
+func (_m *ProductProviderServiceI) Set(ctx context.Context, productID string, items []*model.Edit) ([]*model.Main, error) {
+	ret := _m.Called(ctx, productID, items)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Set")
+	}
+
+	var r0 []*model.Main
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []*model.Edit) ([]*model.Main, error)); ok {
+		return rf(ctx, productID, items)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []*model.Edit) []*model.Main); ok {
+		r0 = rf(ctx, productID, items)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Main)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []*model.Edit) error); ok {
+		r1 = rf(ctx, productID, items)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewProductProviderServiceI creates a new instance of ProductProviderServiceI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProductProviderServiceI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProductProviderServiceI {
+	mock := &ProductProviderServiceI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,70 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "github.com/mechta-market/e-product/internal/service/provider/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ProviderBreakerI is an autogenerated mock type for the ProviderBreakerI type
+type ProviderBreakerI struct {
+	mock.Mock
+}
+
+// Available provides a mock function with no fields
+func (_m *ProviderBreakerI) Available() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Available")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// BreakerState provides a mock function with no fields
+func (_m *ProviderBreakerI) BreakerState() *model.BreakerState {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for BreakerState")
+	}
+
+	var r0 *model.BreakerState
+	if rf, ok := ret.Get(0).(func() *model.BreakerState); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BreakerState)
+		}
+	}
+
+	return r0
+}
+
+// ResetBreaker provides a mock function with no fields
+func (_m *ProviderBreakerI) ResetBreaker() {
+	_m.Called()
+}
+
+// NewProviderBreakerI creates a new instance of ProviderBreakerI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProviderBreakerI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProviderBreakerI {
+	mock := &ProviderBreakerI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,162 @@
+package key
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	commonModel "github.com/mechta-market/e-product/internal/domain/common/model"
+	"github.com/mechta-market/e-product/internal/domain/key/model"
+	operationModel "github.com/mechta-market/e-product/internal/domain/operation/model"
+)
+
+const (
+	// операции моложе этого возраста еще могут завершаться в createOrder
+	reconcilePurchasedDelay = time.Minute
+	// после этого времени исход обращения к провайдеру считается неизвестным
+	reconcileRequestedTimeout = 10 * time.Minute
+
+	reconcileBatchSize = 100
+)
+
+// Reconcile разбирает зависшие операции журнала provider_operation:
+// купленные, но не сохраненные ключи сохраняются в пул или закрепляются за заказом, а операции
+// с неизвестным исходом отправляются на ручную проверку
+func (u *Usecase) Reconcile(ctx context.Context) error {
+	err := u.reconcilePurchased(ctx)
+	if err != nil {
+		return fmt.Errorf("reconcilePurchased: %w", err)
+	}
+
+	err = u.reconcileRequested(ctx)
+	if err != nil {
+		return fmt.Errorf("reconcileRequested: %w", err)
+	}
+
+	return nil
+}
+
+func (u *Usecase) reconcilePurchased(ctx context.Context) error {
+	items, _, err := u.operationService.List(ctx, &operationModel.ListReq{
+		ListParams: commonModel.ListParams{
+			PageSize: reconcileBatchSize,
+		},
+		Status:        lo.ToPtr(constant.OperationStatusPurchased),
+		UpdatedBefore: lo.ToPtr(time.Now().Add(-reconcilePurchasedDelay)),
+	})
+	if err != nil {
+		return fmt.Errorf("operationService.List: %w", err)
+	}
+
+	for _, item := range items {
+		err = u.storePurchased(ctx, item)
+		if err != nil {
+			slog.Error("reconcile: storePurchased", "error", err, "operation_id", item.ID)
+		}
+	}
+
+	return nil
+}
+
+func (u *Usecase) storePurchased(ctx context.Context, op *operationModel.Main) error {
+	if op.Value == "" {
+		return u.operationService.Update(ctx, &operationModel.Edit{
+			ID:     lo.ToPtr(op.ID),
+			Status: lo.ToPtr(constant.OperationStatusManualReview),
+			Error:  lo.ToPtr("provider returned empty value"),
+		})
+	}
+
+	keyID := ""
+
+	existing, err := u.service.GetByValue(ctx, op.Value)
+	if err != nil {
+		return fmt.Errorf("service.GetByValue: %w", err)
+	}
+
+	if existing != nil {
+		keyID = existing.ID
+	} else {
+		obj := &model.Edit{
+			Value:                 lo.ToPtr(op.Value),
+			ProductID:             lo.ToPtr(op.ProductID),
+			ProviderID:            lo.ToPtr(op.ProviderID),
+			ProviderProductID:     lo.ToPtr(op.ProviderProductID),
+			ProviderTransactionID: lo.ToPtr(op.ProviderTransactionID),
+			ProviderOrderID:       lo.ToPtr(op.ProviderOrderID),
+			// операция без заказа - пополнение пула
+			Source: lo.ToPtr(constant.KeySourcePool),
+		}
+
+		// ключ куплен для заказа: в пул он не попадает, а закрепляется за заказом выданным,
+		// повторный Activate по заказу вернет его
+		if op.OrderID != "" {
+			issued, found, err := u.service.GetByOrderAndProductID(ctx, op.OrderID, op.ProductID)
+			if err != nil {
+				return fmt.Errorf("service.GetByOrderAndProductID: %w", err)
+			}
+			if found {
+				return u.operationService.Update(ctx, &operationModel.Edit{
+					ID:     lo.ToPtr(op.ID),
+					Status: lo.ToPtr(constant.OperationStatusManualReview),
+					Error:  lo.ToPtr("order already has key " + issued.ID),
+				})
+			}
+
+			obj.Source = lo.ToPtr(constant.KeySourceProvider)
+			obj.Status = lo.ToPtr(constant.KeyStatusActivated)
+			obj.OrderID = lo.ToPtr(op.OrderID)
+		}
+
+		keyID, err = u.service.Create(ctx, obj)
+		if err != nil {
+			return fmt.Errorf("service.Create: %w", err)
+		}
+	}
+
+	err = u.operationService.Update(ctx, &operationModel.Edit{
+		ID:     lo.ToPtr(op.ID),
+		Status: lo.ToPtr(constant.OperationStatusStored),
+		KeyID:  lo.ToPtr(keyID),
+	})
+	if err != nil {
+		return fmt.Errorf("operationService.Update: %w", err)
+	}
+
+	slog.Info("reconcile: purchased key stored", "operation_id", op.ID, "key_id", keyID)
+
+	return nil
+}
+
+func (u *Usecase) reconcileRequested(ctx context.Context) error {
+	items, _, err := u.operationService.List(ctx, &operationModel.ListReq{
+		ListParams: commonModel.ListParams{
+			PageSize: reconcileBatchSize,
+		},
+		Status:        lo.ToPtr(constant.OperationStatusRequested),
+		UpdatedBefore: lo.ToPtr(time.Now().Add(-reconcileRequestedTimeout)),
+	})
+	if err != nil {
+		return fmt.Errorf("operationService.List: %w", err)
+	}
+
+	for _, item := range items {
+		err = u.operationService.Update(ctx, &operationModel.Edit{
+			ID:     lo.ToPtr(item.ID),
+			Status: lo.ToPtr(constant.OperationStatusManualReview),
+			Error:  lo.ToPtr("provider outcome is unknown"),
+		})
+		if err != nil {
+			slog.Error("reconcile: operationService.Update", "error", err, "operation_id", item.ID)
+			continue
+		}
+
+		slog.Warn("reconcile: operation flagged for manual review", "operation_id", item.ID, "order_id", item.OrderID)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package key
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+const reencryptBatchSize = 500
+
+// Reencrypt переводит значения ключей и журнала provider_operation на активный мастер-ключ.
+// После ротации старый ключ можно убрать из конфигурации, когда задача обработает все строки
+func (u *Usecase) Reencrypt(ctx context.Context) error {
+	count, err := reencryptAll(ctx, u.service.Reencrypt)
+	if count > 0 {
+		slog.Info("keys reencrypted", "count", count)
+	}
+	if err != nil {
+		return fmt.Errorf("service.Reencrypt: %w", err)
+	}
+
+	count, err = reencryptAll(ctx, u.operationService.Reencrypt)
+	if count > 0 {
+		slog.Info("provider operations reencrypted", "count", count)
+	}
+	if err != nil {
+		return fmt.Errorf("operationService.Reencrypt: %w", err)
+	}
+
+	return nil
+}
+
+func reencryptAll(ctx context.Context, fn func(ctx context.Context, limit uint64) (int, error)) (int, error) {
+	total := 0
+
+	for ctx.Err() == nil {
+		count, err := fn(ctx, reencryptBatchSize)
+		total += count
+		if err != nil {
+			return total, err
+		}
+
+		if count < reencryptBatchSize {
+			break
+		}
+	}
+
+	return total, nil
+}
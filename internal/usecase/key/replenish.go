@@ -0,0 +1,314 @@
+package key
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	commonModel "github.com/mechta-market/e-product/internal/domain/common/model"
+	"github.com/mechta-market/e-product/internal/domain/common/util"
+	"github.com/mechta-market/e-product/internal/domain/key/model"
+	operationModel "github.com/mechta-market/e-product/internal/domain/operation/model"
+	poolLevelModel "github.com/mechta-market/e-product/internal/domain/poollevel/model"
+	webhookModel "github.com/mechta-market/e-product/internal/domain/webhook/model"
+	"github.com/mechta-market/e-product/internal/errs"
+	alertModel "github.com/mechta-market/e-product/internal/service/alert/model"
+)
+
+// ListPoolLevels возвращает пороги пулов вместе с текущим числом свободных ключей
+func (u *Usecase) ListPoolLevels(ctx context.Context, pars *poolLevelModel.ListReq) ([]*poolLevelModel.State, int64, error) {
+	if err := util.RequirePageSize(pars.ListParams, constant.MaxPageSize); err != nil {
+		return nil, 0, errs.IncorrectPageSize
+	}
+
+	levels, tCount, err := u.poolLevelService.List(ctx, pars)
+	if err != nil {
+		return nil, 0, fmt.Errorf("poolLevelService.List: %w", err)
+	}
+
+	result := make([]*poolLevelModel.State, 0, len(levels))
+	for _, level := range levels {
+		available, err := u.countAvailable(ctx, level.ProductID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("countAvailable: %w", err)
+		}
+
+		result = append(result, &poolLevelModel.State{
+			Level:     level,
+			Available: available,
+		})
+	}
+
+	return result, tCount, nil
+}
+
+// SetPoolLevel задает пороги пула продукта. Пополнять можно только пулы продуктов,
+// у которых хотя бы один провайдер работает с пулом
+func (u *Usecase) SetPoolLevel(ctx context.Context, obj *poolLevelModel.Edit) (*poolLevelModel.Main, error) {
+	if err := u.validatePoolLevel(ctx, obj); err != nil {
+		return nil, err
+	}
+
+	poolRoutes, err := u.poolRoutes(ctx, obj.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("poolRoutes: %w", err)
+	}
+
+	if len(poolRoutes) == 0 {
+		return nil, errs.ErrFull{
+			Err: errs.PoolNotSupported,
+			Fields: map[string]string{
+				"productID": obj.ProductID,
+			},
+		}
+	}
+
+	err = u.poolLevelService.Set(ctx, obj)
+	if err != nil {
+		return nil, fmt.Errorf("poolLevelService.Set: %w", err)
+	}
+
+	result, _, err := u.poolLevelService.Get(ctx, obj.ProductID, true)
+	if err != nil {
+		return nil, fmt.Errorf("poolLevelService.Get: %w", err)
+	}
+
+	return result, nil
+}
+
+// DeletePoolLevel отключает пополнение пула продукта
+func (u *Usecase) DeletePoolLevel(ctx context.Context, productID string) error {
+	productID = strings.TrimSpace(productID)
+	if productID == "" {
+		return errs.ProductIDRequired
+	}
+
+	err := u.poolLevelService.Delete(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("poolLevelService.Delete: %w", err)
+	}
+
+	return nil
+}
+
+// Replenish докупает ключи в пулы, опустившиеся ниже минимального уровня, до целевого уровня.
+// dailyCap - общий лимит покупок на пополнение за сутки, 0 - без ограничения.
+// Если пул не удалось поднять до минимума, отправляется оповещение
+func (u *Usecase) Replenish(ctx context.Context, dailyCap int64) ([]*poolLevelModel.State, error) {
+	levels, _, err := u.poolLevelService.List(ctx, &poolLevelModel.ListReq{})
+	if err != nil {
+		return nil, fmt.Errorf("poolLevelService.List: %w", err)
+	}
+
+	dayStart := startOfDay(time.Now())
+
+	// -1 - без ограничения
+	left := int64(-1)
+	if dailyCap > 0 {
+		spent, err := u.countReplenished(ctx, nil, dayStart)
+		if err != nil {
+			return nil, fmt.Errorf("countReplenished: %w", err)
+		}
+		left = max(dailyCap-spent, 0)
+	}
+
+	result := make([]*poolLevelModel.State, 0, len(levels))
+	for _, level := range levels {
+		if ctx.Err() != nil {
+			break
+		}
+
+		state, err := u.replenishProduct(ctx, level, dayStart, left)
+		if err != nil {
+			return nil, fmt.Errorf("replenishProduct: %w", err)
+		}
+
+		if left > 0 {
+			left = max(left-state.Purchased, 0)
+		}
+
+		if state.Purchased > 0 {
+			slog.Info("pool replenished", "product_id", level.ProductID, "purchased", state.Purchased, "available", state.Available)
+		}
+
+		if state.Low() {
+			u.alertPool(ctx, state)
+		}
+
+		result = append(result, state)
+	}
+
+	return result, nil
+}
+
+// replenishProduct докупает ключи одного продукта. Ошибки провайдера не прерывают пополнение
+// остальных продуктов и возвращаются в State.Error
+func (u *Usecase) replenishProduct(ctx context.Context, level *poolLevelModel.Main, dayStart time.Time, left int64) (*poolLevelModel.State, error) {
+	state := &poolLevelModel.State{Level: level}
+
+	available, err := u.countAvailable(ctx, level.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("countAvailable: %w", err)
+	}
+	state.Available = available
+
+	if !state.Low() {
+		return state, nil
+	}
+
+	need := level.TargetLevel - available
+
+	if level.DailyCap > 0 {
+		spent, err := u.countReplenished(ctx, lo.ToPtr(level.ProductID), dayStart)
+		if err != nil {
+			return nil, fmt.Errorf("countReplenished: %w", err)
+		}
+
+		if productLeft := max(level.DailyCap-spent, 0); need > productLeft {
+			need = productLeft
+			state.CapReached = true
+		}
+	}
+
+	if left >= 0 && need > left {
+		need = left
+		state.CapReached = true
+	}
+
+	if need <= 0 {
+		return state, nil
+	}
+
+	poolRoutes, err := u.poolRoutes(ctx, level.ProductID)
+	if err != nil {
+		state.Error = fmt.Sprintf("poolRoutes: %s", err)
+		return state, nil
+	}
+
+	if len(poolRoutes) == 0 {
+		state.Error = errs.PoolNotSupported.Error()
+		return state, nil
+	}
+
+	for range need {
+		if ctx.Err() != nil {
+			break
+		}
+
+		err = u.replenishOne(ctx, poolRoutes)
+		if err != nil {
+			// провайдеры недоступны, следующий запуск попробует снова
+			state.Failed++
+			state.Error = err.Error()
+			break
+		}
+
+		state.Purchased++
+		state.Available++
+	}
+
+	return state, nil
+}
+
+// replenishOne докупает в пул один ключ у первого продавшего провайдера из poolRoutes
+func (u *Usecase) replenishOne(ctx context.Context, poolRoutes []*poolRoute) error {
+	var err error
+
+	for _, route := range poolRoutes {
+		_, err = u.createOrder(ctx, route.providerService, route.product, "", "", constant.KeySourcePool)
+		if err == nil || errors.Is(err, errKeyNotStored) {
+			return err
+		}
+
+		slog.Error("replenish: createOrder", "error", err, "provider_id", route.product.ProviderID, "product_id", route.product.ProductID)
+	}
+
+	return err
+}
+
+// countAvailable возвращает число свободных ключей продукта в пуле
+func (u *Usecase) countAvailable(ctx context.Context, productID string) (int64, error) {
+	_, count, err := u.service.List(ctx, &model.ListReq{
+		ListParams: commonModel.ListParams{OnlyCount: true},
+		ProductID:  lo.ToPtr(productID),
+		Status:     lo.ToPtr(constant.KeyStatusNew),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("service.List: %w", err)
+	}
+
+	return count, nil
+}
+
+// countReplenished возвращает число покупок на пополнение пула (без заказа) с since, неудачные не считаются.
+// productID == nil - по всем продуктам
+func (u *Usecase) countReplenished(ctx context.Context, productID *string, since time.Time) (int64, error) {
+	_, count, err := u.operationService.List(ctx, &operationModel.ListReq{
+		ListParams:    commonModel.ListParams{OnlyCount: true},
+		ExcludeStatus: lo.ToPtr(constant.OperationStatusFailed),
+		ProductID:     productID,
+		OrderID:       lo.ToPtr(""),
+		CreatedAfter:  lo.ToPtr(since),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("operationService.List: %w", err)
+	}
+
+	return count, nil
+}
+
+// alertPool не прерывает пополнение: оповещение повторится при следующем запуске
+func (u *Usecase) alertPool(ctx context.Context, state *poolLevelModel.State) {
+	err := u.alertService.PoolAlert(ctx, &alertModel.PoolAlert{
+		ProductID:   state.Level.ProductID,
+		MinLevel:    state.Level.MinLevel,
+		TargetLevel: state.Level.TargetLevel,
+		Available:   state.Available,
+		Purchased:   state.Purchased,
+		Failed:      state.Failed,
+		CapReached:  state.CapReached,
+		Error:       state.Error,
+	})
+	if err != nil {
+		slog.Error("alertService.PoolAlert", "error", err, "product_id", state.Level.ProductID)
+	}
+
+	u.publish(ctx, constant.WebhookEventPoolLow, &webhookModel.PoolLowEvent{
+		ProductID:   state.Level.ProductID,
+		MinLevel:    state.Level.MinLevel,
+		TargetLevel: state.Level.TargetLevel,
+		Available:   state.Available,
+		CapReached:  state.CapReached,
+		Error:       state.Error,
+	})
+}
+
+func (u *Usecase) validatePoolLevel(_ context.Context, obj *poolLevelModel.Edit) error {
+	obj.ProductID = strings.TrimSpace(obj.ProductID)
+	if obj.ProductID == "" {
+		return errs.ProductIDRequired
+	}
+
+	minLevel := lo.FromPtr(obj.MinLevel)
+	targetLevel := lo.FromPtr(obj.TargetLevel)
+
+	if minLevel < 0 || targetLevel <= 0 || targetLevel < minLevel || lo.FromPtr(obj.DailyCap) < 0 {
+		return errs.ErrFull{
+			Err: errs.InvalidPoolLevel,
+		}
+	}
+
+	return nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,178 @@
+package key
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/key/model"
+	"github.com/mechta-market/e-product/internal/errs"
+)
+
+const releaseBatchSize = 100
+
+// Reserve удерживает ключ заказа на время оплаты. Если провайдер работает с пулом, резервируется
+// свободный ключ из пула, иначе ключ покупается у провайдера при Confirm.
+// Повторный вызов по тому же заказу возвращает действующий резерв
+func (u *Usecase) Reserve(ctx context.Context, productID, orderID string) (*model.Reservation, error) {
+	productID = strings.TrimSpace(productID)
+	orderID = strings.TrimSpace(orderID)
+
+	if orderID == "" {
+		return nil, errs.OrderIDRequired
+	}
+
+	if productID == "" {
+		return nil, errs.ProductIDRequired
+	}
+
+	unlock, err := u.lockOrder(ctx, orderID, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	issued, err := u.getIssued(ctx, orderID, productID)
+	if err != nil {
+		return nil, fmt.Errorf("getIssued: %w", err)
+	}
+	if issued != nil {
+		return nil, errs.AlreadyActivated
+	}
+
+	reservation, found, err := u.service.GetActiveReservation(ctx, orderID, productID)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetActiveReservation: %w", err)
+	}
+	if found {
+		return reservation, nil
+	}
+
+	poolRoutes, err := u.poolRoutes(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("poolRoutes: %w", err)
+	}
+
+	reservation, err = u.service.Reserve(ctx, &model.ReservationEdit{
+		ProductID: lo.ToPtr(productID),
+		OrderID:   lo.ToPtr(orderID),
+		ExpiresAt: lo.ToPtr(time.Now().Add(constant.ReservationTTL)),
+	}, len(poolRoutes) > 0)
+	if err != nil {
+		return nil, fmt.Errorf("service.Reserve: %w", err)
+	}
+
+	return reservation, nil
+}
+
+// Confirm выдает зарезервированный ключ после оплаты. Повторный вызов возвращает тот же ключ
+func (u *Usecase) Confirm(ctx context.Context, reservationID, customerPhone string) (*model.Main, error) {
+	reservationID = strings.TrimSpace(reservationID)
+	if reservationID == "" {
+		return nil, errs.IDRequired
+	}
+
+	reservation, _, err := u.service.GetReservation(ctx, reservationID, true)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetReservation: %w", err)
+	}
+
+	switch reservation.Status {
+	case constant.ReservationStatusConfirmed:
+		key, _, err := u.service.Get(ctx, reservation.KeyID, true)
+		if err != nil {
+			return nil, fmt.Errorf("service.Get: %w", err)
+		}
+
+		return key, nil
+	case constant.ReservationStatusActive:
+		if time.Now().After(reservation.ExpiresAt) {
+			return nil, errs.ReservationExpired
+		}
+	case constant.ReservationStatusExpired:
+		return nil, errs.ReservationExpired
+	default:
+		return nil, errs.ReservationNotActive
+	}
+
+	return u.Activate(ctx, reservation.ProductID, reservation.OrderID, customerPhone)
+}
+
+// Release снимает резерв, ключ из пула возвращается в статус new
+func (u *Usecase) Release(ctx context.Context, reservationID string) error {
+	reservationID = strings.TrimSpace(reservationID)
+	if reservationID == "" {
+		return errs.IDRequired
+	}
+
+	reservation, _, err := u.service.GetReservation(ctx, reservationID, true)
+	if err != nil {
+		return fmt.Errorf("service.GetReservation: %w", err)
+	}
+
+	switch reservation.Status {
+	case constant.ReservationStatusReleased, constant.ReservationStatusExpired:
+		return nil
+	case constant.ReservationStatusConfirmed:
+		return errs.ReservationNotActive
+	}
+
+	err = u.service.ReleaseReservation(ctx, reservation, constant.ReservationStatusReleased, constant.EventReasonReservationReleased)
+	if err != nil {
+		return fmt.Errorf("service.ReleaseReservation: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseExpired снимает истекшие резервы и возвращает удерживаемые ими ключи в пул
+func (u *Usecase) ReleaseExpired(ctx context.Context) error {
+	for ctx.Err() == nil {
+		items, err := u.service.ListExpiredReservations(ctx, releaseBatchSize)
+		if err != nil {
+			return fmt.Errorf("service.ListExpiredReservations: %w", err)
+		}
+
+		released := 0
+		for _, item := range items {
+			err = u.service.ReleaseReservation(ctx, item, constant.ReservationStatusExpired, constant.EventReasonReservationExpired)
+			if err != nil {
+				// резерв мог успеть подтвердиться параллельно
+				slog.Warn("service.ReleaseReservation", "error", err, "reservation_id", item.ID)
+				continue
+			}
+			released++
+		}
+
+		if released > 0 {
+			slog.Info("expired reservations released", "count", released)
+		}
+
+		// неполная пачка - больше истекших нет, ни одного снятого - следующая пачка будет той же
+		if len(items) < releaseBatchSize || released == 0 {
+			break
+		}
+	}
+
+	return nil
+}
+
+// confirmReserved активирует ключ из пула, удерживаемый резервом
+func (u *Usecase) confirmReserved(ctx context.Context, reservation *model.Reservation, customerPhone string) (*model.Main, error) {
+	err := u.service.ConfirmReservation(ctx, reservation, customerPhone, "")
+	if err != nil {
+		return nil, fmt.Errorf("service.ConfirmReservation: %w", err)
+	}
+
+	key, _, err := u.service.Get(ctx, reservation.KeyID, true)
+	if err != nil {
+		return nil, fmt.Errorf("service.Get: %w", err)
+	}
+
+	return key, nil
+}
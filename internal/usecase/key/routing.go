@@ -0,0 +1,214 @@
+package key
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	operationModel "github.com/mechta-market/e-product/internal/domain/operation/model"
+	productProviderModel "github.com/mechta-market/e-product/internal/domain/productprovider/model"
+	"github.com/mechta-market/e-product/internal/errs"
+	mdmModel "github.com/mechta-market/e-product/internal/service/mdm/model"
+)
+
+// ListProductProviders провайдеры продукта в порядке, в котором они пробуются при выдаче ключа
+func (u *Usecase) ListProductProviders(ctx context.Context, productID string) ([]*productProviderModel.Main, error) {
+	productID = strings.TrimSpace(productID)
+	if productID == "" {
+		return nil, errs.ProductIDRequired
+	}
+
+	items, err := u.routeService.ListByProduct(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("routeService.ListByProduct: %w", err)
+	}
+
+	return items, nil
+}
+
+// SetProductProviders заменяет провайдеров продукта, порядок items - порядок попыток.
+// Пустой список возвращает продукт к провайдеру из mdm
+func (u *Usecase) SetProductProviders(ctx context.Context, productID string, items []*productProviderModel.Edit) ([]*productProviderModel.Main, error) {
+	productID = strings.TrimSpace(productID)
+	if productID == "" {
+		return nil, errs.ProductIDRequired
+	}
+
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		item.ProviderID = strings.TrimSpace(item.ProviderID)
+		item.ProviderProductID = strings.TrimSpace(item.ProviderProductID)
+
+		if item.ProviderID == "" {
+			return nil, errs.ProviderIDRequired
+		}
+		if _, err := u.getProvider(item.ProviderID); err != nil {
+			return nil, err
+		}
+		if seen[item.ProviderID] {
+			return nil, errs.ErrFull{
+				Err: errs.AlreadyExists,
+				Fields: map[string]string{
+					"providerID": item.ProviderID,
+				},
+			}
+		}
+		seen[item.ProviderID] = true
+
+		if item.ProviderProductID == "" {
+			return nil, errs.ErrFull{
+				Err: errs.ProviderProductIDRequired,
+				Fields: map[string]string{
+					"providerID": item.ProviderID,
+				},
+			}
+		}
+	}
+
+	result, err := u.routeService.Set(ctx, productID, items)
+	if err != nil {
+		return nil, fmt.Errorf("routeService.Set: %w", err)
+	}
+
+	return result, nil
+}
+
+// productRoutes варианты покупки продукта по порядку: привязки из product_provider,
+// если их нет - провайдер из mdm
+func (u *Usecase) productRoutes(ctx context.Context, product *mdmModel.Product) ([]*mdmModel.Product, error) {
+	items, err := u.routeService.ListByProduct(ctx, product.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("routeService.ListByProduct: %w", err)
+	}
+
+	if len(items) == 0 {
+		return []*mdmModel.Product{product}, nil
+	}
+
+	return lo.Map(items, func(item *productProviderModel.Main, _ int) *mdmModel.Product {
+		return &mdmModel.Product{
+			ProductID:          product.ProductID,
+			ProviderID:         item.ProviderID,
+			ProviderProductID:  item.ProviderProductID,
+			PromotionKey:       lo.EmptyableToPtr(item.PromotionKey),
+			ProviderExternalID: lo.EmptyableToPtr(item.ProviderExternalProductID),
+		}
+	}), nil
+}
+
+// orderByRoutes покупает ключ у первого ответившего провайдера и возвращает id ключа.
+// Пустой id - ни один провайдер не продал ключ, но хотя бы один работает с пулом.
+// Каждая попытка фиксируется в provider_operation: пропущенные провайдеры - со статусом skipped
+func (u *Usecase) orderByRoutes(ctx context.Context, routes []*mdmModel.Product, orderID, customerPhone string) (string, error) {
+	poolAllowed := false
+	var lastErr error
+
+	for _, route := range routes {
+		providerService, err := u.getProvider(route.ProviderID)
+		if err != nil {
+			u.skipRoute(ctx, route, orderID, err)
+			lastErr = err
+			continue
+		}
+
+		if providerService.SupportsPool() {
+			poolAllowed = true
+		}
+
+		if !providerAvailable(providerService) {
+			u.skipRoute(ctx, route, orderID, errs.ServiceNA)
+			continue
+		}
+
+		id, err := u.createOrder(ctx, providerService, route, orderID, customerPhone, constant.KeySourceProvider) // customerPhone для megogo
+		if err == nil {
+			return id, nil
+		}
+
+		// ключ уже куплен: другой провайдер продал бы заказу второй ключ, купленный сохранит Reconcile
+		if errors.Is(err, errKeyNotStored) {
+			return "", err
+		}
+
+		slog.Error("createOrder", "error", err, "provider_id", route.ProviderID, "order_id", orderID)
+	}
+
+	if poolAllowed {
+		return "", nil
+	}
+
+	// ни один провайдер не подключен
+	if lastErr != nil && len(routes) == 1 {
+		return "", fmt.Errorf("getProvider: %w", lastErr)
+	}
+
+	return "", errs.ServiceNA
+}
+
+// skipRoute фиксирует провайдера, к которому не обращались
+func (u *Usecase) skipRoute(ctx context.Context, route *mdmModel.Product, orderID string, reason error) {
+	slog.Warn("provider skipped", "provider_id", route.ProviderID, "product_id", route.ProductID, "order_id", orderID, "reason", reason)
+
+	_, err := u.operationService.Create(ctx, &operationModel.Edit{
+		ProviderID:        lo.ToPtr(route.ProviderID),
+		ProductID:         lo.ToPtr(route.ProductID),
+		ProviderProductID: lo.ToPtr(route.ProviderProductID),
+		OrderID:           lo.ToPtr(orderID),
+		Status:            lo.ToPtr(constant.OperationStatusSkipped),
+		Error:             lo.ToPtr(reason.Error()),
+	})
+	if err != nil {
+		slog.Error("operationService.Create", "error", err, "provider_id", route.ProviderID, "order_id", orderID)
+	}
+}
+
+// poolRoute вариант покупки продукта у провайдера, работающего с пулом
+type poolRoute struct {
+	product         *mdmModel.Product
+	providerService ProviderServiceI
+}
+
+// poolRoutes варианты покупки продукта в пул в порядке productRoutes: только подключенные провайдеры,
+// работающие с пулом. Ошибка - не подключен ни один провайдер продукта
+func (u *Usecase) poolRoutes(ctx context.Context, productID string) ([]*poolRoute, error) {
+	product, _, err := u.mdmService.FindProduct(ctx, &productID)
+	if err != nil {
+		return nil, fmt.Errorf("mdmService.FindProduct: %w", err)
+	}
+
+	routes, err := u.productRoutes(ctx, product)
+	if err != nil {
+		return nil, fmt.Errorf("productRoutes: %w", err)
+	}
+
+	var result []*poolRoute
+	var lastErr error
+	connected := false
+
+	for _, route := range routes {
+		providerService, err := u.getProvider(route.ProviderID)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		connected = true
+
+		if providerService.SupportsPool() {
+			result = append(result, &poolRoute{
+				product:         route,
+				providerService: providerService,
+			})
+		}
+	}
+
+	if !connected {
+		return nil, fmt.Errorf("getProvider: %w", lastErr)
+	}
+
+	return result, nil
+}
@@ -2,31 +2,61 @@ package key
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/samber/lo"
 	"log/slog"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/mechta-market/e-product/internal/constant"
 	commonModel "github.com/mechta-market/e-product/internal/domain/common/model"
 	"github.com/mechta-market/e-product/internal/domain/common/util"
 	"github.com/mechta-market/e-product/internal/domain/key/model"
+	operationModel "github.com/mechta-market/e-product/internal/domain/operation/model"
+	webhookModel "github.com/mechta-market/e-product/internal/domain/webhook/model"
 	"github.com/mechta-market/e-product/internal/errs"
 	mdmModel "github.com/mechta-market/e-product/internal/service/mdm/model"
 	providerModel "github.com/mechta-market/e-product/internal/service/provider/model"
 )
 
+// revealValueRoles роли, которым доступен RevealValue
+var revealValueRoles = []string{constant.RoleSupport, constant.RoleAdmin}
+
 type Usecase struct {
-	service    KeyServiceI
-	mdmService MdmServiceI
-	providers  map[string]ProviderServiceI
+	service          KeyServiceI
+	operationService OperationServiceI
+	importJobService ImportJobServiceI
+	poolLevelService PoolLevelServiceI
+	routeService     ProductProviderServiceI
+	mdmService       MdmServiceI
+	alertService     AlertServiceI
+	callbackService  CallbackServiceI
+	webhookService   WebhookServiceI
+	providers        map[string]ProviderServiceI
+	returnPolicy     string
+
+	activationWake chan struct{}
 }
 
-func New(service KeyServiceI, mdmService MdmServiceI, providers map[string]ProviderServiceI) *Usecase {
+func New(service KeyServiceI, operationService OperationServiceI, importJobService ImportJobServiceI, poolLevelService PoolLevelServiceI,
+	routeService ProductProviderServiceI, mdmService MdmServiceI, alertService AlertServiceI, callbackService CallbackServiceI,
+	webhookService WebhookServiceI, providers map[string]ProviderServiceI, returnPolicy string) *Usecase {
 	return &Usecase{
-		service:    service,
-		mdmService: mdmService,
-		providers:  providers,
+		service:          service,
+		operationService: operationService,
+		importJobService: importJobService,
+		poolLevelService: poolLevelService,
+		routeService:     routeService,
+		mdmService:       mdmService,
+		alertService:     alertService,
+		callbackService:  callbackService,
+		webhookService:   webhookService,
+		providers:        providers,
+		returnPolicy:     returnPolicy,
+
+		activationWake: make(chan struct{}, 1),
 	}
 }
 
@@ -37,6 +67,83 @@ func (u *Usecase) List(ctx context.Context,
 		return nil, 0, errs.IncorrectPageSize
 	}
 
+	if err := u.validateListReq(pars); err != nil {
+		return nil, 0, err
+	}
+
+	items, tCount, err := u.service.List(ctx, pars)
+	if err != nil {
+		return nil, 0, fmt.Errorf("service.List: %w", err)
+	}
+
+	return items, tCount, nil
+}
+
+// ListAfter возвращает страницу ключей после курсора (keyset-пагинация по created_at, id)
+// и курсор следующей страницы. Пустой cursor - первая страница, пустой результирующий - страниц больше нет
+func (u *Usecase) ListAfter(ctx context.Context,
+	pars *model.ListReq,
+	cursor string,
+) ([]*model.Main, string, error) {
+	if err := util.RequirePageSize(pars.ListParams, constant.MaxPageSize); err != nil {
+		return nil, "", errs.IncorrectPageSize
+	}
+
+	if err := u.validateListReq(pars); err != nil {
+		return nil, "", err
+	}
+
+	var err error
+
+	pars.Cursor, err = model.DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", errs.ErrFull{
+			Err: errs.InvalidCursor,
+		}
+	}
+
+	items, _, err := u.service.List(ctx, pars)
+	if err != nil {
+		return nil, "", fmt.Errorf("service.List: %w", err)
+	}
+
+	nextCursor := ""
+	if len(items) > 0 && int64(len(items)) == pars.PageSize {
+		last := items[len(items)-1]
+		nextCursor = (&model.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}).Encode()
+	}
+
+	return items, nextCursor, nil
+}
+
+// ListByCustomer возвращает страницу ключей, выданных клиенту, по всем провайдерам.
+// Без заданной сортировки новые первыми
+func (u *Usecase) ListByCustomer(ctx context.Context,
+	customerPhone string,
+	listParams commonModel.ListParams,
+) ([]*model.Main, int64, error) {
+	customerPhone = strings.TrimSpace(customerPhone)
+	if customerPhone == "" {
+		return nil, 0, errs.CustomerPhoneRequired
+	}
+
+	if err := util.RequirePageSize(listParams, constant.MaxPageSize); err != nil {
+		return nil, 0, errs.IncorrectPageSize
+	}
+
+	if len(listParams.Sort) == 0 {
+		listParams.Sort = []string{"-created_at"}
+	}
+
+	pars := &model.ListReq{
+		ListParams:    listParams,
+		CustomerPhone: lo.ToPtr(customerPhone),
+	}
+
+	if err := u.validateListReq(pars); err != nil {
+		return nil, 0, err
+	}
+
 	items, tCount, err := u.service.List(ctx, pars)
 	if err != nil {
 		return nil, 0, fmt.Errorf("service.List: %w", err)
@@ -45,44 +152,138 @@ func (u *Usecase) List(ctx context.Context,
 	return items, tCount, nil
 }
 
-func (u *Usecase) Load(ctx context.Context, objs []*model.Edit) error {
+// InventoryReport возвращает число ключей по продукту, провайдеру и статусу с разбивкой по возрасту
+func (u *Usecase) InventoryReport(ctx context.Context, pars *model.ListReq) ([]*model.InventoryItem, error) {
+	items, err := u.service.InventoryReport(ctx, pars)
+	if err != nil {
+		return nil, fmt.Errorf("service.InventoryReport: %w", err)
+	}
+
+	return items, nil
+}
+
+// Load загружает ключи в пул и возвращает результат по каждому ключу.
+// В режиме all_or_nothing ключи сохраняются одной транзакцией и только если все они валидны
+func (u *Usecase) Load(ctx context.Context, objs []*model.Edit, mode string) ([]*model.LoadResult, error) {
 	if len(objs) == 0 {
-		return errs.ErrFull{
-			Err:  errs.EmptyData,
-			Desc: "keys list cannot be empty",
+		return nil, errs.ErrFull{
+			Err: errs.EmptyData,
+			Msg: errs.MsgKeysEmpty,
 		}
 	}
 
 	for _, obj := range objs {
-		err := u.load(ctx, obj)
+		obj.Source = lo.ToPtr(constant.KeySourcePool)
+	}
+
+	results, err := u.checkLoad(ctx, objs)
+	if err != nil {
+		return nil, fmt.Errorf("checkLoad: %w", err)
+	}
+
+	if mode == constant.LoadModeAllOrNothing {
+		err = u.loadAll(ctx, objs, results)
 		if err != nil {
-			return fmt.Errorf("load: %w", err)
+			return nil, fmt.Errorf("loadAll: %w", err)
 		}
+
+		return results, nil
 	}
 
-	return nil
+	for i, obj := range objs {
+		if results[i].Result != constant.LoadResultCreated {
+			continue
+		}
+
+		results[i].ID, err = u.service.Create(ctx, obj)
+		if err != nil {
+			slog.Error("load: service.Create", "error", err, "product_id", results[i].ProductID)
+
+			results[i].Result = constant.LoadResultFailed
+			results[i].Reason = errs.ServiceNA.Error()
+		}
+	}
+
+	return results, nil
 }
 
-func (u *Usecase) load(ctx context.Context, obj *model.Edit) error {
-	err := u.validateLoad(ctx, obj)
-	if err != nil {
-		return fmt.Errorf("validate: %w", err)
+// checkLoad проверяет ключи до сохранения: валидные помечаются как created,
+// остальные как invalid или duplicate
+func (u *Usecase) checkLoad(ctx context.Context, objs []*model.Edit) ([]*model.LoadResult, error) {
+	results := make([]*model.LoadResult, len(objs))
+	// ключи, повторяющиеся внутри одной загрузки
+	seen := make(map[string]string, len(objs))
+
+	for i, obj := range objs {
+		result := &model.LoadResult{
+			Index:     i,
+			ProductID: lo.FromPtr(obj.ProductID),
+			Result:    constant.LoadResultCreated,
+		}
+		results[i] = result
+
+		err := u.validateLoad(ctx, obj)
+		if err != nil {
+			result.Result = constant.LoadResultInvalid
+			result.Reason = err.Error()
+			continue
+		}
+		result.ProductID = *obj.ProductID
+
+		if productID, ok := seen[*obj.Value]; ok {
+			result.Result = constant.LoadResultDuplicate
+			result.ExistingProductID = productID
+			continue
+		}
+		seen[*obj.Value] = *obj.ProductID
+
+		existingKey, err := u.service.GetByValue(ctx, *obj.Value)
+		if err != nil {
+			return nil, fmt.Errorf("service.GetByValue: %w", err)
+		}
+
+		if existingKey != nil {
+			result.Result = constant.LoadResultDuplicate
+			result.ExistingProductID = existingKey.ProductID
+		}
 	}
 
-	existingKey, err := u.service.GetByValue(ctx, *obj.Value)
-	if err != nil {
-		return fmt.Errorf("service.GetByValue: %w", err)
+	return results, nil
+}
+
+func (u *Usecase) loadAll(ctx context.Context, objs []*model.Edit, results []*model.LoadResult) error {
+	hasInvalid := lo.ContainsBy(results, func(item *model.LoadResult) bool {
+		return item.Result == constant.LoadResultInvalid
+	})
+
+	toCreate := make([]*model.Edit, 0, len(objs))
+	toCreateResults := make([]*model.LoadResult, 0, len(objs))
+
+	for i, obj := range objs {
+		if results[i].Result != constant.LoadResultCreated {
+			continue
+		}
+
+		if hasInvalid {
+			results[i].Result = constant.LoadResultSkipped
+			continue
+		}
+
+		toCreate = append(toCreate, obj)
+		toCreateResults = append(toCreateResults, results[i])
 	}
 
-	// пропускаем ключ, если уже имеется запись в БД
-	if existingKey != nil && existingKey.ProductID == *obj.ProductID {
-		slog.Warn("attempt to add duplicate key", "value", obj.Value, "product_id", obj.ProductID)
+	if len(toCreate) == 0 {
 		return nil
 	}
 
-	_, err = u.service.Create(ctx, obj)
+	ids, err := u.service.CreateMany(ctx, toCreate)
 	if err != nil {
-		return fmt.Errorf("service.Create: %w", err)
+		return fmt.Errorf("service.CreateMany: %w", err)
+	}
+
+	for i, id := range ids {
+		toCreateResults[i].ID = id
 	}
 
 	return nil
@@ -99,6 +300,54 @@ func (u *Usecase) Get(ctx context.Context,
 	return key, nil
 }
 
+// History возвращает журнал смены статусов ключа: кто, когда и почему менял статус
+func (u *Usecase) History(ctx context.Context, id string) ([]*model.Event, error) {
+	_, _, err := u.service.Get(ctx, id, true)
+	if err != nil {
+		return nil, fmt.Errorf("service.Get: %w", err)
+	}
+
+	items, err := u.service.History(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("service.History: %w", err)
+	}
+
+	return items, nil
+}
+
+// RevealValue выдает открытое значение ключа сотрудникам поддержки. Причина обязательна
+// и вместе с инициатором попадает в журнал доступа
+func (u *Usecase) RevealValue(ctx context.Context, id, reason string) (string, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return "", errs.IDRequired
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return "", errs.ReasonRequired
+	}
+
+	if role := util.RoleFromCtx(ctx); !slices.Contains(revealValueRoles, role) {
+		return "", errs.ErrFull{
+			Err: errs.PermissionDenied,
+			Msg: errs.MsgRevealValueDenied,
+			Fields: map[string]string{
+				"role": role,
+			},
+		}
+	}
+
+	key, err := u.service.RevealValue(ctx, id, reason)
+	if err != nil {
+		return "", fmt.Errorf("service.RevealValue: %w", err)
+	}
+
+	slog.Info("key value revealed", "key_id", id, "actor", util.ActorFromCtx(ctx))
+
+	return key.Value, nil
+}
+
 func (u *Usecase) GetCatalog(ctx context.Context, providerID string) ([]*providerModel.CatalogResponse, error) {
 	providerService, err := u.getProvider(providerID)
 	if err != nil {
@@ -113,29 +362,70 @@ func (u *Usecase) GetCatalog(ctx context.Context, providerID string) ([]*provide
 	return items, nil
 }
 
-func (u *Usecase) Activate(ctx context.Context, productID, orderID, customerPhone string) (*string, error) {
-	if err := u.validateActivate(ctx, orderID, productID, customerPhone); err != nil {
+// Activate выдает ключ по заказу вместе со ссылкой и инструкцией провайдера
+func (u *Usecase) Activate(ctx context.Context, productID, orderID, customerPhone string) (*model.Main, error) {
+	if err := u.validateActivate(ctx, &orderID, &productID, &customerPhone); err != nil {
 		return nil, err
 	}
 
-	product, _, err := u.mdmService.FindProduct(ctx, &productID)
+	// повторный вызов по тому же заказу возвращает уже выданный ключ
+	issued, err := u.getIssued(ctx, orderID, productID)
 	if err != nil {
-		return nil, fmt.Errorf("mdmService.FindProduct: %w", err)
+		return nil, fmt.Errorf("getIssued: %w", err)
+	}
+	if issued != nil {
+		return issued, nil
 	}
 
-	providerService, err := u.getProvider(product.ProviderID)
+	unlock, err := u.lockOrder(ctx, orderID, productID)
 	if err != nil {
-		return nil, fmt.Errorf("getProvider: %w", err)
+		return nil, err
 	}
+	defer unlock()
 
-	// Обращение к провайдеру
-	id, err := u.createOrder(ctx, providerService, product, customerPhone) // customerPhone для megogo
+	// пока блокировка бралась, параллельный запрос мог успеть выдать ключ
+	issued, err = u.getIssued(ctx, orderID, productID)
 	if err != nil {
-		slog.Error("createOrder", "error", err)
+		return nil, fmt.Errorf("getIssued: %w", err)
+	}
+	if issued != nil {
+		return issued, nil
+	}
 
-		if !providerService.SupportsPool() {
-			return nil, errs.ServiceNA
+	// по заказу есть резерв: ключ из пула выдается без обращения к провайдеру
+	reservation, reserved, err := u.service.GetActiveReservation(ctx, orderID, productID)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetActiveReservation: %w", err)
+	}
+	// просроченный резерв не используется, как и в Confirm: его снимет sweeper, выдача идет обычным путем
+	if reserved && time.Now().After(reservation.ExpiresAt) {
+		reserved = false
+	}
+	if reserved && reservation.KeyID != "" {
+		key, err := u.confirmReserved(ctx, reservation, customerPhone)
+		if err != nil {
+			return nil, fmt.Errorf("confirmReserved: %w", err)
 		}
+
+		u.publishKeyEvent(ctx, constant.WebhookEventActivated, key, "")
+
+		return key, nil
+	}
+
+	product, _, err := u.mdmService.FindProduct(ctx, &productID)
+	if err != nil {
+		return nil, fmt.Errorf("mdmService.FindProduct: %w", err)
+	}
+
+	routes, err := u.productRoutes(ctx, product)
+	if err != nil {
+		return nil, fmt.Errorf("productRoutes: %w", err)
+	}
+
+	// Обращение к провайдерам по порядку, при неудаче ключ забирается из пула
+	id, err := u.orderByRoutes(ctx, routes, orderID, customerPhone)
+	if err != nil {
+		return nil, err
 	}
 
 	key, err := u.activate(ctx, id, orderID, customerPhone, productID)
@@ -143,10 +433,76 @@ func (u *Usecase) Activate(ctx context.Context, productID, orderID, customerPhon
 		return nil, fmt.Errorf("activate: %w", err)
 	}
 
-	return lo.ToPtr(key.Value), nil
+	u.publishKeyEvent(ctx, constant.WebhookEventActivated, key, "")
+
+	// ключ уже выдан, поэтому ошибка завершения резерва не возвращается: резерв без ключа снимет sweeper
+	if reserved {
+		err = u.service.ConfirmReservation(ctx, reservation, customerPhone, key.ID)
+		if err != nil {
+			slog.Error("service.ConfirmReservation", "error", err, "reservation_id", reservation.ID)
+		}
+	}
+
+	return key, nil
+}
+
+// lockOrder занимает заказ на время выдачи ключа, unlock снимает только свою блокировку
+func (u *Usecase) lockOrder(ctx context.Context, orderID, productID string) (func(), error) {
+	token, locked, err := u.service.LockOrder(ctx, orderID, productID)
+	if err != nil {
+		return nil, fmt.Errorf("service.LockOrder: %w", err)
+	}
+	if !locked {
+		return nil, errs.ErrFull{
+			Err: errs.ActivationInProgress,
+			Fields: map[string]string{
+				"orderID": orderID,
+			},
+		}
+	}
+
+	return func() {
+		if err := u.service.UnlockOrder(ctx, orderID, productID, token); err != nil {
+			slog.Error("service.UnlockOrder", "error", err, "order_id", orderID, "product_id", productID)
+		}
+	}, nil
+}
+
+// getIssued возвращает ключ, ранее выданный по заказу, или nil
+func (u *Usecase) getIssued(ctx context.Context, orderID, productID string) (*model.Main, error) {
+	item, found, err := u.service.GetByOrderAndProductID(ctx, orderID, productID)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetByOrderAndProductID: %w", err)
+	}
+	if !found {
+		return nil, nil
+	}
+
+	if item.Status == constant.KeyStatusCancelled || item.Status == constant.KeyStatusReturned {
+		return nil, errs.AlreadyCancelled
+	}
+
+	return item, nil
 }
 
-func (u *Usecase) createOrder(ctx context.Context, providerService ProviderServiceI, product *mdmModel.Product, customerPhone string) (string, error) {
+// errKeyNotStored ключ куплен у провайдера, но не сохранен: операция остается purchased до Reconcile
+var errKeyNotStored = errors.New("purchased key not stored")
+
+// createOrder покупает ключ у провайдера. Каждый шаг фиксируется в журнале provider_operation,
+// чтобы оплаченный, но не сохраненный ключ подобрал Reconcile. source - источник ключа:
+// докупленный в пул без заказа ключ у провайдера не отменяется
+func (u *Usecase) createOrder(ctx context.Context, providerService ProviderServiceI, product *mdmModel.Product, orderID, customerPhone, source string) (string, error) {
+	// при открытом breaker провайдер не вызывается и операция не журналируется: сразу fallback на пул
+	if !providerAvailable(providerService) {
+		return "", errs.ErrFull{
+			Err: errs.ServiceNA,
+			Msg: errs.MsgProviderUnavailable,
+			Fields: map[string]string{
+				"provider": product.ProviderID,
+			},
+		}
+	}
+
 	orderReq := &providerModel.OrderRequest{
 		ProviderID:                product.ProviderID,
 		ProductID:                 product.ProductID,
@@ -154,13 +510,45 @@ func (u *Usecase) createOrder(ctx context.Context, providerService ProviderServi
 		ProviderExternalProductID: product.ProviderExternalID,
 		PromotionKey:              product.PromotionKey,
 		CustomerPhone:             customerPhone,
+		OrderID:                   orderID,
+	}
+
+	operationID, err := u.operationService.Create(ctx, &operationModel.Edit{
+		ProviderID:        lo.ToPtr(product.ProviderID),
+		ProductID:         lo.ToPtr(product.ProductID),
+		ProviderProductID: lo.ToPtr(product.ProviderProductID),
+		OrderID:           lo.ToPtr(orderID),
+		Status:            lo.ToPtr(constant.OperationStatusRequested),
+	})
+	if err != nil {
+		return "", fmt.Errorf("operationService.Create: %w", err)
 	}
 
 	orderRep, err := providerService.CreateOrder(ctx, orderReq)
 	if err != nil {
+		u.updateOperation(ctx, &operationModel.Edit{
+			ID:     lo.ToPtr(operationID),
+			Status: lo.ToPtr(constant.OperationStatusFailed),
+			Error:  lo.ToPtr(err.Error()),
+		})
+		u.publish(ctx, constant.WebhookEventProviderFailed, &webhookModel.ProviderFailedEvent{
+			ProviderID:  product.ProviderID,
+			ProductID:   product.ProductID,
+			OrderID:     orderID,
+			OperationID: operationID,
+			Error:       err.Error(),
+		})
 		return "", fmt.Errorf("providerService.CreateOrder: %w", err)
 	}
 
+	u.updateOperation(ctx, &operationModel.Edit{
+		ID:                    lo.ToPtr(operationID),
+		Status:                lo.ToPtr(constant.OperationStatusPurchased),
+		Value:                 lo.ToPtr(orderRep.Value),
+		ProviderOrderID:       lo.ToPtr(lo.FromPtr(orderRep.OrderID)),
+		ProviderTransactionID: lo.ToPtr(orderRep.TransactionID),
+	})
+
 	obj := &model.Edit{
 		Value:                 lo.ToPtr(orderRep.Value),
 		ProductID:             lo.ToPtr(product.ProductID),
@@ -168,87 +556,98 @@ func (u *Usecase) createOrder(ctx context.Context, providerService ProviderServi
 		ProviderProductID:     lo.ToPtr(product.ProviderProductID),
 		ProviderTransactionID: lo.ToPtr(orderRep.TransactionID),
 		ProviderOrderID:       orderRep.OrderID,
+		Link:                  orderRep.Link,
+		Instructions:          orderRep.Instructions,
+		LicenseTerm:           orderRep.LicenseTerm,
+		Source:                lo.ToPtr(source),
 	}
 
 	id, err := u.service.Create(ctx, obj)
 	if err != nil {
-		return "", fmt.Errorf("service.Create: %w", err)
+		return "", fmt.Errorf("service.Create: %w: %w", errKeyNotStored, err)
 	}
 
+	u.updateOperation(ctx, &operationModel.Edit{
+		ID:     lo.ToPtr(operationID),
+		Status: lo.ToPtr(constant.OperationStatusStored),
+		KeyID:  lo.ToPtr(id),
+	})
+
 	return id, nil
 }
 
+// updateOperation не прерывает покупку: незавершенную операцию подберет Reconcile
+func (u *Usecase) updateOperation(ctx context.Context, obj *operationModel.Edit) {
+	err := u.operationService.Update(ctx, obj)
+	if err != nil {
+		slog.Error("operationService.Update", "error", err, "operation_id", lo.FromPtr(obj.ID), "status", lo.FromPtr(obj.Status))
+	}
+}
+
 func (u *Usecase) activate(ctx context.Context, id, orderID, customerPhone, productID string) (*model.Main, error) {
-	var item *model.Main
-	var err error
-	// при ошибке обращения к провайдеру
+	// при ошибке обращения к провайдеру ключ забирается из пула
 	if id == "" {
-		listReq := &model.ListReq{
-			ListParams: commonModel.ListParams{
-				Page:     0,
-				PageSize: 1,
-			},
-			ProductID: lo.ToPtr(productID),
-			Status:    lo.ToPtr(constant.KeyStatusNew),
-		}
-
-		items, _, err := u.service.List(ctx, listReq)
+		item, found, err := u.service.ClaimNew(ctx, productID, orderID, customerPhone)
 		if err != nil {
-			return nil, fmt.Errorf("serivce.List: %w", err)
+			return nil, fmt.Errorf("service.ClaimNew: %w", err)
 		}
 
-		if len(items) == 0 {
+		if !found {
 			return nil, errs.ErrFull{
-				Err:  errs.ObjectNotFound,
-				Desc: "Услуга провайдера в данный момент недоступна. Не найдено доступных ключей для данного продукта",
+				Err: errs.ObjectNotFound,
+				Msg: errs.MsgNoPoolKeys,
 			}
 		}
 
-		item = items[0]
-
-		item, _, err = u.service.Get(ctx, item.ID, true)
-		if err != nil {
-			return nil, fmt.Errorf("service.Get: %w", err)
-		}
-	} else {
-		item, _, err = u.service.Get(ctx, id, true)
-		if err != nil {
-			return nil, fmt.Errorf("service.Get: %w", err)
-		}
+		return item, nil
 	}
 
-	if item.Status != constant.KeyStatusNew {
-		return nil, errs.AlreadyActivated
+	item, _, err := u.service.Get(ctx, id, true)
+	if err != nil {
+		return nil, fmt.Errorf("service.Get: %w", err)
 	}
 
-	key := &model.Edit{
-		ID:            lo.ToPtr(item.ID),
+	err = u.service.Transition(ctx, item, &model.Edit{
 		OrderID:       lo.ToPtr(orderID),
 		CustomerPhone: lo.ToPtr(customerPhone),
 		Status:        lo.ToPtr(constant.KeyStatusActivated),
-	}
-
-	err = u.service.Update(ctx, key)
+	}, "")
 	if err != nil {
-		return nil, fmt.Errorf("service.Update: %w", err)
+		return nil, fmt.Errorf("service.Transition: %w", err)
 	}
 
+	item.OrderID = orderID
+	item.CustomerPhone = customerPhone
+	item.Status = constant.KeyStatusActivated
+
 	return item, nil
 }
 
-func (u *Usecase) Cancel(ctx context.Context, orderID string) (*string, error) {
-	err := u.validateCancel(ctx, &orderID)
+func (u *Usecase) Cancel(ctx context.Context, orderID, productID, reason string) (*string, error) {
+	err := u.validateCancel(ctx, &orderID, &productID)
 	if err != nil {
 		return nil, err
 	}
 
-	product, _, err := u.service.GetByOrderID(ctx, orderID, true)
+	product, err := u.getOrderKey(ctx, orderID, productID)
 	if err != nil {
-		return nil, fmt.Errorf("service.GetByOrderID: %w", err)
+		return nil, err
 	}
 
-	if product.Status == constant.KeyStatusCancelled {
-		return nil, errs.AlreadyCancelled
+	// ключ из пула куплен не по заказу, поэтому у провайдера не отменяется, а возвращается
+	if product.Source == constant.KeySourcePool {
+		err = u.returnToPool(ctx, product, reason)
+		if err != nil {
+			return nil, err
+		}
+
+		return lo.ToPtr(product.ID), nil
+	}
+
+	// провайдер не вызывается для ключа, который нельзя отменить
+	err = model.CheckTransition(product.Status, constant.KeyStatusCancelled)
+	if err != nil {
+		return nil, err
 	}
 
 	providerService, err := u.getProvider(product.ProviderID)
@@ -268,23 +667,101 @@ func (u *Usecase) Cancel(ctx context.Context, orderID string) (*string, error) {
 		return nil, fmt.Errorf("providerService.CancelOrder: %w", err)
 	}
 
-	err = u.service.Update(ctx, &model.Edit{
-		ID:     &product.ID,
+	err = u.service.Transition(ctx, product, &model.Edit{
 		Status: lo.ToPtr(constant.KeyStatusCancelled),
-	})
+	}, reason)
 	if err != nil {
-		return nil, fmt.Errorf("service.Update: %w", err)
+		return nil, fmt.Errorf("service.Transition: %w", err)
 	}
 
+	product.Status = constant.KeyStatusCancelled
+	u.publishKeyEvent(ctx, constant.WebhookEventCancelled, product, reason)
+
 	return lo.ToPtr(product.ID), nil
 }
 
+// getOrderKey ключ заказа по продукту. Без productID заказ должен быть однозначным: по нему выдан один ключ
+func (u *Usecase) getOrderKey(ctx context.Context, orderID, productID string) (*model.Main, error) {
+	notFound := errs.ErrFull{
+		Err: errs.ObjectNotFound,
+		Msg: errs.MsgKeyByOrderNotFound,
+		Fields: map[string]string{
+			"orderID": orderID,
+		},
+	}
+
+	if productID != "" {
+		item, found, err := u.service.GetByOrderAndProductID(ctx, orderID, productID)
+		if err != nil {
+			return nil, fmt.Errorf("service.GetByOrderAndProductID: %w", err)
+		}
+		if !found {
+			return nil, notFound
+		}
+
+		return item, nil
+	}
+
+	items, _, err := u.service.List(ctx, &model.ListReq{
+		ListParams: commonModel.ListParams{
+			PageSize: 2,
+		},
+		OrderID: lo.ToPtr(orderID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service.List: %w", err)
+	}
+
+	switch len(items) {
+	case 0:
+		return nil, notFound
+	case 1:
+		return items[0], nil
+	}
+
+	return nil, errs.ErrFull{
+		Err: errs.ProductIDRequired,
+		Fields: map[string]string{
+			"orderID": orderID,
+		},
+	}
+}
+
+// returnToPool переводит отмененный ключ из пула в returned. По политике reissue ключ
+// отвязывается от заказа и снова выдается из пула, иначе остается в карантине
+func (u *Usecase) returnToPool(ctx context.Context, key *model.Main, reason string) error {
+	err := u.service.Transition(ctx, key, &model.Edit{
+		Status: lo.ToPtr(constant.KeyStatusReturned),
+	}, reason)
+	if err != nil {
+		return fmt.Errorf("service.Transition: %w", err)
+	}
+
+	key.Status = constant.KeyStatusReturned
+	u.publishKeyEvent(ctx, constant.WebhookEventCancelled, key, reason)
+
+	if u.returnPolicy != constant.KeyReturnPolicyReissue {
+		return nil
+	}
+
+	err = u.service.Transition(ctx, key, &model.Edit{
+		Status:        lo.ToPtr(constant.KeyStatusNew),
+		OrderID:       lo.ToPtr(""),
+		CustomerPhone: lo.ToPtr(""),
+	}, reason)
+	if err != nil {
+		return fmt.Errorf("service.Transition: %w", err)
+	}
+
+	return nil
+}
+
 func (u *Usecase) getProvider(providerID string) (ProviderServiceI, error) {
 	provider, exists := u.providers[providerID]
 	if !exists {
 		return nil, errs.ErrFull{
-			Err:  errs.ObjectNotFound,
-			Desc: "Услуги провайдера не подключены",
+			Err: errs.ObjectNotFound,
+			Msg: errs.MsgProviderNotConnected,
 			Fields: map[string]string{
 				"providerID": providerID,
 			},
@@ -309,37 +786,39 @@ func (u *Usecase) validateLoad(_ context.Context, obj *model.Edit) error {
 	return nil
 }
 
-func (u *Usecase) validateActivate(ctx context.Context, orderID, productID, customerPhone string) error {
-	if customerPhone != "" {
-		customerPhone = strings.TrimSpace(customerPhone)
-		if !util.NormalizeAndValidatePhone(&customerPhone) {
+// validateActivate нормализует параметры выдачи на месте: ключ и выдача сохраняются
+// с тем же телефоном, по которому их ищут List и ListByCustomer
+func (u *Usecase) validateActivate(_ context.Context, orderID, productID, customerPhone *string) error {
+	*customerPhone = strings.TrimSpace(*customerPhone)
+	if *customerPhone != "" {
+		if !util.NormalizeAndValidatePhone(customerPhone) {
 			return errs.ErrFull{
-				Err:  errs.InvalidPhone,
-				Desc: "Номер телефона клиента не прошел валидацию",
+				Err: errs.InvalidPhone,
 			}
 		}
 	}
 
-	orderID = strings.TrimSpace(orderID)
-	productID = strings.TrimSpace(productID)
+	*orderID = strings.TrimSpace(*orderID)
+	*productID = strings.TrimSpace(*productID)
 
-	if orderID == "" {
+	if *orderID == "" {
 		return errs.OrderIDRequired
 	}
 
-	if productID == "" {
+	if *productID == "" {
 		return errs.ProductIDRequired
 	}
 
-	if customerPhone == "" {
+	if *customerPhone == "" {
 		return errs.CustomerPhoneRequired
 	}
 
 	return nil
 }
 
-func (u *Usecase) validateCancel(_ context.Context, orderID *string) error {
+func (u *Usecase) validateCancel(_ context.Context, orderID, productID *string) error {
 	*orderID = strings.TrimSpace(*orderID)
+	*productID = strings.TrimSpace(*productID)
 
 	if *orderID == "" {
 		return errs.OrderIDRequired
@@ -347,3 +826,21 @@ func (u *Usecase) validateCancel(_ context.Context, orderID *string) error {
 
 	return nil
 }
+
+// validateListReq нормализует фильтры ключей так же, как они сохраняются при активации
+func (u *Usecase) validateListReq(pars *model.ListReq) error {
+	if pars.CustomerPhone != nil {
+		pars.CustomerPhone = lo.ToPtr(strings.TrimSpace(*pars.CustomerPhone))
+		if !util.NormalizeAndValidatePhone(pars.CustomerPhone) {
+			return errs.ErrFull{
+				Err: errs.InvalidPhone,
+			}
+		}
+	}
+
+	if pars.Value != nil {
+		pars.Value = lo.ToPtr(strings.TrimSpace(*pars.Value))
+	}
+
+	return nil
+}
@@ -1,35 +1,67 @@
 package key
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mechta-market/e-product/internal/constant"
 	commonModel "github.com/mechta-market/e-product/internal/domain/common/model"
+	"github.com/mechta-market/e-product/internal/domain/common/util"
+	importJobModel "github.com/mechta-market/e-product/internal/domain/importjob/model"
 	"github.com/mechta-market/e-product/internal/domain/key/model"
+	operationModel "github.com/mechta-market/e-product/internal/domain/operation/model"
+	poolLevelModel "github.com/mechta-market/e-product/internal/domain/poollevel/model"
+	productProviderModel "github.com/mechta-market/e-product/internal/domain/productprovider/model"
+	webhookModel "github.com/mechta-market/e-product/internal/domain/webhook/model"
 	"github.com/mechta-market/e-product/internal/errs"
+	alertModel "github.com/mechta-market/e-product/internal/service/alert/model"
+	callbackModel "github.com/mechta-market/e-product/internal/service/callback/model"
+	mdmModel "github.com/mechta-market/e-product/internal/service/mdm/model"
 	providerModel "github.com/mechta-market/e-product/internal/service/provider/model"
+	"github.com/mechta-market/e-product/internal/service/provider/resilient"
 	"github.com/mechta-market/e-product/internal/usecase/key/mocks"
 )
 
 // TODO: refactor Load & Activate tests
 
 type usecaseTest struct {
-	service         *mocks.KeyServiceI
-	mdmService      *mocks.MdmServiceI
-	providerService *mocks.ProviderServiceI
-	providers       map[string]ProviderServiceI
-	usecase         *Usecase
+	service          *mocks.KeyServiceI
+	operationService *mocks.OperationServiceI
+	importJobService *mocks.ImportJobServiceI
+	poolLevelService *mocks.PoolLevelServiceI
+	routeService     *mocks.ProductProviderServiceI
+	mdmService       *mocks.MdmServiceI
+	alertService     *mocks.AlertServiceI
+	callbackService  *mocks.CallbackServiceI
+	webhookService   *mocks.WebhookServiceI
+	providerService  *mocks.ProviderServiceI
+	providers        map[string]ProviderServiceI
+	usecase          *Usecase
 }
 
 func newTest() *usecaseTest {
 	service := new(mocks.KeyServiceI)
+	operationService := new(mocks.OperationServiceI)
+	importJobService := new(mocks.ImportJobServiceI)
+	poolLevelService := new(mocks.PoolLevelServiceI)
+	routeService := new(mocks.ProductProviderServiceI)
 	mdmSerivce := new(mocks.MdmServiceI)
+	alertService := new(mocks.AlertServiceI)
+	callbackService := new(mocks.CallbackServiceI)
+	webhookService := new(mocks.WebhookServiceI)
+	// события webhook проверяются в отдельных тестах
+	webhookService.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(int64(0), nil).Maybe()
 	providerService := new(mocks.ProviderServiceI)
 
 	providers := map[string]ProviderServiceI{
@@ -37,10 +69,17 @@ func newTest() *usecaseTest {
 	}
 
 	return &usecaseTest{
-		service:         service,
-		mdmService:      mdmSerivce,
-		providerService: providerService,
-		providers:       providers,
+		service:          service,
+		operationService: operationService,
+		importJobService: importJobService,
+		poolLevelService: poolLevelService,
+		routeService:     routeService,
+		mdmService:       mdmSerivce,
+		alertService:     alertService,
+		callbackService:  callbackService,
+		webhookService:   webhookService,
+		providerService:  providerService,
+		providers:        providers,
 	}
 }
 
@@ -94,7 +133,7 @@ func TestUsecase_List(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ut := newTest()
-			ut.usecase = New(ut.service, ut.mdmService, ut.providers)
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
 
 			req := &model.ListReq{
 				ListParams: commonModel.ListParams{
@@ -125,58 +164,289 @@ func TestUsecase_List(t *testing.T) {
 	}
 }
 
-//func TestUsecase_Load(t *testing.T) {
-//	tests := []struct {
-//		name        string
-//		input       []*model.Edit
-//		setupMock   func(ut *usecaseTest, input []*model.Edit)
-//		expectedErr error
-//	}{
-//		{
-//			name: "successful load",
-//			input: []*model.Edit{
-//				{ID: lo.ToPtr("1")},
-//				{ID: lo.ToPtr("2")},
-//			},
-//			setupMock: func(ut *usecaseTest, input []*model.Edit) {
-//				ut.service.On("Load", mock.Anything, input).Return(nil).Once()
-//			},
-//			expectedErr: nil,
-//		},
-//		{
-//			name: "service.Load returns error",
-//			input: []*model.Edit{
-//				{ID: lo.ToPtr("fail")},
-//			},
-//			setupMock: func(ut *usecaseTest, input []*model.Edit) {
-//				ut.service.On("Load", mock.Anything, input).Return(errors.New("load failed")).Once()
-//			},
-//			expectedErr: errors.New("load failed"),
-//		},
-//	}
-//
-//	for _, tt := range tests {
-//		t.Run(tt.name, func(t *testing.T) {
-//			ut := newTest()
-//			ut.usecase = New(ut.service, ut.mdmService, ut.providers)
-//
-//			if tt.setupMock != nil {
-//				tt.setupMock(ut, tt.input)
-//			}
-//
-//			err := ut.usecase.Load(context.Background(), tt.input)
-//
-//			if tt.expectedErr != nil {
-//				assert.Error(t, err)
-//				assert.ErrorContains(t, err, tt.expectedErr.Error())
-//			} else {
-//				assert.NoError(t, err)
-//			}
-//
-//			ut.service.AssertExpectations(t)
-//		})
-//	}
-//}
+func TestUsecase_List_CustomerPhone(t *testing.T) {
+	ut := newTest()
+	ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+	ut.service.On("List", mock.Anything, mock.MatchedBy(func(req *model.ListReq) bool {
+		return *req.CustomerPhone == "77011234567"
+	})).Return([]*model.Main{{ID: "key-1"}}, int64(1), nil).Once()
+
+	items, _, err := ut.usecase.List(context.Background(), &model.ListReq{
+		ListParams:    commonModel.ListParams{PageSize: 10},
+		CustomerPhone: lo.ToPtr(" +77011234567"),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+
+	_, _, err = ut.usecase.List(context.Background(), &model.ListReq{
+		ListParams:    commonModel.ListParams{PageSize: 10},
+		CustomerPhone: lo.ToPtr("123"),
+	})
+	var errFull errs.ErrFull
+	assert.True(t, errors.As(err, &errFull))
+	assert.Equal(t, errs.InvalidPhone, errFull.Err)
+
+	ut.service.AssertExpectations(t)
+}
+
+// ключ сохраняется с телефоном в том же виде, в котором по нему фильтрует List
+func TestUsecase_List_CustomerPhone_Activated(t *testing.T) {
+	ut := newTest()
+	ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+	reservation := &model.Reservation{ID: "res-1", OrderID: "ord-1", ProductID: "prod-1", KeyID: "key-1", ExpiresAt: time.Now().Add(time.Minute)}
+
+	ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").Return(nil, false, nil).Twice()
+	ut.service.On("LockOrder", mock.Anything, "ord-1", "prod-1").Return("lock-1", true, nil).Once()
+	ut.service.On("GetActiveReservation", mock.Anything, "ord-1", "prod-1").Return(reservation, true, nil).Once()
+	ut.service.On("ConfirmReservation", mock.Anything, reservation, "77011234567", "").Return(nil).Once()
+	ut.service.On("Get", mock.Anything, "key-1", true).
+		Return(&model.Main{ID: "key-1", Status: constant.KeyStatusActivated, CustomerPhone: "77011234567"}, true, nil).Once()
+	ut.service.On("UnlockOrder", mock.Anything, "ord-1", "prod-1", "lock-1").Return(nil).Once()
+	ut.service.On("List", mock.Anything, mock.MatchedBy(func(req *model.ListReq) bool {
+		return *req.CustomerPhone == "77011234567"
+	})).Return([]*model.Main{{ID: "key-1"}}, int64(1), nil).Once()
+
+	key, err := ut.usecase.Activate(context.Background(), "prod-1", "ord-1", " +87011234567 ")
+	require.NoError(t, err)
+
+	items, _, err := ut.usecase.List(context.Background(), &model.ListReq{
+		ListParams:    commonModel.ListParams{PageSize: 10},
+		CustomerPhone: lo.ToPtr("87011234567"),
+	})
+	require.NoError(t, err)
+	if assert.Len(t, items, 1) {
+		assert.Equal(t, key.ID, items[0].ID)
+	}
+
+	ut.service.AssertExpectations(t)
+}
+
+func TestUsecase_ListByCustomer(t *testing.T) {
+	ut := newTest()
+	ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+	ut.service.On("List", mock.Anything, mock.MatchedBy(func(req *model.ListReq) bool {
+		return *req.CustomerPhone == "77011234567" && req.ProviderID == nil &&
+			req.Page == 2 && req.PageSize == 2 && req.WithTotalCount && slices.Equal(req.Sort, []string{"-created_at"})
+	})).Return([]*model.Main{{ID: "key-3"}, {ID: "key-4"}}, int64(5), nil).Once()
+
+	listParams := commonModel.ListParams{Page: 2, PageSize: 2, WithTotalCount: true}
+
+	items, tCount, err := ut.usecase.ListByCustomer(context.Background(), "87011234567", listParams)
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.EqualValues(t, 5, tCount)
+
+	_, _, err = ut.usecase.ListByCustomer(context.Background(), " ", listParams)
+	assert.ErrorIs(t, err, errs.CustomerPhoneRequired)
+
+	_, _, err = ut.usecase.ListByCustomer(context.Background(), "87011234567", commonModel.ListParams{})
+	assert.ErrorIs(t, err, errs.IncorrectPageSize)
+
+	ut.service.AssertExpectations(t)
+}
+
+func TestUsecase_ListAfter(t *testing.T) {
+	createdAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	cursor := (&model.Cursor{CreatedAt: createdAt, ID: "key-2"}).Encode()
+
+	t.Run("first page", func(t *testing.T) {
+		ut := newTest()
+		ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+		ut.service.On("List", mock.Anything, mock.MatchedBy(func(req *model.ListReq) bool {
+			return req.Cursor != nil && req.Cursor.IsZero()
+		})).Return([]*model.Main{
+			{ID: "key-1", CreatedAt: createdAt},
+			{ID: "key-2", CreatedAt: createdAt},
+		}, int64(0), nil).Once()
+
+		items, next, err := ut.usecase.ListAfter(context.Background(), &model.ListReq{
+			ListParams: commonModel.ListParams{PageSize: 2},
+		}, "")
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+		assert.Equal(t, cursor, next)
+
+		ut.service.AssertExpectations(t)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		ut := newTest()
+		ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+		ut.service.On("List", mock.Anything, mock.MatchedBy(func(req *model.ListReq) bool {
+			return req.Cursor != nil && req.Cursor.ID == "key-2" && req.Cursor.CreatedAt.Equal(createdAt)
+		})).Return([]*model.Main{
+			{ID: "key-3", CreatedAt: createdAt},
+		}, int64(0), nil).Once()
+
+		items, next, err := ut.usecase.ListAfter(context.Background(), &model.ListReq{
+			ListParams: commonModel.ListParams{PageSize: 2},
+		}, cursor)
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.Empty(t, next)
+
+		ut.service.AssertExpectations(t)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		ut := newTest()
+		ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+		_, _, err := ut.usecase.ListAfter(context.Background(), &model.ListReq{
+			ListParams: commonModel.ListParams{PageSize: 2},
+		}, "bad")
+
+		var errFull errs.ErrFull
+		assert.True(t, errors.As(err, &errFull))
+		assert.Equal(t, errs.InvalidCursor, errFull.Err)
+
+		ut.service.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUsecase_Load(t *testing.T) {
+	newItems := func() []*model.Edit {
+		return []*model.Edit{
+			{ProductID: lo.ToPtr("prod-1"), Value: lo.ToPtr(" key-1 ")},
+			{ProductID: lo.ToPtr("prod-1"), Value: lo.ToPtr("")},
+			{ProductID: lo.ToPtr("prod-2"), Value: lo.ToPtr("key-2")},
+			{ProductID: lo.ToPtr("prod-1"), Value: lo.ToPtr("key-1")},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		mode            string
+		setupMock       func(ut *usecaseTest)
+		expectedResults []string
+	}{
+		{
+			name: "best effort - valid keys stored",
+			mode: constant.LoadModeBestEffort,
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("GetByValue", mock.Anything, "key-1").Return(nil, nil).Once()
+				ut.service.On("GetByValue", mock.Anything, "key-2").Return(&model.Main{ProductID: "prod-3"}, nil).Once()
+				ut.service.On("Create", mock.Anything, mock.MatchedBy(func(obj *model.Edit) bool {
+					return *obj.Value == "key-1"
+				})).Return("id-1", nil).Once()
+			},
+			expectedResults: []string{
+				constant.LoadResultCreated,
+				constant.LoadResultInvalid,
+				constant.LoadResultDuplicate,
+				constant.LoadResultDuplicate,
+			},
+		},
+		{
+			name: "best effort - store error reported per item",
+			mode: constant.LoadModeBestEffort,
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("GetByValue", mock.Anything, mock.Anything).Return(nil, nil).Twice()
+				ut.service.On("Create", mock.Anything, mock.Anything).Return("", errors.New("db down")).Once()
+				ut.service.On("Create", mock.Anything, mock.Anything).Return("id-2", nil).Once()
+			},
+			expectedResults: []string{
+				constant.LoadResultFailed,
+				constant.LoadResultInvalid,
+				constant.LoadResultCreated,
+				constant.LoadResultDuplicate,
+			},
+		},
+		{
+			name: "all or nothing - invalid item rejects batch",
+			mode: constant.LoadModeAllOrNothing,
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("GetByValue", mock.Anything, mock.Anything).Return(nil, nil).Twice()
+			},
+			expectedResults: []string{
+				constant.LoadResultSkipped,
+				constant.LoadResultInvalid,
+				constant.LoadResultSkipped,
+				constant.LoadResultDuplicate,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ut := newTest()
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+			if tt.setupMock != nil {
+				tt.setupMock(ut)
+			}
+
+			results, err := ut.usecase.Load(context.Background(), newItems(), tt.mode)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedResults, lo.Map(results, func(item *model.LoadResult, _ int) string {
+				return item.Result
+			}))
+			assert.Equal(t, errs.ValueRequired.Error(), results[1].Reason)
+			assert.Equal(t, "prod-1", results[3].ExistingProductID)
+
+			ut.service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUsecase_Load_AllOrNothing(t *testing.T) {
+	ut := newTest()
+	ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+	items := []*model.Edit{
+		{ProductID: lo.ToPtr("prod-1"), Value: lo.ToPtr("key-1")},
+		{ProductID: lo.ToPtr("prod-1"), Value: lo.ToPtr("key-2")},
+		{ProductID: lo.ToPtr("prod-1"), Value: lo.ToPtr("key-3")},
+	}
+
+	ut.service.On("GetByValue", mock.Anything, "key-1").Return(nil, nil).Once()
+	ut.service.On("GetByValue", mock.Anything, "key-2").Return(&model.Main{ProductID: "prod-1"}, nil).Once()
+	ut.service.On("GetByValue", mock.Anything, "key-3").Return(nil, nil).Once()
+	ut.service.On("CreateMany", mock.Anything, []*model.Edit{items[0], items[2]}).Return([]string{"id-1", "id-3"}, nil).Once()
+
+	results, err := ut.usecase.Load(context.Background(), items, constant.LoadModeAllOrNothing)
+	assert.NoError(t, err)
+
+	assert.Equal(t, constant.LoadResultCreated, results[0].Result)
+	assert.Equal(t, "id-1", results[0].ID)
+	assert.Equal(t, constant.LoadResultDuplicate, results[1].Result)
+	assert.Equal(t, constant.LoadResultCreated, results[2].Result)
+	assert.Equal(t, "id-3", results[2].ID)
+
+	ut.service.AssertExpectations(t)
+}
+
+func TestUsecase_Load_AllOrNothingTxError(t *testing.T) {
+	ut := newTest()
+	ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+	items := []*model.Edit{
+		{ProductID: lo.ToPtr("prod-1"), Value: lo.ToPtr("key-1")},
+	}
+
+	ut.service.On("GetByValue", mock.Anything, "key-1").Return(nil, nil).Once()
+	ut.service.On("CreateMany", mock.Anything, items).Return(nil, errors.New("tx failed")).Once()
+
+	results, err := ut.usecase.Load(context.Background(), items, constant.LoadModeAllOrNothing)
+	assert.ErrorContains(t, err, "tx failed")
+	assert.Nil(t, results)
+
+	ut.service.AssertExpectations(t)
+}
+
+func TestUsecase_Load_Empty(t *testing.T) {
+	ut := newTest()
+	ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+	_, err := ut.usecase.Load(context.Background(), nil, constant.LoadModeBestEffort)
+	assert.ErrorContains(t, err, errs.EmptyData.Error())
+}
 
 func TestUsecase_Get(t *testing.T) {
 	tests := []struct {
@@ -218,7 +488,7 @@ func TestUsecase_Get(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ut := newTest()
-			ut.usecase = New(ut.service, ut.mdmService, ut.providers)
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
 
 			if tt.setupMock != nil {
 				tt.setupMock(ut, tt.keyID)
@@ -239,6 +509,126 @@ func TestUsecase_Get(t *testing.T) {
 	}
 }
 
+func TestUsecase_History(t *testing.T) {
+	tests := []struct {
+		name           string
+		keyID          string
+		setupMock      func(t *usecaseTest, id string)
+		expectedEvents []*model.Event
+		expectedErr    error
+	}{
+		{
+			name:  "success",
+			keyID: "key-1",
+			setupMock: func(ut *usecaseTest, id string) {
+				ut.service.On("Get", mock.Anything, id, true).Return(&model.Main{ID: id}, true, nil).Once()
+				ut.service.On("History", mock.Anything, id).Return([]*model.Event{
+					{KeyID: id, FromStatus: constant.KeyStatusNew, ToStatus: constant.KeyStatusActivated, Actor: "system"},
+				}, nil).Once()
+			},
+			expectedEvents: []*model.Event{
+				{KeyID: "key-1", FromStatus: constant.KeyStatusNew, ToStatus: constant.KeyStatusActivated, Actor: "system"},
+			},
+		},
+		{
+			name:  "key not found",
+			keyID: "key-2",
+			setupMock: func(ut *usecaseTest, id string) {
+				ut.service.On("Get", mock.Anything, id, true).Return(nil, false, errs.ObjectNotFound).Once()
+			},
+			expectedErr: errs.ObjectNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ut := newTest()
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+			tt.setupMock(ut, tt.keyID)
+
+			result, err := ut.usecase.History(context.Background(), tt.keyID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedEvents, result)
+
+			ut.service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUsecase_RevealValue(t *testing.T) {
+	tests := []struct {
+		name        string
+		role        string
+		reason      string
+		setupMock   func(ut *usecaseTest)
+		want        string
+		expectedErr error
+	}{
+		{
+			name:   "support",
+			role:   constant.RoleSupport,
+			reason: "ticket-1",
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("RevealValue", mock.Anything, "key-1", "ticket-1").
+					Return(&model.Main{ID: "key-1", Value: "ABCD-1234"}, nil).Once()
+			},
+			want: "ABCD-1234",
+		},
+		{
+			name:        "storefront denied",
+			role:        constant.RoleStorefront,
+			reason:      "ticket-1",
+			expectedErr: errs.PermissionDenied,
+		},
+		{
+			name:        "no role",
+			reason:      "ticket-1",
+			expectedErr: errs.PermissionDenied,
+		},
+		{
+			name:        "reason required",
+			role:        constant.RoleAdmin,
+			reason:      " ",
+			expectedErr: errs.ReasonRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ut := newTest()
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+			if tt.setupMock != nil {
+				tt.setupMock(ut)
+			}
+
+			ctx := util.CtxWithRole(context.Background(), tt.role)
+
+			value, err := ut.usecase.RevealValue(ctx, "key-1", tt.reason)
+			if tt.expectedErr != nil {
+				var errFull errs.ErrFull
+				if errors.As(err, &errFull) {
+					err = errFull.Err
+				}
+				assert.ErrorIs(t, err, tt.expectedErr)
+				ut.service.AssertNotCalled(t, "RevealValue", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, value)
+			ut.service.AssertExpectations(t)
+		})
+	}
+}
+
 func TestUsecase_GetCatalog(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -284,7 +674,7 @@ func TestUsecase_GetCatalog(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ut := newTest()
-			ut.usecase = New(ut.service, ut.mdmService, ut.providers)
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
 
 			if tt.setupMock != nil {
 				tt.setupMock(ut, tt.providerID)
@@ -433,7 +823,7 @@ func TestUsecase_GetCatalog(t *testing.T) {
 //	for _, tt := range tests {
 //		t.Run(tt.name, func(t *testing.T) {
 //			ut := newTest()
-//			ut.usecase = New(ut.service, ut.mdmService, ut.providers)
+//			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
 //
 //			if tt.setupMock != nil {
 //				tt.setupMock(ut)
@@ -463,13 +853,15 @@ func TestUsecase_Cancel(t *testing.T) {
 	tests := []struct {
 		name        string
 		orderID     string
+		productID   string
 		setupMock   func(ut *usecaseTest)
 		expectedID  *string
 		expectedErr error
 	}{
 		{
-			name:    "success",
-			orderID: "ord-1",
+			name:      "success",
+			orderID:   "ord-1",
+			productID: "prod-1",
 			setupMock: func(ut *usecaseTest) {
 				main := &model.Main{
 					ID:                "key-1",
@@ -477,12 +869,15 @@ func TestUsecase_Cancel(t *testing.T) {
 					ProductID:         "prod-1",
 					ProviderProductID: "prov-prod-1",
 					CustomerPhone:     "+77001112233",
+					Status:            constant.KeyStatusActivated,
 				}
-				ut.service.On("GetByOrderID", mock.Anything, "ord-1", true).Return(main, true, nil).Once()
+				ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").Return(main, true, nil).Once()
 
 				ut.providerService.On("CancelOrder", mock.Anything, mock.Anything).Return(&providerModel.CancelResponse{Success: true}, nil).Once()
 
-				ut.service.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				ut.service.On("Transition", mock.Anything, main, mock.MatchedBy(func(obj *model.Edit) bool {
+					return *obj.Status == constant.KeyStatusCancelled
+				}), "customer request").Return(nil).Once()
 			},
 			expectedID:  lo.ToPtr("key-1"),
 			expectedErr: nil,
@@ -495,53 +890,125 @@ func TestUsecase_Cancel(t *testing.T) {
 			expectedErr: errs.OrderIDRequired,
 		},
 		{
-			name:    "order not found",
-			orderID: "non-existent",
+			name:      "order not found",
+			orderID:   "non-existent",
+			productID: "prod-1",
 			setupMock: func(ut *usecaseTest) {
-				ut.service.On("GetByOrderID", mock.Anything, "non-existent", true).Return(nil, false, errs.ObjectNotFound).Once()
+				ut.service.On("GetByOrderAndProductID", mock.Anything, "non-existent", "prod-1").Return(nil, false, nil).Once()
 			},
 			expectedID:  nil,
 			expectedErr: errs.ObjectNotFound,
 		},
 		{
-			name:    "provider not found",
-			orderID: "ord-1",
+			name:      "provider not found",
+			orderID:   "ord-1",
+			productID: "prod-1",
 			setupMock: func(ut *usecaseTest) {
 				main := &model.Main{
 					ID:         "key-1",
 					ProviderID: "unknown-provider",
+					Status:     constant.KeyStatusActivated,
 				}
-				ut.service.On("GetByOrderID", mock.Anything, "ord-1", true).Return(main, true, nil).Once()
+				ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").Return(main, true, nil).Once()
 			},
 			expectedID:  nil,
 			expectedErr: errors.New("Услуги провайдера не подключены"),
 		},
 		{
-			name:    "provider service error",
-			orderID: "ord-1",
+			name:      "provider service error",
+			orderID:   "ord-1",
+			productID: "prod-1",
 			setupMock: func(ut *usecaseTest) {
 				main := &model.Main{
 					ID:         "key-1",
 					ProviderID: "provider-1",
+					Status:     constant.KeyStatusActivated,
 				}
-				ut.service.On("GetByOrderID", mock.Anything, "ord-1", true).Return(main, true, nil).Once()
+				ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").Return(main, true, nil).Once()
 				ut.providerService.On("CancelOrder", mock.Anything, mock.Anything).Return(nil, errors.New("provider error")).Once()
 			},
 			expectedID:  nil,
 			expectedErr: errors.New("provider error"),
 		},
+		{
+			name:      "already cancelled - provider not called",
+			orderID:   "ord-1",
+			productID: "prod-1",
+			setupMock: func(ut *usecaseTest) {
+				main := &model.Main{
+					ID:         "key-1",
+					ProviderID: "provider-1",
+					Status:     constant.KeyStatusCancelled,
+				}
+				ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").Return(main, true, nil).Once()
+			},
+			expectedID:  nil,
+			expectedErr: errs.AlreadyCancelled,
+		},
+		{
+			name:      "key not issued - provider not called",
+			orderID:   "ord-1",
+			productID: "prod-1",
+			setupMock: func(ut *usecaseTest) {
+				main := &model.Main{
+					ID:         "key-1",
+					ProviderID: "provider-1",
+					Status:     constant.KeyStatusReserved,
+				}
+				ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").Return(main, true, nil).Once()
+			},
+			expectedID:  nil,
+			expectedErr: errs.InvalidKeyTransition,
+		},
+		{
+			name:    "without product - the only key of the order",
+			orderID: "ord-1",
+			setupMock: func(ut *usecaseTest) {
+				main := &model.Main{
+					ID:         "key-1",
+					ProviderID: "provider-1",
+					ProductID:  "prod-1",
+					Status:     constant.KeyStatusActivated,
+				}
+				ut.service.On("List", mock.Anything, mock.MatchedBy(func(pars *model.ListReq) bool {
+					return *pars.OrderID == "ord-1"
+				})).Return([]*model.Main{main}, int64(0), nil).Once()
+				ut.providerService.On("CancelOrder", mock.Anything, mock.Anything).Return(&providerModel.CancelResponse{Success: true}, nil).Once()
+				ut.service.On("Transition", mock.Anything, main, mock.Anything, "customer request").Return(nil).Once()
+			},
+			expectedID: lo.ToPtr("key-1"),
+		},
+		{
+			name:    "without product - several keys on the order",
+			orderID: "ord-1",
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("List", mock.Anything, mock.Anything).Return([]*model.Main{
+					{ID: "key-1", ProductID: "prod-1"},
+					{ID: "key-2", ProductID: "prod-2"},
+				}, int64(0), nil).Once()
+			},
+			expectedErr: errs.ProductIDRequired,
+		},
+		{
+			name:    "without product - no keys on the order",
+			orderID: "ord-1",
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("List", mock.Anything, mock.Anything).Return(nil, int64(0), nil).Once()
+			},
+			expectedErr: errs.ObjectNotFound,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ut := newTest()
-			ut.usecase = New(ut.service, ut.mdmService, ut.providers)
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
 
 			if tt.setupMock != nil {
 				tt.setupMock(ut)
 			}
 
-			result, err := ut.usecase.Cancel(context.Background(), tt.orderID)
+			result, err := ut.usecase.Cancel(context.Background(), tt.orderID, tt.productID, "customer request")
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
@@ -563,36 +1030,104 @@ func TestUsecase_Cancel(t *testing.T) {
 	}
 }
 
-func TestUsecase_validateActivate(t *testing.T) {
+func TestUsecase_Cancel_PoolKey(t *testing.T) {
 	tests := []struct {
-		productID     string
-		orderID       string
-		customerPhone string
-		expectedErr   error
+		name                  string
+		returnPolicy          string
+		providerTransactionID string
+		reissued              bool
 	}{
 		{
-			productID:     "prod-1",
-			orderID:       "ord-1",
-			customerPhone: "+77001112233",
-			expectedErr:   nil,
+			name:         "quarantine - key left returned",
+			returnPolicy: constant.KeyReturnPolicyQuarantine,
 		},
 		{
-			productID:     "  prod-1  ",
-			orderID:       "  ord-1  ",
-			customerPhone: "  +77001112233  ",
-			expectedErr:   nil,
+			name:         "reissue - key detached from order and back in pool",
+			returnPolicy: constant.KeyReturnPolicyReissue,
+			reissued:     true,
 		},
 		{
-			productID:     "",
-			orderID:       "ord-1",
-			customerPhone: "+77001112233",
-			expectedErr:   errs.ProductIDRequired,
+			// ключ докуплен в пул у провайдера без заказа, выдан через ClaimNew
+			name:                  "replenished key - provider not called",
+			returnPolicy:          constant.KeyReturnPolicyQuarantine,
+			providerTransactionID: "tr-1",
 		},
-		{
-			productID:     "prod-1",
-			orderID:       "",
-			customerPhone: "+77001112233",
-			expectedErr:   errs.OrderIDRequired,
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ut := newTest()
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, tt.returnPolicy)
+
+			main := &model.Main{
+				ID:                    "key-1",
+				ProviderID:            "provider-1",
+				ProductID:             "prod-1",
+				OrderID:               "ord-1",
+				Status:                constant.KeyStatusActivated,
+				Source:                constant.KeySourcePool,
+				ProviderTransactionID: tt.providerTransactionID,
+			}
+			ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").Return(main, true, nil).Once()
+			ut.service.On("Transition", mock.Anything, main, mock.MatchedBy(func(obj *model.Edit) bool {
+				return *obj.Status == constant.KeyStatusReturned
+			}), "customer request").Return(nil).Once()
+			if tt.reissued {
+				ut.service.On("Transition", mock.Anything, main, mock.MatchedBy(func(obj *model.Edit) bool {
+					return *obj.Status == constant.KeyStatusNew && *obj.OrderID == "" && *obj.CustomerPhone == ""
+				}), "customer request").Return(nil).Once()
+			}
+
+			result, err := ut.usecase.Cancel(context.Background(), "ord-1", "prod-1", "customer request")
+			require.NoError(t, err)
+			assert.Equal(t, "key-1", lo.FromPtr(result))
+
+			ut.service.AssertExpectations(t)
+			ut.providerService.AssertNotCalled(t, "CancelOrder", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUsecase_validateActivate(t *testing.T) {
+	tests := []struct {
+		productID     string
+		orderID       string
+		customerPhone string
+		expectedPhone string
+		expectedErr   error
+	}{
+		{
+			productID:     "prod-1",
+			orderID:       "ord-1",
+			customerPhone: "+77001112233",
+			expectedPhone: "77001112233",
+			expectedErr:   nil,
+		},
+		{
+			productID:     "  prod-1  ",
+			orderID:       "  ord-1  ",
+			customerPhone: "  +77001112233  ",
+			expectedPhone: "77001112233",
+			expectedErr:   nil,
+		},
+		{
+			productID:     "prod-1",
+			orderID:       "ord-1",
+			customerPhone: "87001112233",
+			expectedPhone: "77001112233",
+			expectedErr:   nil,
+		},
+		{
+			productID:     "",
+			orderID:       "ord-1",
+			customerPhone: "+77001112233",
+			expectedErr:   errs.ProductIDRequired,
+		},
+		{
+			productID:     "prod-1",
+			orderID:       "",
+			customerPhone: "+77001112233",
+			expectedErr:   errs.OrderIDRequired,
 		},
 		{
 			productID:     "prod-1",
@@ -628,6 +1163,7 @@ func TestUsecase_validateActivate(t *testing.T) {
 			productID:     "prod-1",
 			orderID:       "ord-1",
 			customerPhone: "+77001112233",
+			expectedPhone: "77001112233",
 			expectedErr:   nil,
 		},
 	}
@@ -635,18 +1171,1666 @@ func TestUsecase_validateActivate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
 			ut := newTest()
-			ut.usecase = New(ut.service, ut.mdmService, ut.providers)
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
 
 			ut.service.On("GetByOrderID", mock.Anything, strings.TrimSpace(tt.orderID), false).Return(nil, false, nil).Once()
 
-			err := ut.usecase.validateActivate(context.Background(), tt.orderID, tt.productID, tt.customerPhone)
+			orderID, productID, customerPhone := tt.orderID, tt.productID, tt.customerPhone
+			err := ut.usecase.validateActivate(context.Background(), &orderID, &productID, &customerPhone)
+
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				assert.ErrorContains(t, err, tt.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "ord-1", orderID)
+				assert.Equal(t, "prod-1", productID)
+				assert.Equal(t, tt.expectedPhone, customerPhone)
+			}
+		})
+	}
+}
+
+func TestUsecase_activate(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          string
+		setupMock   func(ut *usecaseTest)
+		expectedKey *model.Main
+		expectedErr error
+	}{
+		{
+			name: "pool - key claimed",
+			id:   "",
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("ClaimNew", mock.Anything, "prod-1", "ord-1", "77001112233").
+					Return(&model.Main{ID: "key-1", Value: "secret", Status: constant.KeyStatusActivated}, true, nil).Once()
+			},
+			expectedKey: &model.Main{ID: "key-1", Value: "secret", Status: constant.KeyStatusActivated},
+		},
+		{
+			name: "pool - empty",
+			id:   "",
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("ClaimNew", mock.Anything, "prod-1", "ord-1", "77001112233").
+					Return(nil, false, nil).Once()
+			},
+			expectedErr: errs.ObjectNotFound,
+		},
+		{
+			name: "pool - claim error",
+			id:   "",
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("ClaimNew", mock.Anything, "prod-1", "ord-1", "77001112233").
+					Return(nil, false, errors.New("db error")).Once()
+			},
+			expectedErr: errors.New("db error"),
+		},
+		{
+			name: "provider - key activated",
+			id:   "key-2",
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("Get", mock.Anything, "key-2", true).
+					Return(&model.Main{ID: "key-2", Value: "secret-2", Status: constant.KeyStatusNew, Link: "https://dl.example/key-2"}, true, nil).Once()
+				ut.service.On("Transition", mock.Anything, mock.Anything, mock.MatchedBy(func(obj *model.Edit) bool {
+					return *obj.Status == constant.KeyStatusActivated && *obj.OrderID == "ord-1"
+				}), "").Return(nil).Once()
+			},
+			expectedKey: &model.Main{
+				ID:            "key-2",
+				Value:         "secret-2",
+				Status:        constant.KeyStatusActivated,
+				OrderID:       "ord-1",
+				CustomerPhone: "77001112233",
+				Link:          "https://dl.example/key-2",
+			},
+		},
+		{
+			name: "provider - key already activated",
+			id:   "key-3",
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("Get", mock.Anything, "key-3", true).
+					Return(&model.Main{ID: "key-3", Status: constant.KeyStatusActivated}, true, nil).Once()
+				ut.service.On("Transition", mock.Anything, mock.Anything, mock.Anything, "").Return(errs.AlreadyActivated).Once()
+			},
+			expectedErr: errs.AlreadyActivated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ut := newTest()
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+			if tt.setupMock != nil {
+				tt.setupMock(ut)
+			}
+
+			result, err := ut.usecase.activate(context.Background(), tt.id, "ord-1", "77001112233", "prod-1")
+
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				assert.ErrorContains(t, err, tt.expectedErr.Error())
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedKey, result)
+			}
+
+			ut.service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUsecase_Activate_Idempotent(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMock     func(ut *usecaseTest)
+		expectedValue string
+		expectedErr   error
+	}{
+		{
+			name: "already issued - returns existing value",
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").
+					Return(&model.Main{ID: "key-1", Value: "secret", Status: constant.KeyStatusActivated}, true, nil).Once()
+			},
+			expectedValue: "secret",
+		},
+		{
+			name: "already cancelled",
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").
+					Return(&model.Main{ID: "key-1", Status: constant.KeyStatusCancelled}, true, nil).Once()
+			},
+			expectedErr: errs.AlreadyCancelled,
+		},
+		{
+			name: "concurrent request in progress",
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").
+					Return(nil, false, nil).Once()
+				ut.service.On("LockOrder", mock.Anything, "ord-1", "prod-1").Return("", false, nil).Once()
+			},
+			expectedErr: errs.ActivationInProgress,
+		},
+		{
+			name: "issued while waiting for lock",
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").
+					Return(nil, false, nil).Once()
+				ut.service.On("LockOrder", mock.Anything, "ord-1", "prod-1").Return("lock-1", true, nil).Once()
+				ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").
+					Return(&model.Main{ID: "key-1", Value: "secret", Status: constant.KeyStatusActivated}, true, nil).Once()
+				ut.service.On("UnlockOrder", mock.Anything, "ord-1", "prod-1", "lock-1").Return(nil).Once()
+			},
+			expectedValue: "secret",
+		},
+		{
+			name: "reserved pool key - issued without provider",
+			setupMock: func(ut *usecaseTest) {
+				reservation := &model.Reservation{ID: "res-1", OrderID: "ord-1", ProductID: "prod-1", KeyID: "key-1", ExpiresAt: time.Now().Add(time.Minute)}
+
+				ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").
+					Return(nil, false, nil).Twice()
+				ut.service.On("LockOrder", mock.Anything, "ord-1", "prod-1").Return("lock-1", true, nil).Once()
+				ut.service.On("GetActiveReservation", mock.Anything, "ord-1", "prod-1").Return(reservation, true, nil).Once()
+				ut.service.On("ConfirmReservation", mock.Anything, reservation, "77001112233", "").Return(nil).Once()
+				ut.service.On("Get", mock.Anything, "key-1", true).
+					Return(&model.Main{ID: "key-1", Value: "secret", Status: constant.KeyStatusActivated}, true, nil).Once()
+				ut.service.On("UnlockOrder", mock.Anything, "ord-1", "prod-1", "lock-1").Return(nil).Once()
+			},
+			expectedValue: "secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ut := newTest()
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+			if tt.setupMock != nil {
+				tt.setupMock(ut)
+			}
+
+			result, err := ut.usecase.Activate(context.Background(), "prod-1", "ord-1", "77001112233")
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
 				assert.ErrorContains(t, err, tt.expectedErr.Error())
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedValue, result.Value)
+			}
+
+			ut.service.AssertExpectations(t)
+			ut.mdmService.AssertExpectations(t)
+			ut.providerService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUsecase_Reserve(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(ut *usecaseTest)
+		expectedID  string
+		expectedErr error
+	}{
+		{
+			name: "pool provider - reserved from pool",
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("LockOrder", mock.Anything, "ord-1", "prod-1").Return("lock-1", true, nil).Once()
+				ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").Return(nil, false, nil).Once()
+				ut.service.On("GetActiveReservation", mock.Anything, "ord-1", "prod-1").Return(nil, false, nil).Once()
+				ut.mdmService.On("FindProduct", mock.Anything, lo.ToPtr("prod-1")).
+					Return(&mdmModel.Product{ProductID: "prod-1", ProviderID: "provider-1"}, true, nil).Once()
+				ut.routeService.On("ListByProduct", mock.Anything, "prod-1").Return(nil, nil).Once()
+				ut.providerService.On("SupportsPool").Return(true).Once()
+				ut.service.On("Reserve", mock.Anything, mock.MatchedBy(func(obj *model.ReservationEdit) bool {
+					return *obj.OrderID == "ord-1" && *obj.ProductID == "prod-1" && obj.ExpiresAt.After(time.Now())
+				}), true).Return(&model.Reservation{ID: "res-1", KeyID: "key-1"}, nil).Once()
+				ut.service.On("UnlockOrder", mock.Anything, "ord-1", "prod-1", "lock-1").Return(nil).Once()
+			},
+			expectedID: "res-1",
+		},
+		{
+			name: "bound provider without pool - key bought on confirm",
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("LockOrder", mock.Anything, "ord-1", "prod-1").Return("lock-1", true, nil).Once()
+				ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").Return(nil, false, nil).Once()
+				ut.service.On("GetActiveReservation", mock.Anything, "ord-1", "prod-1").Return(nil, false, nil).Once()
+				ut.mdmService.On("FindProduct", mock.Anything, lo.ToPtr("prod-1")).
+					Return(&mdmModel.Product{ProductID: "prod-1", ProviderID: "provider-1"}, true, nil).Once()
+				// привязка заменяет провайдера из mdm
+				ut.routeService.On("ListByProduct", mock.Anything, "prod-1").
+					Return([]*productProviderModel.Main{{ProductID: "prod-1", ProviderID: "provider-2", ProviderProductID: "p2-sku"}}, nil).Once()
+				ut.providers["provider-2"].(*mocks.ProviderServiceI).On("SupportsPool").Return(false).Once()
+				ut.service.On("Reserve", mock.Anything, mock.Anything, false).Return(&model.Reservation{ID: "res-2"}, nil).Once()
+				ut.service.On("UnlockOrder", mock.Anything, "ord-1", "prod-1", "lock-1").Return(nil).Once()
+			},
+			expectedID: "res-2",
+		},
+		{
+			name: "active reservation - returned again",
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("LockOrder", mock.Anything, "ord-1", "prod-1").Return("lock-1", true, nil).Once()
+				ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").Return(nil, false, nil).Once()
+				ut.service.On("GetActiveReservation", mock.Anything, "ord-1", "prod-1").
+					Return(&model.Reservation{ID: "res-1"}, true, nil).Once()
+				ut.service.On("UnlockOrder", mock.Anything, "ord-1", "prod-1", "lock-1").Return(nil).Once()
+			},
+			expectedID: "res-1",
+		},
+		{
+			name: "key already issued",
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("LockOrder", mock.Anything, "ord-1", "prod-1").Return("lock-1", true, nil).Once()
+				ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").
+					Return(&model.Main{ID: "key-1", Status: constant.KeyStatusActivated}, true, nil).Once()
+				ut.service.On("UnlockOrder", mock.Anything, "ord-1", "prod-1", "lock-1").Return(nil).Once()
+			},
+			expectedErr: errs.AlreadyActivated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ut := newTest()
+			ut.providers["provider-2"] = new(mocks.ProviderServiceI)
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+			tt.setupMock(ut)
+
+			result, err := ut.usecase.Reserve(context.Background(), "prod-1", "ord-1")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedID, result.ID)
+			}
+
+			ut.service.AssertExpectations(t)
+			ut.mdmService.AssertExpectations(t)
+			ut.providerService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUsecase_Confirm(t *testing.T) {
+	tests := []struct {
+		name          string
+		reservation   *model.Reservation
+		setupMock     func(ut *usecaseTest)
+		expectedValue string
+		expectedErr   error
+	}{
+		{
+			name: "already confirmed - returns issued key",
+			reservation: &model.Reservation{
+				ID: "res-1", KeyID: "key-1", Status: constant.ReservationStatusConfirmed,
+			},
+			setupMock: func(ut *usecaseTest) {
+				ut.service.On("Get", mock.Anything, "key-1", true).
+					Return(&model.Main{ID: "key-1", Value: "secret"}, true, nil).Once()
+			},
+			expectedValue: "secret",
+		},
+		{
+			name: "expired but not released yet",
+			reservation: &model.Reservation{
+				ID: "res-1", Status: constant.ReservationStatusActive, ExpiresAt: time.Now().Add(-time.Minute),
+			},
+			expectedErr: errs.ReservationExpired,
+		},
+		{
+			name: "released",
+			reservation: &model.Reservation{
+				ID: "res-1", Status: constant.ReservationStatusReleased,
+			},
+			expectedErr: errs.ReservationNotActive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ut := newTest()
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+			ut.service.On("GetReservation", mock.Anything, "res-1", true).Return(tt.reservation, true, nil).Once()
+			if tt.setupMock != nil {
+				tt.setupMock(ut)
+			}
+
+			result, err := ut.usecase.Confirm(context.Background(), "res-1", "77001112233")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedValue, result.Value)
 			}
+
+			ut.service.AssertExpectations(t)
 		})
 	}
 }
+
+func TestUsecase_Release(t *testing.T) {
+	ut := newTest()
+	ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+	active := &model.Reservation{ID: "res-1", KeyID: "key-1", Status: constant.ReservationStatusActive}
+	released := &model.Reservation{ID: "res-2", Status: constant.ReservationStatusReleased}
+
+	ut.service.On("GetReservation", mock.Anything, "res-1", true).Return(active, true, nil).Once()
+	ut.service.On("ReleaseReservation", mock.Anything, active, constant.ReservationStatusReleased, constant.EventReasonReservationReleased).
+		Return(nil).Once()
+	ut.service.On("GetReservation", mock.Anything, "res-2", true).Return(released, true, nil).Once()
+
+	assert.NoError(t, ut.usecase.Release(context.Background(), "res-1"))
+	// повторное снятие ничего не меняет
+	assert.NoError(t, ut.usecase.Release(context.Background(), "res-2"))
+
+	ut.service.AssertExpectations(t)
+}
+
+func TestUsecase_ReleaseExpired(t *testing.T) {
+	ut := newTest()
+	ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+	items := []*model.Reservation{
+		{ID: "res-1", KeyID: "key-1"},
+		{ID: "res-2"},
+	}
+
+	ut.service.On("ListExpiredReservations", mock.Anything, uint64(releaseBatchSize)).Return(items, nil).Once()
+	ut.service.On("ReleaseReservation", mock.Anything, items[0], constant.ReservationStatusExpired, constant.EventReasonReservationExpired).
+		Return(errs.ReservationNotActive).Once()
+	ut.service.On("ReleaseReservation", mock.Anything, items[1], constant.ReservationStatusExpired, constant.EventReasonReservationExpired).
+		Return(nil).Once()
+
+	assert.NoError(t, ut.usecase.ReleaseExpired(context.Background()))
+
+	ut.service.AssertExpectations(t)
+}
+
+func TestUsecase_Replenish(t *testing.T) {
+	level := func(dailyCap int64) *poolLevelModel.Main {
+		return &poolLevelModel.Main{ProductID: "prod-1", MinLevel: 2, TargetLevel: 5, DailyCap: dailyCap}
+	}
+
+	expectAvailable := func(ut *usecaseTest, count int64) {
+		ut.service.On("List", mock.Anything, mock.MatchedBy(func(req *model.ListReq) bool {
+			return req.OnlyCount && *req.ProductID == "prod-1" && *req.Status == constant.KeyStatusNew
+		})).Return(nil, count, nil).Once()
+	}
+
+	expectSpent := func(ut *usecaseTest, perProduct bool, count int64) {
+		ut.operationService.On("List", mock.Anything, mock.MatchedBy(func(req *operationModel.ListReq) bool {
+			return req.OnlyCount && (req.ProductID != nil) == perProduct && *req.OrderID == "" &&
+				*req.ExcludeStatus == constant.OperationStatusFailed && req.CreatedAfter != nil
+		})).Return(nil, count, nil).Once()
+	}
+
+	expectProvider := func(ut *usecaseTest, supportsPool bool) {
+		ut.mdmService.On("FindProduct", mock.Anything, mock.Anything).
+			Return(&mdmModel.Product{ProductID: "prod-1", ProviderID: "provider-1"}, true, nil).Once()
+		ut.routeService.On("ListByProduct", mock.Anything, "prod-1").Return(nil, nil).Once()
+		ut.providerService.On("SupportsPool").Return(supportsPool).Once()
+	}
+
+	expectPurchases := func(ut *usecaseTest, n int) {
+		ut.operationService.On("Create", mock.Anything, mock.MatchedBy(func(obj *operationModel.Edit) bool {
+			return *obj.OrderID == ""
+		})).Return("op-1", nil).Times(n)
+		ut.providerService.On("CreateOrder", mock.Anything, mock.Anything).
+			Return(&providerModel.OrderResponse{Value: "secret"}, nil).Times(n)
+		ut.operationService.On("Update", mock.Anything, mock.Anything).Return(nil).Times(2 * n)
+		// докупленный без заказа ключ - ключ пула
+		ut.service.On("Create", mock.Anything, mock.MatchedBy(func(obj *model.Edit) bool {
+			return *obj.Source == constant.KeySourcePool
+		})).Return("key-1", nil).Times(n)
+	}
+
+	tests := []struct {
+		name          string
+		dailyCap      int64
+		level         *poolLevelModel.Main
+		setupMock     func(ut *usecaseTest)
+		wantAvailable int64
+		wantPurchased int64
+		wantFailed    int64
+		wantCap       bool
+		wantAlert     bool
+	}{
+		{
+			name:  "pool above min - nothing bought",
+			level: level(0),
+			setupMock: func(ut *usecaseTest) {
+				expectAvailable(ut, 2)
+			},
+			wantAvailable: 2,
+		},
+		{
+			name:  "buys up to target",
+			level: level(0),
+			setupMock: func(ut *usecaseTest) {
+				expectAvailable(ut, 1)
+				expectProvider(ut, true)
+				expectPurchases(ut, 4)
+			},
+			wantAvailable: 5,
+			wantPurchased: 4,
+		},
+		{
+			name:  "product daily cap",
+			level: level(3),
+			setupMock: func(ut *usecaseTest) {
+				expectAvailable(ut, 1)
+				expectSpent(ut, true, 1)
+				expectProvider(ut, true)
+				expectPurchases(ut, 2)
+			},
+			wantAvailable: 3,
+			wantPurchased: 2,
+			wantCap:       true,
+		},
+		{
+			name:     "global daily cap spent - alert",
+			dailyCap: 10,
+			level:    level(0),
+			setupMock: func(ut *usecaseTest) {
+				expectSpent(ut, false, 10)
+				expectAvailable(ut, 0)
+			},
+			wantCap:   true,
+			wantAlert: true,
+		},
+		{
+			name:  "provider error - alert",
+			level: level(0),
+			setupMock: func(ut *usecaseTest) {
+				expectAvailable(ut, 0)
+				expectProvider(ut, true)
+				expectPurchases(ut, 1)
+				ut.operationService.On("Create", mock.Anything, mock.Anything).Return("op-2", nil).Once()
+				ut.providerService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil, errors.New("provider down")).Once()
+				ut.operationService.On("Update", mock.Anything, operationStatusIs(constant.OperationStatusFailed)).Return(nil).Once()
+			},
+			wantAvailable: 1,
+			wantPurchased: 1,
+			wantFailed:    1,
+			wantAlert:     true,
+		},
+		{
+			name:  "provider without pool - alert",
+			level: level(0),
+			setupMock: func(ut *usecaseTest) {
+				expectAvailable(ut, 0)
+				expectProvider(ut, false)
+			},
+			wantAlert: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ut := newTest()
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+			ut.poolLevelService.On("List", mock.Anything, mock.Anything).Return([]*poolLevelModel.Main{tt.level}, int64(1), nil).Once()
+			tt.setupMock(ut)
+			if tt.wantAlert {
+				ut.alertService.On("PoolAlert", mock.Anything, mock.MatchedBy(func(obj *alertModel.PoolAlert) bool {
+					return obj.ProductID == "prod-1" && obj.Available == tt.wantAvailable
+				})).Return(nil).Once()
+			}
+
+			states, err := ut.usecase.Replenish(context.Background(), tt.dailyCap)
+
+			assert.NoError(t, err)
+			if assert.Len(t, states, 1) {
+				assert.Equal(t, tt.wantAvailable, states[0].Available)
+				assert.Equal(t, tt.wantPurchased, states[0].Purchased)
+				assert.Equal(t, tt.wantFailed, states[0].Failed)
+				assert.Equal(t, tt.wantCap, states[0].CapReached)
+			}
+
+			ut.service.AssertExpectations(t)
+			ut.operationService.AssertExpectations(t)
+			ut.providerService.AssertExpectations(t)
+			ut.alertService.AssertExpectations(t)
+		})
+	}
+}
+
+// пул пополняется через привязки продукта: провайдер без пула пропускается,
+// при сбое первого провайдера ключ докупается у следующего
+func TestUsecase_Replenish_Routes(t *testing.T) {
+	ut := newTest()
+	provider2 := new(mocks.ProviderServiceI)
+	provider3 := new(mocks.ProviderServiceI)
+	ut.providers["provider-2"] = provider2
+	ut.providers["provider-3"] = provider3
+	ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+	level := &poolLevelModel.Main{ProductID: "prod-1", MinLevel: 1, TargetLevel: 1}
+
+	ut.poolLevelService.On("List", mock.Anything, mock.Anything).Return([]*poolLevelModel.Main{level}, int64(1), nil).Once()
+	ut.service.On("List", mock.Anything, mock.Anything).Return(nil, int64(0), nil).Once()
+	ut.mdmService.On("FindProduct", mock.Anything, lo.ToPtr("prod-1")).
+		Return(&mdmModel.Product{ProductID: "prod-1", ProviderID: "provider-1"}, true, nil).Once()
+	ut.routeService.On("ListByProduct", mock.Anything, "prod-1").Return([]*productProviderModel.Main{
+		{ProductID: "prod-1", ProviderID: "provider-1", ProviderProductID: "p1-sku"},
+		{ProductID: "prod-1", ProviderID: "provider-2", ProviderProductID: "p2-sku"},
+		{ProductID: "prod-1", ProviderID: "provider-3", ProviderProductID: "p3-sku"},
+	}, nil).Once()
+	ut.providerService.On("SupportsPool").Return(false).Once()
+	provider2.On("SupportsPool").Return(true).Once()
+	provider3.On("SupportsPool").Return(true).Once()
+
+	ut.operationService.On("Create", mock.Anything, mock.Anything).Return("op-1", nil).Twice()
+	provider2.On("CreateOrder", mock.Anything, mock.Anything).Return(nil, providerModel.ErrUnavailable).Once()
+	ut.operationService.On("Update", mock.Anything, operationStatusIs(constant.OperationStatusFailed)).Return(nil).Once()
+	provider3.On("CreateOrder", mock.Anything, mock.MatchedBy(func(req *providerModel.OrderRequest) bool {
+		return req.ProviderProductID == "p3-sku" && req.OrderID == ""
+	})).Return(&providerModel.OrderResponse{Value: "secret"}, nil).Once()
+	ut.operationService.On("Update", mock.Anything, mock.Anything).Return(nil).Twice()
+	ut.service.On("Create", mock.Anything, mock.MatchedBy(func(obj *model.Edit) bool {
+		return *obj.ProviderID == "provider-3" && *obj.Source == constant.KeySourcePool
+	})).Return("key-1", nil).Once()
+
+	states, err := ut.usecase.Replenish(context.Background(), 0)
+
+	assert.NoError(t, err)
+	if assert.Len(t, states, 1) {
+		assert.EqualValues(t, 1, states[0].Purchased)
+		assert.EqualValues(t, 0, states[0].Failed)
+	}
+
+	ut.service.AssertExpectations(t)
+	ut.providerService.AssertExpectations(t)
+	provider2.AssertExpectations(t)
+	provider3.AssertExpectations(t)
+}
+
+func TestUsecase_SetPoolLevel(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          *poolLevelModel.Edit
+		supportsPool bool
+		expectedErr  error
+	}{
+		{
+			name:         "success",
+			req:          &poolLevelModel.Edit{ProductID: " prod-1 ", MinLevel: lo.ToPtr(int64(2)), TargetLevel: lo.ToPtr(int64(5))},
+			supportsPool: true,
+		},
+		{
+			name:        "target below min",
+			req:         &poolLevelModel.Edit{ProductID: "prod-1", MinLevel: lo.ToPtr(int64(5)), TargetLevel: lo.ToPtr(int64(2))},
+			expectedErr: errs.InvalidPoolLevel,
+		},
+		{
+			name:        "product required",
+			req:         &poolLevelModel.Edit{MinLevel: lo.ToPtr(int64(1)), TargetLevel: lo.ToPtr(int64(2))},
+			expectedErr: errs.ProductIDRequired,
+		},
+		{
+			name:         "provider without pool",
+			req:          &poolLevelModel.Edit{ProductID: "prod-1", MinLevel: lo.ToPtr(int64(1)), TargetLevel: lo.ToPtr(int64(2))},
+			supportsPool: false,
+			expectedErr:  errs.PoolNotSupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ut := newTest()
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+			ut.mdmService.On("FindProduct", mock.Anything, mock.Anything).
+				Return(&mdmModel.Product{ProductID: "prod-1", ProviderID: "provider-1"}, true, nil).Maybe()
+			ut.routeService.On("ListByProduct", mock.Anything, "prod-1").Return(nil, nil).Maybe()
+			ut.providerService.On("SupportsPool").Return(tt.supportsPool).Maybe()
+			ut.poolLevelService.On("Set", mock.Anything, mock.MatchedBy(func(obj *poolLevelModel.Edit) bool {
+				return obj.ProductID == "prod-1"
+			})).Return(nil).Maybe()
+			ut.poolLevelService.On("Get", mock.Anything, "prod-1", true).Return(&poolLevelModel.Main{ProductID: "prod-1"}, true, nil).Maybe()
+
+			result, err := ut.usecase.SetPoolLevel(context.Background(), tt.req)
+
+			if tt.expectedErr != nil {
+				var errFull errs.ErrFull
+				if errors.As(err, &errFull) {
+					err = errFull.Err
+				}
+				assert.ErrorIs(t, err, tt.expectedErr)
+				ut.poolLevelService.AssertNotCalled(t, "Set", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "prod-1", result.ProductID)
+		})
+	}
+}
+
+func operationStatusIs(status string) any {
+	return mock.MatchedBy(func(obj *operationModel.Edit) bool {
+		return obj.Status != nil && *obj.Status == status
+	})
+}
+
+func TestUsecase_createOrder(t *testing.T) {
+	product := &mdmModel.Product{
+		ProviderID:        "provider-1",
+		ProductID:         "prod-1",
+		ProviderProductID: "prov-prod-1",
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func(ut *usecaseTest)
+		expectedID  string
+		expectedErr error
+	}{
+		{
+			name: "success - operation journaled up to stored",
+			setupMock: func(ut *usecaseTest) {
+				ut.operationService.On("Create", mock.Anything, operationStatusIs(constant.OperationStatusRequested)).Return("op-1", nil).Once()
+				ut.providerService.On("CreateOrder", mock.Anything, mock.MatchedBy(func(req *providerModel.OrderRequest) bool {
+					return req.OrderID == "ord-1" && req.ProviderProductID == "prov-prod-1"
+				})).Return(&providerModel.OrderResponse{
+					Value:         "secret",
+					TransactionID: "tr-1",
+					OrderID:       lo.ToPtr("po-1"),
+					Link:          lo.ToPtr("https://dl.example/secret"),
+					Instructions:  lo.ToPtr("Скачайте дистрибутив и введите ключ"),
+					LicenseTerm:   lo.ToPtr("1 год"),
+				}, nil).Once()
+				ut.operationService.On("Update", mock.Anything, mock.MatchedBy(func(obj *operationModel.Edit) bool {
+					return *obj.ID == "op-1" && *obj.Status == constant.OperationStatusPurchased && *obj.Value == "secret"
+				})).Return(nil).Once()
+				ut.service.On("Create", mock.Anything, mock.MatchedBy(func(obj *model.Edit) bool {
+					return *obj.ProviderOrderID == "po-1" &&
+						*obj.Link == "https://dl.example/secret" &&
+						*obj.Instructions == "Скачайте дистрибутив и введите ключ" &&
+						*obj.LicenseTerm == "1 год"
+				})).Return("key-1", nil).Once()
+				ut.operationService.On("Update", mock.Anything, mock.MatchedBy(func(obj *operationModel.Edit) bool {
+					return *obj.ID == "op-1" && *obj.Status == constant.OperationStatusStored && *obj.KeyID == "key-1"
+				})).Return(nil).Once()
+			},
+			expectedID: "key-1",
+		},
+		{
+			name: "provider error - operation failed",
+			setupMock: func(ut *usecaseTest) {
+				ut.operationService.On("Create", mock.Anything, mock.Anything).Return("op-1", nil).Once()
+				ut.providerService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil, errors.New("provider down")).Once()
+				ut.operationService.On("Update", mock.Anything, operationStatusIs(constant.OperationStatusFailed)).Return(nil).Once()
+			},
+			expectedErr: errors.New("provider down"),
+		},
+		{
+			name: "key store error - operation left purchased",
+			setupMock: func(ut *usecaseTest) {
+				ut.operationService.On("Create", mock.Anything, mock.Anything).Return("op-1", nil).Once()
+				ut.providerService.On("CreateOrder", mock.Anything, mock.Anything).Return(&providerModel.OrderResponse{Value: "secret"}, nil).Once()
+				ut.operationService.On("Update", mock.Anything, operationStatusIs(constant.OperationStatusPurchased)).Return(nil).Once()
+				ut.service.On("Create", mock.Anything, mock.Anything).Return("", errors.New("db down")).Once()
+			},
+			expectedErr: errors.New("db down"),
+		},
+		{
+			name: "journal error - provider not called",
+			setupMock: func(ut *usecaseTest) {
+				ut.operationService.On("Create", mock.Anything, mock.Anything).Return("", errors.New("db down")).Once()
+			},
+			expectedErr: errors.New("db down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ut := newTest()
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+			if tt.setupMock != nil {
+				tt.setupMock(ut)
+			}
+
+			id, err := ut.usecase.createOrder(context.Background(), ut.providerService, product, "ord-1", "77001112233", constant.KeySourceProvider)
+
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				assert.ErrorContains(t, err, tt.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedID, id)
+			}
+
+			ut.service.AssertExpectations(t)
+			ut.operationService.AssertExpectations(t)
+			ut.providerService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUsecase_Reconcile(t *testing.T) {
+	listByStatus := func(status string) any {
+		return mock.MatchedBy(func(req *operationModel.ListReq) bool {
+			return req.Status != nil && *req.Status == status && req.UpdatedBefore != nil
+		})
+	}
+
+	tests := []struct {
+		name      string
+		setupMock func(ut *usecaseTest)
+	}{
+		{
+			name: "purchased - key stored to pool",
+			setupMock: func(ut *usecaseTest) {
+				ut.operationService.On("List", mock.Anything, listByStatus(constant.OperationStatusPurchased)).Return([]*operationModel.Main{
+					{ID: "op-1", ProductID: "prod-1", ProviderID: "provider-1", Value: "secret"},
+				}, int64(0), nil).Once()
+				ut.service.On("GetByValue", mock.Anything, "secret").Return(nil, nil).Once()
+				ut.service.On("Create", mock.Anything, mock.MatchedBy(func(obj *model.Edit) bool {
+					return *obj.Value == "secret" && *obj.ProductID == "prod-1" && obj.Status == nil && *obj.Source == constant.KeySourcePool
+				})).Return("key-1", nil).Once()
+				ut.operationService.On("Update", mock.Anything, mock.MatchedBy(func(obj *operationModel.Edit) bool {
+					return *obj.ID == "op-1" && *obj.Status == constant.OperationStatusStored && *obj.KeyID == "key-1"
+				})).Return(nil).Once()
+				ut.operationService.On("List", mock.Anything, listByStatus(constant.OperationStatusRequested)).Return(nil, int64(0), nil).Once()
+			},
+		},
+		{
+			name: "purchased for order - key bound to order as activated",
+			setupMock: func(ut *usecaseTest) {
+				ut.operationService.On("List", mock.Anything, listByStatus(constant.OperationStatusPurchased)).Return([]*operationModel.Main{
+					{ID: "op-1", ProductID: "prod-1", ProviderID: "provider-1", OrderID: "ord-1", Value: "secret"},
+				}, int64(0), nil).Once()
+				ut.service.On("GetByValue", mock.Anything, "secret").Return(nil, nil).Once()
+				ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").Return(nil, false, nil).Once()
+				ut.service.On("Create", mock.Anything, mock.MatchedBy(func(obj *model.Edit) bool {
+					return *obj.Value == "secret" && *obj.OrderID == "ord-1" &&
+						*obj.Status == constant.KeyStatusActivated && *obj.Source == constant.KeySourceProvider
+				})).Return("key-1", nil).Once()
+				ut.operationService.On("Update", mock.Anything, mock.MatchedBy(func(obj *operationModel.Edit) bool {
+					return *obj.ID == "op-1" && *obj.Status == constant.OperationStatusStored && *obj.KeyID == "key-1"
+				})).Return(nil).Once()
+				ut.operationService.On("List", mock.Anything, listByStatus(constant.OperationStatusRequested)).Return(nil, int64(0), nil).Once()
+			},
+		},
+		{
+			name: "purchased for order that already has a key - manual review",
+			setupMock: func(ut *usecaseTest) {
+				ut.operationService.On("List", mock.Anything, listByStatus(constant.OperationStatusPurchased)).Return([]*operationModel.Main{
+					{ID: "op-1", ProductID: "prod-1", ProviderID: "provider-1", OrderID: "ord-1", Value: "secret"},
+				}, int64(0), nil).Once()
+				ut.service.On("GetByValue", mock.Anything, "secret").Return(nil, nil).Once()
+				ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").
+					Return(&model.Main{ID: "key-2", Status: constant.KeyStatusActivated}, true, nil).Once()
+				ut.operationService.On("Update", mock.Anything, operationStatusIs(constant.OperationStatusManualReview)).Return(nil).Once()
+				ut.operationService.On("List", mock.Anything, listByStatus(constant.OperationStatusRequested)).Return(nil, int64(0), nil).Once()
+			},
+		},
+		{
+			name: "purchased - key already stored",
+			setupMock: func(ut *usecaseTest) {
+				ut.operationService.On("List", mock.Anything, listByStatus(constant.OperationStatusPurchased)).Return([]*operationModel.Main{
+					{ID: "op-1", Value: "secret"},
+				}, int64(0), nil).Once()
+				ut.service.On("GetByValue", mock.Anything, "secret").Return(&model.Main{ID: "key-1"}, nil).Once()
+				ut.operationService.On("Update", mock.Anything, mock.MatchedBy(func(obj *operationModel.Edit) bool {
+					return *obj.Status == constant.OperationStatusStored && *obj.KeyID == "key-1"
+				})).Return(nil).Once()
+				ut.operationService.On("List", mock.Anything, listByStatus(constant.OperationStatusRequested)).Return(nil, int64(0), nil).Once()
+			},
+		},
+		{
+			name: "purchased without value and stale requested - manual review",
+			setupMock: func(ut *usecaseTest) {
+				ut.operationService.On("List", mock.Anything, listByStatus(constant.OperationStatusPurchased)).Return([]*operationModel.Main{
+					{ID: "op-1"},
+				}, int64(0), nil).Once()
+				ut.operationService.On("List", mock.Anything, listByStatus(constant.OperationStatusRequested)).Return([]*operationModel.Main{
+					{ID: "op-2"},
+				}, int64(0), nil).Once()
+				ut.operationService.On("Update", mock.Anything, operationStatusIs(constant.OperationStatusManualReview)).Return(nil).Twice()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ut := newTest()
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+			if tt.setupMock != nil {
+				tt.setupMock(ut)
+			}
+
+			err := ut.usecase.Reconcile(context.Background())
+			assert.NoError(t, err)
+
+			ut.service.AssertExpectations(t)
+			ut.operationService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUsecase_Import(t *testing.T) {
+	newXLSX := func(t *testing.T) []byte {
+		f := excelize.NewFile()
+		defer f.Close()
+
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"SKU", "Лицензия"}))
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]string{"prod-1", "key-1"}))
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]string{"", "key-2"}))
+
+		buf, err := f.WriteToBuffer()
+		assert.NoError(t, err)
+
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name           string
+		req            *importJobModel.ImportReq
+		data           func(t *testing.T) []byte
+		expectedValues []string
+		expectedRows   []int
+	}{
+		{
+			name: "csv with header, columns by name",
+			req: &importJobModel.ImportReq{
+				FileName: "keys.csv",
+				Mapping: importJobModel.Mapping{
+					ProductIDColumn: "product",
+					ValueColumn:     "key",
+					HasHeader:       true,
+				},
+			},
+			data: func(t *testing.T) []byte {
+				return []byte("\xef\xbb\xbfProduct,Key\nprod-1,key-1\n,\nprod-2,key-2\n")
+			},
+			expectedValues: []string{"key-1", "key-2"},
+			expectedRows:   []int{2, 4},
+		},
+		{
+			name: "csv without header, default product",
+			req: &importJobModel.ImportReq{
+				Format: constant.ImportFormatCSV,
+				Mapping: importJobModel.Mapping{
+					ValueColumn:      "1",
+					DefaultProductID: "prod-1",
+					Delimiter:        ";",
+				},
+			},
+			data: func(t *testing.T) []byte {
+				return []byte("key-1;comment\nkey-2\n")
+			},
+			expectedValues: []string{"key-1", "key-2"},
+			expectedRows:   []int{1, 2},
+		},
+		{
+			name: "xlsx, columns by letter",
+			req: &importJobModel.ImportReq{
+				FileName: "keys.xlsx",
+				Mapping: importJobModel.Mapping{
+					ProductIDColumn:  "A",
+					ValueColumn:      "B",
+					HasHeader:        true,
+					DefaultProductID: "prod-2",
+				},
+			},
+			data:           newXLSX,
+			expectedValues: []string{"key-1", "key-2"},
+			expectedRows:   []int{2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ut := newTest()
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+			var loaded []*model.Edit
+			ut.service.On("GetByValue", mock.Anything, mock.Anything).Return(nil, nil)
+			ut.service.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				loaded = append(loaded, args.Get(1).(*model.Edit))
+			}).Return("key-id", nil)
+
+			ut.importJobService.On("Create", mock.Anything, mock.MatchedBy(func(obj *importJobModel.Edit) bool {
+				return *obj.Status == constant.ImportJobStatusRunning && *obj.FileName == tt.req.FileName
+			})).Return("job-1", nil).Once()
+
+			var report *importJobModel.Edit
+			ut.importJobService.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				report = args.Get(1).(*importJobModel.Edit)
+			}).Return(nil).Once()
+			ut.importJobService.On("Get", mock.Anything, "job-1", true).Return(&importJobModel.Main{ID: "job-1"}, true, nil).Once()
+
+			job, err := ut.usecase.Import(context.Background(), tt.req, bytes.NewReader(tt.data(t)))
+			assert.NoError(t, err)
+			assert.Equal(t, "job-1", job.ID)
+
+			assert.Equal(t, tt.expectedValues, lo.Map(loaded, func(item *model.Edit, _ int) string {
+				return *item.Value
+			}))
+			assert.Equal(t, constant.ImportJobStatusCompleted, *report.Status)
+			assert.Equal(t, int64(len(tt.expectedValues)), *report.TotalCount)
+			assert.Equal(t, int64(len(tt.expectedValues)), *report.CreatedCount)
+			assert.Equal(t, tt.expectedRows, lo.Map(report.Items, func(item *importJobModel.Item, _ int) int {
+				return item.Row
+			}))
+
+			ut.importJobService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUsecase_Import_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         *importJobModel.ImportReq
+		data        string
+		expectedErr error
+		jobFailed   bool
+	}{
+		{
+			name:        "unknown format",
+			req:         &importJobModel.ImportReq{FileName: "keys.txt", Mapping: importJobModel.Mapping{ValueColumn: "1", DefaultProductID: "prod-1"}},
+			data:        "key-1",
+			expectedErr: errs.InvalidImportFormat,
+		},
+		{
+			name:        "no product column",
+			req:         &importJobModel.ImportReq{FileName: "keys.csv", Mapping: importJobModel.Mapping{ValueColumn: "1"}},
+			data:        "key-1",
+			expectedErr: errs.ImportColumnRequired,
+		},
+		// ошибки в самом файле видны только при чтении: задание уже создано и завершается failed
+		{
+			name:        "empty file",
+			req:         &importJobModel.ImportReq{FileName: "keys.csv", Mapping: importJobModel.Mapping{ValueColumn: "1", DefaultProductID: "prod-1"}},
+			expectedErr: errs.EmptyData,
+			jobFailed:   true,
+		},
+		{
+			name:        "no keys",
+			req:         &importJobModel.ImportReq{FileName: "keys.csv", Mapping: importJobModel.Mapping{ValueColumn: "1", HasHeader: true, DefaultProductID: "prod-1"}},
+			data:        "key\n\n",
+			expectedErr: errs.EmptyData,
+			jobFailed:   true,
+		},
+		{
+			name:        "column not in header",
+			req:         &importJobModel.ImportReq{FileName: "keys.csv", Mapping: importJobModel.Mapping{ValueColumn: "serial", HasHeader: true, DefaultProductID: "prod-1"}},
+			data:        "key\nkey-1",
+			expectedErr: errs.ImportColumnNotFound,
+			jobFailed:   true,
+		},
+		{
+			name:        "broken xlsx",
+			req:         &importJobModel.ImportReq{FileName: "keys.xlsx", Mapping: importJobModel.Mapping{ValueColumn: "A", DefaultProductID: "prod-1"}},
+			data:        "not a spreadsheet",
+			expectedErr: errs.InvalidFile,
+			jobFailed:   true,
+		},
+		{
+			name:        "file too large",
+			req:         &importJobModel.ImportReq{FileName: "keys.csv", Mapping: importJobModel.Mapping{ValueColumn: "1", DefaultProductID: "prod-1"}},
+			data:        strings.Repeat("k", constant.MaxImportFileSize+1),
+			expectedErr: errs.FileTooLarge,
+			jobFailed:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ut := newTest()
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+			if tt.jobFailed {
+				ut.importJobService.On("Create", mock.Anything, mock.Anything).Return("job-1", nil).Once()
+				ut.importJobService.On("Update", mock.Anything, mock.MatchedBy(func(obj *importJobModel.Edit) bool {
+					return *obj.ID == "job-1" && *obj.Status == constant.ImportJobStatusFailed && *obj.Error != ""
+				})).Return(nil).Once()
+			}
+
+			_, err := ut.usecase.Import(context.Background(), tt.req, strings.NewReader(tt.data))
+			assert.ErrorContains(t, err, tt.expectedErr.Error())
+
+			if tt.jobFailed {
+				ut.importJobService.AssertExpectations(t)
+			} else {
+				ut.importJobService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			}
+			ut.service.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUsecase_Reencrypt(t *testing.T) {
+	ut := newTest()
+	ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+	// полная пачка - есть еще строки, неполная - все обработаны
+	ut.service.On("Reencrypt", mock.Anything, uint64(reencryptBatchSize)).Return(reencryptBatchSize, nil).Once()
+	ut.service.On("Reencrypt", mock.Anything, uint64(reencryptBatchSize)).Return(3, nil).Once()
+	ut.operationService.On("Reencrypt", mock.Anything, uint64(reencryptBatchSize)).Return(0, nil).Once()
+
+	err := ut.usecase.Reencrypt(context.Background())
+	assert.NoError(t, err)
+
+	ut.service.AssertExpectations(t)
+	ut.operationService.AssertExpectations(t)
+}
+
+func TestUsecase_Reencrypt_Error(t *testing.T) {
+	ut := newTest()
+	ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+	ut.service.On("Reencrypt", mock.Anything, mock.Anything).Return(0, errors.New("master key k1 not found")).Once()
+
+	err := ut.usecase.Reencrypt(context.Background())
+	assert.ErrorContains(t, err, "master key k1 not found")
+
+	ut.operationService.AssertNotCalled(t, "Reencrypt", mock.Anything, mock.Anything)
+}
+
+func TestUsecase_Export(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	items := []*model.Main{
+		{ID: "key-1", CreatedAt: createdAt, UpdatedAt: createdAt, ProductID: "prod-1", Status: constant.KeyStatusActivated, OrderID: "ord-1", Value: "ABCD-EFGH-1234"},
+		{ID: "key-2", CreatedAt: createdAt, UpdatedAt: createdAt, ProductID: "prod-1", Status: constant.KeyStatusActivated, OrderID: "ord-2", Value: "a,b"},
+	}
+
+	tests := []struct {
+		name          string
+		role          string
+		req           *model.ExportReq
+		expected      string
+		expectedAudit bool
+		expectedErr   error
+	}{
+		{
+			name: "csv masked by default",
+			role: constant.RoleLoader,
+			req: &model.ExportReq{
+				ListReq: model.ListReq{Status: lo.ToPtr(constant.KeyStatusActivated)},
+				Format:  constant.ExportFormatCSV,
+			},
+			expected: strings.Join(exportColumns, ",") + "\n" +
+				"key-1,2026-01-02T03:04:05Z,2026-01-02T03:04:05Z,,prod-1,activated,ord-1,,,,,****-****-1234\n" +
+				"key-2,2026-01-02T03:04:05Z,2026-01-02T03:04:05Z,,prod-1,activated,ord-2,,,,,***\n",
+		},
+		{
+			name: "jsonl with revealed values - audited",
+			role: constant.RoleSupport,
+			req: &model.ExportReq{
+				ListReq:     model.ListReq{Status: lo.ToPtr(constant.KeyStatusActivated)},
+				Format:      constant.ExportFormatJSONL,
+				RevealValue: true,
+			},
+			expected: `{"id":"key-1","created_at":"2026-01-02T03:04:05Z","updated_at":"2026-01-02T03:04:05Z","provider_id":"","product_id":"prod-1","status":"activated","order_id":"ord-1","customer_phone":"","provider_product_id":"","provider_order_id":"","provider_transaction_id":"","value":"ABCD-EFGH-1234"}` + "\n" +
+				`{"id":"key-2","created_at":"2026-01-02T03:04:05Z","updated_at":"2026-01-02T03:04:05Z","provider_id":"","product_id":"prod-1","status":"activated","order_id":"ord-2","customer_phone":"","provider_product_id":"","provider_order_id":"","provider_transaction_id":"","value":"a,b"}` + "\n",
+			expectedAudit: true,
+		},
+		{
+			name: "revealed values denied for loader",
+			role: constant.RoleLoader,
+			req: &model.ExportReq{
+				Format:      constant.ExportFormatCSV,
+				RevealValue: true,
+			},
+			expectedErr: errs.PermissionDenied,
+		},
+		{
+			name:        "invalid format",
+			role:        constant.RoleAdmin,
+			req:         &model.ExportReq{Format: "xml"},
+			expectedErr: errs.InvalidExportFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ut := newTest()
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+			ut.service.On("Export", mock.Anything, &tt.req.ListReq, mock.Anything).
+				Run(func(args mock.Arguments) {
+					fn := args.Get(2).(func(item *model.Main) error)
+					for _, item := range items {
+						assert.NoError(t, fn(item))
+					}
+				}).
+				Return(nil).Maybe()
+			ut.service.On("AuditExport", mock.Anything, &tt.req.ListReq, int64(len(items))).Return(nil).Maybe()
+
+			ctx := util.CtxWithRole(context.Background(), tt.role)
+			buf := &strings.Builder{}
+			err := ut.usecase.Export(ctx, tt.req, buf)
+
+			if tt.expectedErr != nil {
+				var errFull errs.ErrFull
+				assert.True(t, errors.As(err, &errFull))
+				assert.Equal(t, tt.expectedErr, errFull.Err)
+				ut.service.AssertNotCalled(t, "Export", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, buf.String())
+
+			if tt.expectedAudit {
+				ut.service.AssertCalled(t, "AuditExport", mock.Anything, &tt.req.ListReq, int64(len(items)))
+			} else {
+				ut.service.AssertNotCalled(t, "AuditExport", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+// newBreakerTest оборачивает провайдера circuit breaker, открытым после первого сбоя
+func newBreakerTest(t *testing.T) (*usecaseTest, *resilient.Service) {
+	ut := newTest()
+
+	provider := resilient.New("provider-1", ut.providerService, resilient.Config{
+		BreakerFailures:    1,
+		BreakerOpenTimeout: time.Minute,
+	})
+	ut.providers["provider-1"] = provider
+	ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+	ut.providerService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil, providerModel.ErrUnavailable).Once()
+	_, err := provider.CreateOrder(context.Background(), &providerModel.OrderRequest{})
+	require.Error(t, err)
+	require.False(t, provider.Available())
+
+	return ut, provider
+}
+
+func TestUsecase_Activate_BreakerOpen(t *testing.T) {
+	ut, _ := newBreakerTest(t)
+
+	ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").Return(nil, false, nil).Twice()
+	ut.service.On("LockOrder", mock.Anything, "ord-1", "prod-1").Return("lock-1", true, nil).Once()
+	ut.service.On("GetActiveReservation", mock.Anything, "ord-1", "prod-1").Return(nil, false, nil).Once()
+	ut.mdmService.On("FindProduct", mock.Anything, lo.ToPtr("prod-1")).
+		Return(&mdmModel.Product{ProductID: "prod-1", ProviderID: "provider-1"}, true, nil).Once()
+	ut.routeService.On("ListByProduct", mock.Anything, "prod-1").Return(nil, nil).Once()
+	ut.providerService.On("SupportsPool").Return(true).Once()
+	// провайдер не вызывается, попытка фиксируется как пропущенная
+	ut.operationService.On("Create", mock.Anything, operationStatusIs(constant.OperationStatusSkipped)).Return("op-1", nil).Once()
+	ut.service.On("ClaimNew", mock.Anything, "prod-1", "ord-1", "77001112233").
+		Return(&model.Main{ID: "key-1", Value: "pool-secret", Status: constant.KeyStatusActivated}, true, nil).Once()
+	ut.service.On("UnlockOrder", mock.Anything, "ord-1", "prod-1", "lock-1").Return(nil).Once()
+
+	result, err := ut.usecase.Activate(context.Background(), "prod-1", "ord-1", "77001112233")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "pool-secret", result.Value)
+
+	ut.operationService.AssertExpectations(t)
+	ut.providerService.AssertExpectations(t)
+	ut.service.AssertExpectations(t)
+}
+
+func TestUsecase_Activate_ExpiredReservation(t *testing.T) {
+	ut, _ := newBreakerTest(t)
+
+	// резерв просрочен: его ключ не выдается и резерв не подтверждается, выдача идет обычным путем
+	reservation := &model.Reservation{ID: "res-1", OrderID: "ord-1", ProductID: "prod-1", KeyID: "key-1", ExpiresAt: time.Now().Add(-time.Minute)}
+
+	ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").Return(nil, false, nil).Twice()
+	ut.service.On("LockOrder", mock.Anything, "ord-1", "prod-1").Return("lock-1", true, nil).Once()
+	ut.service.On("GetActiveReservation", mock.Anything, "ord-1", "prod-1").Return(reservation, true, nil).Once()
+	ut.mdmService.On("FindProduct", mock.Anything, lo.ToPtr("prod-1")).
+		Return(&mdmModel.Product{ProductID: "prod-1", ProviderID: "provider-1"}, true, nil).Once()
+	ut.routeService.On("ListByProduct", mock.Anything, "prod-1").Return(nil, nil).Once()
+	ut.providerService.On("SupportsPool").Return(true).Once()
+	ut.operationService.On("Create", mock.Anything, operationStatusIs(constant.OperationStatusSkipped)).Return("op-1", nil).Once()
+	ut.service.On("ClaimNew", mock.Anything, "prod-1", "ord-1", "77001112233").
+		Return(&model.Main{ID: "key-2", Value: "pool-secret", Status: constant.KeyStatusActivated}, true, nil).Once()
+	ut.service.On("UnlockOrder", mock.Anything, "ord-1", "prod-1", "lock-1").Return(nil).Once()
+
+	result, err := ut.usecase.Activate(context.Background(), "prod-1", "ord-1", "77001112233")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "key-2", result.ID)
+
+	ut.service.AssertExpectations(t)
+	ut.service.AssertNotCalled(t, "ConfirmReservation", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUsecase_ProviderBreakers(t *testing.T) {
+	ut, _ := newBreakerTest(t)
+
+	items := ut.usecase.ListProviderBreakers(context.Background())
+	if assert.Len(t, items, 1) {
+		assert.Equal(t, "provider-1", items[0].ProviderID)
+		assert.Equal(t, constant.BreakerStateOpen, items[0].State)
+		assert.Equal(t, 1, items[0].Failures)
+	}
+
+	_, err := ut.usecase.ResetProviderBreaker(context.Background(), "unknown")
+	assert.ErrorContains(t, err, errs.ObjectNotFound.Error())
+
+	state, err := ut.usecase.ResetProviderBreaker(context.Background(), " provider-1 ")
+	assert.NoError(t, err)
+	assert.Equal(t, constant.BreakerStateClosed, state.State)
+}
+
+func TestUsecase_Activate_Routes(t *testing.T) {
+	routes := []*productProviderModel.Main{
+		{ProductID: "prod-1", ProviderID: "provider-1", Priority: 0, ProviderProductID: "p1-sku"},
+		{ProductID: "prod-1", ProviderID: "provider-2", Priority: 1, ProviderProductID: "p2-sku"},
+	}
+
+	tests := []struct {
+		name          string
+		setupMock     func(ut *usecaseTest, provider2 *mocks.ProviderServiceI)
+		expectedValue string
+		expectedErr   error
+	}{
+		{
+			name: "first provider fails - key bought from second",
+			setupMock: func(ut *usecaseTest, provider2 *mocks.ProviderServiceI) {
+				ut.providerService.On("SupportsPool").Return(false).Once()
+				ut.operationService.On("Create", mock.Anything, mock.MatchedBy(func(obj *operationModel.Edit) bool {
+					return *obj.ProviderID == "provider-1" && *obj.Status == constant.OperationStatusRequested
+				})).Return("op-1", nil).Once()
+				ut.providerService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil, errors.New("provider down")).Once()
+				ut.operationService.On("Update", mock.Anything, operationStatusIs(constant.OperationStatusFailed)).Return(nil).Once()
+
+				provider2.On("SupportsPool").Return(false).Once()
+				ut.operationService.On("Create", mock.Anything, mock.MatchedBy(func(obj *operationModel.Edit) bool {
+					return *obj.ProviderID == "provider-2" && *obj.Status == constant.OperationStatusRequested
+				})).Return("op-2", nil).Once()
+				provider2.On("CreateOrder", mock.Anything, mock.MatchedBy(func(req *providerModel.OrderRequest) bool {
+					return req.ProviderID == "provider-2" && req.ProviderProductID == "p2-sku"
+				})).Return(&providerModel.OrderResponse{Value: "secret-2"}, nil).Once()
+				ut.operationService.On("Update", mock.Anything, operationStatusIs(constant.OperationStatusPurchased)).Return(nil).Once()
+				ut.service.On("Create", mock.Anything, mock.MatchedBy(func(obj *model.Edit) bool {
+					return *obj.ProviderID == "provider-2" && *obj.ProviderProductID == "p2-sku"
+				})).Return("key-2", nil).Once()
+				ut.operationService.On("Update", mock.Anything, operationStatusIs(constant.OperationStatusStored)).Return(nil).Once()
+				ut.service.On("Get", mock.Anything, "key-2", true).
+					Return(&model.Main{ID: "key-2", Value: "secret-2", Status: constant.KeyStatusNew}, true, nil).Once()
+				ut.service.On("Transition", mock.Anything, mock.Anything, mock.Anything, "").Return(nil).Once()
+			},
+			expectedValue: "secret-2",
+		},
+		{
+			name: "key bought but not stored - second provider not called",
+			setupMock: func(ut *usecaseTest, provider2 *mocks.ProviderServiceI) {
+				ut.providerService.On("SupportsPool").Return(false).Once()
+				ut.operationService.On("Create", mock.Anything, mock.Anything).Return("op-1", nil).Once()
+				ut.providerService.On("CreateOrder", mock.Anything, mock.Anything).
+					Return(&providerModel.OrderResponse{Value: "secret-1"}, nil).Once()
+				ut.operationService.On("Update", mock.Anything, operationStatusIs(constant.OperationStatusPurchased)).Return(nil).Once()
+				ut.service.On("Create", mock.Anything, mock.Anything).Return("", errors.New("db down")).Once()
+			},
+			expectedErr: errKeyNotStored,
+		},
+		{
+			name: "all providers fail without pool",
+			setupMock: func(ut *usecaseTest, provider2 *mocks.ProviderServiceI) {
+				ut.providerService.On("SupportsPool").Return(false).Once()
+				ut.operationService.On("Create", mock.Anything, mock.Anything).Return("op-1", nil).Once()
+				ut.providerService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil, errors.New("provider down")).Once()
+				ut.operationService.On("Update", mock.Anything, operationStatusIs(constant.OperationStatusFailed)).Return(nil).Once()
+
+				provider2.On("SupportsPool").Return(false).Once()
+				ut.operationService.On("Create", mock.Anything, mock.Anything).Return("op-2", nil).Once()
+				provider2.On("CreateOrder", mock.Anything, mock.Anything).Return(nil, errors.New("provider down")).Once()
+				ut.operationService.On("Update", mock.Anything, operationStatusIs(constant.OperationStatusFailed)).Return(nil).Once()
+			},
+			expectedErr: errs.ServiceNA,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ut := newTest()
+			provider2 := new(mocks.ProviderServiceI)
+			ut.providers["provider-2"] = provider2
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+			ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").Return(nil, false, nil).Twice()
+			ut.service.On("LockOrder", mock.Anything, "ord-1", "prod-1").Return("lock-1", true, nil).Once()
+			ut.service.On("GetActiveReservation", mock.Anything, "ord-1", "prod-1").Return(nil, false, nil).Once()
+			ut.mdmService.On("FindProduct", mock.Anything, lo.ToPtr("prod-1")).
+				Return(&mdmModel.Product{ProductID: "prod-1", ProviderID: "provider-1", ProviderProductID: "mdm-sku"}, true, nil).Once()
+			ut.routeService.On("ListByProduct", mock.Anything, "prod-1").Return(routes, nil).Once()
+			ut.service.On("UnlockOrder", mock.Anything, "ord-1", "prod-1", "lock-1").Return(nil).Once()
+			tt.setupMock(ut, provider2)
+
+			result, err := ut.usecase.Activate(context.Background(), "prod-1", "ord-1", "77001112233")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedValue, result.Value)
+			}
+
+			ut.service.AssertExpectations(t)
+			ut.operationService.AssertExpectations(t)
+			ut.providerService.AssertExpectations(t)
+			provider2.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUsecase_SetProductProviders(t *testing.T) {
+	tests := []struct {
+		name        string
+		items       []*productProviderModel.Edit
+		expectedErr error
+	}{
+		{
+			name: "success",
+			items: []*productProviderModel.Edit{
+				{ProviderID: " provider-1 ", ProviderProductID: "p1-sku"},
+			},
+		},
+		{
+			name: "unknown provider",
+			items: []*productProviderModel.Edit{
+				{ProviderID: "provider-x", ProviderProductID: "px-sku"},
+			},
+			expectedErr: errs.ObjectNotFound,
+		},
+		{
+			name: "duplicate provider",
+			items: []*productProviderModel.Edit{
+				{ProviderID: "provider-1", ProviderProductID: "p1-sku"},
+				{ProviderID: "provider-1", ProviderProductID: "p1-sku-2"},
+			},
+			expectedErr: errs.AlreadyExists,
+		},
+		{
+			name: "provider product required",
+			items: []*productProviderModel.Edit{
+				{ProviderID: "provider-1"},
+			},
+			expectedErr: errs.ProviderProductIDRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ut := newTest()
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+			if tt.expectedErr == nil {
+				ut.routeService.On("Set", mock.Anything, "prod-1", mock.MatchedBy(func(items []*productProviderModel.Edit) bool {
+					return len(items) == 1 && items[0].ProviderID == "provider-1"
+				})).Return([]*productProviderModel.Main{{ProductID: "prod-1", ProviderID: "provider-1"}}, nil).Once()
+			}
+
+			result, err := ut.usecase.SetProductProviders(context.Background(), "prod-1", tt.items)
+
+			if tt.expectedErr != nil {
+				assert.ErrorContains(t, err, tt.expectedErr.Error())
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result, 1)
+			}
+
+			ut.routeService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUsecase_ActivateAsync(t *testing.T) {
+	ut := newTest()
+	ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+	activation := &model.Activation{ID: "act-1", ProductID: "prod-1", OrderID: "ord-1", Status: constant.ActivationStatusPending}
+
+	ut.service.On("CreateActivation", mock.Anything, mock.MatchedBy(func(obj *model.ActivationEdit) bool {
+		return *obj.ProductID == "prod-1" && *obj.OrderID == "ord-1" && *obj.CustomerPhone == "77001112233"
+	})).Return(activation, true, nil).Once()
+	ut.service.On("CreateActivation", mock.Anything, mock.Anything).Return(activation, false, nil).Once()
+
+	// выдача сохраняется с нормализованными параметрами
+	result, err := ut.usecase.ActivateAsync(context.Background(), " prod-1 ", " ord-1 ", " +87001112233 ")
+	require.NoError(t, err)
+	assert.Equal(t, activation, result)
+
+	// повторный вызов по заказу не будит воркеров еще раз
+	result, err = ut.usecase.ActivateAsync(context.Background(), "prod-1", "ord-1", "77001112233")
+	require.NoError(t, err)
+	assert.Equal(t, activation, result)
+
+	select {
+	case <-ut.usecase.ActivationWake():
+	default:
+		t.Fatal("workers are not woken up")
+	}
+	select {
+	case <-ut.usecase.ActivationWake():
+		t.Fatal("workers are woken up twice")
+	default:
+	}
+
+	_, err = ut.usecase.ActivateAsync(context.Background(), "prod-1", "", "77001112233")
+	assert.ErrorIs(t, err, errs.OrderIDRequired)
+
+	ut.service.AssertExpectations(t)
+}
+
+func TestUsecase_ProcessActivations(t *testing.T) {
+	tests := []struct {
+		name             string
+		issued           *model.Main
+		expectedKeyID    string
+		expectedErrMsg   string
+		expectedCallback *callbackModel.ActivationCallback
+	}{
+		{
+			name:          "completed",
+			issued:        &model.Main{ID: "key-1", Value: "secret", Link: "https://dl", Status: constant.KeyStatusActivated},
+			expectedKeyID: "key-1",
+			expectedCallback: &callbackModel.ActivationCallback{
+				ActivationID: "act-1",
+				Status:       constant.ActivationStatusCompleted,
+				OrderID:      "ord-1",
+				ProductID:    "prod-1",
+				Value:        "secret",
+				Link:         "https://dl",
+			},
+		},
+		{
+			name:           "failed",
+			issued:         &model.Main{ID: "key-1", Status: constant.KeyStatusCancelled},
+			expectedErrMsg: errs.Message(errs.AlreadyCancelled, errs.DefaultLang),
+			expectedCallback: &callbackModel.ActivationCallback{
+				ActivationID: "act-1",
+				Status:       constant.ActivationStatusFailed,
+				OrderID:      "ord-1",
+				ProductID:    "prod-1",
+				Error:        errs.Message(errs.AlreadyCancelled, errs.DefaultLang),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ut := newTest()
+			ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+			activation := &model.Activation{ID: "act-1", ProductID: "prod-1", OrderID: "ord-1", CustomerPhone: "77001112233", Status: constant.ActivationStatusProcessing}
+
+			ut.service.On("ClaimActivation", mock.Anything, time.Minute).Return(activation, nil).Once()
+			ut.service.On("ClaimActivation", mock.Anything, time.Minute).Return(nil, nil).Once()
+			ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").Return(tt.issued, true, nil).Once()
+			ut.service.On("FinishActivation", mock.Anything, activation, tt.expectedKeyID, tt.expectedErrMsg).
+				Run(func(args mock.Arguments) {
+					activation.Status = constant.ActivationStatusFailed
+					if tt.expectedKeyID != "" {
+						activation.Status = constant.ActivationStatusCompleted
+					}
+					activation.KeyID = tt.expectedKeyID
+					activation.Error = tt.expectedErrMsg
+				}).Return(nil).Once()
+			ut.callbackService.On("ActivationCallback", mock.Anything, tt.expectedCallback).Return(errors.New("callback down")).Once()
+
+			err := ut.usecase.ProcessActivations(context.Background(), time.Minute)
+			require.NoError(t, err)
+
+			ut.service.AssertExpectations(t)
+			ut.callbackService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUsecase_ProcessActivations_Retry(t *testing.T) {
+	inProgressMsg := errs.Message(errs.ActivationInProgress, errs.DefaultLang)
+
+	t.Run("transient error is requeued with backoff", func(t *testing.T) {
+		ut := newTest()
+		ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+		activation := &model.Activation{ID: "act-1", ProductID: "prod-1", OrderID: "ord-1", CustomerPhone: "77001112233", Status: constant.ActivationStatusProcessing, Attempts: 2}
+
+		ut.service.On("ClaimActivation", mock.Anything, time.Minute).Return(activation, nil).Once()
+		ut.service.On("ClaimActivation", mock.Anything, time.Minute).Return(nil, nil).Once()
+		ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").Return(nil, false, nil).Once()
+		ut.service.On("LockOrder", mock.Anything, "ord-1", "prod-1").Return("", false, nil).Once()
+		ut.service.On("RetryActivation", mock.Anything, activation, inProgressMsg, time.Minute).Return(nil).Once()
+
+		err := ut.usecase.ProcessActivations(context.Background(), time.Minute)
+		require.NoError(t, err)
+
+		ut.service.AssertExpectations(t)
+		ut.service.AssertNotCalled(t, "FinishActivation", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		ut.callbackService.AssertNotCalled(t, "ActivationCallback", mock.Anything, mock.Anything)
+	})
+
+	t.Run("attempts exhausted - failed", func(t *testing.T) {
+		ut := newTest()
+		ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+		activation := &model.Activation{ID: "act-1", ProductID: "prod-1", OrderID: "ord-1", CustomerPhone: "77001112233", Status: constant.ActivationStatusProcessing, Attempts: activationMaxAttempts}
+
+		ut.service.On("ClaimActivation", mock.Anything, time.Minute).Return(activation, nil).Once()
+		ut.service.On("ClaimActivation", mock.Anything, time.Minute).Return(nil, nil).Once()
+		ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").Return(nil, false, nil).Once()
+		ut.service.On("LockOrder", mock.Anything, "ord-1", "prod-1").Return("", false, nil).Once()
+		ut.service.On("FinishActivation", mock.Anything, activation, "", inProgressMsg).
+			Run(func(args mock.Arguments) {
+				activation.Status = constant.ActivationStatusFailed
+				activation.Error = inProgressMsg
+			}).Return(nil).Once()
+		ut.callbackService.On("ActivationCallback", mock.Anything, mock.MatchedBy(func(obj *callbackModel.ActivationCallback) bool {
+			return obj.Status == constant.ActivationStatusFailed && obj.Error == inProgressMsg
+		})).Return(nil).Once()
+
+		err := ut.usecase.ProcessActivations(context.Background(), time.Minute)
+		require.NoError(t, err)
+
+		ut.service.AssertExpectations(t)
+		ut.callbackService.AssertExpectations(t)
+	})
+}
+
+func TestActivationRetryable(t *testing.T) {
+	assert.True(t, activationRetryable(fmt.Errorf("activate: %w", errs.ErrFull{Err: errs.ActivationInProgress})))
+	assert.True(t, activationRetryable(fmt.Errorf("orderByRoutes: %w", errs.ServiceNA)))
+	assert.True(t, activationRetryable(fmt.Errorf("CreateOrder: %w", providerModel.ErrUnavailable)))
+	assert.False(t, activationRetryable(errs.AlreadyCancelled))
+	assert.False(t, activationRetryable(errs.ErrFull{Err: errs.ProviderRejected}))
+
+	assert.Equal(t, activationRetryBaseDelay, activationRetryDelay(1))
+	assert.Equal(t, 4*activationRetryBaseDelay, activationRetryDelay(3))
+	assert.Equal(t, activationRetryMaxDelay, activationRetryDelay(20))
+}
+
+func TestUsecase_GetActivation(t *testing.T) {
+	ut := newTest()
+	ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+	ut.service.On("GetActivation", mock.Anything, "act-1", true).
+		Return(&model.Activation{ID: "act-1", Status: constant.ActivationStatusCompleted, KeyID: "key-1"}, true, nil).Once()
+	ut.service.On("Get", mock.Anything, "key-1", true).Return(&model.Main{ID: "key-1", Value: "secret"}, true, nil).Once()
+	ut.service.On("GetActivation", mock.Anything, "act-2", true).
+		Return(&model.Activation{ID: "act-2", Status: constant.ActivationStatusPending}, true, nil).Once()
+
+	activation, key, err := ut.usecase.GetActivation(context.Background(), "act-1")
+	require.NoError(t, err)
+	assert.Equal(t, "act-1", activation.ID)
+	assert.Equal(t, "secret", key.Value)
+
+	activation, key, err = ut.usecase.GetActivation(context.Background(), "act-2")
+	require.NoError(t, err)
+	assert.Equal(t, constant.ActivationStatusPending, activation.Status)
+	assert.Nil(t, key)
+
+	_, _, err = ut.usecase.GetActivation(context.Background(), " ")
+	assert.ErrorIs(t, err, errs.IDRequired)
+
+	ut.service.AssertExpectations(t)
+}
+
+func TestUsecase_Webhooks(t *testing.T) {
+	ut := newTest()
+	ut.webhookService = new(mocks.WebhookServiceI)
+	ut.usecase = New(ut.service, ut.operationService, ut.importJobService, ut.poolLevelService, ut.routeService, ut.mdmService, ut.alertService, ut.callbackService, ut.webhookService, ut.providers, constant.KeyReturnPolicyQuarantine)
+
+	// отмена публикует cancelled с причиной
+	main := &model.Main{
+		ID:         "key-1",
+		ProviderID: "provider-1",
+		ProductID:  "prod-1",
+		OrderID:    "ord-1",
+		Status:     constant.KeyStatusActivated,
+	}
+	ut.service.On("GetByOrderAndProductID", mock.Anything, "ord-1", "prod-1").Return(main, true, nil).Once()
+	ut.providerService.On("CancelOrder", mock.Anything, mock.Anything).Return(&providerModel.CancelResponse{Success: true}, nil).Once()
+	ut.service.On("Transition", mock.Anything, main, mock.Anything, "customer request").Return(nil).Once()
+	ut.webhookService.On("Publish", mock.Anything, constant.WebhookEventCancelled, &webhookModel.KeyEvent{
+		KeyID:      "key-1",
+		ProductID:  "prod-1",
+		OrderID:    "ord-1",
+		ProviderID: "provider-1",
+		Reason:     "customer request",
+	}).Return(int64(1), nil).Once()
+
+	_, err := ut.usecase.Cancel(context.Background(), "ord-1", "prod-1", "customer request")
+	require.NoError(t, err)
+
+	// сбой покупки публикует provider_failed, ошибка публикации не прерывает операцию
+	ut.operationService.On("Create", mock.Anything, mock.Anything).Return("op-1", nil).Once()
+	ut.providerService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil, errors.New("provider down")).Once()
+	ut.operationService.On("Update", mock.Anything, operationStatusIs(constant.OperationStatusFailed)).Return(nil).Once()
+	ut.webhookService.On("Publish", mock.Anything, constant.WebhookEventProviderFailed, &webhookModel.ProviderFailedEvent{
+		ProviderID:  "provider-1",
+		ProductID:   "prod-1",
+		OrderID:     "ord-2",
+		OperationID: "op-1",
+		Error:       "provider down",
+	}).Return(int64(0), errors.New("db down")).Once()
+
+	_, err = ut.usecase.createOrder(context.Background(), ut.providerService, &mdmModel.Product{
+		ProviderID: "provider-1",
+		ProductID:  "prod-1",
+	}, "ord-2", "77001112233", constant.KeySourceProvider)
+	assert.ErrorContains(t, err, "provider down")
+
+	ut.webhookService.AssertExpectations(t)
+}
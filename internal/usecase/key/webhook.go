@@ -0,0 +1,27 @@
+package key
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mechta-market/e-product/internal/domain/key/model"
+	webhookModel "github.com/mechta-market/e-product/internal/domain/webhook/model"
+)
+
+// publish ставит событие в очередь доставки подписчикам. Ошибка не прерывает операцию с ключом
+func (u *Usecase) publish(ctx context.Context, event string, data any) {
+	_, err := u.webhookService.Publish(ctx, event, data)
+	if err != nil {
+		slog.Error("webhookService.Publish", "error", err, "event", event)
+	}
+}
+
+func (u *Usecase) publishKeyEvent(ctx context.Context, event string, key *model.Main, reason string) {
+	u.publish(ctx, event, &webhookModel.KeyEvent{
+		KeyID:      key.ID,
+		ProductID:  key.ProductID,
+		OrderID:    key.OrderID,
+		ProviderID: key.ProviderID,
+		Reason:     reason,
+	})
+}
@@ -0,0 +1,108 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/mechta-market/e-product/internal/domain/webhook/model"
+	senderModel "github.com/mechta-market/e-product/internal/service/webhook/model"
+)
+
+// deliverBatchSize сколько доставок забирается за раз
+const deliverBatchSize = 100
+
+type Config struct {
+	MaxAttempts    int64 // после MaxAttempts неудачных попыток доставка переходит в dead
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	Lease          time.Duration // на сколько откладывается повтор забранной доставки, больше таймаута отправки
+}
+
+// Deliver отправляет подписчикам доставки, время попытки которых наступило. Неудачная попытка
+// повторяется с экспоненциальной задержкой, после MaxAttempts доставка переходит в dead
+func (u *Usecase) Deliver(ctx context.Context) error {
+	subscriptions := make(map[string]*model.Subscription)
+
+	for ctx.Err() == nil {
+		items, err := u.service.ClaimDeliveries(ctx, deliverBatchSize, u.conf.Lease)
+		if err != nil {
+			return fmt.Errorf("service.ClaimDeliveries: %w", err)
+		}
+
+		for _, item := range items {
+			subscription, ok := subscriptions[item.SubscriptionID]
+			if !ok {
+				subscription, _, err = u.service.GetSubscription(ctx, item.SubscriptionID, false)
+				if err != nil {
+					return fmt.Errorf("service.GetSubscription: %w", err)
+				}
+				subscriptions[item.SubscriptionID] = subscription
+			}
+
+			err = u.deliver(ctx, item, subscription)
+			if err != nil {
+				slog.Error("webhook delivery update failed", "error", err, "delivery_id", item.ID)
+			}
+		}
+
+		if uint64(len(items)) < deliverBatchSize {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (u *Usecase) deliver(ctx context.Context, item *model.Delivery, subscription *model.Subscription) error {
+	// подписка отключена или удалена: доставку можно вернуть через Replay после включения
+	if subscription == nil || !subscription.Active {
+		return u.service.Failed(ctx, item.ID, 0, "subscription is not active", nil)
+	}
+
+	rep, err := u.sender.Send(ctx, &senderModel.Request{
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		DeliveryID: item.ID,
+		Event:      item.Event,
+		Payload:    []byte(item.Payload),
+	})
+
+	var responseStatus int64
+	if rep != nil {
+		responseStatus = rep.StatusCode
+	}
+
+	if err == nil {
+		return u.service.Delivered(ctx, item.ID, responseStatus)
+	}
+
+	var nextAttemptAt *time.Time
+	if item.Attempts < u.conf.MaxAttempts {
+		nextAttemptAt = lo.ToPtr(time.Now().Add(u.backoff(item.Attempts)))
+	}
+
+	slog.Warn("webhook delivery failed",
+		"error", err,
+		"delivery_id", item.ID,
+		"subscription_id", item.SubscriptionID,
+		"event", item.Event,
+		"attempts", item.Attempts,
+		"dead", nextAttemptAt == nil,
+	)
+
+	return u.service.Failed(ctx, item.ID, responseStatus, err.Error(), nextAttemptAt)
+}
+
+// backoff задержка перед следующей попыткой: base*2^(attempts-1), не больше RetryMaxDelay
+func (u *Usecase) backoff(attempts int64) time.Duration {
+	delay := u.conf.RetryBaseDelay << max(attempts-1, 0)
+	if delay <= 0 || delay > u.conf.RetryMaxDelay {
+		return u.conf.RetryMaxDelay
+	}
+
+	return delay
+}
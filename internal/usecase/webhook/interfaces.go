@@ -0,0 +1,27 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/mechta-market/e-product/internal/domain/webhook/model"
+	senderModel "github.com/mechta-market/e-product/internal/service/webhook/model"
+)
+
+type WebhookServiceI interface {
+	ListSubscriptions(ctx context.Context, pars *model.SubscriptionListReq) ([]*model.Subscription, int64, error)
+	GetSubscription(ctx context.Context, id string, errNE bool) (*model.Subscription, bool, error)
+	CreateSubscription(ctx context.Context, obj *model.SubscriptionEdit) (string, error)
+	UpdateSubscription(ctx context.Context, obj *model.SubscriptionEdit) error
+	DeleteSubscription(ctx context.Context, id string) error
+	ListDeliveries(ctx context.Context, pars *model.DeliveryListReq) ([]*model.Delivery, int64, error)
+	GetDelivery(ctx context.Context, id string, errNE bool) (*model.Delivery, bool, error)
+	ClaimDeliveries(ctx context.Context, limit uint64, lease time.Duration) ([]*model.Delivery, error)
+	Delivered(ctx context.Context, id string, responseStatus int64) error
+	Failed(ctx context.Context, id string, responseStatus int64, errMsg string, nextAttemptAt *time.Time) error
+	Replay(ctx context.Context, id string) error
+}
+
+type SenderServiceI interface {
+	Send(ctx context.Context, req *senderModel.Request) (*senderModel.Response, error)
+}
@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/mechta-market/e-product/internal/service/webhook/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SenderServiceI is an autogenerated mock type for the SenderServiceI type
+type SenderServiceI struct {
+	mock.Mock
+}
+
+// Send provides a mock function with given fields: ctx, req
+func (_m *SenderServiceI) Send(ctx context.Context, req *model.Request) (*model.Response, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 *model.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Request) (*model.Response, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Request) *model.Response); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Request) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSenderServiceI creates a new instance of SenderServiceI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSenderServiceI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SenderServiceI {
+	mock := &SenderServiceI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,327 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/mechta-market/e-product/internal/domain/webhook/model"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// WebhookServiceI is an autogenerated mock type for the WebhookServiceI type
+type WebhookServiceI struct {
+	mock.Mock
+}
+
+// ClaimDeliveries provides a mock function with given fields: ctx, limit, lease
+func (_m *WebhookServiceI) ClaimDeliveries(ctx context.Context, limit uint64, lease time.Duration) ([]*model.Delivery, error) {
+	ret := _m.Called(ctx, limit, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDeliveries")
+	}
+
+	var r0 []*model.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, time.Duration) ([]*model.Delivery, error)); ok {
+		return rf(ctx, limit, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, time.Duration) []*model.Delivery); ok {
+		r0 = rf(ctx, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, time.Duration) error); ok {
+		r1 = rf(ctx, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateSubscription provides a mock function with given fields: ctx, obj
+func (_m *WebhookServiceI) CreateSubscription(ctx context.Context, obj *model.SubscriptionEdit) (string, error) {
+	ret := _m.Called(ctx, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSubscription")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SubscriptionEdit) (string, error)); ok {
+		return rf(ctx, obj)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SubscriptionEdit) string); ok {
+		r0 = rf(ctx, obj)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.SubscriptionEdit) error); ok {
+		r1 = rf(ctx, obj)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteSubscription provides a mock function with given fields: ctx, id
+func (_m *WebhookServiceI) DeleteSubscription(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delivered provides a mock function with given fields: ctx, id, responseStatus
+func (_m *WebhookServiceI) Delivered(ctx context.Context, id string, responseStatus int64) error {
+	ret := _m.Called(ctx, id, responseStatus)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delivered")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, id, responseStatus)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Failed provides a mock function with given fields: ctx, id, responseStatus, errMsg, nextAttemptAt
+func (_m *WebhookServiceI) Failed(ctx context.Context, id string, responseStatus int64, errMsg string, nextAttemptAt *time.Time) error {
+	ret := _m.Called(ctx, id, responseStatus, errMsg, nextAttemptAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Failed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string, *time.Time) error); ok {
+		r0 = rf(ctx, id, responseStatus, errMsg, nextAttemptAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDelivery provides a mock function with given fields: ctx, id, errNE
+func (_m *WebhookServiceI) GetDelivery(ctx context.Context, id string, errNE bool) (*model.Delivery, bool, error) {
+	ret := _m.Called(ctx, id, errNE)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDelivery")
+	}
+
+	var r0 *model.Delivery
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*model.Delivery, bool, error)); ok {
+		return rf(ctx, id, errNE)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *model.Delivery); ok {
+		r0 = rf(ctx, id, errNE)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) bool); ok {
+		r1 = rf(ctx, id, errNE)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, bool) error); ok {
+		r2 = rf(ctx, id, errNE)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetSubscription provides a mock function with given fields: ctx, id, errNE
+func (_m *WebhookServiceI) GetSubscription(ctx context.Context, id string, errNE bool) (*model.Subscription, bool, error) {
+	ret := _m.Called(ctx, id, errNE)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscription")
+	}
+
+	var r0 *model.Subscription
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*model.Subscription, bool, error)); ok {
+		return rf(ctx, id, errNE)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *model.Subscription); ok {
+		r0 = rf(ctx, id, errNE)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) bool); ok {
+		r1 = rf(ctx, id, errNE)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, bool) error); ok {
+		r2 = rf(ctx, id, errNE)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDeliveries provides a mock function with given fields: ctx, pars
+func (_m *WebhookServiceI) ListDeliveries(ctx context.Context, pars *model.DeliveryListReq) ([]*model.Delivery, int64, error) {
+	ret := _m.Called(ctx, pars)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeliveries")
+	}
+
+	var r0 []*model.Delivery
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.DeliveryListReq) ([]*model.Delivery, int64, error)); ok {
+		return rf(ctx, pars)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.DeliveryListReq) []*model.Delivery); ok {
+		r0 = rf(ctx, pars)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.DeliveryListReq) int64); ok {
+		r1 = rf(ctx, pars)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *model.DeliveryListReq) error); ok {
+		r2 = rf(ctx, pars)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListSubscriptions provides a mock function with given fields: ctx, pars
+func (_m *WebhookServiceI) ListSubscriptions(ctx context.Context, pars *model.SubscriptionListReq) ([]*model.Subscription, int64, error) {
+	ret := _m.Called(ctx, pars)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSubscriptions")
+	}
+
+	var r0 []*model.Subscription
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SubscriptionListReq) ([]*model.Subscription, int64, error)); ok {
+		return rf(ctx, pars)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SubscriptionListReq) []*model.Subscription); ok {
+		r0 = rf(ctx, pars)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.SubscriptionListReq) int64); ok {
+		r1 = rf(ctx, pars)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *model.SubscriptionListReq) error); ok {
+		r2 = rf(ctx, pars)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Replay provides a mock function with given fields: ctx, id
+func (_m *WebhookServiceI) Replay(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Replay")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateSubscription provides a mock function with given fields: ctx, obj
+func (_m *WebhookServiceI) UpdateSubscription(ctx context.Context, obj *model.SubscriptionEdit) error {
+	ret := _m.Called(ctx, obj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SubscriptionEdit) error); ok {
+		r0 = rf(ctx, obj)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewWebhookServiceI creates a new instance of WebhookServiceI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookServiceI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookServiceI {
+	mock := &WebhookServiceI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,205 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/common/util"
+	"github.com/mechta-market/e-product/internal/domain/webhook/model"
+	"github.com/mechta-market/e-product/internal/errs"
+)
+
+// secretSize размер секрета подписи, который генерируется, если при создании подписки он не задан
+const secretSize = 32
+
+type Usecase struct {
+	service WebhookServiceI
+	sender  SenderServiceI
+	conf    Config
+}
+
+func New(service WebhookServiceI, sender SenderServiceI, conf Config) *Usecase {
+	return &Usecase{
+		service: service,
+		sender:  sender,
+		conf:    conf,
+	}
+}
+
+func (u *Usecase) ListSubscriptions(ctx context.Context, pars *model.SubscriptionListReq) ([]*model.Subscription, int64, error) {
+	if err := util.RequirePageSize(pars.ListParams, constant.MaxPageSize); err != nil {
+		return nil, 0, err
+	}
+
+	items, tCount, err := u.service.ListSubscriptions(ctx, pars)
+	if err != nil {
+		return nil, 0, fmt.Errorf("service.ListSubscriptions: %w", err)
+	}
+
+	return items, tCount, nil
+}
+
+// CreateSubscription создает подписку. Если секрет не задан, он генерируется и возвращается
+// в ответе: позже секрет не отдается
+func (u *Usecase) CreateSubscription(ctx context.Context, obj *model.SubscriptionEdit) (*model.Subscription, error) {
+	if obj.URL == nil {
+		obj.URL = lo.ToPtr("")
+	}
+	if obj.Events == nil {
+		obj.Events = &[]string{}
+	}
+
+	if err := u.validateSubscription(obj); err != nil {
+		return nil, err
+	}
+
+	if lo.FromPtr(obj.Secret) == "" {
+		secret, err := newSecret()
+		if err != nil {
+			return nil, fmt.Errorf("newSecret: %w", err)
+		}
+		obj.Secret = &secret
+	}
+
+	id, err := u.service.CreateSubscription(ctx, obj)
+	if err != nil {
+		return nil, fmt.Errorf("service.CreateSubscription: %w", err)
+	}
+
+	result, _, err := u.service.GetSubscription(ctx, id, true)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetSubscription: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdateSubscription обновляет заданные поля подписки. Пустой секрет не меняет текущий
+func (u *Usecase) UpdateSubscription(ctx context.Context, obj *model.SubscriptionEdit) (*model.Subscription, error) {
+	id := strings.TrimSpace(lo.FromPtr(obj.ID))
+	if id == "" {
+		return nil, errs.IDRequired
+	}
+
+	if err := u.validateSubscription(obj); err != nil {
+		return nil, err
+	}
+
+	if lo.FromPtr(obj.Secret) == "" {
+		obj.Secret = nil
+	}
+
+	err := u.service.UpdateSubscription(ctx, obj)
+	if err != nil {
+		return nil, fmt.Errorf("service.UpdateSubscription: %w", err)
+	}
+
+	result, _, err := u.service.GetSubscription(ctx, id, true)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetSubscription: %w", err)
+	}
+
+	return result, nil
+}
+
+func (u *Usecase) DeleteSubscription(ctx context.Context, id string) error {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return errs.IDRequired
+	}
+
+	err := u.service.DeleteSubscription(ctx, id)
+	if err != nil {
+		return fmt.Errorf("service.DeleteSubscription: %w", err)
+	}
+
+	return nil
+}
+
+func (u *Usecase) ListDeliveries(ctx context.Context, pars *model.DeliveryListReq) ([]*model.Delivery, int64, error) {
+	if err := util.RequirePageSize(pars.ListParams, constant.MaxPageSize); err != nil {
+		return nil, 0, err
+	}
+
+	items, tCount, err := u.service.ListDeliveries(ctx, pars)
+	if err != nil {
+		return nil, 0, fmt.Errorf("service.ListDeliveries: %w", err)
+	}
+
+	return items, tCount, nil
+}
+
+// Replay повторно ставит доставку в очередь с обнуленным счетчиком попыток, обычно - из dead
+func (u *Usecase) Replay(ctx context.Context, id string) (*model.Delivery, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, errs.IDRequired
+	}
+
+	_, _, err := u.service.GetDelivery(ctx, id, true)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetDelivery: %w", err)
+	}
+
+	err = u.service.Replay(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("service.Replay: %w", err)
+	}
+
+	result, _, err := u.service.GetDelivery(ctx, id, true)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetDelivery: %w", err)
+	}
+
+	return result, nil
+}
+
+// validateSubscription проверяет заданные поля подписки
+func (u *Usecase) validateSubscription(obj *model.SubscriptionEdit) error {
+	if obj.URL != nil {
+		obj.URL = lo.ToPtr(strings.TrimSpace(*obj.URL))
+
+		parsed, err := url.Parse(*obj.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return errs.InvalidWebhookURL
+		}
+	}
+
+	if obj.Events != nil {
+		if len(*obj.Events) == 0 {
+			return errs.WebhookEventsRequired
+		}
+
+		for _, event := range *obj.Events {
+			if !slices.Contains(constant.WebhookEvents, event) {
+				return errs.ErrFull{
+					Err: errs.InvalidWebhookEvent,
+					Fields: map[string]string{
+						"event": event,
+					},
+				}
+			}
+		}
+
+		obj.Events = lo.ToPtr(lo.Uniq(*obj.Events))
+	}
+
+	return nil
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
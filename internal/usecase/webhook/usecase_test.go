@@ -0,0 +1,200 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mechta-market/e-product/internal/constant"
+	"github.com/mechta-market/e-product/internal/domain/common/util"
+	"github.com/mechta-market/e-product/internal/domain/webhook/model"
+	"github.com/mechta-market/e-product/internal/errs"
+	senderService "github.com/mechta-market/e-product/internal/service/webhook"
+	senderRepo "github.com/mechta-market/e-product/internal/service/webhook/repo"
+	"github.com/mechta-market/e-product/internal/usecase/webhook/mocks"
+)
+
+var testConf = Config{
+	MaxAttempts:    3,
+	RetryBaseDelay: time.Minute,
+	RetryMaxDelay:  time.Hour,
+	Lease:          time.Minute,
+}
+
+// newTestReceiver поднимает получателя webhook, который отвечает status и проверяет подпись
+func newTestReceiver(t *testing.T, secret string, status int) (*httptest.Server, *int) {
+	calls := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "sha256="+util.Sign([]byte(secret), r.Header.Get(constant.HeaderSignatureTimestamp), body), r.Header.Get(constant.HeaderSignature))
+		assert.Equal(t, constant.WebhookEventActivated, r.Header.Get(constant.HeaderWebhookEvent))
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &calls
+}
+
+func TestUsecase_Deliver(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		attempts       int64
+		active         bool
+		expectedCalls  int
+		expectedMethod string
+		expectedNext   bool
+	}{
+		{
+			name:           "delivered",
+			status:         http.StatusOK,
+			attempts:       1,
+			active:         true,
+			expectedCalls:  1,
+			expectedMethod: "Delivered",
+		},
+		{
+			name:           "failure scheduled for retry",
+			status:         http.StatusInternalServerError,
+			attempts:       2,
+			active:         true,
+			expectedCalls:  1,
+			expectedMethod: "Failed",
+			expectedNext:   true,
+		},
+		{
+			name:           "attempts exhausted goes dead",
+			status:         http.StatusInternalServerError,
+			attempts:       3,
+			active:         true,
+			expectedCalls:  1,
+			expectedMethod: "Failed",
+		},
+		{
+			name:           "inactive subscription goes dead without sending",
+			status:         http.StatusOK,
+			attempts:       1,
+			expectedMethod: "Failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := newTestReceiver(t, "secret", tt.status)
+
+			service := mocks.NewWebhookServiceI(t)
+			service.On("ClaimDeliveries", mock.Anything, uint64(deliverBatchSize), testConf.Lease).Return([]*model.Delivery{{
+				ID:             "del-1",
+				SubscriptionID: "sub-1",
+				Event:          constant.WebhookEventActivated,
+				Payload:        `{"event":"activated"}`,
+				Attempts:       tt.attempts,
+			}}, nil).Once()
+			service.On("GetSubscription", mock.Anything, "sub-1", false).Return(&model.Subscription{
+				ID:     "sub-1",
+				URL:    server.URL,
+				Active: tt.active,
+				Secret: "secret",
+			}, true, nil).Once()
+
+			var nextAttemptAt *time.Time
+			switch tt.expectedMethod {
+			case "Delivered":
+				service.On("Delivered", mock.Anything, "del-1", int64(tt.status)).Return(nil).Once()
+			case "Failed":
+				service.On("Failed", mock.Anything, "del-1", mock.Anything, mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						nextAttemptAt = args.Get(4).(*time.Time)
+					}).Return(nil).Once()
+			}
+
+			u := New(service, senderService.New(senderRepo.New(time.Second)), testConf)
+
+			before := time.Now()
+			require.NoError(t, u.Deliver(context.Background()))
+
+			assert.Equal(t, tt.expectedCalls, *calls)
+			if tt.expectedNext {
+				require.NotNil(t, nextAttemptAt)
+				// вторая попытка: base*2
+				assert.WithinDuration(t, before.Add(2*time.Minute), *nextAttemptAt, 5*time.Second)
+			} else {
+				assert.Nil(t, nextAttemptAt)
+			}
+		})
+	}
+}
+
+func TestUsecase_Backoff(t *testing.T) {
+	u := New(nil, nil, Config{RetryBaseDelay: time.Minute, RetryMaxDelay: 10 * time.Minute})
+
+	assert.Equal(t, time.Minute, u.backoff(1))
+	assert.Equal(t, 4*time.Minute, u.backoff(3))
+	assert.Equal(t, 10*time.Minute, u.backoff(5))
+	assert.Equal(t, 10*time.Minute, u.backoff(100))
+}
+
+func TestUsecase_CreateSubscription(t *testing.T) {
+	tests := []struct {
+		name        string
+		obj         *model.SubscriptionEdit
+		expectedErr error
+	}{
+		{
+			name:        "invalid url",
+			obj:         &model.SubscriptionEdit{URL: lo.ToPtr("ftp://example.com"), Events: &[]string{constant.WebhookEventActivated}},
+			expectedErr: errs.InvalidWebhookURL,
+		},
+		{
+			name:        "no events",
+			obj:         &model.SubscriptionEdit{URL: lo.ToPtr("https://example.com/hook")},
+			expectedErr: errs.WebhookEventsRequired,
+		},
+		{
+			name:        "unknown event",
+			obj:         &model.SubscriptionEdit{URL: lo.ToPtr("https://example.com/hook"), Events: &[]string{"deleted"}},
+			expectedErr: errs.InvalidWebhookEvent,
+		},
+		{
+			name: "secret generated",
+			obj:  &model.SubscriptionEdit{URL: lo.ToPtr("https://example.com/hook"), Events: &[]string{constant.WebhookEventActivated, constant.WebhookEventActivated}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := mocks.NewWebhookServiceI(t)
+			if tt.expectedErr == nil {
+				service.On("CreateSubscription", mock.Anything, mock.MatchedBy(func(obj *model.SubscriptionEdit) bool {
+					return len(lo.FromPtr(obj.Secret)) == 2*secretSize && len(*obj.Events) == 1
+				})).Return("sub-1", nil).Once()
+				service.On("GetSubscription", mock.Anything, "sub-1", true).Return(&model.Subscription{ID: "sub-1"}, true, nil).Once()
+			}
+
+			_, err := New(service, nil, testConf).CreateSubscription(context.Background(), tt.obj)
+			var errFull errs.ErrFull
+			if errors.As(err, &errFull) {
+				err = errFull.Err
+			}
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS key_order;
+
+DROP INDEX IF EXISTS key_order_id_product_id_uidx;
@@ -0,0 +1,26 @@
+-- ключи, выданные одному заказу по одному продукту повторно, не дадут создать уникальный индекс.
+-- Автоматически их не разбираем: каждый такой ключ уже у покупателя. Найти дубли:
+--   SELECT order_id, product_id, array_agg(id ORDER BY created_at) FROM key
+--   WHERE order_id <> '' GROUP BY order_id, product_id HAVING count(*) > 1;
+-- лишним ключам после сверки с заказом очищают order_id или отменяют их, затем миграцию повторяют
+DO $$
+DECLARE
+    duplicates BIGINT;
+BEGIN
+    SELECT count(*) INTO duplicates FROM (
+        SELECT 1 FROM key WHERE order_id <> '' GROUP BY order_id, product_id HAVING count(*) > 1
+    ) d;
+
+    IF duplicates > 0 THEN
+        RAISE EXCEPTION 'key: % (order_id, product_id) pairs have more than one key, resolve them before creating key_order_id_product_id_uidx', duplicates;
+    END IF;
+END $$;
+
+CREATE UNIQUE INDEX key_order_id_product_id_uidx ON key (order_id, product_id) WHERE order_id <> '';
+
+CREATE TABLE key_order (
+                     order_id TEXT NOT NULL,
+                     product_id TEXT NOT NULL,
+                     created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     PRIMARY KEY (order_id, product_id)
+);
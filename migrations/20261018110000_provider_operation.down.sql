@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS provider_operation;
+
+DROP TYPE IF EXISTS provider_operation_status;
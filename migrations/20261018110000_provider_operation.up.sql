@@ -0,0 +1,19 @@
+CREATE TYPE provider_operation_status AS ENUM ('requested', 'purchased', 'stored', 'failed', 'manual_review');
+
+CREATE TABLE provider_operation (
+                     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                     created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     provider_id TEXT NOT NULL DEFAULT '',
+                     product_id TEXT NOT NULL DEFAULT '',
+                     provider_product_id TEXT NOT NULL DEFAULT '',
+                     order_id TEXT NOT NULL DEFAULT '',
+                     status provider_operation_status NOT NULL DEFAULT 'requested',
+                     key_id TEXT NOT NULL DEFAULT '',
+                     value TEXT NOT NULL DEFAULT '',
+                     provider_order_id TEXT NOT NULL DEFAULT '',
+                     provider_transaction_id TEXT NOT NULL DEFAULT '',
+                     error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX provider_operation_status_updated_at_idx ON provider_operation (status, updated_at);
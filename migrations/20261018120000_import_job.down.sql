@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS import_job;
+
+DROP TYPE IF EXISTS import_job_status;
@@ -0,0 +1,21 @@
+CREATE TYPE import_job_status AS ENUM ('running', 'completed', 'failed');
+
+CREATE TABLE import_job (
+                     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                     created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     file_name TEXT NOT NULL DEFAULT '',
+                     format TEXT NOT NULL DEFAULT '',
+                     mode TEXT NOT NULL DEFAULT '',
+                     status import_job_status NOT NULL DEFAULT 'running',
+                     total_count BIGINT NOT NULL DEFAULT 0,
+                     created_count BIGINT NOT NULL DEFAULT 0,
+                     duplicate_count BIGINT NOT NULL DEFAULT 0,
+                     invalid_count BIGINT NOT NULL DEFAULT 0,
+                     skipped_count BIGINT NOT NULL DEFAULT 0,
+                     failed_count BIGINT NOT NULL DEFAULT 0,
+                     error TEXT NOT NULL DEFAULT '',
+                     items JSONB NOT NULL DEFAULT '[]'
+);
+
+CREATE INDEX import_job_created_at_idx ON import_job (created_at);
@@ -0,0 +1,13 @@
+ALTER TABLE IF EXISTS provider_operation
+    DROP COLUMN IF EXISTS value_enc,
+    DROP COLUMN IF EXISTS value_dek,
+    DROP COLUMN IF EXISTS value_key_id;
+
+DROP INDEX IF EXISTS key_value_key_id_idx;
+DROP INDEX IF EXISTS key_value_hash_idx;
+
+ALTER TABLE IF EXISTS key
+    DROP COLUMN IF EXISTS value_enc,
+    DROP COLUMN IF EXISTS value_dek,
+    DROP COLUMN IF EXISTS value_key_id,
+    DROP COLUMN IF EXISTS value_hash;
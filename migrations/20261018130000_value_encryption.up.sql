@@ -0,0 +1,13 @@
+ALTER TABLE key
+    ADD COLUMN value_enc BYTEA,
+    ADD COLUMN value_dek BYTEA,
+    ADD COLUMN value_key_id TEXT NOT NULL DEFAULT '',
+    ADD COLUMN value_hash TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX key_value_hash_idx ON key (value_hash) WHERE value_hash <> '';
+CREATE INDEX key_value_key_id_idx ON key (value_key_id);
+
+ALTER TABLE provider_operation
+    ADD COLUMN value_enc BYTEA,
+    ADD COLUMN value_dek BYTEA,
+    ADD COLUMN value_key_id TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,16 @@
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_type WHERE typname = 'key_status') THEN
+        ALTER TABLE key ALTER COLUMN status DROP DEFAULT;
+        ALTER TABLE key ALTER COLUMN status TYPE TEXT;
+
+        UPDATE key SET status = 'new' WHERE status IN ('reserved', 'returned');
+        UPDATE key SET status = 'cancelled' WHERE status IN ('expired', 'withdrawn');
+
+        DROP TYPE key_status;
+        CREATE TYPE key_status AS ENUM ('new', 'activated', 'cancelled');
+
+        ALTER TABLE key ALTER COLUMN status TYPE key_status USING status::key_status;
+        ALTER TABLE key ALTER COLUMN status SET DEFAULT 'new';
+    END IF;
+END $$;
@@ -0,0 +1,4 @@
+ALTER TYPE key_status ADD VALUE IF NOT EXISTS 'reserved';
+ALTER TYPE key_status ADD VALUE IF NOT EXISTS 'returned';
+ALTER TYPE key_status ADD VALUE IF NOT EXISTS 'expired';
+ALTER TYPE key_status ADD VALUE IF NOT EXISTS 'withdrawn';
@@ -0,0 +1,13 @@
+CREATE TABLE key_event (
+                     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                     created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     key_id UUID NOT NULL,
+                     from_status key_status NOT NULL,
+                     to_status key_status NOT NULL,
+                     actor TEXT NOT NULL DEFAULT '',
+                     order_id TEXT NOT NULL DEFAULT '',
+                     provider_transaction_id TEXT NOT NULL DEFAULT '',
+                     reason TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX key_event_key_id_created_at_idx ON key_event (key_id, created_at);
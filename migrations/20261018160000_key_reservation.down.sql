@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS key_reservation;
+
+DROP TYPE IF EXISTS key_reservation_status;
@@ -0,0 +1,16 @@
+DROP TYPE IF EXISTS key_reservation_status;
+CREATE TYPE key_reservation_status AS ENUM ('active', 'confirmed', 'released', 'expired');
+
+CREATE TABLE key_reservation (
+                     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                     created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     product_id TEXT NOT NULL DEFAULT '',
+                     order_id TEXT NOT NULL DEFAULT '',
+                     key_id TEXT NOT NULL DEFAULT '',
+                     status key_reservation_status NOT NULL DEFAULT 'active',
+                     expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX key_reservation_order_id_product_id_uidx ON key_reservation (order_id, product_id) WHERE status = 'active';
+CREATE INDEX key_reservation_expires_at_idx ON key_reservation (expires_at) WHERE status = 'active';
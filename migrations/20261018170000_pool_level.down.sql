@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS provider_operation_product_id_created_at_idx;
+
+DROP TABLE IF EXISTS pool_level;
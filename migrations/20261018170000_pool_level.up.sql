@@ -0,0 +1,10 @@
+CREATE TABLE pool_level (
+                     product_id TEXT PRIMARY KEY,
+                     created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     min_level BIGINT NOT NULL DEFAULT 0,
+                     target_level BIGINT NOT NULL DEFAULT 0,
+                     daily_cap BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX provider_operation_product_id_created_at_idx ON provider_operation (product_id, created_at);
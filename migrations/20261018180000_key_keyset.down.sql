@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS key_status_created_at_id_idx;
+DROP INDEX IF EXISTS key_product_id_created_at_id_idx;
+DROP INDEX IF EXISTS key_created_at_id_idx;
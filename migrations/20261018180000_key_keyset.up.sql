@@ -0,0 +1,3 @@
+CREATE INDEX key_created_at_id_idx ON key (created_at, id);
+CREATE INDEX key_product_id_created_at_id_idx ON key (product_id, created_at, id);
+CREATE INDEX key_status_created_at_id_idx ON key (status, created_at, id);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS key_customer_phone_idx;
@@ -0,0 +1 @@
+CREATE INDEX key_customer_phone_idx ON key (customer_phone) WHERE customer_phone <> '';
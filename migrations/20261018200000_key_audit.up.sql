@@ -0,0 +1,11 @@
+CREATE TABLE key_audit (
+                     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                     created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     key_id UUID NOT NULL,
+                     action TEXT NOT NULL,
+                     actor TEXT NOT NULL DEFAULT '',
+                     role TEXT NOT NULL DEFAULT '',
+                     reason TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX key_audit_key_id_created_at_idx ON key_audit (key_id, created_at);
@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS key
+    DROP COLUMN IF EXISTS link,
+    DROP COLUMN IF EXISTS instructions,
+    DROP COLUMN IF EXISTS license_term;
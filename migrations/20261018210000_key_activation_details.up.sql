@@ -0,0 +1,4 @@
+ALTER TABLE key
+    ADD COLUMN link TEXT NOT NULL DEFAULT '',
+    ADD COLUMN instructions TEXT NOT NULL DEFAULT '',
+    ADD COLUMN license_term TEXT NOT NULL DEFAULT '';
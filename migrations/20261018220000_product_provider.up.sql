@@ -0,0 +1,13 @@
+CREATE TABLE product_provider (
+                     product_id TEXT NOT NULL,
+                     provider_id TEXT NOT NULL,
+                     created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     priority BIGINT NOT NULL DEFAULT 0,
+                     provider_product_id TEXT NOT NULL DEFAULT '',
+                     provider_external_product_id TEXT NOT NULL DEFAULT '',
+                     promotion_key TEXT NOT NULL DEFAULT '',
+                     PRIMARY KEY (product_id, provider_id)
+);
+
+CREATE INDEX product_provider_product_id_priority_idx ON product_provider (product_id, priority);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS key_activation;
+DROP TYPE IF EXISTS key_activation_status;
@@ -0,0 +1,19 @@
+DROP TYPE IF EXISTS key_activation_status;
+CREATE TYPE key_activation_status AS ENUM ('pending', 'processing', 'completed', 'failed');
+
+CREATE TABLE key_activation (
+                     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                     created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     product_id TEXT NOT NULL DEFAULT '',
+                     order_id TEXT NOT NULL DEFAULT '',
+                     customer_phone TEXT NOT NULL DEFAULT '',
+                     status key_activation_status NOT NULL DEFAULT 'pending',
+                     key_id TEXT NOT NULL DEFAULT '',
+                     error TEXT NOT NULL DEFAULT '',
+                     attempts BIGINT NOT NULL DEFAULT 0
+);
+
+-- повторный асинхронный запрос по заказу возвращает ту же выдачу, после failed можно создать новую
+CREATE UNIQUE INDEX key_activation_order_id_product_id_uidx ON key_activation (order_id, product_id) WHERE status <> 'failed';
+CREATE INDEX key_activation_queue_idx ON key_activation (created_at) WHERE status IN ('pending', 'processing');
@@ -0,0 +1,35 @@
+CREATE TABLE webhook_subscription (
+                     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                     created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     name TEXT NOT NULL DEFAULT '',
+                     url TEXT NOT NULL,
+                     events TEXT[] NOT NULL DEFAULT '{}',
+                     active BOOLEAN NOT NULL DEFAULT true,
+                     secret TEXT NOT NULL DEFAULT '',
+                     secret_enc BYTEA,
+                     secret_dek BYTEA,
+                     secret_key_id TEXT NOT NULL DEFAULT ''
+);
+
+DROP TYPE IF EXISTS webhook_delivery_status;
+CREATE TYPE webhook_delivery_status AS ENUM ('pending', 'delivered', 'dead');
+
+-- payload хранится текстом: подпись считается по точным байтам тела
+CREATE TABLE webhook_delivery (
+                     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                     created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     subscription_id UUID NOT NULL REFERENCES webhook_subscription (id) ON DELETE CASCADE,
+                     event TEXT NOT NULL,
+                     payload TEXT NOT NULL,
+                     status webhook_delivery_status NOT NULL DEFAULT 'pending',
+                     attempts BIGINT NOT NULL DEFAULT 0,
+                     next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                     last_error TEXT NOT NULL DEFAULT '',
+                     response_status BIGINT NOT NULL DEFAULT 0,
+                     delivered_at TIMESTAMPTZ
+);
+
+CREATE INDEX webhook_delivery_due_idx ON webhook_delivery (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX webhook_delivery_subscription_id_idx ON webhook_delivery (subscription_id, created_at);
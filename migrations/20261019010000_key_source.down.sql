@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS key DROP COLUMN IF EXISTS source;
+
+DROP TYPE IF EXISTS key_source;
@@ -0,0 +1,12 @@
+CREATE TYPE key_source AS ENUM ('pool', 'provider');
+
+ALTER TABLE key ADD COLUMN source key_source NOT NULL DEFAULT 'pool';
+
+-- ключи из Load без провайдера остаются pool. Транзакции у провайдера может не быть (Megogo),
+-- поэтому ключ провайдера определяется по provider_id, кроме докупленных в пул без заказа
+UPDATE key SET source = 'provider'
+WHERE provider_id <> ''
+  AND NOT EXISTS (
+    SELECT 1 FROM provider_operation op
+    WHERE op.key_id = key.id::text AND op.order_id = ''
+  );
@@ -0,0 +1,15 @@
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_type WHERE typname = 'provider_operation_status') THEN
+        ALTER TABLE provider_operation ALTER COLUMN status DROP DEFAULT;
+        ALTER TABLE provider_operation ALTER COLUMN status TYPE TEXT;
+
+        UPDATE provider_operation SET status = 'failed' WHERE status = 'skipped';
+
+        DROP TYPE provider_operation_status;
+        CREATE TYPE provider_operation_status AS ENUM ('requested', 'purchased', 'stored', 'failed', 'manual_review');
+
+        ALTER TABLE provider_operation ALTER COLUMN status TYPE provider_operation_status USING status::provider_operation_status;
+        ALTER TABLE provider_operation ALTER COLUMN status SET DEFAULT 'requested';
+    END IF;
+END $$;
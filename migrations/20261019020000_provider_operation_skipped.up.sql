@@ -0,0 +1 @@
+ALTER TYPE provider_operation_status ADD VALUE IF NOT EXISTS 'skipped';
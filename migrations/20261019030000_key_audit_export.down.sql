@@ -0,0 +1,5 @@
+DELETE FROM key_audit WHERE key_id IS NULL;
+
+ALTER TABLE IF EXISTS key_audit DROP COLUMN IF EXISTS row_count;
+ALTER TABLE IF EXISTS key_audit DROP COLUMN IF EXISTS filter;
+ALTER TABLE IF EXISTS key_audit ALTER COLUMN key_id SET NOT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE key_audit ALTER COLUMN key_id DROP NOT NULL;
+ALTER TABLE key_audit ADD COLUMN filter JSONB;
+ALTER TABLE key_audit ADD COLUMN row_count BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+-- исходный вид телефонов не сохраняется, откатывать нечего
+SELECT 1;
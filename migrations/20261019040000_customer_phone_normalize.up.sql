@@ -0,0 +1,16 @@
+-- телефоны клиентов приводятся к виду, в котором их сохраняет и ищет сервис (util.NormalizeAndValidatePhone):
+-- без пробелов и ведущего +, 7XXXXXXXXX -> 77XXXXXXXXX, 87XXXXXXXXX -> 77XXXXXXXXX
+CREATE FUNCTION pg_temp.normalize_phone(phone TEXT) RETURNS TEXT AS $$
+    SELECT CASE
+        WHEN p ~ '^7.{9}$' THEN '7' || p
+        WHEN p ~ '^87.{9}$' THEN '7' || substr(p, 2)
+        ELSE p
+    END
+    FROM (SELECT regexp_replace(btrim(phone), '^\+(.)', '\1') AS p) t
+$$ LANGUAGE SQL IMMUTABLE;
+
+UPDATE key SET customer_phone = pg_temp.normalize_phone(customer_phone)
+WHERE customer_phone <> '' AND customer_phone <> pg_temp.normalize_phone(customer_phone);
+
+UPDATE key_activation SET customer_phone = pg_temp.normalize_phone(customer_phone)
+WHERE customer_phone <> '' AND customer_phone <> pg_temp.normalize_phone(customer_phone);
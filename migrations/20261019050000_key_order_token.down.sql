@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS key_order DROP COLUMN IF EXISTS token;
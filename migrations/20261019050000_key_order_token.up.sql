@@ -0,0 +1,2 @@
+-- токен владельца блокировки: снять блокировку может только тот, кто ее занял
+ALTER TABLE key_order ADD COLUMN token TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS key_activation DROP COLUMN IF EXISTS next_attempt_at;
@@ -0,0 +1,2 @@
+-- временная ошибка возвращает выдачу в pending: воркер заберет ее не раньше next_attempt_at
+ALTER TABLE key_activation ADD COLUMN next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now();
@@ -11,7 +11,6 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -25,12 +24,216 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type LoadMode int32
+
+const (
+	LoadMode_best_effort    LoadMode = 0 // каждый ключ сохраняется независимо
+	LoadMode_all_or_nothing LoadMode = 1 // все ключи сохраняются в одной транзакции либо ни один
+)
+
+// Enum value maps for LoadMode.
+var (
+	LoadMode_name = map[int32]string{
+		0: "best_effort",
+		1: "all_or_nothing",
+	}
+	LoadMode_value = map[string]int32{
+		"best_effort":    0,
+		"all_or_nothing": 1,
+	}
+)
+
+func (x LoadMode) Enum() *LoadMode {
+	p := new(LoadMode)
+	*p = x
+	return p
+}
+
+func (x LoadMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LoadMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_e_product_e_product_v1_proto_enumTypes[0].Descriptor()
+}
+
+func (LoadMode) Type() protoreflect.EnumType {
+	return &file_e_product_e_product_v1_proto_enumTypes[0]
+}
+
+func (x LoadMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LoadMode.Descriptor instead.
+func (LoadMode) EnumDescriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{0}
+}
+
+type LoadItemResult int32
+
+const (
+	LoadItemResult_created   LoadItemResult = 0
+	LoadItemResult_duplicate LoadItemResult = 1 // ключ уже есть в БД, см. existing_product_id
+	LoadItemResult_invalid   LoadItemResult = 2 // ключ не прошел валидацию, см. reason
+	LoadItemResult_skipped   LoadItemResult = 3 // all_or_nothing: ключ валиден, но загрузка отменена
+	LoadItemResult_failed    LoadItemResult = 4 // best_effort: ошибка при сохранении, см. reason
+)
+
+// Enum value maps for LoadItemResult.
+var (
+	LoadItemResult_name = map[int32]string{
+		0: "created",
+		1: "duplicate",
+		2: "invalid",
+		3: "skipped",
+		4: "failed",
+	}
+	LoadItemResult_value = map[string]int32{
+		"created":   0,
+		"duplicate": 1,
+		"invalid":   2,
+		"skipped":   3,
+		"failed":    4,
+	}
+)
+
+func (x LoadItemResult) Enum() *LoadItemResult {
+	p := new(LoadItemResult)
+	*p = x
+	return p
+}
+
+func (x LoadItemResult) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LoadItemResult) Descriptor() protoreflect.EnumDescriptor {
+	return file_e_product_e_product_v1_proto_enumTypes[1].Descriptor()
+}
+
+func (LoadItemResult) Type() protoreflect.EnumType {
+	return &file_e_product_e_product_v1_proto_enumTypes[1]
+}
+
+func (x LoadItemResult) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LoadItemResult.Descriptor instead.
+func (LoadItemResult) EnumDescriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{1}
+}
+
+// ImportKeys
+type ImportFormat int32
+
+const (
+	ImportFormat_import_format_auto ImportFormat = 0 // по расширению file_name
+	ImportFormat_csv                ImportFormat = 1
+	ImportFormat_xlsx               ImportFormat = 2
+)
+
+// Enum value maps for ImportFormat.
+var (
+	ImportFormat_name = map[int32]string{
+		0: "import_format_auto",
+		1: "csv",
+		2: "xlsx",
+	}
+	ImportFormat_value = map[string]int32{
+		"import_format_auto": 0,
+		"csv":                1,
+		"xlsx":               2,
+	}
+)
+
+func (x ImportFormat) Enum() *ImportFormat {
+	p := new(ImportFormat)
+	*p = x
+	return p
+}
+
+func (x ImportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_e_product_e_product_v1_proto_enumTypes[2].Descriptor()
+}
+
+func (ImportFormat) Type() protoreflect.EnumType {
+	return &file_e_product_e_product_v1_proto_enumTypes[2]
+}
+
+func (x ImportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportFormat.Descriptor instead.
+func (ImportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{2}
+}
+
+type ImportJobStatus int32
+
+const (
+	ImportJobStatus_import_running   ImportJobStatus = 0
+	ImportJobStatus_import_completed ImportJobStatus = 1
+	ImportJobStatus_import_failed    ImportJobStatus = 2
+)
+
+// Enum value maps for ImportJobStatus.
+var (
+	ImportJobStatus_name = map[int32]string{
+		0: "import_running",
+		1: "import_completed",
+		2: "import_failed",
+	}
+	ImportJobStatus_value = map[string]int32{
+		"import_running":   0,
+		"import_completed": 1,
+		"import_failed":    2,
+	}
+)
+
+func (x ImportJobStatus) Enum() *ImportJobStatus {
+	p := new(ImportJobStatus)
+	*p = x
+	return p
+}
+
+func (x ImportJobStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportJobStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_e_product_e_product_v1_proto_enumTypes[3].Descriptor()
+}
+
+func (ImportJobStatus) Type() protoreflect.EnumType {
+	return &file_e_product_e_product_v1_proto_enumTypes[3]
+}
+
+func (x ImportJobStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportJobStatus.Descriptor instead.
+func (ImportJobStatus) EnumDescriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{3}
+}
+
 type KeyStatus int32
 
 const (
 	KeyStatus_new       KeyStatus = 0
 	KeyStatus_activated KeyStatus = 1
 	KeyStatus_cancelled KeyStatus = 2
+	KeyStatus_reserved  KeyStatus = 3
+	KeyStatus_returned  KeyStatus = 4
+	KeyStatus_expired   KeyStatus = 5
+	KeyStatus_withdrawn KeyStatus = 6
 )
 
 // Enum value maps for KeyStatus.
@@ -39,11 +242,19 @@ var (
 		0: "new",
 		1: "activated",
 		2: "cancelled",
+		3: "reserved",
+		4: "returned",
+		5: "expired",
+		6: "withdrawn",
 	}
 	KeyStatus_value = map[string]int32{
 		"new":       0,
 		"activated": 1,
 		"cancelled": 2,
+		"reserved":  3,
+		"returned":  4,
+		"expired":   5,
+		"withdrawn": 6,
 	}
 )
 
@@ -53,51 +264,3886 @@ func (x KeyStatus) Enum() *KeyStatus {
 	return p
 }
 
-func (x KeyStatus) String() string {
-	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+func (x KeyStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KeyStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_e_product_e_product_v1_proto_enumTypes[4].Descriptor()
+}
+
+func (KeyStatus) Type() protoreflect.EnumType {
+	return &file_e_product_e_product_v1_proto_enumTypes[4]
+}
+
+func (x KeyStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KeyStatus.Descriptor instead.
+func (KeyStatus) EnumDescriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{4}
+}
+
+// KeySource откуда получен ключ: при отмене ключ из пула возвращается (returned),
+// а ключ провайдера отменяется у провайдера
+type KeySource int32
+
+const (
+	KeySource_source_pool     KeySource = 0
+	KeySource_source_provider KeySource = 1
+)
+
+// Enum value maps for KeySource.
+var (
+	KeySource_name = map[int32]string{
+		0: "source_pool",
+		1: "source_provider",
+	}
+	KeySource_value = map[string]int32{
+		"source_pool":     0,
+		"source_provider": 1,
+	}
+)
+
+func (x KeySource) Enum() *KeySource {
+	p := new(KeySource)
+	*p = x
+	return p
+}
+
+func (x KeySource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KeySource) Descriptor() protoreflect.EnumDescriptor {
+	return file_e_product_e_product_v1_proto_enumTypes[5].Descriptor()
+}
+
+func (KeySource) Type() protoreflect.EnumType {
+	return &file_e_product_e_product_v1_proto_enumTypes[5]
+}
+
+func (x KeySource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KeySource.Descriptor instead.
+func (KeySource) EnumDescriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{5}
+}
+
+// Export
+type ExportFormat int32
+
+const (
+	ExportFormat_export_csv   ExportFormat = 0
+	ExportFormat_export_jsonl ExportFormat = 1
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "export_csv",
+		1: "export_jsonl",
+	}
+	ExportFormat_value = map[string]int32{
+		"export_csv":   0,
+		"export_jsonl": 1,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_e_product_e_product_v1_proto_enumTypes[6].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_e_product_e_product_v1_proto_enumTypes[6]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{6}
+}
+
+type ActivationStatus int32
+
+const (
+	ActivationStatus_activation_pending    ActivationStatus = 0
+	ActivationStatus_activation_processing ActivationStatus = 1
+	ActivationStatus_activation_completed  ActivationStatus = 2
+	ActivationStatus_activation_failed     ActivationStatus = 3
+)
+
+// Enum value maps for ActivationStatus.
+var (
+	ActivationStatus_name = map[int32]string{
+		0: "activation_pending",
+		1: "activation_processing",
+		2: "activation_completed",
+		3: "activation_failed",
+	}
+	ActivationStatus_value = map[string]int32{
+		"activation_pending":    0,
+		"activation_processing": 1,
+		"activation_completed":  2,
+		"activation_failed":     3,
+	}
+)
+
+func (x ActivationStatus) Enum() *ActivationStatus {
+	p := new(ActivationStatus)
+	*p = x
+	return p
+}
+
+func (x ActivationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ActivationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_e_product_e_product_v1_proto_enumTypes[7].Descriptor()
+}
+
+func (ActivationStatus) Type() protoreflect.EnumType {
+	return &file_e_product_e_product_v1_proto_enumTypes[7]
+}
+
+func (x ActivationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ActivationStatus.Descriptor instead.
+func (ActivationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{7}
+}
+
+type ReservationStatus int32
+
+const (
+	ReservationStatus_reservation_active    ReservationStatus = 0
+	ReservationStatus_reservation_confirmed ReservationStatus = 1
+	ReservationStatus_reservation_released  ReservationStatus = 2
+	ReservationStatus_reservation_expired   ReservationStatus = 3
+)
+
+// Enum value maps for ReservationStatus.
+var (
+	ReservationStatus_name = map[int32]string{
+		0: "reservation_active",
+		1: "reservation_confirmed",
+		2: "reservation_released",
+		3: "reservation_expired",
+	}
+	ReservationStatus_value = map[string]int32{
+		"reservation_active":    0,
+		"reservation_confirmed": 1,
+		"reservation_released":  2,
+		"reservation_expired":   3,
+	}
+)
+
+func (x ReservationStatus) Enum() *ReservationStatus {
+	p := new(ReservationStatus)
+	*p = x
+	return p
+}
+
+func (x ReservationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReservationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_e_product_e_product_v1_proto_enumTypes[8].Descriptor()
+}
+
+func (ReservationStatus) Type() protoreflect.EnumType {
+	return &file_e_product_e_product_v1_proto_enumTypes[8]
+}
+
+func (x ReservationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReservationStatus.Descriptor instead.
+func (ReservationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{8}
+}
+
+// ProviderBreaker
+type ProviderBreakerState int32
+
+const (
+	ProviderBreakerState_breaker_closed    ProviderBreakerState = 0
+	ProviderBreakerState_breaker_open      ProviderBreakerState = 1
+	ProviderBreakerState_breaker_half_open ProviderBreakerState = 2 // пропускается один пробный вызов
+)
+
+// Enum value maps for ProviderBreakerState.
+var (
+	ProviderBreakerState_name = map[int32]string{
+		0: "breaker_closed",
+		1: "breaker_open",
+		2: "breaker_half_open",
+	}
+	ProviderBreakerState_value = map[string]int32{
+		"breaker_closed":    0,
+		"breaker_open":      1,
+		"breaker_half_open": 2,
+	}
+)
+
+func (x ProviderBreakerState) Enum() *ProviderBreakerState {
+	p := new(ProviderBreakerState)
+	*p = x
+	return p
+}
+
+func (x ProviderBreakerState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProviderBreakerState) Descriptor() protoreflect.EnumDescriptor {
+	return file_e_product_e_product_v1_proto_enumTypes[9].Descriptor()
+}
+
+func (ProviderBreakerState) Type() protoreflect.EnumType {
+	return &file_e_product_e_product_v1_proto_enumTypes[9]
+}
+
+func (x ProviderBreakerState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProviderBreakerState.Descriptor instead.
+func (ProviderBreakerState) EnumDescriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{9}
+}
+
+type WebhookDeliveryStatus int32
+
+const (
+	WebhookDeliveryStatus_delivery_pending   WebhookDeliveryStatus = 0
+	WebhookDeliveryStatus_delivery_delivered WebhookDeliveryStatus = 1
+	WebhookDeliveryStatus_delivery_dead      WebhookDeliveryStatus = 2
+)
+
+// Enum value maps for WebhookDeliveryStatus.
+var (
+	WebhookDeliveryStatus_name = map[int32]string{
+		0: "delivery_pending",
+		1: "delivery_delivered",
+		2: "delivery_dead",
+	}
+	WebhookDeliveryStatus_value = map[string]int32{
+		"delivery_pending":   0,
+		"delivery_delivered": 1,
+		"delivery_dead":      2,
+	}
+)
+
+func (x WebhookDeliveryStatus) Enum() *WebhookDeliveryStatus {
+	p := new(WebhookDeliveryStatus)
+	*p = x
+	return p
+}
+
+func (x WebhookDeliveryStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookDeliveryStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_e_product_e_product_v1_proto_enumTypes[10].Descriptor()
+}
+
+func (WebhookDeliveryStatus) Type() protoreflect.EnumType {
+	return &file_e_product_e_product_v1_proto_enumTypes[10]
+}
+
+func (x WebhookDeliveryStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookDeliveryStatus.Descriptor instead.
+func (WebhookDeliveryStatus) EnumDescriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{10}
+}
+
+// Load
+type KeyItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyItem) Reset() {
+	*x = KeyItem{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyItem) ProtoMessage() {}
+
+func (x *KeyItem) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyItem.ProtoReflect.Descriptor instead.
+func (*KeyItem) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *KeyItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *KeyItem) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type LoadKeyReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*KeyItem             `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Mode          LoadMode               `protobuf:"varint,2,opt,name=mode,proto3,enum=e_product_v1.LoadMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoadKeyReq) Reset() {
+	*x = LoadKeyReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadKeyReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadKeyReq) ProtoMessage() {}
+
+func (x *LoadKeyReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadKeyReq.ProtoReflect.Descriptor instead.
+func (*LoadKeyReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LoadKeyReq) GetKeys() []*KeyItem {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *LoadKeyReq) GetMode() LoadMode {
+	if x != nil {
+		return x.Mode
+	}
+	return LoadMode_best_effort
+}
+
+type LoadKeyItemRep struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Index             int64                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // позиция в LoadKeyReq.keys
+	ProductId         string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Result            LoadItemResult         `protobuf:"varint,3,opt,name=result,proto3,enum=e_product_v1.LoadItemResult" json:"result,omitempty"`
+	Id                string                 `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
+	ExistingProductId string                 `protobuf:"bytes,5,opt,name=existing_product_id,json=existingProductId,proto3" json:"existing_product_id,omitempty"`
+	Reason            string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *LoadKeyItemRep) Reset() {
+	*x = LoadKeyItemRep{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadKeyItemRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadKeyItemRep) ProtoMessage() {}
+
+func (x *LoadKeyItemRep) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadKeyItemRep.ProtoReflect.Descriptor instead.
+func (*LoadKeyItemRep) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LoadKeyItemRep) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *LoadKeyItemRep) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *LoadKeyItemRep) GetResult() LoadItemResult {
+	if x != nil {
+		return x.Result
+	}
+	return LoadItemResult_created
+}
+
+func (x *LoadKeyItemRep) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LoadKeyItemRep) GetExistingProductId() string {
+	if x != nil {
+		return x.ExistingProductId
+	}
+	return ""
+}
+
+func (x *LoadKeyItemRep) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type LoadKeyRep struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Items          []*LoadKeyItemRep      `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	CreatedCount   int64                  `protobuf:"varint,2,opt,name=created_count,json=createdCount,proto3" json:"created_count,omitempty"`
+	DuplicateCount int64                  `protobuf:"varint,3,opt,name=duplicate_count,json=duplicateCount,proto3" json:"duplicate_count,omitempty"`
+	InvalidCount   int64                  `protobuf:"varint,4,opt,name=invalid_count,json=invalidCount,proto3" json:"invalid_count,omitempty"`
+	SkippedCount   int64                  `protobuf:"varint,5,opt,name=skipped_count,json=skippedCount,proto3" json:"skipped_count,omitempty"`
+	FailedCount    int64                  `protobuf:"varint,6,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LoadKeyRep) Reset() {
+	*x = LoadKeyRep{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadKeyRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadKeyRep) ProtoMessage() {}
+
+func (x *LoadKeyRep) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadKeyRep.ProtoReflect.Descriptor instead.
+func (*LoadKeyRep) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LoadKeyRep) GetItems() []*LoadKeyItemRep {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *LoadKeyRep) GetCreatedCount() int64 {
+	if x != nil {
+		return x.CreatedCount
+	}
+	return 0
+}
+
+func (x *LoadKeyRep) GetDuplicateCount() int64 {
+	if x != nil {
+		return x.DuplicateCount
+	}
+	return 0
+}
+
+func (x *LoadKeyRep) GetInvalidCount() int64 {
+	if x != nil {
+		return x.InvalidCount
+	}
+	return 0
+}
+
+func (x *LoadKeyRep) GetSkippedCount() int64 {
+	if x != nil {
+		return x.SkippedCount
+	}
+	return 0
+}
+
+func (x *LoadKeyRep) GetFailedCount() int64 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+// Колонка задается названием из заголовка, номером (с 1) или буквой, как в Excel
+type ImportColumnMapping struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ProductIdColumn  string                 `protobuf:"bytes,1,opt,name=product_id_column,json=productIdColumn,proto3" json:"product_id_column,omitempty"`
+	ValueColumn      string                 `protobuf:"bytes,2,opt,name=value_column,json=valueColumn,proto3" json:"value_column,omitempty"`
+	HasHeader        bool                   `protobuf:"varint,3,opt,name=has_header,json=hasHeader,proto3" json:"has_header,omitempty"`
+	DefaultProductId string                 `protobuf:"bytes,4,opt,name=default_product_id,json=defaultProductId,proto3" json:"default_product_id,omitempty"` // если колонка product_id не задана или пуста
+	Sheet            string                 `protobuf:"bytes,5,opt,name=sheet,proto3" json:"sheet,omitempty"`                                                 // xlsx, по умолчанию первый лист
+	Delimiter        string                 `protobuf:"bytes,6,opt,name=delimiter,proto3" json:"delimiter,omitempty"`                                         // csv, по умолчанию ","
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ImportColumnMapping) Reset() {
+	*x = ImportColumnMapping{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportColumnMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportColumnMapping) ProtoMessage() {}
+
+func (x *ImportColumnMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportColumnMapping.ProtoReflect.Descriptor instead.
+func (*ImportColumnMapping) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ImportColumnMapping) GetProductIdColumn() string {
+	if x != nil {
+		return x.ProductIdColumn
+	}
+	return ""
+}
+
+func (x *ImportColumnMapping) GetValueColumn() string {
+	if x != nil {
+		return x.ValueColumn
+	}
+	return ""
+}
+
+func (x *ImportColumnMapping) GetHasHeader() bool {
+	if x != nil {
+		return x.HasHeader
+	}
+	return false
+}
+
+func (x *ImportColumnMapping) GetDefaultProductId() string {
+	if x != nil {
+		return x.DefaultProductId
+	}
+	return ""
+}
+
+func (x *ImportColumnMapping) GetSheet() string {
+	if x != nil {
+		return x.Sheet
+	}
+	return ""
+}
+
+func (x *ImportColumnMapping) GetDelimiter() string {
+	if x != nil {
+		return x.Delimiter
+	}
+	return ""
+}
+
+type ImportKeysHeader struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileName      string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Format        ImportFormat           `protobuf:"varint,2,opt,name=format,proto3,enum=e_product_v1.ImportFormat" json:"format,omitempty"`
+	Mapping       *ImportColumnMapping   `protobuf:"bytes,3,opt,name=mapping,proto3" json:"mapping,omitempty"`
+	Mode          LoadMode               `protobuf:"varint,4,opt,name=mode,proto3,enum=e_product_v1.LoadMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportKeysHeader) Reset() {
+	*x = ImportKeysHeader{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportKeysHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportKeysHeader) ProtoMessage() {}
+
+func (x *ImportKeysHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportKeysHeader.ProtoReflect.Descriptor instead.
+func (*ImportKeysHeader) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ImportKeysHeader) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ImportKeysHeader) GetFormat() ImportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ImportFormat_import_format_auto
+}
+
+func (x *ImportKeysHeader) GetMapping() *ImportColumnMapping {
+	if x != nil {
+		return x.Mapping
+	}
+	return nil
+}
+
+func (x *ImportKeysHeader) GetMode() LoadMode {
+	if x != nil {
+		return x.Mode
+	}
+	return LoadMode_best_effort
+}
+
+type ImportKeysReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ImportKeysReq_Header
+	//	*ImportKeysReq_Chunk
+	Payload       isImportKeysReq_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportKeysReq) Reset() {
+	*x = ImportKeysReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportKeysReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportKeysReq) ProtoMessage() {}
+
+func (x *ImportKeysReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportKeysReq.ProtoReflect.Descriptor instead.
+func (*ImportKeysReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ImportKeysReq) GetPayload() isImportKeysReq_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ImportKeysReq) GetHeader() *ImportKeysHeader {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportKeysReq_Header); ok {
+			return x.Header
+		}
+	}
+	return nil
+}
+
+func (x *ImportKeysReq) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportKeysReq_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isImportKeysReq_Payload interface {
+	isImportKeysReq_Payload()
+}
+
+type ImportKeysReq_Header struct {
+	Header *ImportKeysHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type ImportKeysReq_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*ImportKeysReq_Header) isImportKeysReq_Payload() {}
+
+func (*ImportKeysReq_Chunk) isImportKeysReq_Payload() {}
+
+type ImportJobItem struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Row               int64                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"` // номер строки в файле, с 1
+	ProductId         string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Result            LoadItemResult         `protobuf:"varint,3,opt,name=result,proto3,enum=e_product_v1.LoadItemResult" json:"result,omitempty"`
+	Id                string                 `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
+	ExistingProductId string                 `protobuf:"bytes,5,opt,name=existing_product_id,json=existingProductId,proto3" json:"existing_product_id,omitempty"`
+	Reason            string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ImportJobItem) Reset() {
+	*x = ImportJobItem{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportJobItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportJobItem) ProtoMessage() {}
+
+func (x *ImportJobItem) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportJobItem.ProtoReflect.Descriptor instead.
+func (*ImportJobItem) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ImportJobItem) GetRow() int64 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportJobItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ImportJobItem) GetResult() LoadItemResult {
+	if x != nil {
+		return x.Result
+	}
+	return LoadItemResult_created
+}
+
+func (x *ImportJobItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ImportJobItem) GetExistingProductId() string {
+	if x != nil {
+		return x.ExistingProductId
+	}
+	return ""
+}
+
+func (x *ImportJobItem) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ImportJob struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	FileName       string                 `protobuf:"bytes,4,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Format         ImportFormat           `protobuf:"varint,5,opt,name=format,proto3,enum=e_product_v1.ImportFormat" json:"format,omitempty"`
+	Mode           LoadMode               `protobuf:"varint,6,opt,name=mode,proto3,enum=e_product_v1.LoadMode" json:"mode,omitempty"`
+	Status         ImportJobStatus        `protobuf:"varint,7,opt,name=status,proto3,enum=e_product_v1.ImportJobStatus" json:"status,omitempty"`
+	TotalCount     int64                  `protobuf:"varint,8,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	CreatedCount   int64                  `protobuf:"varint,9,opt,name=created_count,json=createdCount,proto3" json:"created_count,omitempty"`
+	DuplicateCount int64                  `protobuf:"varint,10,opt,name=duplicate_count,json=duplicateCount,proto3" json:"duplicate_count,omitempty"`
+	InvalidCount   int64                  `protobuf:"varint,11,opt,name=invalid_count,json=invalidCount,proto3" json:"invalid_count,omitempty"`
+	SkippedCount   int64                  `protobuf:"varint,12,opt,name=skipped_count,json=skippedCount,proto3" json:"skipped_count,omitempty"`
+	FailedCount    int64                  `protobuf:"varint,13,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+	Error          string                 `protobuf:"bytes,14,opt,name=error,proto3" json:"error,omitempty"`
+	Items          []*ImportJobItem       `protobuf:"bytes,15,rep,name=items,proto3" json:"items,omitempty"` // только в GetImportJob и ImportKeys
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ImportJob) Reset() {
+	*x = ImportJob{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportJob) ProtoMessage() {}
+
+func (x *ImportJob) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportJob.ProtoReflect.Descriptor instead.
+func (*ImportJob) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ImportJob) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ImportJob) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ImportJob) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *ImportJob) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ImportJob) GetFormat() ImportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ImportFormat_import_format_auto
+}
+
+func (x *ImportJob) GetMode() LoadMode {
+	if x != nil {
+		return x.Mode
+	}
+	return LoadMode_best_effort
+}
+
+func (x *ImportJob) GetStatus() ImportJobStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ImportJobStatus_import_running
+}
+
+func (x *ImportJob) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ImportJob) GetCreatedCount() int64 {
+	if x != nil {
+		return x.CreatedCount
+	}
+	return 0
+}
+
+func (x *ImportJob) GetDuplicateCount() int64 {
+	if x != nil {
+		return x.DuplicateCount
+	}
+	return 0
+}
+
+func (x *ImportJob) GetInvalidCount() int64 {
+	if x != nil {
+		return x.InvalidCount
+	}
+	return 0
+}
+
+func (x *ImportJob) GetSkippedCount() int64 {
+	if x != nil {
+		return x.SkippedCount
+	}
+	return 0
+}
+
+func (x *ImportJob) GetFailedCount() int64 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+func (x *ImportJob) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ImportJob) GetItems() []*ImportJobItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ImportJobGetReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportJobGetReq) Reset() {
+	*x = ImportJobGetReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportJobGetReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportJobGetReq) ProtoMessage() {}
+
+func (x *ImportJobGetReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportJobGetReq.ProtoReflect.Descriptor instead.
+func (*ImportJobGetReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ImportJobGetReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ImportJobListReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *ImportJobStatus       `protobuf:"varint,1,opt,name=status,proto3,enum=e_product_v1.ImportJobStatus,oneof" json:"status,omitempty"`
+	ListParams    *common.ListParamsSt   `protobuf:"bytes,2,opt,name=list_params,json=listParams,proto3" json:"list_params,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportJobListReq) Reset() {
+	*x = ImportJobListReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportJobListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportJobListReq) ProtoMessage() {}
+
+func (x *ImportJobListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportJobListReq.ProtoReflect.Descriptor instead.
+func (*ImportJobListReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ImportJobListReq) GetStatus() ImportJobStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ImportJobStatus_import_running
+}
+
+func (x *ImportJobListReq) GetListParams() *common.ListParamsSt {
+	if x != nil {
+		return x.ListParams
+	}
+	return nil
+}
+
+type ImportJobListRep struct {
+	state          protoimpl.MessageState   `protogen:"open.v1"`
+	Jobs           []*ImportJob             `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	PaginationInfo *common.PaginationInfoSt `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ImportJobListRep) Reset() {
+	*x = ImportJobListRep{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportJobListRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportJobListRep) ProtoMessage() {}
+
+func (x *ImportJobListRep) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportJobListRep.ProtoReflect.Descriptor instead.
+func (*ImportJobListRep) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ImportJobListRep) GetJobs() []*ImportJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *ImportJobListRep) GetPaginationInfo() *common.PaginationInfoSt {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
+type KeyResponseItem struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProviderId        string                 `protobuf:"bytes,2,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	ProductId         string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CustomerPhone     string                 `protobuf:"bytes,6,opt,name=customer_phone,json=customerPhone,proto3" json:"customer_phone,omitempty"`
+	Status            KeyStatus              `protobuf:"varint,7,opt,name=status,proto3,enum=e_product_v1.KeyStatus" json:"status,omitempty"`
+	OrderId           string                 `protobuf:"bytes,8,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ProviderProductId string                 `protobuf:"bytes,9,opt,name=provider_product_id,json=providerProductId,proto3" json:"provider_product_id,omitempty"`
+	ProviderOrderId   string                 `protobuf:"bytes,10,opt,name=provider_order_id,json=providerOrderId,proto3" json:"provider_order_id,omitempty"`
+	MaskedValue       string                 `protobuf:"bytes,11,opt,name=masked_value,json=maskedValue,proto3" json:"masked_value,omitempty"` // видны только последние символы ключа
+	Link              string                 `protobuf:"bytes,12,opt,name=link,proto3" json:"link,omitempty"`
+	Instructions      string                 `protobuf:"bytes,13,opt,name=instructions,proto3" json:"instructions,omitempty"`
+	LicenseTerm       string                 `protobuf:"bytes,14,opt,name=license_term,json=licenseTerm,proto3" json:"license_term,omitempty"`
+	Source            KeySource              `protobuf:"varint,15,opt,name=source,proto3,enum=e_product_v1.KeySource" json:"source,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *KeyResponseItem) Reset() {
+	*x = KeyResponseItem{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyResponseItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyResponseItem) ProtoMessage() {}
+
+func (x *KeyResponseItem) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyResponseItem.ProtoReflect.Descriptor instead.
+func (*KeyResponseItem) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *KeyResponseItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *KeyResponseItem) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *KeyResponseItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *KeyResponseItem) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *KeyResponseItem) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *KeyResponseItem) GetCustomerPhone() string {
+	if x != nil {
+		return x.CustomerPhone
+	}
+	return ""
+}
+
+func (x *KeyResponseItem) GetStatus() KeyStatus {
+	if x != nil {
+		return x.Status
+	}
+	return KeyStatus_new
+}
+
+func (x *KeyResponseItem) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *KeyResponseItem) GetProviderProductId() string {
+	if x != nil {
+		return x.ProviderProductId
+	}
+	return ""
+}
+
+func (x *KeyResponseItem) GetProviderOrderId() string {
+	if x != nil {
+		return x.ProviderOrderId
+	}
+	return ""
+}
+
+func (x *KeyResponseItem) GetMaskedValue() string {
+	if x != nil {
+		return x.MaskedValue
+	}
+	return ""
+}
+
+func (x *KeyResponseItem) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *KeyResponseItem) GetInstructions() string {
+	if x != nil {
+		return x.Instructions
+	}
+	return ""
+}
+
+func (x *KeyResponseItem) GetLicenseTerm() string {
+	if x != nil {
+		return x.LicenseTerm
+	}
+	return ""
+}
+
+func (x *KeyResponseItem) GetSource() KeySource {
+	if x != nil {
+		return x.Source
+	}
+	return KeySource_source_pool
+}
+
+// List
+type KeyListReq struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId  *string                `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3,oneof" json:"provider_id,omitempty"`
+	Status      *KeyStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=e_product_v1.KeyStatus,oneof" json:"status,omitempty"`
+	OrderId     *string                `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3,oneof" json:"order_id,omitempty"`
+	ProductId   *string                `protobuf:"bytes,4,opt,name=product_id,json=productId,proto3,oneof" json:"product_id,omitempty"`
+	ListParams  *common.ListParamsSt   `protobuf:"bytes,5,opt,name=list_params,json=listParams,proto3" json:"list_params,omitempty"`
+	UpdatedFrom *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_from,json=updatedFrom,proto3" json:"updated_from,omitempty"` // updated_at >= updated_from
+	UpdatedTo   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_to,json=updatedTo,proto3" json:"updated_to,omitempty"`       // updated_at < updated_to
+	// keyset-пагинация вместо list_params.page: пустая строка - первая страница, далее next_cursor из ответа.
+	// Сортировка по created_at, id, total_count не считается
+	Cursor        *string                `protobuf:"bytes,8,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	CustomerPhone *string                `protobuf:"bytes,9,opt,name=customer_phone,json=customerPhone,proto3,oneof" json:"customer_phone,omitempty"`
+	Value         *string                `protobuf:"bytes,10,opt,name=value,proto3,oneof" json:"value,omitempty"`                                     // точное совпадение значения ключа
+	Statuses      []KeyStatus            `protobuf:"varint,11,rep,packed,name=statuses,proto3,enum=e_product_v1.KeyStatus" json:"statuses,omitempty"` // любой из, не применяется при заданном status
+	ProductIds    []string               `protobuf:"bytes,12,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`               // любой из, не применяется при заданном product_id
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`            // created_at >= created_from
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`                  // created_at < created_to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyListReq) Reset() {
+	*x = KeyListReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyListReq) ProtoMessage() {}
+
+func (x *KeyListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyListReq.ProtoReflect.Descriptor instead.
+func (*KeyListReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *KeyListReq) GetProviderId() string {
+	if x != nil && x.ProviderId != nil {
+		return *x.ProviderId
+	}
+	return ""
+}
+
+func (x *KeyListReq) GetStatus() KeyStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return KeyStatus_new
+}
+
+func (x *KeyListReq) GetOrderId() string {
+	if x != nil && x.OrderId != nil {
+		return *x.OrderId
+	}
+	return ""
+}
+
+func (x *KeyListReq) GetProductId() string {
+	if x != nil && x.ProductId != nil {
+		return *x.ProductId
+	}
+	return ""
+}
+
+func (x *KeyListReq) GetListParams() *common.ListParamsSt {
+	if x != nil {
+		return x.ListParams
+	}
+	return nil
+}
+
+func (x *KeyListReq) GetUpdatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedFrom
+	}
+	return nil
+}
+
+func (x *KeyListReq) GetUpdatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedTo
+	}
+	return nil
+}
+
+func (x *KeyListReq) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *KeyListReq) GetCustomerPhone() string {
+	if x != nil && x.CustomerPhone != nil {
+		return *x.CustomerPhone
+	}
+	return ""
+}
+
+func (x *KeyListReq) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return ""
+}
+
+func (x *KeyListReq) GetStatuses() []KeyStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *KeyListReq) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *KeyListReq) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *KeyListReq) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+type KeyListRep struct {
+	state          protoimpl.MessageState   `protogen:"open.v1"`
+	Keys           []*KeyResponseItem       `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	PaginationInfo *common.PaginationInfoSt `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	NextCursor     string                   `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // пусто - страниц больше нет или запрос без cursor
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *KeyListRep) Reset() {
+	*x = KeyListRep{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyListRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyListRep) ProtoMessage() {}
+
+func (x *KeyListRep) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyListRep.ProtoReflect.Descriptor instead.
+func (*KeyListRep) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *KeyListRep) GetKeys() []*KeyResponseItem {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *KeyListRep) GetPaginationInfo() *common.PaginationInfoSt {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
+func (x *KeyListRep) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// Get
+type KeyGetReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyGetReq) Reset() {
+	*x = KeyGetReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyGetReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyGetReq) ProtoMessage() {}
+
+func (x *KeyGetReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyGetReq.ProtoReflect.Descriptor instead.
+func (*KeyGetReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *KeyGetReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// History
+type KeyHistoryReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyHistoryReq) Reset() {
+	*x = KeyHistoryReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyHistoryReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyHistoryReq) ProtoMessage() {}
+
+func (x *KeyHistoryReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyHistoryReq.ProtoReflect.Descriptor instead.
+func (*KeyHistoryReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *KeyHistoryReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type KeyEvent struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt             *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	FromStatus            KeyStatus              `protobuf:"varint,3,opt,name=from_status,json=fromStatus,proto3,enum=e_product_v1.KeyStatus" json:"from_status,omitempty"`
+	ToStatus              KeyStatus              `protobuf:"varint,4,opt,name=to_status,json=toStatus,proto3,enum=e_product_v1.KeyStatus" json:"to_status,omitempty"`
+	Actor                 string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"` // инициатор изменения, system - фоновые задачи
+	OrderId               string                 `protobuf:"bytes,6,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ProviderTransactionId string                 `protobuf:"bytes,7,opt,name=provider_transaction_id,json=providerTransactionId,proto3" json:"provider_transaction_id,omitempty"`
+	Reason                string                 `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *KeyEvent) Reset() {
+	*x = KeyEvent{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyEvent) ProtoMessage() {}
+
+func (x *KeyEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyEvent.ProtoReflect.Descriptor instead.
+func (*KeyEvent) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *KeyEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *KeyEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *KeyEvent) GetFromStatus() KeyStatus {
+	if x != nil {
+		return x.FromStatus
+	}
+	return KeyStatus_new
+}
+
+func (x *KeyEvent) GetToStatus() KeyStatus {
+	if x != nil {
+		return x.ToStatus
+	}
+	return KeyStatus_new
+}
+
+func (x *KeyEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *KeyEvent) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *KeyEvent) GetProviderTransactionId() string {
+	if x != nil {
+		return x.ProviderTransactionId
+	}
+	return ""
+}
+
+func (x *KeyEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type KeyHistoryRep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*KeyEvent            `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyHistoryRep) Reset() {
+	*x = KeyHistoryRep{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyHistoryRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyHistoryRep) ProtoMessage() {}
+
+func (x *KeyHistoryRep) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyHistoryRep.ProtoReflect.Descriptor instead.
+func (*KeyHistoryRep) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *KeyHistoryRep) GetEvents() []*KeyEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// RevealValue
+type KeyRevealValueReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // обращение клиента, номер тикета
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyRevealValueReq) Reset() {
+	*x = KeyRevealValueReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyRevealValueReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRevealValueReq) ProtoMessage() {}
+
+func (x *KeyRevealValueReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRevealValueReq.ProtoReflect.Descriptor instead.
+func (*KeyRevealValueReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *KeyRevealValueReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *KeyRevealValueReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type KeyRevealValueRep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyRevealValueRep) Reset() {
+	*x = KeyRevealValueRep{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyRevealValueRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRevealValueRep) ProtoMessage() {}
+
+func (x *KeyRevealValueRep) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRevealValueRep.ProtoReflect.Descriptor instead.
+func (*KeyRevealValueRep) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *KeyRevealValueRep) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type KeyExportReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *KeyListReq            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"` // list_params не применяются
+	Format        ExportFormat           `protobuf:"varint,2,opt,name=format,proto3,enum=e_product_v1.ExportFormat" json:"format,omitempty"`
+	RevealValue   bool                   `protobuf:"varint,4,opt,name=reveal_value,json=revealValue,proto3" json:"reveal_value,omitempty"` // открытые значения вместо маскированных, только support/admin, пишется в журнал
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyExportReq) Reset() {
+	*x = KeyExportReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyExportReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyExportReq) ProtoMessage() {}
+
+func (x *KeyExportReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyExportReq.ProtoReflect.Descriptor instead.
+func (*KeyExportReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *KeyExportReq) GetFilter() *KeyListReq {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *KeyExportReq) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_export_csv
+}
+
+func (x *KeyExportReq) GetRevealValue() bool {
+	if x != nil {
+		return x.RevealValue
+	}
+	return false
+}
+
+type KeyExportChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyExportChunk) Reset() {
+	*x = KeyExportChunk{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyExportChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyExportChunk) ProtoMessage() {}
+
+func (x *KeyExportChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyExportChunk.ProtoReflect.Descriptor instead.
+func (*KeyExportChunk) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *KeyExportChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// InventoryReport
+type KeyInventoryReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    *string                `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3,oneof" json:"provider_id,omitempty"`
+	Status        *KeyStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=e_product_v1.KeyStatus,oneof" json:"status,omitempty"`
+	ProductId     *string                `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3,oneof" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyInventoryReq) Reset() {
+	*x = KeyInventoryReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyInventoryReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyInventoryReq) ProtoMessage() {}
+
+func (x *KeyInventoryReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyInventoryReq.ProtoReflect.Descriptor instead.
+func (*KeyInventoryReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *KeyInventoryReq) GetProviderId() string {
+	if x != nil && x.ProviderId != nil {
+		return *x.ProviderId
+	}
+	return ""
+}
+
+func (x *KeyInventoryReq) GetStatus() KeyStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return KeyStatus_new
+}
+
+func (x *KeyInventoryReq) GetProductId() string {
+	if x != nil && x.ProductId != nil {
+		return *x.ProductId
+	}
+	return ""
+}
+
+type KeyInventoryItem struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProductId       string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProviderId      string                 `protobuf:"bytes,2,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	Status          KeyStatus              `protobuf:"varint,3,opt,name=status,proto3,enum=e_product_v1.KeyStatus" json:"status,omitempty"`
+	Count           int64                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	AgeDay          int64                  `protobuf:"varint,5,opt,name=age_day,json=ageDay,proto3" json:"age_day,omitempty"`             // до суток
+	AgeWeek         int64                  `protobuf:"varint,6,opt,name=age_week,json=ageWeek,proto3" json:"age_week,omitempty"`          // от суток до 7 дней
+	AgeMonth        int64                  `protobuf:"varint,7,opt,name=age_month,json=ageMonth,proto3" json:"age_month,omitempty"`       // от 7 до 30 дней
+	AgeQuarter      int64                  `protobuf:"varint,8,opt,name=age_quarter,json=ageQuarter,proto3" json:"age_quarter,omitempty"` // от 30 до 90 дней
+	AgeOlder        int64                  `protobuf:"varint,9,opt,name=age_older,json=ageOlder,proto3" json:"age_older,omitempty"`       // старше 90 дней
+	OldestCreatedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=oldest_created_at,json=oldestCreatedAt,proto3" json:"oldest_created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *KeyInventoryItem) Reset() {
+	*x = KeyInventoryItem{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyInventoryItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyInventoryItem) ProtoMessage() {}
+
+func (x *KeyInventoryItem) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyInventoryItem.ProtoReflect.Descriptor instead.
+func (*KeyInventoryItem) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *KeyInventoryItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *KeyInventoryItem) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *KeyInventoryItem) GetStatus() KeyStatus {
+	if x != nil {
+		return x.Status
+	}
+	return KeyStatus_new
+}
+
+func (x *KeyInventoryItem) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *KeyInventoryItem) GetAgeDay() int64 {
+	if x != nil {
+		return x.AgeDay
+	}
+	return 0
+}
+
+func (x *KeyInventoryItem) GetAgeWeek() int64 {
+	if x != nil {
+		return x.AgeWeek
+	}
+	return 0
+}
+
+func (x *KeyInventoryItem) GetAgeMonth() int64 {
+	if x != nil {
+		return x.AgeMonth
+	}
+	return 0
+}
+
+func (x *KeyInventoryItem) GetAgeQuarter() int64 {
+	if x != nil {
+		return x.AgeQuarter
+	}
+	return 0
+}
+
+func (x *KeyInventoryItem) GetAgeOlder() int64 {
+	if x != nil {
+		return x.AgeOlder
+	}
+	return 0
+}
+
+func (x *KeyInventoryItem) GetOldestCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OldestCreatedAt
+	}
+	return nil
+}
+
+type KeyInventoryRep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*KeyInventoryItem    `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyInventoryRep) Reset() {
+	*x = KeyInventoryRep{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyInventoryRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyInventoryRep) ProtoMessage() {}
+
+func (x *KeyInventoryRep) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyInventoryRep.ProtoReflect.Descriptor instead.
+func (*KeyInventoryRep) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *KeyInventoryRep) GetItems() []*KeyInventoryItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// ListByCustomer
+type KeyListByCustomerReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerPhone string                 `protobuf:"bytes,1,opt,name=customer_phone,json=customerPhone,proto3" json:"customer_phone,omitempty"`
+	ListParams    *common.ListParamsSt   `protobuf:"bytes,2,opt,name=list_params,json=listParams,proto3" json:"list_params,omitempty"` // по умолчанию сортировка -created_at
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyListByCustomerReq) Reset() {
+	*x = KeyListByCustomerReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyListByCustomerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyListByCustomerReq) ProtoMessage() {}
+
+func (x *KeyListByCustomerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyListByCustomerReq.ProtoReflect.Descriptor instead.
+func (*KeyListByCustomerReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *KeyListByCustomerReq) GetCustomerPhone() string {
+	if x != nil {
+		return x.CustomerPhone
+	}
+	return ""
+}
+
+func (x *KeyListByCustomerReq) GetListParams() *common.ListParamsSt {
+	if x != nil {
+		return x.ListParams
+	}
+	return nil
+}
+
+type KeyListByCustomerRep struct {
+	state          protoimpl.MessageState   `protogen:"open.v1"`
+	Keys           []*KeyResponseItem       `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	PaginationInfo *common.PaginationInfoSt `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *KeyListByCustomerRep) Reset() {
+	*x = KeyListByCustomerRep{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyListByCustomerRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyListByCustomerRep) ProtoMessage() {}
+
+func (x *KeyListByCustomerRep) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyListByCustomerRep.ProtoReflect.Descriptor instead.
+func (*KeyListByCustomerRep) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *KeyListByCustomerRep) GetKeys() []*KeyResponseItem {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *KeyListByCustomerRep) GetPaginationInfo() *common.PaginationInfoSt {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
+type KeyActivateReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	CustomerPhone string                 `protobuf:"bytes,2,opt,name=customer_phone,json=customerPhone,proto3" json:"customer_phone,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Async         bool                   `protobuf:"varint,4,opt,name=async,proto3" json:"async,omitempty"` // true - ответ сразу со статусом pending, результат через GetActivation или callback
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyActivateReq) Reset() {
+	*x = KeyActivateReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyActivateReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyActivateReq) ProtoMessage() {}
+
+func (x *KeyActivateReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyActivateReq.ProtoReflect.Descriptor instead.
+func (*KeyActivateReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *KeyActivateReq) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *KeyActivateReq) GetCustomerPhone() string {
+	if x != nil {
+		return x.CustomerPhone
+	}
+	return ""
+}
+
+func (x *KeyActivateReq) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *KeyActivateReq) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+type KeyActivateRep struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Value           string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Link            string                 `protobuf:"bytes,2,opt,name=link,proto3" json:"link,omitempty"`                 // ссылка на скачивание от провайдера
+	Instructions    string                 `protobuf:"bytes,3,opt,name=instructions,proto3" json:"instructions,omitempty"` // инструкция по активации
+	LicenseTerm     string                 `protobuf:"bytes,4,opt,name=license_term,json=licenseTerm,proto3" json:"license_term,omitempty"`
+	ProviderOrderId string                 `protobuf:"bytes,5,opt,name=provider_order_id,json=providerOrderId,proto3" json:"provider_order_id,omitempty"`
+	ActivationId    string                 `protobuf:"bytes,6,opt,name=activation_id,json=activationId,proto3" json:"activation_id,omitempty"` // только для async
+	Status          ActivationStatus       `protobuf:"varint,7,opt,name=status,proto3,enum=e_product_v1.ActivationStatus" json:"status,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *KeyActivateRep) Reset() {
+	*x = KeyActivateRep{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyActivateRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyActivateRep) ProtoMessage() {}
+
+func (x *KeyActivateRep) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyActivateRep.ProtoReflect.Descriptor instead.
+func (*KeyActivateRep) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *KeyActivateRep) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *KeyActivateRep) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *KeyActivateRep) GetInstructions() string {
+	if x != nil {
+		return x.Instructions
+	}
+	return ""
+}
+
+func (x *KeyActivateRep) GetLicenseTerm() string {
+	if x != nil {
+		return x.LicenseTerm
+	}
+	return ""
+}
+
+func (x *KeyActivateRep) GetProviderOrderId() string {
+	if x != nil {
+		return x.ProviderOrderId
+	}
+	return ""
+}
+
+func (x *KeyActivateRep) GetActivationId() string {
+	if x != nil {
+		return x.ActivationId
+	}
+	return ""
+}
+
+func (x *KeyActivateRep) GetStatus() ActivationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ActivationStatus_activation_pending
+}
+
+type KeyActivationGetReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyActivationGetReq) Reset() {
+	*x = KeyActivationGetReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyActivationGetReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyActivationGetReq) ProtoMessage() {}
+
+func (x *KeyActivationGetReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyActivationGetReq.ProtoReflect.Descriptor instead.
+func (*KeyActivationGetReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *KeyActivationGetReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type KeyActivation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Status        ActivationStatus       `protobuf:"varint,4,opt,name=status,proto3,enum=e_product_v1.ActivationStatus" json:"status,omitempty"`
+	ProductId     string                 `protobuf:"bytes,5,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,6,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"` // причина для failed
+	Key           *KeyActivateRep        `protobuf:"bytes,8,opt,name=key,proto3" json:"key,omitempty"`     // для completed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyActivation) Reset() {
+	*x = KeyActivation{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyActivation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyActivation) ProtoMessage() {}
+
+func (x *KeyActivation) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyActivation.ProtoReflect.Descriptor instead.
+func (*KeyActivation) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *KeyActivation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *KeyActivation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *KeyActivation) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *KeyActivation) GetStatus() ActivationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ActivationStatus_activation_pending
+}
+
+func (x *KeyActivation) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *KeyActivation) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *KeyActivation) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *KeyActivation) GetKey() *KeyActivateRep {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+// Reserve
+type KeyReserveReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyReserveReq) Reset() {
+	*x = KeyReserveReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyReserveReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyReserveReq) ProtoMessage() {}
+
+func (x *KeyReserveReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyReserveReq.ProtoReflect.Descriptor instead.
+func (*KeyReserveReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *KeyReserveReq) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *KeyReserveReq) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type KeyReservation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        ReservationStatus      `protobuf:"varint,4,opt,name=status,proto3,enum=e_product_v1.ReservationStatus" json:"status,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	FromPool      bool                   `protobuf:"varint,6,opt,name=from_pool,json=fromPool,proto3" json:"from_pool,omitempty"` // false - ключ будет куплен у провайдера при Confirm
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyReservation) Reset() {
+	*x = KeyReservation{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyReservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyReservation) ProtoMessage() {}
+
+func (x *KeyReservation) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyReservation.ProtoReflect.Descriptor instead.
+func (*KeyReservation) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *KeyReservation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *KeyReservation) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *KeyReservation) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *KeyReservation) GetStatus() ReservationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReservationStatus_reservation_active
+}
+
+func (x *KeyReservation) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *KeyReservation) GetFromPool() bool {
+	if x != nil {
+		return x.FromPool
+	}
+	return false
+}
+
+type KeyConfirmReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	CustomerPhone string                 `protobuf:"bytes,2,opt,name=customer_phone,json=customerPhone,proto3" json:"customer_phone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyConfirmReq) Reset() {
+	*x = KeyConfirmReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyConfirmReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyConfirmReq) ProtoMessage() {}
+
+func (x *KeyConfirmReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyConfirmReq.ProtoReflect.Descriptor instead.
+func (*KeyConfirmReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *KeyConfirmReq) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *KeyConfirmReq) GetCustomerPhone() string {
+	if x != nil {
+		return x.CustomerPhone
+	}
+	return ""
+}
+
+type KeyReleaseReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyReleaseReq) Reset() {
+	*x = KeyReleaseReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyReleaseReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyReleaseReq) ProtoMessage() {}
+
+func (x *KeyReleaseReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyReleaseReq.ProtoReflect.Descriptor instead.
+func (*KeyReleaseReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *KeyReleaseReq) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type KeyReleaseRep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyReleaseRep) Reset() {
+	*x = KeyReleaseRep{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyReleaseRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyReleaseRep) ProtoMessage() {}
+
+func (x *KeyReleaseRep) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyReleaseRep.ProtoReflect.Descriptor instead.
+func (*KeyReleaseRep) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{36}
+}
+
+type KeyCancelReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`                        // сохраняется в журнале ключа
+	ProductId     string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"` // обязателен, если по заказу выдано несколько ключей
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyCancelReq) Reset() {
+	*x = KeyCancelReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyCancelReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyCancelReq) ProtoMessage() {}
+
+func (x *KeyCancelReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyCancelReq.ProtoReflect.Descriptor instead.
+func (*KeyCancelReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *KeyCancelReq) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *KeyCancelReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *KeyCancelReq) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type KeyCancelRep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyCancelRep) Reset() {
+	*x = KeyCancelRep{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyCancelRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyCancelRep) ProtoMessage() {}
+
+func (x *KeyCancelRep) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyCancelRep.ProtoReflect.Descriptor instead.
+func (*KeyCancelRep) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *KeyCancelRep) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// PoolLevel
+type PoolLevel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	MinLevel      int64                  `protobuf:"varint,4,opt,name=min_level,json=minLevel,proto3" json:"min_level,omitempty"`
+	TargetLevel   int64                  `protobuf:"varint,5,opt,name=target_level,json=targetLevel,proto3" json:"target_level,omitempty"`
+	DailyCap      int64                  `protobuf:"varint,6,opt,name=daily_cap,json=dailyCap,proto3" json:"daily_cap,omitempty"` // лимит покупок на пополнение в сутки, 0 - без ограничения
+	Available     int64                  `protobuf:"varint,7,opt,name=available,proto3" json:"available,omitempty"`               // свободных ключей в пуле
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoolLevel) Reset() {
+	*x = PoolLevel{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolLevel) ProtoMessage() {}
+
+func (x *PoolLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolLevel.ProtoReflect.Descriptor instead.
+func (*PoolLevel) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PoolLevel) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *PoolLevel) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PoolLevel) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *PoolLevel) GetMinLevel() int64 {
+	if x != nil {
+		return x.MinLevel
+	}
+	return 0
+}
+
+func (x *PoolLevel) GetTargetLevel() int64 {
+	if x != nil {
+		return x.TargetLevel
+	}
+	return 0
+}
+
+func (x *PoolLevel) GetDailyCap() int64 {
+	if x != nil {
+		return x.DailyCap
+	}
+	return 0
+}
+
+func (x *PoolLevel) GetAvailable() int64 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+type PoolLevelListReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ListParams    *common.ListParamsSt   `protobuf:"bytes,1,opt,name=list_params,json=listParams,proto3" json:"list_params,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoolLevelListReq) Reset() {
+	*x = PoolLevelListReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolLevelListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolLevelListReq) ProtoMessage() {}
+
+func (x *PoolLevelListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolLevelListReq.ProtoReflect.Descriptor instead.
+func (*PoolLevelListReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *PoolLevelListReq) GetListParams() *common.ListParamsSt {
+	if x != nil {
+		return x.ListParams
+	}
+	return nil
+}
+
+type PoolLevelListRep struct {
+	state          protoimpl.MessageState   `protogen:"open.v1"`
+	Levels         []*PoolLevel             `protobuf:"bytes,1,rep,name=levels,proto3" json:"levels,omitempty"`
+	PaginationInfo *common.PaginationInfoSt `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PoolLevelListRep) Reset() {
+	*x = PoolLevelListRep{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolLevelListRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolLevelListRep) ProtoMessage() {}
+
+func (x *PoolLevelListRep) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolLevelListRep.ProtoReflect.Descriptor instead.
+func (*PoolLevelListRep) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PoolLevelListRep) GetLevels() []*PoolLevel {
+	if x != nil {
+		return x.Levels
+	}
+	return nil
+}
+
+func (x *PoolLevelListRep) GetPaginationInfo() *common.PaginationInfoSt {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
+type PoolLevelSetReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	MinLevel      int64                  `protobuf:"varint,2,opt,name=min_level,json=minLevel,proto3" json:"min_level,omitempty"`
+	TargetLevel   int64                  `protobuf:"varint,3,opt,name=target_level,json=targetLevel,proto3" json:"target_level,omitempty"`
+	DailyCap      int64                  `protobuf:"varint,4,opt,name=daily_cap,json=dailyCap,proto3" json:"daily_cap,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoolLevelSetReq) Reset() {
+	*x = PoolLevelSetReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolLevelSetReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolLevelSetReq) ProtoMessage() {}
+
+func (x *PoolLevelSetReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolLevelSetReq.ProtoReflect.Descriptor instead.
+func (*PoolLevelSetReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *PoolLevelSetReq) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *PoolLevelSetReq) GetMinLevel() int64 {
+	if x != nil {
+		return x.MinLevel
+	}
+	return 0
+}
+
+func (x *PoolLevelSetReq) GetTargetLevel() int64 {
+	if x != nil {
+		return x.TargetLevel
+	}
+	return 0
+}
+
+func (x *PoolLevelSetReq) GetDailyCap() int64 {
+	if x != nil {
+		return x.DailyCap
+	}
+	return 0
+}
+
+type PoolLevelDeleteReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoolLevelDeleteReq) Reset() {
+	*x = PoolLevelDeleteReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolLevelDeleteReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolLevelDeleteReq) ProtoMessage() {}
+
+func (x *PoolLevelDeleteReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolLevelDeleteReq.ProtoReflect.Descriptor instead.
+func (*PoolLevelDeleteReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *PoolLevelDeleteReq) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type PoolLevelDeleteRep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoolLevelDeleteRep) Reset() {
+	*x = PoolLevelDeleteRep{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolLevelDeleteRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolLevelDeleteRep) ProtoMessage() {}
+
+func (x *PoolLevelDeleteRep) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolLevelDeleteRep.ProtoReflect.Descriptor instead.
+func (*PoolLevelDeleteRep) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{44}
+}
+
+// ProductProvider
+type ProductProvider struct {
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId                string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	Priority                  int64                  `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"`
+	ProviderProductId         string                 `protobuf:"bytes,3,opt,name=provider_product_id,json=providerProductId,proto3" json:"provider_product_id,omitempty"`
+	ProviderExternalProductId string                 `protobuf:"bytes,4,opt,name=provider_external_product_id,json=providerExternalProductId,proto3" json:"provider_external_product_id,omitempty"`
+	PromotionKey              string                 `protobuf:"bytes,5,opt,name=promotion_key,json=promotionKey,proto3" json:"promotion_key,omitempty"`
+	UpdatedAt                 *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *ProductProvider) Reset() {
+	*x = ProductProvider{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductProvider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductProvider) ProtoMessage() {}
+
+func (x *ProductProvider) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductProvider.ProtoReflect.Descriptor instead.
+func (*ProductProvider) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ProductProvider) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *ProductProvider) GetPriority() int64 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *ProductProvider) GetProviderProductId() string {
+	if x != nil {
+		return x.ProviderProductId
+	}
+	return ""
+}
+
+func (x *ProductProvider) GetProviderExternalProductId() string {
+	if x != nil {
+		return x.ProviderExternalProductId
+	}
+	return ""
+}
+
+func (x *ProductProvider) GetPromotionKey() string {
+	if x != nil {
+		return x.PromotionKey
+	}
+	return ""
+}
+
+func (x *ProductProvider) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ProductProviderListReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductProviderListReq) Reset() {
+	*x = ProductProviderListReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductProviderListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductProviderListReq) ProtoMessage() {}
+
+func (x *ProductProviderListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductProviderListReq.ProtoReflect.Descriptor instead.
+func (*ProductProviderListReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ProductProviderListReq) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type ProductProviderListRep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Providers     []*ProductProvider     `protobuf:"bytes,2,rep,name=providers,proto3" json:"providers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductProviderListRep) Reset() {
+	*x = ProductProviderListRep{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductProviderListRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductProviderListRep) ProtoMessage() {}
+
+func (x *ProductProviderListRep) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductProviderListRep.ProtoReflect.Descriptor instead.
+func (*ProductProviderListRep) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ProductProviderListRep) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductProviderListRep) GetProviders() []*ProductProvider {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+type ProductProviderSetReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Providers     []*ProductProvider     `protobuf:"bytes,2,rep,name=providers,proto3" json:"providers,omitempty"` // priority и updated_at игнорируются
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductProviderSetReq) Reset() {
+	*x = ProductProviderSetReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductProviderSetReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductProviderSetReq) ProtoMessage() {}
+
+func (x *ProductProviderSetReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductProviderSetReq.ProtoReflect.Descriptor instead.
+func (*ProductProviderSetReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ProductProviderSetReq) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductProviderSetReq) GetProviders() []*ProductProvider {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+type GetCatalogReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCatalogReq) Reset() {
+	*x = GetCatalogReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCatalogReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCatalogReq) ProtoMessage() {}
+
+func (x *GetCatalogReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCatalogReq.ProtoReflect.Descriptor instead.
+func (*GetCatalogReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetCatalogReq) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+type GetCatalogRep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*CatalogItem         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCatalogRep) Reset() {
+	*x = GetCatalogRep{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCatalogRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCatalogRep) ProtoMessage() {}
+
+func (x *GetCatalogRep) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCatalogRep.ProtoReflect.Descriptor instead.
+func (*GetCatalogRep) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetCatalogRep) GetItems() []*CatalogItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type CatalogItem struct {
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	ProviderProductId         string                 `protobuf:"bytes,1,opt,name=provider_product_id,json=providerProductId,proto3" json:"provider_product_id,omitempty"`
+	ProviderExternalProductId string                 `protobuf:"bytes,2,opt,name=provider_external_product_id,json=providerExternalProductId,proto3" json:"provider_external_product_id,omitempty"`
+	Name                      string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Desc                      string                 `protobuf:"bytes,4,opt,name=desc,proto3" json:"desc,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *CatalogItem) Reset() {
+	*x = CatalogItem{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CatalogItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CatalogItem) ProtoMessage() {}
+
+func (x *CatalogItem) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CatalogItem.ProtoReflect.Descriptor instead.
+func (*CatalogItem) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *CatalogItem) GetProviderProductId() string {
+	if x != nil {
+		return x.ProviderProductId
+	}
+	return ""
+}
+
+func (x *CatalogItem) GetProviderExternalProductId() string {
+	if x != nil {
+		return x.ProviderExternalProductId
+	}
+	return ""
+}
+
+func (x *CatalogItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CatalogItem) GetDesc() string {
+	if x != nil {
+		return x.Desc
+	}
+	return ""
+}
+
+type ProviderBreaker struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	State         ProviderBreakerState   `protobuf:"varint,2,opt,name=state,proto3,enum=e_product_v1.ProviderBreakerState" json:"state,omitempty"`
+	Failures      int64                  `protobuf:"varint,3,opt,name=failures,proto3" json:"failures,omitempty"` // неудачных вызовов подряд
+	OpenedAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=opened_at,json=openedAt,proto3" json:"opened_at,omitempty"`
+	RetryAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=retry_at,json=retryAt,proto3" json:"retry_at,omitempty"` // после этого времени пропускается пробный вызов
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProviderBreaker) Reset() {
+	*x = ProviderBreaker{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProviderBreaker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderBreaker) ProtoMessage() {}
+
+func (x *ProviderBreaker) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderBreaker.ProtoReflect.Descriptor instead.
+func (*ProviderBreaker) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ProviderBreaker) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *ProviderBreaker) GetState() ProviderBreakerState {
+	if x != nil {
+		return x.State
+	}
+	return ProviderBreakerState_breaker_closed
+}
+
+func (x *ProviderBreaker) GetFailures() int64 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *ProviderBreaker) GetOpenedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OpenedAt
+	}
+	return nil
+}
+
+func (x *ProviderBreaker) GetRetryAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RetryAt
+	}
+	return nil
+}
+
+type ProviderBreakerListReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProviderBreakerListReq) Reset() {
+	*x = ProviderBreakerListReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (KeyStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_e_product_e_product_v1_proto_enumTypes[0].Descriptor()
+func (x *ProviderBreakerListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (KeyStatus) Type() protoreflect.EnumType {
-	return &file_e_product_e_product_v1_proto_enumTypes[0]
-}
+func (*ProviderBreakerListReq) ProtoMessage() {}
 
-func (x KeyStatus) Number() protoreflect.EnumNumber {
-	return protoreflect.EnumNumber(x)
+func (x *ProviderBreakerListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyStatus.Descriptor instead.
-func (KeyStatus) EnumDescriptor() ([]byte, []int) {
-	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use ProviderBreakerListReq.ProtoReflect.Descriptor instead.
+func (*ProviderBreakerListReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{53}
 }
 
-// Load
-type KeyItem struct {
+type ProviderBreakerListRep struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Breakers      []*ProviderBreaker     `protobuf:"bytes,1,rep,name=breakers,proto3" json:"breakers,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *KeyItem) Reset() {
-	*x = KeyItem{}
-	mi := &file_e_product_e_product_v1_proto_msgTypes[0]
+func (x *ProviderBreakerListRep) Reset() {
+	*x = ProviderBreakerListRep{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *KeyItem) String() string {
+func (x *ProviderBreakerListRep) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyItem) ProtoMessage() {}
+func (*ProviderBreakerListRep) ProtoMessage() {}
 
-func (x *KeyItem) ProtoReflect() protoreflect.Message {
-	mi := &file_e_product_e_product_v1_proto_msgTypes[0]
+func (x *ProviderBreakerListRep) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -108,47 +4154,40 @@ func (x *KeyItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyItem.ProtoReflect.Descriptor instead.
-func (*KeyItem) Descriptor() ([]byte, []int) {
-	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{0}
-}
-
-func (x *KeyItem) GetProductId() string {
-	if x != nil {
-		return x.ProductId
-	}
-	return ""
+// Deprecated: Use ProviderBreakerListRep.ProtoReflect.Descriptor instead.
+func (*ProviderBreakerListRep) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *KeyItem) GetValue() string {
+func (x *ProviderBreakerListRep) GetBreakers() []*ProviderBreaker {
 	if x != nil {
-		return x.Value
+		return x.Breakers
 	}
-	return ""
+	return nil
 }
 
-type LoadKeyReq struct {
+type ProviderBreakerResetReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Keys          []*KeyItem             `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LoadKeyReq) Reset() {
-	*x = LoadKeyReq{}
-	mi := &file_e_product_e_product_v1_proto_msgTypes[1]
+func (x *ProviderBreakerResetReq) Reset() {
+	*x = ProviderBreakerResetReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LoadKeyReq) String() string {
+func (x *ProviderBreakerResetReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoadKeyReq) ProtoMessage() {}
+func (*ProviderBreakerResetReq) ProtoMessage() {}
 
-func (x *LoadKeyReq) ProtoReflect() protoreflect.Message {
-	mi := &file_e_product_e_product_v1_proto_msgTypes[1]
+func (x *ProviderBreakerResetReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -159,49 +4198,48 @@ func (x *LoadKeyReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoadKeyReq.ProtoReflect.Descriptor instead.
-func (*LoadKeyReq) Descriptor() ([]byte, []int) {
-	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use ProviderBreakerResetReq.ProtoReflect.Descriptor instead.
+func (*ProviderBreakerResetReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *LoadKeyReq) GetKeys() []*KeyItem {
+func (x *ProviderBreakerResetReq) GetProviderId() string {
 	if x != nil {
-		return x.Keys
+		return x.ProviderId
 	}
-	return nil
+	return ""
 }
 
-type KeyResponseItem struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ProviderId        string                 `protobuf:"bytes,2,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
-	ProductId         string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	CustomerPhone     string                 `protobuf:"bytes,6,opt,name=customer_phone,json=customerPhone,proto3" json:"customer_phone,omitempty"`
-	Status            KeyStatus              `protobuf:"varint,7,opt,name=status,proto3,enum=e_product_v1.KeyStatus" json:"status,omitempty"`
-	OrderId           string                 `protobuf:"bytes,8,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	ProviderProductId string                 `protobuf:"bytes,9,opt,name=provider_product_id,json=providerProductId,proto3" json:"provider_product_id,omitempty"`
-	ProviderOrderId   string                 `protobuf:"bytes,10,opt,name=provider_order_id,json=providerOrderId,proto3" json:"provider_order_id,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+// Webhook
+type WebhookSubscription struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Url           string                 `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	Events        []string               `protobuf:"bytes,6,rep,name=events,proto3" json:"events,omitempty"`
+	Active        bool                   `protobuf:"varint,7,opt,name=active,proto3" json:"active,omitempty"`
+	Secret        string                 `protobuf:"bytes,8,opt,name=secret,proto3" json:"secret,omitempty"` // только в ответе CreateSubscription
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *KeyResponseItem) Reset() {
-	*x = KeyResponseItem{}
-	mi := &file_e_product_e_product_v1_proto_msgTypes[2]
+func (x *WebhookSubscription) Reset() {
+	*x = WebhookSubscription{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *KeyResponseItem) String() string {
+func (x *WebhookSubscription) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyResponseItem) ProtoMessage() {}
+func (*WebhookSubscription) ProtoMessage() {}
 
-func (x *KeyResponseItem) ProtoReflect() protoreflect.Message {
-	mi := &file_e_product_e_product_v1_proto_msgTypes[2]
+func (x *WebhookSubscription) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -212,108 +4250,90 @@ func (x *KeyResponseItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyResponseItem.ProtoReflect.Descriptor instead.
-func (*KeyResponseItem) Descriptor() ([]byte, []int) {
-	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use WebhookSubscription.ProtoReflect.Descriptor instead.
+func (*WebhookSubscription) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *KeyResponseItem) GetId() string {
+func (x *WebhookSubscription) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *KeyResponseItem) GetProviderId() string {
-	if x != nil {
-		return x.ProviderId
-	}
-	return ""
-}
-
-func (x *KeyResponseItem) GetProductId() string {
-	if x != nil {
-		return x.ProductId
-	}
-	return ""
-}
-
-func (x *KeyResponseItem) GetCreatedAt() *timestamppb.Timestamp {
+func (x *WebhookSubscription) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return nil
 }
 
-func (x *KeyResponseItem) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *WebhookSubscription) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
 		return x.UpdatedAt
 	}
 	return nil
 }
 
-func (x *KeyResponseItem) GetCustomerPhone() string {
+func (x *WebhookSubscription) GetName() string {
 	if x != nil {
-		return x.CustomerPhone
+		return x.Name
 	}
 	return ""
 }
 
-func (x *KeyResponseItem) GetStatus() KeyStatus {
+func (x *WebhookSubscription) GetUrl() string {
 	if x != nil {
-		return x.Status
+		return x.Url
 	}
-	return KeyStatus_new
+	return ""
 }
 
-func (x *KeyResponseItem) GetOrderId() string {
+func (x *WebhookSubscription) GetEvents() []string {
 	if x != nil {
-		return x.OrderId
+		return x.Events
 	}
-	return ""
+	return nil
 }
 
-func (x *KeyResponseItem) GetProviderProductId() string {
+func (x *WebhookSubscription) GetActive() bool {
 	if x != nil {
-		return x.ProviderProductId
+		return x.Active
 	}
-	return ""
+	return false
 }
 
-func (x *KeyResponseItem) GetProviderOrderId() string {
+func (x *WebhookSubscription) GetSecret() string {
 	if x != nil {
-		return x.ProviderOrderId
+		return x.Secret
 	}
 	return ""
 }
 
-// List
-type KeyListReq struct {
+type WebhookSubscriptionListReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProviderId    *string                `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3,oneof" json:"provider_id,omitempty"`
-	Status        *KeyStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=e_product_v1.KeyStatus,oneof" json:"status,omitempty"`
-	OrderId       *string                `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3,oneof" json:"order_id,omitempty"`
-	ProductId     *string                `protobuf:"bytes,4,opt,name=product_id,json=productId,proto3,oneof" json:"product_id,omitempty"`
-	ListParams    *common.ListParamsSt   `protobuf:"bytes,5,opt,name=list_params,json=listParams,proto3" json:"list_params,omitempty"`
+	ListParams    *common.ListParamsSt   `protobuf:"bytes,1,opt,name=list_params,json=listParams,proto3" json:"list_params,omitempty"`
+	Active        *bool                  `protobuf:"varint,2,opt,name=active,proto3,oneof" json:"active,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *KeyListReq) Reset() {
-	*x = KeyListReq{}
-	mi := &file_e_product_e_product_v1_proto_msgTypes[3]
+func (x *WebhookSubscriptionListReq) Reset() {
+	*x = WebhookSubscriptionListReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *KeyListReq) String() string {
+func (x *WebhookSubscriptionListReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyListReq) ProtoMessage() {}
+func (*WebhookSubscriptionListReq) ProtoMessage() {}
 
-func (x *KeyListReq) ProtoReflect() protoreflect.Message {
-	mi := &file_e_product_e_product_v1_proto_msgTypes[3]
+func (x *WebhookSubscriptionListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -324,69 +4344,48 @@ func (x *KeyListReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyListReq.ProtoReflect.Descriptor instead.
-func (*KeyListReq) Descriptor() ([]byte, []int) {
-	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{3}
-}
-
-func (x *KeyListReq) GetProviderId() string {
-	if x != nil && x.ProviderId != nil {
-		return *x.ProviderId
-	}
-	return ""
-}
-
-func (x *KeyListReq) GetStatus() KeyStatus {
-	if x != nil && x.Status != nil {
-		return *x.Status
-	}
-	return KeyStatus_new
-}
-
-func (x *KeyListReq) GetOrderId() string {
-	if x != nil && x.OrderId != nil {
-		return *x.OrderId
-	}
-	return ""
-}
-
-func (x *KeyListReq) GetProductId() string {
-	if x != nil && x.ProductId != nil {
-		return *x.ProductId
-	}
-	return ""
+// Deprecated: Use WebhookSubscriptionListReq.ProtoReflect.Descriptor instead.
+func (*WebhookSubscriptionListReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *KeyListReq) GetListParams() *common.ListParamsSt {
+func (x *WebhookSubscriptionListReq) GetListParams() *common.ListParamsSt {
 	if x != nil {
 		return x.ListParams
 	}
 	return nil
 }
 
-type KeyListRep struct {
+func (x *WebhookSubscriptionListReq) GetActive() bool {
+	if x != nil && x.Active != nil {
+		return *x.Active
+	}
+	return false
+}
+
+type WebhookSubscriptionListRep struct {
 	state          protoimpl.MessageState   `protogen:"open.v1"`
-	Keys           []*KeyResponseItem       `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Subscriptions  []*WebhookSubscription   `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
 	PaginationInfo *common.PaginationInfoSt `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
-func (x *KeyListRep) Reset() {
-	*x = KeyListRep{}
-	mi := &file_e_product_e_product_v1_proto_msgTypes[4]
+func (x *WebhookSubscriptionListRep) Reset() {
+	*x = WebhookSubscriptionListRep{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *KeyListRep) String() string {
+func (x *WebhookSubscriptionListRep) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyListRep) ProtoMessage() {}
+func (*WebhookSubscriptionListRep) ProtoMessage() {}
 
-func (x *KeyListRep) ProtoReflect() protoreflect.Message {
-	mi := &file_e_product_e_product_v1_proto_msgTypes[4]
+func (x *WebhookSubscriptionListRep) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -397,48 +4396,51 @@ func (x *KeyListRep) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyListRep.ProtoReflect.Descriptor instead.
-func (*KeyListRep) Descriptor() ([]byte, []int) {
-	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use WebhookSubscriptionListRep.ProtoReflect.Descriptor instead.
+func (*WebhookSubscriptionListRep) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *KeyListRep) GetKeys() []*KeyResponseItem {
+func (x *WebhookSubscriptionListRep) GetSubscriptions() []*WebhookSubscription {
 	if x != nil {
-		return x.Keys
+		return x.Subscriptions
 	}
 	return nil
 }
 
-func (x *KeyListRep) GetPaginationInfo() *common.PaginationInfoSt {
+func (x *WebhookSubscriptionListRep) GetPaginationInfo() *common.PaginationInfoSt {
 	if x != nil {
 		return x.PaginationInfo
 	}
 	return nil
 }
 
-// Get
-type KeyGetReq struct {
+type WebhookSubscriptionCreateReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Events        []string               `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	Secret        string                 `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	Active        *bool                  `protobuf:"varint,5,opt,name=active,proto3,oneof" json:"active,omitempty"` // по умолчанию true
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *KeyGetReq) Reset() {
-	*x = KeyGetReq{}
-	mi := &file_e_product_e_product_v1_proto_msgTypes[5]
+func (x *WebhookSubscriptionCreateReq) Reset() {
+	*x = WebhookSubscriptionCreateReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *KeyGetReq) String() string {
+func (x *WebhookSubscriptionCreateReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyGetReq) ProtoMessage() {}
+func (*WebhookSubscriptionCreateReq) ProtoMessage() {}
 
-func (x *KeyGetReq) ProtoReflect() protoreflect.Message {
-	mi := &file_e_product_e_product_v1_proto_msgTypes[5]
+func (x *WebhookSubscriptionCreateReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -449,42 +4451,73 @@ func (x *KeyGetReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyGetReq.ProtoReflect.Descriptor instead.
-func (*KeyGetReq) Descriptor() ([]byte, []int) {
-	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use WebhookSubscriptionCreateReq.ProtoReflect.Descriptor instead.
+func (*WebhookSubscriptionCreateReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *KeyGetReq) GetId() string {
+func (x *WebhookSubscriptionCreateReq) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
 	}
 	return ""
 }
 
-type KeyActivateReq struct {
+func (x *WebhookSubscriptionCreateReq) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookSubscriptionCreateReq) GetEvents() []string {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *WebhookSubscriptionCreateReq) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *WebhookSubscriptionCreateReq) GetActive() bool {
+	if x != nil && x.Active != nil {
+		return *x.Active
+	}
+	return false
+}
+
+type WebhookSubscriptionUpdateReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	CustomerPhone string                 `protobuf:"bytes,2,opt,name=customer_phone,json=customerPhone,proto3" json:"customer_phone,omitempty"`
-	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Url           *string                `protobuf:"bytes,3,opt,name=url,proto3,oneof" json:"url,omitempty"`
+	Events        []string               `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"` // пустой список не меняет события
+	Active        *bool                  `protobuf:"varint,5,opt,name=active,proto3,oneof" json:"active,omitempty"`
+	Secret        string                 `protobuf:"bytes,6,opt,name=secret,proto3" json:"secret,omitempty"` // пустой не меняет секрет
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *KeyActivateReq) Reset() {
-	*x = KeyActivateReq{}
-	mi := &file_e_product_e_product_v1_proto_msgTypes[6]
+func (x *WebhookSubscriptionUpdateReq) Reset() {
+	*x = WebhookSubscriptionUpdateReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *KeyActivateReq) String() string {
+func (x *WebhookSubscriptionUpdateReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyActivateReq) ProtoMessage() {}
+func (*WebhookSubscriptionUpdateReq) ProtoMessage() {}
 
-func (x *KeyActivateReq) ProtoReflect() protoreflect.Message {
-	mi := &file_e_product_e_product_v1_proto_msgTypes[6]
+func (x *WebhookSubscriptionUpdateReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -495,54 +4528,75 @@ func (x *KeyActivateReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyActivateReq.ProtoReflect.Descriptor instead.
-func (*KeyActivateReq) Descriptor() ([]byte, []int) {
-	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use WebhookSubscriptionUpdateReq.ProtoReflect.Descriptor instead.
+func (*WebhookSubscriptionUpdateReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *KeyActivateReq) GetProductId() string {
+func (x *WebhookSubscriptionUpdateReq) GetId() string {
 	if x != nil {
-		return x.ProductId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *KeyActivateReq) GetCustomerPhone() string {
+func (x *WebhookSubscriptionUpdateReq) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *WebhookSubscriptionUpdateReq) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
+	}
+	return ""
+}
+
+func (x *WebhookSubscriptionUpdateReq) GetEvents() []string {
 	if x != nil {
-		return x.CustomerPhone
+		return x.Events
+	}
+	return nil
+}
+
+func (x *WebhookSubscriptionUpdateReq) GetActive() bool {
+	if x != nil && x.Active != nil {
+		return *x.Active
 	}
-	return ""
+	return false
 }
 
-func (x *KeyActivateReq) GetOrderId() string {
+func (x *WebhookSubscriptionUpdateReq) GetSecret() string {
 	if x != nil {
-		return x.OrderId
+		return x.Secret
 	}
 	return ""
 }
 
-type KeyActivateRep struct {
+type WebhookSubscriptionDeleteReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *KeyActivateRep) Reset() {
-	*x = KeyActivateRep{}
-	mi := &file_e_product_e_product_v1_proto_msgTypes[7]
+func (x *WebhookSubscriptionDeleteReq) Reset() {
+	*x = WebhookSubscriptionDeleteReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *KeyActivateRep) String() string {
+func (x *WebhookSubscriptionDeleteReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyActivateRep) ProtoMessage() {}
+func (*WebhookSubscriptionDeleteReq) ProtoMessage() {}
 
-func (x *KeyActivateRep) ProtoReflect() protoreflect.Message {
-	mi := &file_e_product_e_product_v1_proto_msgTypes[7]
+func (x *WebhookSubscriptionDeleteReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -553,40 +4607,39 @@ func (x *KeyActivateRep) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyActivateRep.ProtoReflect.Descriptor instead.
-func (*KeyActivateRep) Descriptor() ([]byte, []int) {
-	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use WebhookSubscriptionDeleteReq.ProtoReflect.Descriptor instead.
+func (*WebhookSubscriptionDeleteReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *KeyActivateRep) GetValue() string {
+func (x *WebhookSubscriptionDeleteReq) GetId() string {
 	if x != nil {
-		return x.Value
+		return x.Id
 	}
 	return ""
 }
 
-type KeyCancelReq struct {
+type WebhookSubscriptionDeleteRep struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *KeyCancelReq) Reset() {
-	*x = KeyCancelReq{}
-	mi := &file_e_product_e_product_v1_proto_msgTypes[8]
+func (x *WebhookSubscriptionDeleteRep) Reset() {
+	*x = WebhookSubscriptionDeleteRep{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *KeyCancelReq) String() string {
+func (x *WebhookSubscriptionDeleteRep) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyCancelReq) ProtoMessage() {}
+func (*WebhookSubscriptionDeleteRep) ProtoMessage() {}
 
-func (x *KeyCancelReq) ProtoReflect() protoreflect.Message {
-	mi := &file_e_product_e_product_v1_proto_msgTypes[8]
+func (x *WebhookSubscriptionDeleteRep) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -597,40 +4650,44 @@ func (x *KeyCancelReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyCancelReq.ProtoReflect.Descriptor instead.
-func (*KeyCancelReq) Descriptor() ([]byte, []int) {
-	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{8}
-}
-
-func (x *KeyCancelReq) GetOrderId() string {
-	if x != nil {
-		return x.OrderId
-	}
-	return ""
+// Deprecated: Use WebhookSubscriptionDeleteRep.ProtoReflect.Descriptor instead.
+func (*WebhookSubscriptionDeleteRep) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{62}
 }
 
-type KeyCancelRep struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type WebhookDelivery struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	SubscriptionId string                 `protobuf:"bytes,4,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	Event          string                 `protobuf:"bytes,5,opt,name=event,proto3" json:"event,omitempty"`
+	Payload        string                 `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
+	Status         WebhookDeliveryStatus  `protobuf:"varint,7,opt,name=status,proto3,enum=e_product_v1.WebhookDeliveryStatus" json:"status,omitempty"`
+	Attempts       int64                  `protobuf:"varint,8,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	NextAttemptAt  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	LastError      string                 `protobuf:"bytes,10,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	ResponseStatus int64                  `protobuf:"varint,11,opt,name=response_status,json=responseStatus,proto3" json:"response_status,omitempty"`
+	DeliveredAt    *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *KeyCancelRep) Reset() {
-	*x = KeyCancelRep{}
-	mi := &file_e_product_e_product_v1_proto_msgTypes[9]
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *KeyCancelRep) String() string {
+func (x *WebhookDelivery) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyCancelRep) ProtoMessage() {}
+func (*WebhookDelivery) ProtoMessage() {}
 
-func (x *KeyCancelRep) ProtoReflect() protoreflect.Message {
-	mi := &file_e_product_e_product_v1_proto_msgTypes[9]
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -641,84 +4698,120 @@ func (x *KeyCancelRep) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyCancelRep.ProtoReflect.Descriptor instead.
-func (*KeyCancelRep) Descriptor() ([]byte, []int) {
-	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *KeyCancelRep) GetId() string {
+func (x *WebhookDelivery) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-type GetCatalogReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *WebhookDelivery) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
 }
 
-func (x *GetCatalogReq) Reset() {
-	*x = GetCatalogReq{}
-	mi := &file_e_product_e_product_v1_proto_msgTypes[10]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *WebhookDelivery) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
 }
 
-func (x *GetCatalogReq) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *WebhookDelivery) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
 }
 
-func (*GetCatalogReq) ProtoMessage() {}
+func (x *WebhookDelivery) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
 
-func (x *GetCatalogReq) ProtoReflect() protoreflect.Message {
-	mi := &file_e_product_e_product_v1_proto_msgTypes[10]
+func (x *WebhookDelivery) GetPayload() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Payload
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use GetCatalogReq.ProtoReflect.Descriptor instead.
-func (*GetCatalogReq) Descriptor() ([]byte, []int) {
-	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{10}
+func (x *WebhookDelivery) GetStatus() WebhookDeliveryStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WebhookDeliveryStatus_delivery_pending
 }
 
-func (x *GetCatalogReq) GetProviderId() string {
+func (x *WebhookDelivery) GetAttempts() int64 {
 	if x != nil {
-		return x.ProviderId
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
 	}
 	return ""
 }
 
-type GetCatalogRep struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Items         []*CatalogItem         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *WebhookDelivery) GetResponseStatus() int64 {
+	if x != nil {
+		return x.ResponseStatus
+	}
+	return 0
 }
 
-func (x *GetCatalogRep) Reset() {
-	*x = GetCatalogRep{}
-	mi := &file_e_product_e_product_v1_proto_msgTypes[11]
+func (x *WebhookDelivery) GetDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return nil
+}
+
+type WebhookDeliveryListReq struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ListParams     *common.ListParamsSt   `protobuf:"bytes,1,opt,name=list_params,json=listParams,proto3" json:"list_params,omitempty"`
+	SubscriptionId *string                `protobuf:"bytes,2,opt,name=subscription_id,json=subscriptionId,proto3,oneof" json:"subscription_id,omitempty"`
+	Event          *string                `protobuf:"bytes,3,opt,name=event,proto3,oneof" json:"event,omitempty"`
+	Status         *WebhookDeliveryStatus `protobuf:"varint,4,opt,name=status,proto3,enum=e_product_v1.WebhookDeliveryStatus,oneof" json:"status,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WebhookDeliveryListReq) Reset() {
+	*x = WebhookDeliveryListReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCatalogRep) String() string {
+func (x *WebhookDeliveryListReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCatalogRep) ProtoMessage() {}
+func (*WebhookDeliveryListReq) ProtoMessage() {}
 
-func (x *GetCatalogRep) ProtoReflect() protoreflect.Message {
-	mi := &file_e_product_e_product_v1_proto_msgTypes[11]
+func (x *WebhookDeliveryListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -729,43 +4822,62 @@ func (x *GetCatalogRep) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCatalogRep.ProtoReflect.Descriptor instead.
-func (*GetCatalogRep) Descriptor() ([]byte, []int) {
-	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use WebhookDeliveryListReq.ProtoReflect.Descriptor instead.
+func (*WebhookDeliveryListReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *GetCatalogRep) GetItems() []*CatalogItem {
+func (x *WebhookDeliveryListReq) GetListParams() *common.ListParamsSt {
 	if x != nil {
-		return x.Items
+		return x.ListParams
 	}
 	return nil
 }
 
-type CatalogItem struct {
-	state                     protoimpl.MessageState `protogen:"open.v1"`
-	ProviderProductId         string                 `protobuf:"bytes,1,opt,name=provider_product_id,json=providerProductId,proto3" json:"provider_product_id,omitempty"`
-	ProviderExternalProductId string                 `protobuf:"bytes,2,opt,name=provider_external_product_id,json=providerExternalProductId,proto3" json:"provider_external_product_id,omitempty"`
-	Name                      string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Desc                      string                 `protobuf:"bytes,4,opt,name=desc,proto3" json:"desc,omitempty"`
-	unknownFields             protoimpl.UnknownFields
-	sizeCache                 protoimpl.SizeCache
+func (x *WebhookDeliveryListReq) GetSubscriptionId() string {
+	if x != nil && x.SubscriptionId != nil {
+		return *x.SubscriptionId
+	}
+	return ""
 }
 
-func (x *CatalogItem) Reset() {
-	*x = CatalogItem{}
-	mi := &file_e_product_e_product_v1_proto_msgTypes[12]
+func (x *WebhookDeliveryListReq) GetEvent() string {
+	if x != nil && x.Event != nil {
+		return *x.Event
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryListReq) GetStatus() WebhookDeliveryStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return WebhookDeliveryStatus_delivery_pending
+}
+
+type WebhookDeliveryListRep struct {
+	state          protoimpl.MessageState   `protogen:"open.v1"`
+	Deliveries     []*WebhookDelivery       `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	PaginationInfo *common.PaginationInfoSt `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WebhookDeliveryListRep) Reset() {
+	*x = WebhookDeliveryListRep{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CatalogItem) String() string {
+func (x *WebhookDeliveryListRep) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CatalogItem) ProtoMessage() {}
+func (*WebhookDeliveryListRep) ProtoMessage() {}
 
-func (x *CatalogItem) ProtoReflect() protoreflect.Message {
-	mi := &file_e_product_e_product_v1_proto_msgTypes[12]
+func (x *WebhookDeliveryListRep) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -776,35 +4888,65 @@ func (x *CatalogItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CatalogItem.ProtoReflect.Descriptor instead.
-func (*CatalogItem) Descriptor() ([]byte, []int) {
-	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use WebhookDeliveryListRep.ProtoReflect.Descriptor instead.
+func (*WebhookDeliveryListRep) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *CatalogItem) GetProviderProductId() string {
+func (x *WebhookDeliveryListRep) GetDeliveries() []*WebhookDelivery {
 	if x != nil {
-		return x.ProviderProductId
+		return x.Deliveries
 	}
-	return ""
+	return nil
 }
 
-func (x *CatalogItem) GetProviderExternalProductId() string {
+func (x *WebhookDeliveryListRep) GetPaginationInfo() *common.PaginationInfoSt {
 	if x != nil {
-		return x.ProviderExternalProductId
+		return x.PaginationInfo
 	}
-	return ""
+	return nil
 }
 
-func (x *CatalogItem) GetName() string {
+type WebhookReplayReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookReplayReq) Reset() {
+	*x = WebhookReplayReq{}
+	mi := &file_e_product_e_product_v1_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookReplayReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookReplayReq) ProtoMessage() {}
+
+func (x *WebhookReplayReq) ProtoReflect() protoreflect.Message {
+	mi := &file_e_product_e_product_v1_proto_msgTypes[66]
 	if x != nil {
-		return x.Name
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *CatalogItem) GetDesc() string {
+// Deprecated: Use WebhookReplayReq.ProtoReflect.Descriptor instead.
+func (*WebhookReplayReq) Descriptor() ([]byte, []int) {
+	return file_e_product_e_product_v1_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *WebhookReplayReq) GetId() string {
 	if x != nil {
-		return x.Desc
+		return x.Id
 	}
 	return ""
 }
@@ -813,14 +4955,86 @@ var File_e_product_e_product_v1_proto protoreflect.FileDescriptor
 
 const file_e_product_e_product_v1_proto_rawDesc = "" +
 	"\n" +
-	"\x1ce_product/e_product_v1.proto\x12\fe_product_v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x13common/common.proto\">\n" +
+	"\x1ce_product/e_product_v1.proto\x12\fe_product_v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13common/common.proto\">\n" +
 	"\aKeyItem\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value\"7\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"c\n" +
 	"\n" +
 	"LoadKeyReq\x12)\n" +
-	"\x04keys\x18\x01 \x03(\v2\x15.e_product_v1.KeyItemR\x04keys\"\xa6\x03\n" +
+	"\x04keys\x18\x01 \x03(\v2\x15.e_product_v1.KeyItemR\x04keys\x12*\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x16.e_product_v1.LoadModeR\x04mode\"\xd3\x01\n" +
+	"\x0eLoadKeyItemRep\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x124\n" +
+	"\x06result\x18\x03 \x01(\x0e2\x1c.e_product_v1.LoadItemResultR\x06result\x12\x0e\n" +
+	"\x02id\x18\x04 \x01(\tR\x02id\x12.\n" +
+	"\x13existing_product_id\x18\x05 \x01(\tR\x11existingProductId\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\"\xfb\x01\n" +
+	"\n" +
+	"LoadKeyRep\x122\n" +
+	"\x05items\x18\x01 \x03(\v2\x1c.e_product_v1.LoadKeyItemRepR\x05items\x12#\n" +
+	"\rcreated_count\x18\x02 \x01(\x03R\fcreatedCount\x12'\n" +
+	"\x0fduplicate_count\x18\x03 \x01(\x03R\x0eduplicateCount\x12#\n" +
+	"\rinvalid_count\x18\x04 \x01(\x03R\finvalidCount\x12#\n" +
+	"\rskipped_count\x18\x05 \x01(\x03R\fskippedCount\x12!\n" +
+	"\ffailed_count\x18\x06 \x01(\x03R\vfailedCount\"\xe5\x01\n" +
+	"\x13ImportColumnMapping\x12*\n" +
+	"\x11product_id_column\x18\x01 \x01(\tR\x0fproductIdColumn\x12!\n" +
+	"\fvalue_column\x18\x02 \x01(\tR\vvalueColumn\x12\x1d\n" +
+	"\n" +
+	"has_header\x18\x03 \x01(\bR\thasHeader\x12,\n" +
+	"\x12default_product_id\x18\x04 \x01(\tR\x10defaultProductId\x12\x14\n" +
+	"\x05sheet\x18\x05 \x01(\tR\x05sheet\x12\x1c\n" +
+	"\tdelimiter\x18\x06 \x01(\tR\tdelimiter\"\xcc\x01\n" +
+	"\x10ImportKeysHeader\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x122\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x1a.e_product_v1.ImportFormatR\x06format\x12;\n" +
+	"\amapping\x18\x03 \x01(\v2!.e_product_v1.ImportColumnMappingR\amapping\x12*\n" +
+	"\x04mode\x18\x04 \x01(\x0e2\x16.e_product_v1.LoadModeR\x04mode\"l\n" +
+	"\rImportKeysReq\x128\n" +
+	"\x06header\x18\x01 \x01(\v2\x1e.e_product_v1.ImportKeysHeaderH\x00R\x06header\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"\xce\x01\n" +
+	"\rImportJobItem\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x03R\x03row\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x124\n" +
+	"\x06result\x18\x03 \x01(\x0e2\x1c.e_product_v1.LoadItemResultR\x06result\x12\x0e\n" +
+	"\x02id\x18\x04 \x01(\tR\x02id\x12.\n" +
+	"\x13existing_product_id\x18\x05 \x01(\tR\x11existingProductId\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\"\xea\x04\n" +
+	"\tImportJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1b\n" +
+	"\tfile_name\x18\x04 \x01(\tR\bfileName\x122\n" +
+	"\x06format\x18\x05 \x01(\x0e2\x1a.e_product_v1.ImportFormatR\x06format\x12*\n" +
+	"\x04mode\x18\x06 \x01(\x0e2\x16.e_product_v1.LoadModeR\x04mode\x125\n" +
+	"\x06status\x18\a \x01(\x0e2\x1d.e_product_v1.ImportJobStatusR\x06status\x12\x1f\n" +
+	"\vtotal_count\x18\b \x01(\x03R\n" +
+	"totalCount\x12#\n" +
+	"\rcreated_count\x18\t \x01(\x03R\fcreatedCount\x12'\n" +
+	"\x0fduplicate_count\x18\n" +
+	" \x01(\x03R\x0eduplicateCount\x12#\n" +
+	"\rinvalid_count\x18\v \x01(\x03R\finvalidCount\x12#\n" +
+	"\rskipped_count\x18\f \x01(\x03R\fskippedCount\x12!\n" +
+	"\ffailed_count\x18\r \x01(\x03R\vfailedCount\x12\x14\n" +
+	"\x05error\x18\x0e \x01(\tR\x05error\x121\n" +
+	"\x05items\x18\x0f \x03(\v2\x1b.e_product_v1.ImportJobItemR\x05items\"!\n" +
+	"\x0fImportJobGetReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x90\x01\n" +
+	"\x10ImportJobListReq\x12:\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x1d.e_product_v1.ImportJobStatusH\x00R\x06status\x88\x01\x01\x125\n" +
+	"\vlist_params\x18\x02 \x01(\v2\x14.common.ListParamsStR\n" +
+	"listParamsB\t\n" +
+	"\a_status\"\x82\x01\n" +
+	"\x10ImportJobListRep\x12+\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x17.e_product_v1.ImportJobR\x04jobs\x12A\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x18.common.PaginationInfoStR\x0epaginationInfo\"\xd5\x04\n" +
 	"\x0fKeyResponseItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
 	"\vprovider_id\x18\x02 \x01(\tR\n" +
@@ -836,7 +5050,12 @@ const file_e_product_e_product_v1_proto_rawDesc = "" +
 	"\border_id\x18\b \x01(\tR\aorderId\x12.\n" +
 	"\x13provider_product_id\x18\t \x01(\tR\x11providerProductId\x12*\n" +
 	"\x11provider_order_id\x18\n" +
-	" \x01(\tR\x0fproviderOrderId\"\x9a\x02\n" +
+	" \x01(\tR\x0fproviderOrderId\x12!\n" +
+	"\fmasked_value\x18\v \x01(\tR\vmaskedValue\x12\x12\n" +
+	"\x04link\x18\f \x01(\tR\x04link\x12\"\n" +
+	"\finstructions\x18\r \x01(\tR\finstructions\x12!\n" +
+	"\flicense_term\x18\x0e \x01(\tR\vlicenseTerm\x12/\n" +
+	"\x06source\x18\x0f \x01(\x0e2\x17.e_product_v1.KeySourceR\x06source\"\xf0\x05\n" +
 	"\n" +
 	"KeyListReq\x12$\n" +
 	"\vprovider_id\x18\x01 \x01(\tH\x00R\n" +
@@ -846,28 +5065,196 @@ const file_e_product_e_product_v1_proto_rawDesc = "" +
 	"\n" +
 	"product_id\x18\x04 \x01(\tH\x03R\tproductId\x88\x01\x01\x125\n" +
 	"\vlist_params\x18\x05 \x01(\v2\x14.common.ListParamsStR\n" +
-	"listParamsB\x0e\n" +
+	"listParams\x12=\n" +
+	"\fupdated_from\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vupdatedFrom\x129\n" +
+	"\n" +
+	"updated_to\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedTo\x12\x1b\n" +
+	"\x06cursor\x18\b \x01(\tH\x04R\x06cursor\x88\x01\x01\x12*\n" +
+	"\x0ecustomer_phone\x18\t \x01(\tH\x05R\rcustomerPhone\x88\x01\x01\x12\x19\n" +
+	"\x05value\x18\n" +
+	" \x01(\tH\x06R\x05value\x88\x01\x01\x123\n" +
+	"\bstatuses\x18\v \x03(\x0e2\x17.e_product_v1.KeyStatusR\bstatuses\x12\x1f\n" +
+	"\vproduct_ids\x18\f \x03(\tR\n" +
+	"productIds\x12=\n" +
+	"\fcreated_from\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedToB\x0e\n" +
 	"\f_provider_idB\t\n" +
 	"\a_statusB\v\n" +
 	"\t_order_idB\r\n" +
-	"\v_product_id\"\x82\x01\n" +
+	"\v_product_idB\t\n" +
+	"\a_cursorB\x11\n" +
+	"\x0f_customer_phoneB\b\n" +
+	"\x06_value\"\xa3\x01\n" +
 	"\n" +
 	"KeyListRep\x121\n" +
 	"\x04keys\x18\x01 \x03(\v2\x1d.e_product_v1.KeyResponseItemR\x04keys\x12A\n" +
-	"\x0fpagination_info\x18\x02 \x01(\v2\x18.common.PaginationInfoStR\x0epaginationInfo\"\x1b\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x18.common.PaginationInfoStR\x0epaginationInfo\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"\x1b\n" +
 	"\tKeyGetReq\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"q\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1f\n" +
+	"\rKeyHistoryReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xc6\x02\n" +
+	"\bKeyEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
+	"\vfrom_status\x18\x03 \x01(\x0e2\x17.e_product_v1.KeyStatusR\n" +
+	"fromStatus\x124\n" +
+	"\tto_status\x18\x04 \x01(\x0e2\x17.e_product_v1.KeyStatusR\btoStatus\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12\x19\n" +
+	"\border_id\x18\x06 \x01(\tR\aorderId\x126\n" +
+	"\x17provider_transaction_id\x18\a \x01(\tR\x15providerTransactionId\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\"?\n" +
+	"\rKeyHistoryRep\x12.\n" +
+	"\x06events\x18\x01 \x03(\v2\x16.e_product_v1.KeyEventR\x06events\";\n" +
+	"\x11KeyRevealValueReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\")\n" +
+	"\x11KeyRevealValueRep\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\"\xa9\x01\n" +
+	"\fKeyExportReq\x120\n" +
+	"\x06filter\x18\x01 \x01(\v2\x18.e_product_v1.KeyListReqR\x06filter\x122\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x1a.e_product_v1.ExportFormatR\x06format\x12!\n" +
+	"\freveal_value\x18\x04 \x01(\bR\vrevealValueJ\x04\b\x03\x10\x04R\n" +
+	"mask_value\"$\n" +
+	"\x0eKeyExportChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\xbb\x01\n" +
+	"\x0fKeyInventoryReq\x12$\n" +
+	"\vprovider_id\x18\x01 \x01(\tH\x00R\n" +
+	"providerId\x88\x01\x01\x124\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x17.e_product_v1.KeyStatusH\x01R\x06status\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tH\x02R\tproductId\x88\x01\x01B\x0e\n" +
+	"\f_provider_idB\t\n" +
+	"\a_statusB\r\n" +
+	"\v_product_id\"\xf0\x02\n" +
+	"\x10KeyInventoryItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vprovider_id\x18\x02 \x01(\tR\n" +
+	"providerId\x12/\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x17.e_product_v1.KeyStatusR\x06status\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x03R\x05count\x12\x17\n" +
+	"\aage_day\x18\x05 \x01(\x03R\x06ageDay\x12\x19\n" +
+	"\bage_week\x18\x06 \x01(\x03R\aageWeek\x12\x1b\n" +
+	"\tage_month\x18\a \x01(\x03R\bageMonth\x12\x1f\n" +
+	"\vage_quarter\x18\b \x01(\x03R\n" +
+	"ageQuarter\x12\x1b\n" +
+	"\tage_older\x18\t \x01(\x03R\bageOlder\x12F\n" +
+	"\x11oldest_created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x0foldestCreatedAt\"G\n" +
+	"\x0fKeyInventoryRep\x124\n" +
+	"\x05items\x18\x01 \x03(\v2\x1e.e_product_v1.KeyInventoryItemR\x05items\"t\n" +
+	"\x14KeyListByCustomerReq\x12%\n" +
+	"\x0ecustomer_phone\x18\x01 \x01(\tR\rcustomerPhone\x125\n" +
+	"\vlist_params\x18\x02 \x01(\v2\x14.common.ListParamsStR\n" +
+	"listParams\"\x8c\x01\n" +
+	"\x14KeyListByCustomerRep\x121\n" +
+	"\x04keys\x18\x01 \x03(\v2\x1d.e_product_v1.KeyResponseItemR\x04keys\x12A\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x18.common.PaginationInfoStR\x0epaginationInfo\"\x87\x01\n" +
 	"\x0eKeyActivateReq\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12%\n" +
 	"\x0ecustomer_phone\x18\x02 \x01(\tR\rcustomerPhone\x12\x19\n" +
-	"\border_id\x18\x03 \x01(\tR\aorderId\"&\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12\x14\n" +
+	"\x05async\x18\x04 \x01(\bR\x05async\"\x8a\x02\n" +
 	"\x0eKeyActivateRep\x12\x14\n" +
-	"\x05value\x18\x01 \x01(\tR\x05value\")\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x12\n" +
+	"\x04link\x18\x02 \x01(\tR\x04link\x12\"\n" +
+	"\finstructions\x18\x03 \x01(\tR\finstructions\x12!\n" +
+	"\flicense_term\x18\x04 \x01(\tR\vlicenseTerm\x12*\n" +
+	"\x11provider_order_id\x18\x05 \x01(\tR\x0fproviderOrderId\x12#\n" +
+	"\ractivation_id\x18\x06 \x01(\tR\factivationId\x126\n" +
+	"\x06status\x18\a \x01(\x0e2\x1e.e_product_v1.ActivationStatusR\x06status\"%\n" +
+	"\x13KeyActivationGetReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xcd\x02\n" +
+	"\rKeyActivation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x126\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1e.e_product_v1.ActivationStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x05 \x01(\tR\tproductId\x12\x19\n" +
+	"\border_id\x18\x06 \x01(\tR\aorderId\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12.\n" +
+	"\x03key\x18\b \x01(\v2\x1c.e_product_v1.KeyActivateRepR\x03key\"I\n" +
+	"\rKeyReserveReq\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\"\xeb\x01\n" +
+	"\x0eKeyReservation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x127\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1f.e_product_v1.ReservationStatusR\x06status\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1b\n" +
+	"\tfrom_pool\x18\x06 \x01(\bR\bfromPool\"]\n" +
+	"\rKeyConfirmReq\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12%\n" +
+	"\x0ecustomer_phone\x18\x02 \x01(\tR\rcustomerPhone\"6\n" +
+	"\rKeyReleaseReq\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"\x0f\n" +
+	"\rKeyReleaseRep\"`\n" +
 	"\fKeyCancelReq\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\tR\aorderId\"\x1e\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\"\x1e\n" +
 	"\fKeyCancelRep\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"0\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x9b\x02\n" +
+	"\tPoolLevel\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1b\n" +
+	"\tmin_level\x18\x04 \x01(\x03R\bminLevel\x12!\n" +
+	"\ftarget_level\x18\x05 \x01(\x03R\vtargetLevel\x12\x1b\n" +
+	"\tdaily_cap\x18\x06 \x01(\x03R\bdailyCap\x12\x1c\n" +
+	"\tavailable\x18\a \x01(\x03R\tavailable\"I\n" +
+	"\x10PoolLevelListReq\x125\n" +
+	"\vlist_params\x18\x01 \x01(\v2\x14.common.ListParamsStR\n" +
+	"listParams\"\x86\x01\n" +
+	"\x10PoolLevelListRep\x12/\n" +
+	"\x06levels\x18\x01 \x03(\v2\x17.e_product_v1.PoolLevelR\x06levels\x12A\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x18.common.PaginationInfoStR\x0epaginationInfo\"\x8d\x01\n" +
+	"\x0fPoolLevelSetReq\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1b\n" +
+	"\tmin_level\x18\x02 \x01(\x03R\bminLevel\x12!\n" +
+	"\ftarget_level\x18\x03 \x01(\x03R\vtargetLevel\x12\x1b\n" +
+	"\tdaily_cap\x18\x04 \x01(\x03R\bdailyCap\"3\n" +
+	"\x12PoolLevelDeleteReq\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\"\x14\n" +
+	"\x12PoolLevelDeleteRep\"\x9f\x02\n" +
+	"\x0fProductProvider\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\x12\x1a\n" +
+	"\bpriority\x18\x02 \x01(\x03R\bpriority\x12.\n" +
+	"\x13provider_product_id\x18\x03 \x01(\tR\x11providerProductId\x12?\n" +
+	"\x1cprovider_external_product_id\x18\x04 \x01(\tR\x19providerExternalProductId\x12#\n" +
+	"\rpromotion_key\x18\x05 \x01(\tR\fpromotionKey\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"7\n" +
+	"\x16ProductProviderListReq\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\"t\n" +
+	"\x16ProductProviderListRep\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12;\n" +
+	"\tproviders\x18\x02 \x03(\v2\x1d.e_product_v1.ProductProviderR\tproviders\"s\n" +
+	"\x15ProductProviderSetReq\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12;\n" +
+	"\tproviders\x18\x02 \x03(\v2\x1d.e_product_v1.ProductProviderR\tproviders\"0\n" +
 	"\rGetCatalogReq\x12\x1f\n" +
 	"\vprovider_id\x18\x01 \x01(\tR\n" +
 	"providerId\"@\n" +
@@ -877,18 +5264,178 @@ const file_e_product_e_product_v1_proto_rawDesc = "" +
 	"\x13provider_product_id\x18\x01 \x01(\tR\x11providerProductId\x12?\n" +
 	"\x1cprovider_external_product_id\x18\x02 \x01(\tR\x19providerExternalProductId\x12\x12\n" +
 	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
-	"\x04desc\x18\x04 \x01(\tR\x04desc*2\n" +
+	"\x04desc\x18\x04 \x01(\tR\x04desc\"\xf8\x01\n" +
+	"\x0fProviderBreaker\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\x128\n" +
+	"\x05state\x18\x02 \x01(\x0e2\".e_product_v1.ProviderBreakerStateR\x05state\x12\x1a\n" +
+	"\bfailures\x18\x03 \x01(\x03R\bfailures\x127\n" +
+	"\topened_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bopenedAt\x125\n" +
+	"\bretry_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aretryAt\"\x18\n" +
+	"\x16ProviderBreakerListReq\"S\n" +
+	"\x16ProviderBreakerListRep\x129\n" +
+	"\bbreakers\x18\x01 \x03(\v2\x1d.e_product_v1.ProviderBreakerR\bbreakers\":\n" +
+	"\x17ProviderBreakerResetReq\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\"\x89\x02\n" +
+	"\x13WebhookSubscription\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x05 \x01(\tR\x03url\x12\x16\n" +
+	"\x06events\x18\x06 \x03(\tR\x06events\x12\x16\n" +
+	"\x06active\x18\a \x01(\bR\x06active\x12\x16\n" +
+	"\x06secret\x18\b \x01(\tR\x06secret\"{\n" +
+	"\x1aWebhookSubscriptionListReq\x125\n" +
+	"\vlist_params\x18\x01 \x01(\v2\x14.common.ListParamsStR\n" +
+	"listParams\x12\x1b\n" +
+	"\x06active\x18\x02 \x01(\bH\x00R\x06active\x88\x01\x01B\t\n" +
+	"\a_active\"\xa8\x01\n" +
+	"\x1aWebhookSubscriptionListRep\x12G\n" +
+	"\rsubscriptions\x18\x01 \x03(\v2!.e_product_v1.WebhookSubscriptionR\rsubscriptions\x12A\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x18.common.PaginationInfoStR\x0epaginationInfo\"\x9c\x01\n" +
+	"\x1cWebhookSubscriptionCreateReq\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06events\x18\x03 \x03(\tR\x06events\x12\x16\n" +
+	"\x06secret\x18\x04 \x01(\tR\x06secret\x12\x1b\n" +
+	"\x06active\x18\x05 \x01(\bH\x00R\x06active\x88\x01\x01B\t\n" +
+	"\a_active\"\xc7\x01\n" +
+	"\x1cWebhookSubscriptionUpdateReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x15\n" +
+	"\x03url\x18\x03 \x01(\tH\x01R\x03url\x88\x01\x01\x12\x16\n" +
+	"\x06events\x18\x04 \x03(\tR\x06events\x12\x1b\n" +
+	"\x06active\x18\x05 \x01(\bH\x02R\x06active\x88\x01\x01\x12\x16\n" +
+	"\x06secret\x18\x06 \x01(\tR\x06secretB\a\n" +
+	"\x05_nameB\x06\n" +
+	"\x04_urlB\t\n" +
+	"\a_active\".\n" +
+	"\x1cWebhookSubscriptionDeleteReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1e\n" +
+	"\x1cWebhookSubscriptionDeleteRep\"\x94\x04\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12'\n" +
+	"\x0fsubscription_id\x18\x04 \x01(\tR\x0esubscriptionId\x12\x14\n" +
+	"\x05event\x18\x05 \x01(\tR\x05event\x12\x18\n" +
+	"\apayload\x18\x06 \x01(\tR\apayload\x12;\n" +
+	"\x06status\x18\a \x01(\x0e2#.e_product_v1.WebhookDeliveryStatusR\x06status\x12\x1a\n" +
+	"\battempts\x18\b \x01(\x03R\battempts\x12B\n" +
+	"\x0fnext_attempt_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\rnextAttemptAt\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\n" +
+	" \x01(\tR\tlastError\x12'\n" +
+	"\x0fresponse_status\x18\v \x01(\x03R\x0eresponseStatus\x12=\n" +
+	"\fdelivered_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\vdeliveredAt\"\x83\x02\n" +
+	"\x16WebhookDeliveryListReq\x125\n" +
+	"\vlist_params\x18\x01 \x01(\v2\x14.common.ListParamsStR\n" +
+	"listParams\x12,\n" +
+	"\x0fsubscription_id\x18\x02 \x01(\tH\x00R\x0esubscriptionId\x88\x01\x01\x12\x19\n" +
+	"\x05event\x18\x03 \x01(\tH\x01R\x05event\x88\x01\x01\x12@\n" +
+	"\x06status\x18\x04 \x01(\x0e2#.e_product_v1.WebhookDeliveryStatusH\x02R\x06status\x88\x01\x01B\x12\n" +
+	"\x10_subscription_idB\b\n" +
+	"\x06_eventB\t\n" +
+	"\a_status\"\x9a\x01\n" +
+	"\x16WebhookDeliveryListRep\x12=\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x1d.e_product_v1.WebhookDeliveryR\n" +
+	"deliveries\x12A\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x18.common.PaginationInfoStR\x0epaginationInfo\"\"\n" +
+	"\x10WebhookReplayReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id*/\n" +
+	"\bLoadMode\x12\x0f\n" +
+	"\vbest_effort\x10\x00\x12\x12\n" +
+	"\x0eall_or_nothing\x10\x01*R\n" +
+	"\x0eLoadItemResult\x12\v\n" +
+	"\acreated\x10\x00\x12\r\n" +
+	"\tduplicate\x10\x01\x12\v\n" +
+	"\ainvalid\x10\x02\x12\v\n" +
+	"\askipped\x10\x03\x12\n" +
+	"\n" +
+	"\x06failed\x10\x04*9\n" +
+	"\fImportFormat\x12\x16\n" +
+	"\x12import_format_auto\x10\x00\x12\a\n" +
+	"\x03csv\x10\x01\x12\b\n" +
+	"\x04xlsx\x10\x02*N\n" +
+	"\x0fImportJobStatus\x12\x12\n" +
+	"\x0eimport_running\x10\x00\x12\x14\n" +
+	"\x10import_completed\x10\x01\x12\x11\n" +
+	"\rimport_failed\x10\x02*j\n" +
 	"\tKeyStatus\x12\a\n" +
 	"\x03new\x10\x00\x12\r\n" +
 	"\tactivated\x10\x01\x12\r\n" +
-	"\tcancelled\x10\x022\x8d\x04\n" +
-	"\x03Key\x12I\n" +
-	"\x04Load\x12\x18.e_product_v1.LoadKeyReq\x1a\x16.google.protobuf.Empty\"\x0f\x82\xd3\xe4\x93\x02\t:\x01*\"\x04/key\x12H\n" +
+	"\tcancelled\x10\x02\x12\f\n" +
+	"\breserved\x10\x03\x12\f\n" +
+	"\breturned\x10\x04\x12\v\n" +
+	"\aexpired\x10\x05\x12\r\n" +
+	"\twithdrawn\x10\x06*1\n" +
+	"\tKeySource\x12\x0f\n" +
+	"\vsource_pool\x10\x00\x12\x13\n" +
+	"\x0fsource_provider\x10\x01*0\n" +
+	"\fExportFormat\x12\x0e\n" +
+	"\n" +
+	"export_csv\x10\x00\x12\x10\n" +
+	"\fexport_jsonl\x10\x01*v\n" +
+	"\x10ActivationStatus\x12\x16\n" +
+	"\x12activation_pending\x10\x00\x12\x19\n" +
+	"\x15activation_processing\x10\x01\x12\x18\n" +
+	"\x14activation_completed\x10\x02\x12\x15\n" +
+	"\x11activation_failed\x10\x03*y\n" +
+	"\x11ReservationStatus\x12\x16\n" +
+	"\x12reservation_active\x10\x00\x12\x19\n" +
+	"\x15reservation_confirmed\x10\x01\x12\x18\n" +
+	"\x14reservation_released\x10\x02\x12\x17\n" +
+	"\x13reservation_expired\x10\x03*S\n" +
+	"\x14ProviderBreakerState\x12\x12\n" +
+	"\x0ebreaker_closed\x10\x00\x12\x10\n" +
+	"\fbreaker_open\x10\x01\x12\x15\n" +
+	"\x11breaker_half_open\x10\x02*X\n" +
+	"\x15WebhookDeliveryStatus\x12\x14\n" +
+	"\x10delivery_pending\x10\x00\x12\x16\n" +
+	"\x12delivery_delivered\x10\x01\x12\x11\n" +
+	"\rdelivery_dead\x10\x022\xa7\x14\n" +
+	"\x03Key\x12K\n" +
+	"\x04Load\x12\x18.e_product_v1.LoadKeyReq\x1a\x18.e_product_v1.LoadKeyRep\"\x0f\x82\xd3\xe4\x93\x02\t:\x01*\"\x04/key\x12D\n" +
+	"\n" +
+	"ImportKeys\x12\x1b.e_product_v1.ImportKeysReq\x1a\x17.e_product_v1.ImportJob(\x01\x12`\n" +
+	"\fGetImportJob\x12\x1d.e_product_v1.ImportJobGetReq\x1a\x17.e_product_v1.ImportJob\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/import_job/{id}\x12e\n" +
+	"\x0eListImportJobs\x12\x1e.e_product_v1.ImportJobListReq\x1a\x1e.e_product_v1.ImportJobListRep\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/import_job\x12H\n" +
 	"\x04List\x12\x18.e_product_v1.KeyListReq\x1a\x18.e_product_v1.KeyListRep\"\f\x82\xd3\xe4\x93\x02\x06\x12\x04/key\x12P\n" +
-	"\x03Get\x12\x17.e_product_v1.KeyGetReq\x1a\x1d.e_product_v1.KeyResponseItem\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/key/{id}\x12`\n" +
-	"\bActivate\x12\x1c.e_product_v1.KeyActivateReq\x1a\x1c.e_product_v1.KeyActivateRep\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\x1a\r/key/activate\x12X\n" +
-	"\x06Cancel\x12\x1a.e_product_v1.KeyCancelReq\x1a\x1a.e_product_v1.KeyCancelRep\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/key/cancel\x12c\n" +
-	"\aCatalog\x12\x1b.e_product_v1.GetCatalogReq\x1a\x1b.e_product_v1.GetCatalogRep\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/catalog/{provider_id}B\x0fZ\r/e_product_v1b\x06proto3"
+	"\x03Get\x12\x17.e_product_v1.KeyGetReq\x1a\x1d.e_product_v1.KeyResponseItem\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/key/{id}\x12^\n" +
+	"\aHistory\x12\x1b.e_product_v1.KeyHistoryReq\x1a\x1b.e_product_v1.KeyHistoryRep\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/key/{id}/history\x12r\n" +
+	"\vRevealValue\x12\x1f.e_product_v1.KeyRevealValueReq\x1a\x1f.e_product_v1.KeyRevealValueRep\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/key/{id}/reveal_value\x12D\n" +
+	"\x06Export\x12\x1a.e_product_v1.KeyExportReq\x1a\x1c.e_product_v1.KeyExportChunk0\x01\x12g\n" +
+	"\x0fInventoryReport\x12\x1d.e_product_v1.KeyInventoryReq\x1a\x1d.e_product_v1.KeyInventoryRep\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/key/inventory\x12\x80\x01\n" +
+	"\x0eListByCustomer\x12\".e_product_v1.KeyListByCustomerReq\x1a\".e_product_v1.KeyListByCustomerRep\"&\x82\xd3\xe4\x93\x02 \x12\x1e/key/customer/{customer_phone}\x12`\n" +
+	"\bActivate\x12\x1c.e_product_v1.KeyActivateReq\x1a\x1c.e_product_v1.KeyActivateRep\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\x1a\r/key/activate\x12m\n" +
+	"\rGetActivation\x12!.e_product_v1.KeyActivationGetReq\x1a\x1b.e_product_v1.KeyActivation\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/key/activation/{id}\x12]\n" +
+	"\aReserve\x12\x1b.e_product_v1.KeyReserveReq\x1a\x1c.e_product_v1.KeyReservation\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/key/reserve\x12]\n" +
+	"\aConfirm\x12\x1b.e_product_v1.KeyConfirmReq\x1a\x1c.e_product_v1.KeyActivateRep\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/key/confirm\x12\\\n" +
+	"\aRelease\x12\x1b.e_product_v1.KeyReleaseReq\x1a\x1b.e_product_v1.KeyReleaseRep\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/key/release\x12X\n" +
+	"\x06Cancel\x12\x1a.e_product_v1.KeyCancelReq\x1a\x1a.e_product_v1.KeyCancelRep\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/key/cancel\x12e\n" +
+	"\x0eListPoolLevels\x12\x1e.e_product_v1.PoolLevelListReq\x1a\x1e.e_product_v1.PoolLevelListRep\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/pool_level\x12k\n" +
+	"\fSetPoolLevel\x12\x1d.e_product_v1.PoolLevelSetReq\x1a\x17.e_product_v1.PoolLevel\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\x1a\x18/pool_level/{product_id}\x12w\n" +
+	"\x0fDeletePoolLevel\x12 .e_product_v1.PoolLevelDeleteReq\x1a .e_product_v1.PoolLevelDeleteRep\" \x82\xd3\xe4\x93\x02\x1a*\x18/pool_level/{product_id}\x12\x8a\x01\n" +
+	"\x14ListProductProviders\x12$.e_product_v1.ProductProviderListReq\x1a$.e_product_v1.ProductProviderListRep\"&\x82\xd3\xe4\x93\x02 \x12\x1e/product_provider/{product_id}\x12\x8b\x01\n" +
+	"\x13SetProductProviders\x12#.e_product_v1.ProductProviderSetReq\x1a$.e_product_v1.ProductProviderListRep\")\x82\xd3\xe4\x93\x02#:\x01*\x1a\x1e/product_provider/{product_id}\x12c\n" +
+	"\aCatalog\x12\x1b.e_product_v1.GetCatalogReq\x1a\x1b.e_product_v1.GetCatalogRep\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/catalog/{provider_id}\x12}\n" +
+	"\x14ListProviderBreakers\x12$.e_product_v1.ProviderBreakerListReq\x1a$.e_product_v1.ProviderBreakerListRep\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/provider_breaker\x12\x8e\x01\n" +
+	"\x14ResetProviderBreaker\x12%.e_product_v1.ProviderBreakerResetReq\x1a\x1d.e_product_v1.ProviderBreaker\"0\x82\xd3\xe4\x93\x02*:\x01*\"%/provider_breaker/{provider_id}/reset2\xef\x05\n" +
+	"\aWebhook\x12y\n" +
+	"\x11ListSubscriptions\x12(.e_product_v1.WebhookSubscriptionListReq\x1a(.e_product_v1.WebhookSubscriptionListRep\"\x10\x82\xd3\xe4\x93\x02\n" +
+	"\x12\b/webhook\x12x\n" +
+	"\x12CreateSubscription\x12*.e_product_v1.WebhookSubscriptionCreateReq\x1a!.e_product_v1.WebhookSubscription\"\x13\x82\xd3\xe4\x93\x02\r:\x01*\"\b/webhook\x12}\n" +
+	"\x12UpdateSubscription\x12*.e_product_v1.WebhookSubscriptionUpdateReq\x1a!.e_product_v1.WebhookSubscription\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\x1a\r/webhook/{id}\x12\x83\x01\n" +
+	"\x12DeleteSubscription\x12*.e_product_v1.WebhookSubscriptionDeleteReq\x1a*.e_product_v1.WebhookSubscriptionDeleteRep\"\x15\x82\xd3\xe4\x93\x02\x0f*\r/webhook/{id}\x12w\n" +
+	"\x0eListDeliveries\x12$.e_product_v1.WebhookDeliveryListReq\x1a$.e_product_v1.WebhookDeliveryListRep\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/webhook/delivery\x12q\n" +
+	"\x06Replay\x12\x1e.e_product_v1.WebhookReplayReq\x1a\x1d.e_product_v1.WebhookDelivery\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/webhook/delivery/{id}/replayB\x0fZ\r/e_product_v1b\x06proto3"
 
 var (
 	file_e_product_e_product_v1_proto_rawDescOnce sync.Once
@@ -902,55 +5449,238 @@ func file_e_product_e_product_v1_proto_rawDescGZIP() []byte {
 	return file_e_product_e_product_v1_proto_rawDescData
 }
 
-var file_e_product_e_product_v1_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_e_product_e_product_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_e_product_e_product_v1_proto_enumTypes = make([]protoimpl.EnumInfo, 11)
+var file_e_product_e_product_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 67)
 var file_e_product_e_product_v1_proto_goTypes = []any{
-	(KeyStatus)(0),                  // 0: e_product_v1.KeyStatus
-	(*KeyItem)(nil),                 // 1: e_product_v1.KeyItem
-	(*LoadKeyReq)(nil),              // 2: e_product_v1.LoadKeyReq
-	(*KeyResponseItem)(nil),         // 3: e_product_v1.KeyResponseItem
-	(*KeyListReq)(nil),              // 4: e_product_v1.KeyListReq
-	(*KeyListRep)(nil),              // 5: e_product_v1.KeyListRep
-	(*KeyGetReq)(nil),               // 6: e_product_v1.KeyGetReq
-	(*KeyActivateReq)(nil),          // 7: e_product_v1.KeyActivateReq
-	(*KeyActivateRep)(nil),          // 8: e_product_v1.KeyActivateRep
-	(*KeyCancelReq)(nil),            // 9: e_product_v1.KeyCancelReq
-	(*KeyCancelRep)(nil),            // 10: e_product_v1.KeyCancelRep
-	(*GetCatalogReq)(nil),           // 11: e_product_v1.GetCatalogReq
-	(*GetCatalogRep)(nil),           // 12: e_product_v1.GetCatalogRep
-	(*CatalogItem)(nil),             // 13: e_product_v1.CatalogItem
-	(*timestamppb.Timestamp)(nil),   // 14: google.protobuf.Timestamp
-	(*common.ListParamsSt)(nil),     // 15: common.ListParamsSt
-	(*common.PaginationInfoSt)(nil), // 16: common.PaginationInfoSt
-	(*emptypb.Empty)(nil),           // 17: google.protobuf.Empty
+	(LoadMode)(0),                        // 0: e_product_v1.LoadMode
+	(LoadItemResult)(0),                  // 1: e_product_v1.LoadItemResult
+	(ImportFormat)(0),                    // 2: e_product_v1.ImportFormat
+	(ImportJobStatus)(0),                 // 3: e_product_v1.ImportJobStatus
+	(KeyStatus)(0),                       // 4: e_product_v1.KeyStatus
+	(KeySource)(0),                       // 5: e_product_v1.KeySource
+	(ExportFormat)(0),                    // 6: e_product_v1.ExportFormat
+	(ActivationStatus)(0),                // 7: e_product_v1.ActivationStatus
+	(ReservationStatus)(0),               // 8: e_product_v1.ReservationStatus
+	(ProviderBreakerState)(0),            // 9: e_product_v1.ProviderBreakerState
+	(WebhookDeliveryStatus)(0),           // 10: e_product_v1.WebhookDeliveryStatus
+	(*KeyItem)(nil),                      // 11: e_product_v1.KeyItem
+	(*LoadKeyReq)(nil),                   // 12: e_product_v1.LoadKeyReq
+	(*LoadKeyItemRep)(nil),               // 13: e_product_v1.LoadKeyItemRep
+	(*LoadKeyRep)(nil),                   // 14: e_product_v1.LoadKeyRep
+	(*ImportColumnMapping)(nil),          // 15: e_product_v1.ImportColumnMapping
+	(*ImportKeysHeader)(nil),             // 16: e_product_v1.ImportKeysHeader
+	(*ImportKeysReq)(nil),                // 17: e_product_v1.ImportKeysReq
+	(*ImportJobItem)(nil),                // 18: e_product_v1.ImportJobItem
+	(*ImportJob)(nil),                    // 19: e_product_v1.ImportJob
+	(*ImportJobGetReq)(nil),              // 20: e_product_v1.ImportJobGetReq
+	(*ImportJobListReq)(nil),             // 21: e_product_v1.ImportJobListReq
+	(*ImportJobListRep)(nil),             // 22: e_product_v1.ImportJobListRep
+	(*KeyResponseItem)(nil),              // 23: e_product_v1.KeyResponseItem
+	(*KeyListReq)(nil),                   // 24: e_product_v1.KeyListReq
+	(*KeyListRep)(nil),                   // 25: e_product_v1.KeyListRep
+	(*KeyGetReq)(nil),                    // 26: e_product_v1.KeyGetReq
+	(*KeyHistoryReq)(nil),                // 27: e_product_v1.KeyHistoryReq
+	(*KeyEvent)(nil),                     // 28: e_product_v1.KeyEvent
+	(*KeyHistoryRep)(nil),                // 29: e_product_v1.KeyHistoryRep
+	(*KeyRevealValueReq)(nil),            // 30: e_product_v1.KeyRevealValueReq
+	(*KeyRevealValueRep)(nil),            // 31: e_product_v1.KeyRevealValueRep
+	(*KeyExportReq)(nil),                 // 32: e_product_v1.KeyExportReq
+	(*KeyExportChunk)(nil),               // 33: e_product_v1.KeyExportChunk
+	(*KeyInventoryReq)(nil),              // 34: e_product_v1.KeyInventoryReq
+	(*KeyInventoryItem)(nil),             // 35: e_product_v1.KeyInventoryItem
+	(*KeyInventoryRep)(nil),              // 36: e_product_v1.KeyInventoryRep
+	(*KeyListByCustomerReq)(nil),         // 37: e_product_v1.KeyListByCustomerReq
+	(*KeyListByCustomerRep)(nil),         // 38: e_product_v1.KeyListByCustomerRep
+	(*KeyActivateReq)(nil),               // 39: e_product_v1.KeyActivateReq
+	(*KeyActivateRep)(nil),               // 40: e_product_v1.KeyActivateRep
+	(*KeyActivationGetReq)(nil),          // 41: e_product_v1.KeyActivationGetReq
+	(*KeyActivation)(nil),                // 42: e_product_v1.KeyActivation
+	(*KeyReserveReq)(nil),                // 43: e_product_v1.KeyReserveReq
+	(*KeyReservation)(nil),               // 44: e_product_v1.KeyReservation
+	(*KeyConfirmReq)(nil),                // 45: e_product_v1.KeyConfirmReq
+	(*KeyReleaseReq)(nil),                // 46: e_product_v1.KeyReleaseReq
+	(*KeyReleaseRep)(nil),                // 47: e_product_v1.KeyReleaseRep
+	(*KeyCancelReq)(nil),                 // 48: e_product_v1.KeyCancelReq
+	(*KeyCancelRep)(nil),                 // 49: e_product_v1.KeyCancelRep
+	(*PoolLevel)(nil),                    // 50: e_product_v1.PoolLevel
+	(*PoolLevelListReq)(nil),             // 51: e_product_v1.PoolLevelListReq
+	(*PoolLevelListRep)(nil),             // 52: e_product_v1.PoolLevelListRep
+	(*PoolLevelSetReq)(nil),              // 53: e_product_v1.PoolLevelSetReq
+	(*PoolLevelDeleteReq)(nil),           // 54: e_product_v1.PoolLevelDeleteReq
+	(*PoolLevelDeleteRep)(nil),           // 55: e_product_v1.PoolLevelDeleteRep
+	(*ProductProvider)(nil),              // 56: e_product_v1.ProductProvider
+	(*ProductProviderListReq)(nil),       // 57: e_product_v1.ProductProviderListReq
+	(*ProductProviderListRep)(nil),       // 58: e_product_v1.ProductProviderListRep
+	(*ProductProviderSetReq)(nil),        // 59: e_product_v1.ProductProviderSetReq
+	(*GetCatalogReq)(nil),                // 60: e_product_v1.GetCatalogReq
+	(*GetCatalogRep)(nil),                // 61: e_product_v1.GetCatalogRep
+	(*CatalogItem)(nil),                  // 62: e_product_v1.CatalogItem
+	(*ProviderBreaker)(nil),              // 63: e_product_v1.ProviderBreaker
+	(*ProviderBreakerListReq)(nil),       // 64: e_product_v1.ProviderBreakerListReq
+	(*ProviderBreakerListRep)(nil),       // 65: e_product_v1.ProviderBreakerListRep
+	(*ProviderBreakerResetReq)(nil),      // 66: e_product_v1.ProviderBreakerResetReq
+	(*WebhookSubscription)(nil),          // 67: e_product_v1.WebhookSubscription
+	(*WebhookSubscriptionListReq)(nil),   // 68: e_product_v1.WebhookSubscriptionListReq
+	(*WebhookSubscriptionListRep)(nil),   // 69: e_product_v1.WebhookSubscriptionListRep
+	(*WebhookSubscriptionCreateReq)(nil), // 70: e_product_v1.WebhookSubscriptionCreateReq
+	(*WebhookSubscriptionUpdateReq)(nil), // 71: e_product_v1.WebhookSubscriptionUpdateReq
+	(*WebhookSubscriptionDeleteReq)(nil), // 72: e_product_v1.WebhookSubscriptionDeleteReq
+	(*WebhookSubscriptionDeleteRep)(nil), // 73: e_product_v1.WebhookSubscriptionDeleteRep
+	(*WebhookDelivery)(nil),              // 74: e_product_v1.WebhookDelivery
+	(*WebhookDeliveryListReq)(nil),       // 75: e_product_v1.WebhookDeliveryListReq
+	(*WebhookDeliveryListRep)(nil),       // 76: e_product_v1.WebhookDeliveryListRep
+	(*WebhookReplayReq)(nil),             // 77: e_product_v1.WebhookReplayReq
+	(*timestamppb.Timestamp)(nil),        // 78: google.protobuf.Timestamp
+	(*common.ListParamsSt)(nil),          // 79: common.ListParamsSt
+	(*common.PaginationInfoSt)(nil),      // 80: common.PaginationInfoSt
 }
 var file_e_product_e_product_v1_proto_depIdxs = []int32{
-	1,  // 0: e_product_v1.LoadKeyReq.keys:type_name -> e_product_v1.KeyItem
-	14, // 1: e_product_v1.KeyResponseItem.created_at:type_name -> google.protobuf.Timestamp
-	14, // 2: e_product_v1.KeyResponseItem.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 3: e_product_v1.KeyResponseItem.status:type_name -> e_product_v1.KeyStatus
-	0,  // 4: e_product_v1.KeyListReq.status:type_name -> e_product_v1.KeyStatus
-	15, // 5: e_product_v1.KeyListReq.list_params:type_name -> common.ListParamsSt
-	3,  // 6: e_product_v1.KeyListRep.keys:type_name -> e_product_v1.KeyResponseItem
-	16, // 7: e_product_v1.KeyListRep.pagination_info:type_name -> common.PaginationInfoSt
-	13, // 8: e_product_v1.GetCatalogRep.items:type_name -> e_product_v1.CatalogItem
-	2,  // 9: e_product_v1.Key.Load:input_type -> e_product_v1.LoadKeyReq
-	4,  // 10: e_product_v1.Key.List:input_type -> e_product_v1.KeyListReq
-	6,  // 11: e_product_v1.Key.Get:input_type -> e_product_v1.KeyGetReq
-	7,  // 12: e_product_v1.Key.Activate:input_type -> e_product_v1.KeyActivateReq
-	9,  // 13: e_product_v1.Key.Cancel:input_type -> e_product_v1.KeyCancelReq
-	11, // 14: e_product_v1.Key.Catalog:input_type -> e_product_v1.GetCatalogReq
-	17, // 15: e_product_v1.Key.Load:output_type -> google.protobuf.Empty
-	5,  // 16: e_product_v1.Key.List:output_type -> e_product_v1.KeyListRep
-	3,  // 17: e_product_v1.Key.Get:output_type -> e_product_v1.KeyResponseItem
-	8,  // 18: e_product_v1.Key.Activate:output_type -> e_product_v1.KeyActivateRep
-	10, // 19: e_product_v1.Key.Cancel:output_type -> e_product_v1.KeyCancelRep
-	12, // 20: e_product_v1.Key.Catalog:output_type -> e_product_v1.GetCatalogRep
-	15, // [15:21] is the sub-list for method output_type
-	9,  // [9:15] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	11,  // 0: e_product_v1.LoadKeyReq.keys:type_name -> e_product_v1.KeyItem
+	0,   // 1: e_product_v1.LoadKeyReq.mode:type_name -> e_product_v1.LoadMode
+	1,   // 2: e_product_v1.LoadKeyItemRep.result:type_name -> e_product_v1.LoadItemResult
+	13,  // 3: e_product_v1.LoadKeyRep.items:type_name -> e_product_v1.LoadKeyItemRep
+	2,   // 4: e_product_v1.ImportKeysHeader.format:type_name -> e_product_v1.ImportFormat
+	15,  // 5: e_product_v1.ImportKeysHeader.mapping:type_name -> e_product_v1.ImportColumnMapping
+	0,   // 6: e_product_v1.ImportKeysHeader.mode:type_name -> e_product_v1.LoadMode
+	16,  // 7: e_product_v1.ImportKeysReq.header:type_name -> e_product_v1.ImportKeysHeader
+	1,   // 8: e_product_v1.ImportJobItem.result:type_name -> e_product_v1.LoadItemResult
+	78,  // 9: e_product_v1.ImportJob.created_at:type_name -> google.protobuf.Timestamp
+	78,  // 10: e_product_v1.ImportJob.updated_at:type_name -> google.protobuf.Timestamp
+	2,   // 11: e_product_v1.ImportJob.format:type_name -> e_product_v1.ImportFormat
+	0,   // 12: e_product_v1.ImportJob.mode:type_name -> e_product_v1.LoadMode
+	3,   // 13: e_product_v1.ImportJob.status:type_name -> e_product_v1.ImportJobStatus
+	18,  // 14: e_product_v1.ImportJob.items:type_name -> e_product_v1.ImportJobItem
+	3,   // 15: e_product_v1.ImportJobListReq.status:type_name -> e_product_v1.ImportJobStatus
+	79,  // 16: e_product_v1.ImportJobListReq.list_params:type_name -> common.ListParamsSt
+	19,  // 17: e_product_v1.ImportJobListRep.jobs:type_name -> e_product_v1.ImportJob
+	80,  // 18: e_product_v1.ImportJobListRep.pagination_info:type_name -> common.PaginationInfoSt
+	78,  // 19: e_product_v1.KeyResponseItem.created_at:type_name -> google.protobuf.Timestamp
+	78,  // 20: e_product_v1.KeyResponseItem.updated_at:type_name -> google.protobuf.Timestamp
+	4,   // 21: e_product_v1.KeyResponseItem.status:type_name -> e_product_v1.KeyStatus
+	5,   // 22: e_product_v1.KeyResponseItem.source:type_name -> e_product_v1.KeySource
+	4,   // 23: e_product_v1.KeyListReq.status:type_name -> e_product_v1.KeyStatus
+	79,  // 24: e_product_v1.KeyListReq.list_params:type_name -> common.ListParamsSt
+	78,  // 25: e_product_v1.KeyListReq.updated_from:type_name -> google.protobuf.Timestamp
+	78,  // 26: e_product_v1.KeyListReq.updated_to:type_name -> google.protobuf.Timestamp
+	4,   // 27: e_product_v1.KeyListReq.statuses:type_name -> e_product_v1.KeyStatus
+	78,  // 28: e_product_v1.KeyListReq.created_from:type_name -> google.protobuf.Timestamp
+	78,  // 29: e_product_v1.KeyListReq.created_to:type_name -> google.protobuf.Timestamp
+	23,  // 30: e_product_v1.KeyListRep.keys:type_name -> e_product_v1.KeyResponseItem
+	80,  // 31: e_product_v1.KeyListRep.pagination_info:type_name -> common.PaginationInfoSt
+	78,  // 32: e_product_v1.KeyEvent.created_at:type_name -> google.protobuf.Timestamp
+	4,   // 33: e_product_v1.KeyEvent.from_status:type_name -> e_product_v1.KeyStatus
+	4,   // 34: e_product_v1.KeyEvent.to_status:type_name -> e_product_v1.KeyStatus
+	28,  // 35: e_product_v1.KeyHistoryRep.events:type_name -> e_product_v1.KeyEvent
+	24,  // 36: e_product_v1.KeyExportReq.filter:type_name -> e_product_v1.KeyListReq
+	6,   // 37: e_product_v1.KeyExportReq.format:type_name -> e_product_v1.ExportFormat
+	4,   // 38: e_product_v1.KeyInventoryReq.status:type_name -> e_product_v1.KeyStatus
+	4,   // 39: e_product_v1.KeyInventoryItem.status:type_name -> e_product_v1.KeyStatus
+	78,  // 40: e_product_v1.KeyInventoryItem.oldest_created_at:type_name -> google.protobuf.Timestamp
+	35,  // 41: e_product_v1.KeyInventoryRep.items:type_name -> e_product_v1.KeyInventoryItem
+	79,  // 42: e_product_v1.KeyListByCustomerReq.list_params:type_name -> common.ListParamsSt
+	23,  // 43: e_product_v1.KeyListByCustomerRep.keys:type_name -> e_product_v1.KeyResponseItem
+	80,  // 44: e_product_v1.KeyListByCustomerRep.pagination_info:type_name -> common.PaginationInfoSt
+	7,   // 45: e_product_v1.KeyActivateRep.status:type_name -> e_product_v1.ActivationStatus
+	78,  // 46: e_product_v1.KeyActivation.created_at:type_name -> google.protobuf.Timestamp
+	78,  // 47: e_product_v1.KeyActivation.updated_at:type_name -> google.protobuf.Timestamp
+	7,   // 48: e_product_v1.KeyActivation.status:type_name -> e_product_v1.ActivationStatus
+	40,  // 49: e_product_v1.KeyActivation.key:type_name -> e_product_v1.KeyActivateRep
+	8,   // 50: e_product_v1.KeyReservation.status:type_name -> e_product_v1.ReservationStatus
+	78,  // 51: e_product_v1.KeyReservation.expires_at:type_name -> google.protobuf.Timestamp
+	78,  // 52: e_product_v1.PoolLevel.created_at:type_name -> google.protobuf.Timestamp
+	78,  // 53: e_product_v1.PoolLevel.updated_at:type_name -> google.protobuf.Timestamp
+	79,  // 54: e_product_v1.PoolLevelListReq.list_params:type_name -> common.ListParamsSt
+	50,  // 55: e_product_v1.PoolLevelListRep.levels:type_name -> e_product_v1.PoolLevel
+	80,  // 56: e_product_v1.PoolLevelListRep.pagination_info:type_name -> common.PaginationInfoSt
+	78,  // 57: e_product_v1.ProductProvider.updated_at:type_name -> google.protobuf.Timestamp
+	56,  // 58: e_product_v1.ProductProviderListRep.providers:type_name -> e_product_v1.ProductProvider
+	56,  // 59: e_product_v1.ProductProviderSetReq.providers:type_name -> e_product_v1.ProductProvider
+	62,  // 60: e_product_v1.GetCatalogRep.items:type_name -> e_product_v1.CatalogItem
+	9,   // 61: e_product_v1.ProviderBreaker.state:type_name -> e_product_v1.ProviderBreakerState
+	78,  // 62: e_product_v1.ProviderBreaker.opened_at:type_name -> google.protobuf.Timestamp
+	78,  // 63: e_product_v1.ProviderBreaker.retry_at:type_name -> google.protobuf.Timestamp
+	63,  // 64: e_product_v1.ProviderBreakerListRep.breakers:type_name -> e_product_v1.ProviderBreaker
+	78,  // 65: e_product_v1.WebhookSubscription.created_at:type_name -> google.protobuf.Timestamp
+	78,  // 66: e_product_v1.WebhookSubscription.updated_at:type_name -> google.protobuf.Timestamp
+	79,  // 67: e_product_v1.WebhookSubscriptionListReq.list_params:type_name -> common.ListParamsSt
+	67,  // 68: e_product_v1.WebhookSubscriptionListRep.subscriptions:type_name -> e_product_v1.WebhookSubscription
+	80,  // 69: e_product_v1.WebhookSubscriptionListRep.pagination_info:type_name -> common.PaginationInfoSt
+	78,  // 70: e_product_v1.WebhookDelivery.created_at:type_name -> google.protobuf.Timestamp
+	78,  // 71: e_product_v1.WebhookDelivery.updated_at:type_name -> google.protobuf.Timestamp
+	10,  // 72: e_product_v1.WebhookDelivery.status:type_name -> e_product_v1.WebhookDeliveryStatus
+	78,  // 73: e_product_v1.WebhookDelivery.next_attempt_at:type_name -> google.protobuf.Timestamp
+	78,  // 74: e_product_v1.WebhookDelivery.delivered_at:type_name -> google.protobuf.Timestamp
+	79,  // 75: e_product_v1.WebhookDeliveryListReq.list_params:type_name -> common.ListParamsSt
+	10,  // 76: e_product_v1.WebhookDeliveryListReq.status:type_name -> e_product_v1.WebhookDeliveryStatus
+	74,  // 77: e_product_v1.WebhookDeliveryListRep.deliveries:type_name -> e_product_v1.WebhookDelivery
+	80,  // 78: e_product_v1.WebhookDeliveryListRep.pagination_info:type_name -> common.PaginationInfoSt
+	12,  // 79: e_product_v1.Key.Load:input_type -> e_product_v1.LoadKeyReq
+	17,  // 80: e_product_v1.Key.ImportKeys:input_type -> e_product_v1.ImportKeysReq
+	20,  // 81: e_product_v1.Key.GetImportJob:input_type -> e_product_v1.ImportJobGetReq
+	21,  // 82: e_product_v1.Key.ListImportJobs:input_type -> e_product_v1.ImportJobListReq
+	24,  // 83: e_product_v1.Key.List:input_type -> e_product_v1.KeyListReq
+	26,  // 84: e_product_v1.Key.Get:input_type -> e_product_v1.KeyGetReq
+	27,  // 85: e_product_v1.Key.History:input_type -> e_product_v1.KeyHistoryReq
+	30,  // 86: e_product_v1.Key.RevealValue:input_type -> e_product_v1.KeyRevealValueReq
+	32,  // 87: e_product_v1.Key.Export:input_type -> e_product_v1.KeyExportReq
+	34,  // 88: e_product_v1.Key.InventoryReport:input_type -> e_product_v1.KeyInventoryReq
+	37,  // 89: e_product_v1.Key.ListByCustomer:input_type -> e_product_v1.KeyListByCustomerReq
+	39,  // 90: e_product_v1.Key.Activate:input_type -> e_product_v1.KeyActivateReq
+	41,  // 91: e_product_v1.Key.GetActivation:input_type -> e_product_v1.KeyActivationGetReq
+	43,  // 92: e_product_v1.Key.Reserve:input_type -> e_product_v1.KeyReserveReq
+	45,  // 93: e_product_v1.Key.Confirm:input_type -> e_product_v1.KeyConfirmReq
+	46,  // 94: e_product_v1.Key.Release:input_type -> e_product_v1.KeyReleaseReq
+	48,  // 95: e_product_v1.Key.Cancel:input_type -> e_product_v1.KeyCancelReq
+	51,  // 96: e_product_v1.Key.ListPoolLevels:input_type -> e_product_v1.PoolLevelListReq
+	53,  // 97: e_product_v1.Key.SetPoolLevel:input_type -> e_product_v1.PoolLevelSetReq
+	54,  // 98: e_product_v1.Key.DeletePoolLevel:input_type -> e_product_v1.PoolLevelDeleteReq
+	57,  // 99: e_product_v1.Key.ListProductProviders:input_type -> e_product_v1.ProductProviderListReq
+	59,  // 100: e_product_v1.Key.SetProductProviders:input_type -> e_product_v1.ProductProviderSetReq
+	60,  // 101: e_product_v1.Key.Catalog:input_type -> e_product_v1.GetCatalogReq
+	64,  // 102: e_product_v1.Key.ListProviderBreakers:input_type -> e_product_v1.ProviderBreakerListReq
+	66,  // 103: e_product_v1.Key.ResetProviderBreaker:input_type -> e_product_v1.ProviderBreakerResetReq
+	68,  // 104: e_product_v1.Webhook.ListSubscriptions:input_type -> e_product_v1.WebhookSubscriptionListReq
+	70,  // 105: e_product_v1.Webhook.CreateSubscription:input_type -> e_product_v1.WebhookSubscriptionCreateReq
+	71,  // 106: e_product_v1.Webhook.UpdateSubscription:input_type -> e_product_v1.WebhookSubscriptionUpdateReq
+	72,  // 107: e_product_v1.Webhook.DeleteSubscription:input_type -> e_product_v1.WebhookSubscriptionDeleteReq
+	75,  // 108: e_product_v1.Webhook.ListDeliveries:input_type -> e_product_v1.WebhookDeliveryListReq
+	77,  // 109: e_product_v1.Webhook.Replay:input_type -> e_product_v1.WebhookReplayReq
+	14,  // 110: e_product_v1.Key.Load:output_type -> e_product_v1.LoadKeyRep
+	19,  // 111: e_product_v1.Key.ImportKeys:output_type -> e_product_v1.ImportJob
+	19,  // 112: e_product_v1.Key.GetImportJob:output_type -> e_product_v1.ImportJob
+	22,  // 113: e_product_v1.Key.ListImportJobs:output_type -> e_product_v1.ImportJobListRep
+	25,  // 114: e_product_v1.Key.List:output_type -> e_product_v1.KeyListRep
+	23,  // 115: e_product_v1.Key.Get:output_type -> e_product_v1.KeyResponseItem
+	29,  // 116: e_product_v1.Key.History:output_type -> e_product_v1.KeyHistoryRep
+	31,  // 117: e_product_v1.Key.RevealValue:output_type -> e_product_v1.KeyRevealValueRep
+	33,  // 118: e_product_v1.Key.Export:output_type -> e_product_v1.KeyExportChunk
+	36,  // 119: e_product_v1.Key.InventoryReport:output_type -> e_product_v1.KeyInventoryRep
+	38,  // 120: e_product_v1.Key.ListByCustomer:output_type -> e_product_v1.KeyListByCustomerRep
+	40,  // 121: e_product_v1.Key.Activate:output_type -> e_product_v1.KeyActivateRep
+	42,  // 122: e_product_v1.Key.GetActivation:output_type -> e_product_v1.KeyActivation
+	44,  // 123: e_product_v1.Key.Reserve:output_type -> e_product_v1.KeyReservation
+	40,  // 124: e_product_v1.Key.Confirm:output_type -> e_product_v1.KeyActivateRep
+	47,  // 125: e_product_v1.Key.Release:output_type -> e_product_v1.KeyReleaseRep
+	49,  // 126: e_product_v1.Key.Cancel:output_type -> e_product_v1.KeyCancelRep
+	52,  // 127: e_product_v1.Key.ListPoolLevels:output_type -> e_product_v1.PoolLevelListRep
+	50,  // 128: e_product_v1.Key.SetPoolLevel:output_type -> e_product_v1.PoolLevel
+	55,  // 129: e_product_v1.Key.DeletePoolLevel:output_type -> e_product_v1.PoolLevelDeleteRep
+	58,  // 130: e_product_v1.Key.ListProductProviders:output_type -> e_product_v1.ProductProviderListRep
+	58,  // 131: e_product_v1.Key.SetProductProviders:output_type -> e_product_v1.ProductProviderListRep
+	61,  // 132: e_product_v1.Key.Catalog:output_type -> e_product_v1.GetCatalogRep
+	65,  // 133: e_product_v1.Key.ListProviderBreakers:output_type -> e_product_v1.ProviderBreakerListRep
+	63,  // 134: e_product_v1.Key.ResetProviderBreaker:output_type -> e_product_v1.ProviderBreaker
+	69,  // 135: e_product_v1.Webhook.ListSubscriptions:output_type -> e_product_v1.WebhookSubscriptionListRep
+	67,  // 136: e_product_v1.Webhook.CreateSubscription:output_type -> e_product_v1.WebhookSubscription
+	67,  // 137: e_product_v1.Webhook.UpdateSubscription:output_type -> e_product_v1.WebhookSubscription
+	73,  // 138: e_product_v1.Webhook.DeleteSubscription:output_type -> e_product_v1.WebhookSubscriptionDeleteRep
+	76,  // 139: e_product_v1.Webhook.ListDeliveries:output_type -> e_product_v1.WebhookDeliveryListRep
+	74,  // 140: e_product_v1.Webhook.Replay:output_type -> e_product_v1.WebhookDelivery
+	110, // [110:141] is the sub-list for method output_type
+	79,  // [79:110] is the sub-list for method input_type
+	79,  // [79:79] is the sub-list for extension type_name
+	79,  // [79:79] is the sub-list for extension extendee
+	0,   // [0:79] is the sub-list for field type_name
 }
 
 func init() { file_e_product_e_product_v1_proto_init() }
@@ -958,16 +5688,26 @@ func file_e_product_e_product_v1_proto_init() {
 	if File_e_product_e_product_v1_proto != nil {
 		return
 	}
-	file_e_product_e_product_v1_proto_msgTypes[3].OneofWrappers = []any{}
+	file_e_product_e_product_v1_proto_msgTypes[6].OneofWrappers = []any{
+		(*ImportKeysReq_Header)(nil),
+		(*ImportKeysReq_Chunk)(nil),
+	}
+	file_e_product_e_product_v1_proto_msgTypes[10].OneofWrappers = []any{}
+	file_e_product_e_product_v1_proto_msgTypes[13].OneofWrappers = []any{}
+	file_e_product_e_product_v1_proto_msgTypes[23].OneofWrappers = []any{}
+	file_e_product_e_product_v1_proto_msgTypes[57].OneofWrappers = []any{}
+	file_e_product_e_product_v1_proto_msgTypes[59].OneofWrappers = []any{}
+	file_e_product_e_product_v1_proto_msgTypes[60].OneofWrappers = []any{}
+	file_e_product_e_product_v1_proto_msgTypes[64].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_e_product_e_product_v1_proto_rawDesc), len(file_e_product_e_product_v1_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   13,
+			NumEnums:      11,
+			NumMessages:   67,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_e_product_e_product_v1_proto_goTypes,
 		DependencyIndexes: file_e_product_e_product_v1_proto_depIdxs,
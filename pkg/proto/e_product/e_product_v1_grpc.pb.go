@@ -11,7 +11,6 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -20,24 +19,81 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Key_Load_FullMethodName     = "/e_product_v1.Key/Load"
-	Key_List_FullMethodName     = "/e_product_v1.Key/List"
-	Key_Get_FullMethodName      = "/e_product_v1.Key/Get"
-	Key_Activate_FullMethodName = "/e_product_v1.Key/Activate"
-	Key_Cancel_FullMethodName   = "/e_product_v1.Key/Cancel"
-	Key_Catalog_FullMethodName  = "/e_product_v1.Key/Catalog"
+	Key_Load_FullMethodName                 = "/e_product_v1.Key/Load"
+	Key_ImportKeys_FullMethodName           = "/e_product_v1.Key/ImportKeys"
+	Key_GetImportJob_FullMethodName         = "/e_product_v1.Key/GetImportJob"
+	Key_ListImportJobs_FullMethodName       = "/e_product_v1.Key/ListImportJobs"
+	Key_List_FullMethodName                 = "/e_product_v1.Key/List"
+	Key_Get_FullMethodName                  = "/e_product_v1.Key/Get"
+	Key_History_FullMethodName              = "/e_product_v1.Key/History"
+	Key_RevealValue_FullMethodName          = "/e_product_v1.Key/RevealValue"
+	Key_Export_FullMethodName               = "/e_product_v1.Key/Export"
+	Key_InventoryReport_FullMethodName      = "/e_product_v1.Key/InventoryReport"
+	Key_ListByCustomer_FullMethodName       = "/e_product_v1.Key/ListByCustomer"
+	Key_Activate_FullMethodName             = "/e_product_v1.Key/Activate"
+	Key_GetActivation_FullMethodName        = "/e_product_v1.Key/GetActivation"
+	Key_Reserve_FullMethodName              = "/e_product_v1.Key/Reserve"
+	Key_Confirm_FullMethodName              = "/e_product_v1.Key/Confirm"
+	Key_Release_FullMethodName              = "/e_product_v1.Key/Release"
+	Key_Cancel_FullMethodName               = "/e_product_v1.Key/Cancel"
+	Key_ListPoolLevels_FullMethodName       = "/e_product_v1.Key/ListPoolLevels"
+	Key_SetPoolLevel_FullMethodName         = "/e_product_v1.Key/SetPoolLevel"
+	Key_DeletePoolLevel_FullMethodName      = "/e_product_v1.Key/DeletePoolLevel"
+	Key_ListProductProviders_FullMethodName = "/e_product_v1.Key/ListProductProviders"
+	Key_SetProductProviders_FullMethodName  = "/e_product_v1.Key/SetProductProviders"
+	Key_Catalog_FullMethodName              = "/e_product_v1.Key/Catalog"
+	Key_ListProviderBreakers_FullMethodName = "/e_product_v1.Key/ListProviderBreakers"
+	Key_ResetProviderBreaker_FullMethodName = "/e_product_v1.Key/ResetProviderBreaker"
 )
 
 // KeyClient is the client API for Key service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type KeyClient interface {
-	Load(ctx context.Context, in *LoadKeyReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Load(ctx context.Context, in *LoadKeyReq, opts ...grpc.CallOption) (*LoadKeyRep, error)
+	// Загрузка ключей из CSV/XLSX файла. Первое сообщение потока - header, затем части файла.
+	// Для HTTP: multipart POST /key/import
+	ImportKeys(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportKeysReq, ImportJob], error)
+	GetImportJob(ctx context.Context, in *ImportJobGetReq, opts ...grpc.CallOption) (*ImportJob, error)
+	ListImportJobs(ctx context.Context, in *ImportJobListReq, opts ...grpc.CallOption) (*ImportJobListRep, error)
 	List(ctx context.Context, in *KeyListReq, opts ...grpc.CallOption) (*KeyListRep, error)
 	Get(ctx context.Context, in *KeyGetReq, opts ...grpc.CallOption) (*KeyResponseItem, error)
+	// Журнал смены статусов ключа
+	History(ctx context.Context, in *KeyHistoryReq, opts ...grpc.CallOption) (*KeyHistoryRep, error)
+	// Открытое значение ключа для поддержки, доступ пишется в журнал. Требует роль support или admin
+	RevealValue(ctx context.Context, in *KeyRevealValueReq, opts ...grpc.CallOption) (*KeyRevealValueRep, error)
+	// Выгрузка ключей по фильтрам KeyListReq без пагинации, поток частей файла.
+	// Для скачивания через http: GET /key/export?format=export_csv&filter.status=activated
+	Export(ctx context.Context, in *KeyExportReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[KeyExportChunk], error)
+	// Остатки ключей по продукту, провайдеру и статусу с разбивкой по возрасту
+	InventoryReport(ctx context.Context, in *KeyInventoryReq, opts ...grpc.CallOption) (*KeyInventoryRep, error)
+	// Ключи, выданные клиенту по номеру телефона, значения маскируются
+	ListByCustomer(ctx context.Context, in *KeyListByCustomerReq, opts ...grpc.CallOption) (*KeyListByCustomerRep, error)
 	Activate(ctx context.Context, in *KeyActivateReq, opts ...grpc.CallOption) (*KeyActivateRep, error)
+	// Статус асинхронной выдачи (Activate с async=true), для completed - выданный ключ
+	GetActivation(ctx context.Context, in *KeyActivationGetReq, opts ...grpc.CallOption) (*KeyActivation, error)
+	// Резерв ключа на время оплаты заказа, истекший резерв снимается автоматически
+	Reserve(ctx context.Context, in *KeyReserveReq, opts ...grpc.CallOption) (*KeyReservation, error)
+	// Выдача зарезервированного ключа после оплаты
+	Confirm(ctx context.Context, in *KeyConfirmReq, opts ...grpc.CallOption) (*KeyActivateRep, error)
+	Release(ctx context.Context, in *KeyReleaseReq, opts ...grpc.CallOption) (*KeyReleaseRep, error)
+	// Отменяется ключ заказа по продукту. Ключ провайдера отменяется у провайдера и переходит в cancelled,
+	// ключ из пула - в returned и по KEY_RETURN_POLICY остается в карантине или снова выдается
 	Cancel(ctx context.Context, in *KeyCancelReq, opts ...grpc.CallOption) (*KeyCancelRep, error)
+	// Пороги пулов: пул продукта ниже min_level докупается у провайдера до target_level
+	ListPoolLevels(ctx context.Context, in *PoolLevelListReq, opts ...grpc.CallOption) (*PoolLevelListRep, error)
+	SetPoolLevel(ctx context.Context, in *PoolLevelSetReq, opts ...grpc.CallOption) (*PoolLevel, error)
+	DeletePoolLevel(ctx context.Context, in *PoolLevelDeleteReq, opts ...grpc.CallOption) (*PoolLevelDeleteRep, error)
+	// Провайдеры продукта: при выдаче ключа пробуются по порядку, затем пул.
+	// Если провайдеры не заданы, используется провайдер продукта из mdm
+	ListProductProviders(ctx context.Context, in *ProductProviderListReq, opts ...grpc.CallOption) (*ProductProviderListRep, error)
+	// Заменяет провайдеров продукта, порядок providers - порядок попыток
+	SetProductProviders(ctx context.Context, in *ProductProviderSetReq, opts ...grpc.CallOption) (*ProductProviderListRep, error)
 	Catalog(ctx context.Context, in *GetCatalogReq, opts ...grpc.CallOption) (*GetCatalogRep, error)
+	// Состояние circuit breaker провайдеров: при открытом breaker ключи выдаются только из пула
+	ListProviderBreakers(ctx context.Context, in *ProviderBreakerListReq, opts ...grpc.CallOption) (*ProviderBreakerListRep, error)
+	// Закрывает breaker провайдера, не дожидаясь пробного вызова
+	ResetProviderBreaker(ctx context.Context, in *ProviderBreakerResetReq, opts ...grpc.CallOption) (*ProviderBreaker, error)
 }
 
 type keyClient struct {
@@ -48,9 +104,9 @@ func NewKeyClient(cc grpc.ClientConnInterface) KeyClient {
 	return &keyClient{cc}
 }
 
-func (c *keyClient) Load(ctx context.Context, in *LoadKeyReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *keyClient) Load(ctx context.Context, in *LoadKeyReq, opts ...grpc.CallOption) (*LoadKeyRep, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(emptypb.Empty)
+	out := new(LoadKeyRep)
 	err := c.cc.Invoke(ctx, Key_Load_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -58,6 +114,39 @@ func (c *keyClient) Load(ctx context.Context, in *LoadKeyReq, opts ...grpc.CallO
 	return out, nil
 }
 
+func (c *keyClient) ImportKeys(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportKeysReq, ImportJob], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Key_ServiceDesc.Streams[0], Key_ImportKeys_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportKeysReq, ImportJob]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Key_ImportKeysClient = grpc.ClientStreamingClient[ImportKeysReq, ImportJob]
+
+func (c *keyClient) GetImportJob(ctx context.Context, in *ImportJobGetReq, opts ...grpc.CallOption) (*ImportJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportJob)
+	err := c.cc.Invoke(ctx, Key_GetImportJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyClient) ListImportJobs(ctx context.Context, in *ImportJobListReq, opts ...grpc.CallOption) (*ImportJobListRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportJobListRep)
+	err := c.cc.Invoke(ctx, Key_ListImportJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *keyClient) List(ctx context.Context, in *KeyListReq, opts ...grpc.CallOption) (*KeyListRep, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(KeyListRep)
@@ -78,6 +167,65 @@ func (c *keyClient) Get(ctx context.Context, in *KeyGetReq, opts ...grpc.CallOpt
 	return out, nil
 }
 
+func (c *keyClient) History(ctx context.Context, in *KeyHistoryReq, opts ...grpc.CallOption) (*KeyHistoryRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeyHistoryRep)
+	err := c.cc.Invoke(ctx, Key_History_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyClient) RevealValue(ctx context.Context, in *KeyRevealValueReq, opts ...grpc.CallOption) (*KeyRevealValueRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeyRevealValueRep)
+	err := c.cc.Invoke(ctx, Key_RevealValue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyClient) Export(ctx context.Context, in *KeyExportReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[KeyExportChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Key_ServiceDesc.Streams[1], Key_Export_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[KeyExportReq, KeyExportChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Key_ExportClient = grpc.ServerStreamingClient[KeyExportChunk]
+
+func (c *keyClient) InventoryReport(ctx context.Context, in *KeyInventoryReq, opts ...grpc.CallOption) (*KeyInventoryRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeyInventoryRep)
+	err := c.cc.Invoke(ctx, Key_InventoryReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyClient) ListByCustomer(ctx context.Context, in *KeyListByCustomerReq, opts ...grpc.CallOption) (*KeyListByCustomerRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeyListByCustomerRep)
+	err := c.cc.Invoke(ctx, Key_ListByCustomer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *keyClient) Activate(ctx context.Context, in *KeyActivateReq, opts ...grpc.CallOption) (*KeyActivateRep, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(KeyActivateRep)
@@ -88,6 +236,46 @@ func (c *keyClient) Activate(ctx context.Context, in *KeyActivateReq, opts ...gr
 	return out, nil
 }
 
+func (c *keyClient) GetActivation(ctx context.Context, in *KeyActivationGetReq, opts ...grpc.CallOption) (*KeyActivation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeyActivation)
+	err := c.cc.Invoke(ctx, Key_GetActivation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyClient) Reserve(ctx context.Context, in *KeyReserveReq, opts ...grpc.CallOption) (*KeyReservation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeyReservation)
+	err := c.cc.Invoke(ctx, Key_Reserve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyClient) Confirm(ctx context.Context, in *KeyConfirmReq, opts ...grpc.CallOption) (*KeyActivateRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeyActivateRep)
+	err := c.cc.Invoke(ctx, Key_Confirm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyClient) Release(ctx context.Context, in *KeyReleaseReq, opts ...grpc.CallOption) (*KeyReleaseRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeyReleaseRep)
+	err := c.cc.Invoke(ctx, Key_Release_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *keyClient) Cancel(ctx context.Context, in *KeyCancelReq, opts ...grpc.CallOption) (*KeyCancelRep, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(KeyCancelRep)
@@ -98,6 +286,56 @@ func (c *keyClient) Cancel(ctx context.Context, in *KeyCancelReq, opts ...grpc.C
 	return out, nil
 }
 
+func (c *keyClient) ListPoolLevels(ctx context.Context, in *PoolLevelListReq, opts ...grpc.CallOption) (*PoolLevelListRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PoolLevelListRep)
+	err := c.cc.Invoke(ctx, Key_ListPoolLevels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyClient) SetPoolLevel(ctx context.Context, in *PoolLevelSetReq, opts ...grpc.CallOption) (*PoolLevel, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PoolLevel)
+	err := c.cc.Invoke(ctx, Key_SetPoolLevel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyClient) DeletePoolLevel(ctx context.Context, in *PoolLevelDeleteReq, opts ...grpc.CallOption) (*PoolLevelDeleteRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PoolLevelDeleteRep)
+	err := c.cc.Invoke(ctx, Key_DeletePoolLevel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyClient) ListProductProviders(ctx context.Context, in *ProductProviderListReq, opts ...grpc.CallOption) (*ProductProviderListRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductProviderListRep)
+	err := c.cc.Invoke(ctx, Key_ListProductProviders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyClient) SetProductProviders(ctx context.Context, in *ProductProviderSetReq, opts ...grpc.CallOption) (*ProductProviderListRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductProviderListRep)
+	err := c.cc.Invoke(ctx, Key_SetProductProviders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *keyClient) Catalog(ctx context.Context, in *GetCatalogReq, opts ...grpc.CallOption) (*GetCatalogRep, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetCatalogRep)
@@ -108,16 +346,74 @@ func (c *keyClient) Catalog(ctx context.Context, in *GetCatalogReq, opts ...grpc
 	return out, nil
 }
 
+func (c *keyClient) ListProviderBreakers(ctx context.Context, in *ProviderBreakerListReq, opts ...grpc.CallOption) (*ProviderBreakerListRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProviderBreakerListRep)
+	err := c.cc.Invoke(ctx, Key_ListProviderBreakers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyClient) ResetProviderBreaker(ctx context.Context, in *ProviderBreakerResetReq, opts ...grpc.CallOption) (*ProviderBreaker, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProviderBreaker)
+	err := c.cc.Invoke(ctx, Key_ResetProviderBreaker_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // KeyServer is the server API for Key service.
 // All implementations must embed UnimplementedKeyServer
 // for forward compatibility.
 type KeyServer interface {
-	Load(context.Context, *LoadKeyReq) (*emptypb.Empty, error)
+	Load(context.Context, *LoadKeyReq) (*LoadKeyRep, error)
+	// Загрузка ключей из CSV/XLSX файла. Первое сообщение потока - header, затем части файла.
+	// Для HTTP: multipart POST /key/import
+	ImportKeys(grpc.ClientStreamingServer[ImportKeysReq, ImportJob]) error
+	GetImportJob(context.Context, *ImportJobGetReq) (*ImportJob, error)
+	ListImportJobs(context.Context, *ImportJobListReq) (*ImportJobListRep, error)
 	List(context.Context, *KeyListReq) (*KeyListRep, error)
 	Get(context.Context, *KeyGetReq) (*KeyResponseItem, error)
+	// Журнал смены статусов ключа
+	History(context.Context, *KeyHistoryReq) (*KeyHistoryRep, error)
+	// Открытое значение ключа для поддержки, доступ пишется в журнал. Требует роль support или admin
+	RevealValue(context.Context, *KeyRevealValueReq) (*KeyRevealValueRep, error)
+	// Выгрузка ключей по фильтрам KeyListReq без пагинации, поток частей файла.
+	// Для скачивания через http: GET /key/export?format=export_csv&filter.status=activated
+	Export(*KeyExportReq, grpc.ServerStreamingServer[KeyExportChunk]) error
+	// Остатки ключей по продукту, провайдеру и статусу с разбивкой по возрасту
+	InventoryReport(context.Context, *KeyInventoryReq) (*KeyInventoryRep, error)
+	// Ключи, выданные клиенту по номеру телефона, значения маскируются
+	ListByCustomer(context.Context, *KeyListByCustomerReq) (*KeyListByCustomerRep, error)
 	Activate(context.Context, *KeyActivateReq) (*KeyActivateRep, error)
+	// Статус асинхронной выдачи (Activate с async=true), для completed - выданный ключ
+	GetActivation(context.Context, *KeyActivationGetReq) (*KeyActivation, error)
+	// Резерв ключа на время оплаты заказа, истекший резерв снимается автоматически
+	Reserve(context.Context, *KeyReserveReq) (*KeyReservation, error)
+	// Выдача зарезервированного ключа после оплаты
+	Confirm(context.Context, *KeyConfirmReq) (*KeyActivateRep, error)
+	Release(context.Context, *KeyReleaseReq) (*KeyReleaseRep, error)
+	// Отменяется ключ заказа по продукту. Ключ провайдера отменяется у провайдера и переходит в cancelled,
+	// ключ из пула - в returned и по KEY_RETURN_POLICY остается в карантине или снова выдается
 	Cancel(context.Context, *KeyCancelReq) (*KeyCancelRep, error)
+	// Пороги пулов: пул продукта ниже min_level докупается у провайдера до target_level
+	ListPoolLevels(context.Context, *PoolLevelListReq) (*PoolLevelListRep, error)
+	SetPoolLevel(context.Context, *PoolLevelSetReq) (*PoolLevel, error)
+	DeletePoolLevel(context.Context, *PoolLevelDeleteReq) (*PoolLevelDeleteRep, error)
+	// Провайдеры продукта: при выдаче ключа пробуются по порядку, затем пул.
+	// Если провайдеры не заданы, используется провайдер продукта из mdm
+	ListProductProviders(context.Context, *ProductProviderListReq) (*ProductProviderListRep, error)
+	// Заменяет провайдеров продукта, порядок providers - порядок попыток
+	SetProductProviders(context.Context, *ProductProviderSetReq) (*ProductProviderListRep, error)
 	Catalog(context.Context, *GetCatalogReq) (*GetCatalogRep, error)
+	// Состояние circuit breaker провайдеров: при открытом breaker ключи выдаются только из пула
+	ListProviderBreakers(context.Context, *ProviderBreakerListReq) (*ProviderBreakerListRep, error)
+	// Закрывает breaker провайдера, не дожидаясь пробного вызова
+	ResetProviderBreaker(context.Context, *ProviderBreakerResetReq) (*ProviderBreaker, error)
 	mustEmbedUnimplementedKeyServer()
 }
 
@@ -128,24 +424,81 @@ type KeyServer interface {
 // pointer dereference when methods are called.
 type UnimplementedKeyServer struct{}
 
-func (UnimplementedKeyServer) Load(context.Context, *LoadKeyReq) (*emptypb.Empty, error) {
+func (UnimplementedKeyServer) Load(context.Context, *LoadKeyReq) (*LoadKeyRep, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Load not implemented")
 }
+func (UnimplementedKeyServer) ImportKeys(grpc.ClientStreamingServer[ImportKeysReq, ImportJob]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportKeys not implemented")
+}
+func (UnimplementedKeyServer) GetImportJob(context.Context, *ImportJobGetReq) (*ImportJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetImportJob not implemented")
+}
+func (UnimplementedKeyServer) ListImportJobs(context.Context, *ImportJobListReq) (*ImportJobListRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListImportJobs not implemented")
+}
 func (UnimplementedKeyServer) List(context.Context, *KeyListReq) (*KeyListRep, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
 func (UnimplementedKeyServer) Get(context.Context, *KeyGetReq) (*KeyResponseItem, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
+func (UnimplementedKeyServer) History(context.Context, *KeyHistoryReq) (*KeyHistoryRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method History not implemented")
+}
+func (UnimplementedKeyServer) RevealValue(context.Context, *KeyRevealValueReq) (*KeyRevealValueRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevealValue not implemented")
+}
+func (UnimplementedKeyServer) Export(*KeyExportReq, grpc.ServerStreamingServer[KeyExportChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (UnimplementedKeyServer) InventoryReport(context.Context, *KeyInventoryReq) (*KeyInventoryRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InventoryReport not implemented")
+}
+func (UnimplementedKeyServer) ListByCustomer(context.Context, *KeyListByCustomerReq) (*KeyListByCustomerRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListByCustomer not implemented")
+}
 func (UnimplementedKeyServer) Activate(context.Context, *KeyActivateReq) (*KeyActivateRep, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Activate not implemented")
 }
+func (UnimplementedKeyServer) GetActivation(context.Context, *KeyActivationGetReq) (*KeyActivation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActivation not implemented")
+}
+func (UnimplementedKeyServer) Reserve(context.Context, *KeyReserveReq) (*KeyReservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reserve not implemented")
+}
+func (UnimplementedKeyServer) Confirm(context.Context, *KeyConfirmReq) (*KeyActivateRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Confirm not implemented")
+}
+func (UnimplementedKeyServer) Release(context.Context, *KeyReleaseReq) (*KeyReleaseRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Release not implemented")
+}
 func (UnimplementedKeyServer) Cancel(context.Context, *KeyCancelReq) (*KeyCancelRep, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
 }
+func (UnimplementedKeyServer) ListPoolLevels(context.Context, *PoolLevelListReq) (*PoolLevelListRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPoolLevels not implemented")
+}
+func (UnimplementedKeyServer) SetPoolLevel(context.Context, *PoolLevelSetReq) (*PoolLevel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPoolLevel not implemented")
+}
+func (UnimplementedKeyServer) DeletePoolLevel(context.Context, *PoolLevelDeleteReq) (*PoolLevelDeleteRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePoolLevel not implemented")
+}
+func (UnimplementedKeyServer) ListProductProviders(context.Context, *ProductProviderListReq) (*ProductProviderListRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProductProviders not implemented")
+}
+func (UnimplementedKeyServer) SetProductProviders(context.Context, *ProductProviderSetReq) (*ProductProviderListRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProductProviders not implemented")
+}
 func (UnimplementedKeyServer) Catalog(context.Context, *GetCatalogReq) (*GetCatalogRep, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Catalog not implemented")
 }
+func (UnimplementedKeyServer) ListProviderBreakers(context.Context, *ProviderBreakerListReq) (*ProviderBreakerListRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProviderBreakers not implemented")
+}
+func (UnimplementedKeyServer) ResetProviderBreaker(context.Context, *ProviderBreakerResetReq) (*ProviderBreaker, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetProviderBreaker not implemented")
+}
 func (UnimplementedKeyServer) mustEmbedUnimplementedKeyServer() {}
 func (UnimplementedKeyServer) testEmbeddedByValue()             {}
 
@@ -185,6 +538,49 @@ func _Key_Load_Handler(srv interface{}, ctx context.Context, dec func(interface{
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Key_ImportKeys_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(KeyServer).ImportKeys(&grpc.GenericServerStream[ImportKeysReq, ImportJob]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Key_ImportKeysServer = grpc.ClientStreamingServer[ImportKeysReq, ImportJob]
+
+func _Key_GetImportJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportJobGetReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServer).GetImportJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Key_GetImportJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServer).GetImportJob(ctx, req.(*ImportJobGetReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Key_ListImportJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportJobListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServer).ListImportJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Key_ListImportJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServer).ListImportJobs(ctx, req.(*ImportJobListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Key_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(KeyListReq)
 	if err := dec(in); err != nil {
@@ -221,6 +617,89 @@ func _Key_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Key_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyHistoryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServer).History(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Key_History_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServer).History(ctx, req.(*KeyHistoryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Key_RevealValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyRevealValueReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServer).RevealValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Key_RevealValue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServer).RevealValue(ctx, req.(*KeyRevealValueReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Key_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(KeyExportReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KeyServer).Export(m, &grpc.GenericServerStream[KeyExportReq, KeyExportChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Key_ExportServer = grpc.ServerStreamingServer[KeyExportChunk]
+
+func _Key_InventoryReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyInventoryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServer).InventoryReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Key_InventoryReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServer).InventoryReport(ctx, req.(*KeyInventoryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Key_ListByCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyListByCustomerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServer).ListByCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Key_ListByCustomer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServer).ListByCustomer(ctx, req.(*KeyListByCustomerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Key_Activate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(KeyActivateReq)
 	if err := dec(in); err != nil {
@@ -239,46 +718,244 @@ func _Key_Activate_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Key_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(KeyCancelReq)
+func _Key_GetActivation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyActivationGetReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeyServer).Cancel(ctx, in)
+		return srv.(KeyServer).GetActivation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Key_Cancel_FullMethodName,
+		FullMethod: Key_GetActivation_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeyServer).Cancel(ctx, req.(*KeyCancelReq))
+		return srv.(KeyServer).GetActivation(ctx, req.(*KeyActivationGetReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Key_Catalog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetCatalogReq)
+func _Key_Reserve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyReserveReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeyServer).Catalog(ctx, in)
+		return srv.(KeyServer).Reserve(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Key_Catalog_FullMethodName,
+		FullMethod: Key_Reserve_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeyServer).Catalog(ctx, req.(*GetCatalogReq))
+		return srv.(KeyServer).Reserve(ctx, req.(*KeyReserveReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// Key_ServiceDesc is the grpc.ServiceDesc for Key service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Key_ServiceDesc = grpc.ServiceDesc{
+func _Key_Confirm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyConfirmReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServer).Confirm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Key_Confirm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServer).Confirm(ctx, req.(*KeyConfirmReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Key_Release_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyReleaseReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServer).Release(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Key_Release_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServer).Release(ctx, req.(*KeyReleaseReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Key_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyCancelReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Key_Cancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServer).Cancel(ctx, req.(*KeyCancelReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Key_ListPoolLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoolLevelListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServer).ListPoolLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Key_ListPoolLevels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServer).ListPoolLevels(ctx, req.(*PoolLevelListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Key_SetPoolLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoolLevelSetReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServer).SetPoolLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Key_SetPoolLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServer).SetPoolLevel(ctx, req.(*PoolLevelSetReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Key_DeletePoolLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoolLevelDeleteReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServer).DeletePoolLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Key_DeletePoolLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServer).DeletePoolLevel(ctx, req.(*PoolLevelDeleteReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Key_ListProductProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductProviderListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServer).ListProductProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Key_ListProductProviders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServer).ListProductProviders(ctx, req.(*ProductProviderListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Key_SetProductProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductProviderSetReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServer).SetProductProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Key_SetProductProviders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServer).SetProductProviders(ctx, req.(*ProductProviderSetReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Key_Catalog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCatalogReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServer).Catalog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Key_Catalog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServer).Catalog(ctx, req.(*GetCatalogReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Key_ListProviderBreakers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProviderBreakerListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServer).ListProviderBreakers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Key_ListProviderBreakers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServer).ListProviderBreakers(ctx, req.(*ProviderBreakerListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Key_ResetProviderBreaker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProviderBreakerResetReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServer).ResetProviderBreaker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Key_ResetProviderBreaker_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServer).ResetProviderBreaker(ctx, req.(*ProviderBreakerResetReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Key_ServiceDesc is the grpc.ServiceDesc for Key service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Key_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "e_product_v1.Key",
 	HandlerType: (*KeyServer)(nil),
 	Methods: []grpc.MethodDesc{
@@ -286,6 +963,14 @@ var Key_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Load",
 			Handler:    _Key_Load_Handler,
 		},
+		{
+			MethodName: "GetImportJob",
+			Handler:    _Key_GetImportJob_Handler,
+		},
+		{
+			MethodName: "ListImportJobs",
+			Handler:    _Key_ListImportJobs_Handler,
+		},
 		{
 			MethodName: "List",
 			Handler:    _Key_List_Handler,
@@ -294,18 +979,397 @@ var Key_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Get",
 			Handler:    _Key_Get_Handler,
 		},
+		{
+			MethodName: "History",
+			Handler:    _Key_History_Handler,
+		},
+		{
+			MethodName: "RevealValue",
+			Handler:    _Key_RevealValue_Handler,
+		},
+		{
+			MethodName: "InventoryReport",
+			Handler:    _Key_InventoryReport_Handler,
+		},
+		{
+			MethodName: "ListByCustomer",
+			Handler:    _Key_ListByCustomer_Handler,
+		},
 		{
 			MethodName: "Activate",
 			Handler:    _Key_Activate_Handler,
 		},
+		{
+			MethodName: "GetActivation",
+			Handler:    _Key_GetActivation_Handler,
+		},
+		{
+			MethodName: "Reserve",
+			Handler:    _Key_Reserve_Handler,
+		},
+		{
+			MethodName: "Confirm",
+			Handler:    _Key_Confirm_Handler,
+		},
+		{
+			MethodName: "Release",
+			Handler:    _Key_Release_Handler,
+		},
 		{
 			MethodName: "Cancel",
 			Handler:    _Key_Cancel_Handler,
 		},
+		{
+			MethodName: "ListPoolLevels",
+			Handler:    _Key_ListPoolLevels_Handler,
+		},
+		{
+			MethodName: "SetPoolLevel",
+			Handler:    _Key_SetPoolLevel_Handler,
+		},
+		{
+			MethodName: "DeletePoolLevel",
+			Handler:    _Key_DeletePoolLevel_Handler,
+		},
+		{
+			MethodName: "ListProductProviders",
+			Handler:    _Key_ListProductProviders_Handler,
+		},
+		{
+			MethodName: "SetProductProviders",
+			Handler:    _Key_SetProductProviders_Handler,
+		},
 		{
 			MethodName: "Catalog",
 			Handler:    _Key_Catalog_Handler,
 		},
+		{
+			MethodName: "ListProviderBreakers",
+			Handler:    _Key_ListProviderBreakers_Handler,
+		},
+		{
+			MethodName: "ResetProviderBreaker",
+			Handler:    _Key_ResetProviderBreaker_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportKeys",
+			Handler:       _Key_ImportKeys_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Export",
+			Handler:       _Key_Export_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "e_product/e_product_v1.proto",
+}
+
+const (
+	Webhook_ListSubscriptions_FullMethodName  = "/e_product_v1.Webhook/ListSubscriptions"
+	Webhook_CreateSubscription_FullMethodName = "/e_product_v1.Webhook/CreateSubscription"
+	Webhook_UpdateSubscription_FullMethodName = "/e_product_v1.Webhook/UpdateSubscription"
+	Webhook_DeleteSubscription_FullMethodName = "/e_product_v1.Webhook/DeleteSubscription"
+	Webhook_ListDeliveries_FullMethodName     = "/e_product_v1.Webhook/ListDeliveries"
+	Webhook_Replay_FullMethodName             = "/e_product_v1.Webhook/Replay"
+)
+
+// WebhookClient is the client API for Webhook service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Webhook подписки на события ключей: activated, cancelled, pool_low, provider_failed.
+// Тело запроса подписывается HMAC-SHA256 секретом подписки: заголовок X-Signature
+// содержит sha256=hex(hmac(timestamp + "." + body)), timestamp - в X-Signature-Timestamp
+type WebhookClient interface {
+	ListSubscriptions(ctx context.Context, in *WebhookSubscriptionListReq, opts ...grpc.CallOption) (*WebhookSubscriptionListRep, error)
+	// Секрет возвращается только при создании. Если secret не задан, он генерируется
+	CreateSubscription(ctx context.Context, in *WebhookSubscriptionCreateReq, opts ...grpc.CallOption) (*WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, in *WebhookSubscriptionUpdateReq, opts ...grpc.CallOption) (*WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, in *WebhookSubscriptionDeleteReq, opts ...grpc.CallOption) (*WebhookSubscriptionDeleteRep, error)
+	// Доставки событий: неудачная доставка повторяется с экспоненциальной задержкой,
+	// после исчерпания попыток переходит в dead
+	ListDeliveries(ctx context.Context, in *WebhookDeliveryListReq, opts ...grpc.CallOption) (*WebhookDeliveryListRep, error)
+	// Повторно ставит доставку в очередь со сброшенным счетчиком попыток
+	Replay(ctx context.Context, in *WebhookReplayReq, opts ...grpc.CallOption) (*WebhookDelivery, error)
+}
+
+type webhookClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebhookClient(cc grpc.ClientConnInterface) WebhookClient {
+	return &webhookClient{cc}
+}
+
+func (c *webhookClient) ListSubscriptions(ctx context.Context, in *WebhookSubscriptionListReq, opts ...grpc.CallOption) (*WebhookSubscriptionListRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookSubscriptionListRep)
+	err := c.cc.Invoke(ctx, Webhook_ListSubscriptions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookClient) CreateSubscription(ctx context.Context, in *WebhookSubscriptionCreateReq, opts ...grpc.CallOption) (*WebhookSubscription, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookSubscription)
+	err := c.cc.Invoke(ctx, Webhook_CreateSubscription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookClient) UpdateSubscription(ctx context.Context, in *WebhookSubscriptionUpdateReq, opts ...grpc.CallOption) (*WebhookSubscription, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookSubscription)
+	err := c.cc.Invoke(ctx, Webhook_UpdateSubscription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookClient) DeleteSubscription(ctx context.Context, in *WebhookSubscriptionDeleteReq, opts ...grpc.CallOption) (*WebhookSubscriptionDeleteRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookSubscriptionDeleteRep)
+	err := c.cc.Invoke(ctx, Webhook_DeleteSubscription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookClient) ListDeliveries(ctx context.Context, in *WebhookDeliveryListReq, opts ...grpc.CallOption) (*WebhookDeliveryListRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookDeliveryListRep)
+	err := c.cc.Invoke(ctx, Webhook_ListDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookClient) Replay(ctx context.Context, in *WebhookReplayReq, opts ...grpc.CallOption) (*WebhookDelivery, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookDelivery)
+	err := c.cc.Invoke(ctx, Webhook_Replay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WebhookServer is the server API for Webhook service.
+// All implementations must embed UnimplementedWebhookServer
+// for forward compatibility.
+//
+// Webhook подписки на события ключей: activated, cancelled, pool_low, provider_failed.
+// Тело запроса подписывается HMAC-SHA256 секретом подписки: заголовок X-Signature
+// содержит sha256=hex(hmac(timestamp + "." + body)), timestamp - в X-Signature-Timestamp
+type WebhookServer interface {
+	ListSubscriptions(context.Context, *WebhookSubscriptionListReq) (*WebhookSubscriptionListRep, error)
+	// Секрет возвращается только при создании. Если secret не задан, он генерируется
+	CreateSubscription(context.Context, *WebhookSubscriptionCreateReq) (*WebhookSubscription, error)
+	UpdateSubscription(context.Context, *WebhookSubscriptionUpdateReq) (*WebhookSubscription, error)
+	DeleteSubscription(context.Context, *WebhookSubscriptionDeleteReq) (*WebhookSubscriptionDeleteRep, error)
+	// Доставки событий: неудачная доставка повторяется с экспоненциальной задержкой,
+	// после исчерпания попыток переходит в dead
+	ListDeliveries(context.Context, *WebhookDeliveryListReq) (*WebhookDeliveryListRep, error)
+	// Повторно ставит доставку в очередь со сброшенным счетчиком попыток
+	Replay(context.Context, *WebhookReplayReq) (*WebhookDelivery, error)
+	mustEmbedUnimplementedWebhookServer()
+}
+
+// UnimplementedWebhookServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWebhookServer struct{}
+
+func (UnimplementedWebhookServer) ListSubscriptions(context.Context, *WebhookSubscriptionListReq) (*WebhookSubscriptionListRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubscriptions not implemented")
+}
+func (UnimplementedWebhookServer) CreateSubscription(context.Context, *WebhookSubscriptionCreateReq) (*WebhookSubscription, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSubscription not implemented")
+}
+func (UnimplementedWebhookServer) UpdateSubscription(context.Context, *WebhookSubscriptionUpdateReq) (*WebhookSubscription, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSubscription not implemented")
+}
+func (UnimplementedWebhookServer) DeleteSubscription(context.Context, *WebhookSubscriptionDeleteReq) (*WebhookSubscriptionDeleteRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSubscription not implemented")
+}
+func (UnimplementedWebhookServer) ListDeliveries(context.Context, *WebhookDeliveryListReq) (*WebhookDeliveryListRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeliveries not implemented")
+}
+func (UnimplementedWebhookServer) Replay(context.Context, *WebhookReplayReq) (*WebhookDelivery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Replay not implemented")
+}
+func (UnimplementedWebhookServer) mustEmbedUnimplementedWebhookServer() {}
+func (UnimplementedWebhookServer) testEmbeddedByValue()                 {}
+
+// UnsafeWebhookServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WebhookServer will
+// result in compilation errors.
+type UnsafeWebhookServer interface {
+	mustEmbedUnimplementedWebhookServer()
+}
+
+func RegisterWebhookServer(s grpc.ServiceRegistrar, srv WebhookServer) {
+	// If the following call pancis, it indicates UnimplementedWebhookServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Webhook_ServiceDesc, srv)
+}
+
+func _Webhook_ListSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookSubscriptionListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServer).ListSubscriptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Webhook_ListSubscriptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServer).ListSubscriptions(ctx, req.(*WebhookSubscriptionListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Webhook_CreateSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookSubscriptionCreateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServer).CreateSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Webhook_CreateSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServer).CreateSubscription(ctx, req.(*WebhookSubscriptionCreateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Webhook_UpdateSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookSubscriptionUpdateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServer).UpdateSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Webhook_UpdateSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServer).UpdateSubscription(ctx, req.(*WebhookSubscriptionUpdateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Webhook_DeleteSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookSubscriptionDeleteReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServer).DeleteSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Webhook_DeleteSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServer).DeleteSubscription(ctx, req.(*WebhookSubscriptionDeleteReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Webhook_ListDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookDeliveryListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServer).ListDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Webhook_ListDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServer).ListDeliveries(ctx, req.(*WebhookDeliveryListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Webhook_Replay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookReplayReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServer).Replay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Webhook_Replay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServer).Replay(ctx, req.(*WebhookReplayReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Webhook_ServiceDesc is the grpc.ServiceDesc for Webhook service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Webhook_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "e_product_v1.Webhook",
+	HandlerType: (*WebhookServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSubscriptions",
+			Handler:    _Webhook_ListSubscriptions_Handler,
+		},
+		{
+			MethodName: "CreateSubscription",
+			Handler:    _Webhook_CreateSubscription_Handler,
+		},
+		{
+			MethodName: "UpdateSubscription",
+			Handler:    _Webhook_UpdateSubscription_Handler,
+		},
+		{
+			MethodName: "DeleteSubscription",
+			Handler:    _Webhook_DeleteSubscription_Handler,
+		},
+		{
+			MethodName: "ListDeliveries",
+			Handler:    _Webhook_ListDeliveries_Handler,
+		},
+		{
+			MethodName: "Replay",
+			Handler:    _Webhook_Replay_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "e_product/e_product_v1.proto",